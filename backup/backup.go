@@ -0,0 +1,429 @@
+// Package backup creates and restores encrypted archives of the full state of
+// a drand node: the long-term key pair, the distributed share, the group file
+// and the beacon database.
+//
+// The archive is a gzipped tar file encrypted by chunks with AES-256-GCM under
+// a key derived from a passphrase with scrypt, so that it is streamed instead
+// of being held in memory. The authenticated encryption guarantees the
+// integrity of the whole archive, and the cryptographic material is checked
+// for consistency before anything is restored.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/drand/drand/fs"
+	"github.com/drand/drand/key"
+	"golang.org/x/crypto/scrypt"
+)
+
+// Folders lists the folders holding the state of a drand node.
+type Folders struct {
+	// Config is the base configuration folder containing the key/ and groups/
+	// folders.
+	Config string
+	// DB is the folder containing the beacon database. It can be located
+	// inside the configuration folder.
+	DB string
+	// ShareParts are the files holding the parts of the private share when it
+	// is split across several locations.
+	ShareParts []string
+}
+
+const (
+	magic     = "drandbk2"
+	saltSize  = 16
+	configDir = "config"
+	dbDir     = "db"
+	partsDir  = "parts"
+	// scrypt parameters recommended for interactive logins as of 2017
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	keySize = 32
+)
+
+// MinPassphraseLength is the minimum length of the passphrase protecting an
+// archive.
+const MinPassphraseLength = 12
+
+// ErrInvalidArchive is returned when an archive can not be decrypted, either
+// because the passphrase is wrong or because the archive has been altered.
+var ErrInvalidArchive = errors.New("backup: invalid passphrase or corrupted archive")
+
+// Create writes an encrypted archive of the given folders to out. The caller
+// must make sure no drand daemon is modifying the folders at the same time.
+func Create(out io.Writer, f Folders, passphrase []byte) error {
+	if len(passphrase) < MinPassphraseLength {
+		return fmt.Errorf("backup: passphrase must be at least %d characters", MinPassphraseLength)
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := newAEAD(passphrase, salt)
+	if err != nil {
+		return err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := rand.Read(prefix); err != nil {
+		return err
+	}
+	header := append([]byte(magic), salt...)
+	header = append(header, prefix...)
+	if _, err := out.Write(header); err != nil {
+		return err
+	}
+	// the header is authenticated with each chunk
+	sw := newSealWriter(out, aead, prefix, header)
+	gz := gzip.NewWriter(sw)
+	tw := tar.NewWriter(gz)
+	// the db folder is archived separately if it is nested in the config folder
+	skip := func(p string) bool {
+		return p == filepath.Join(f.Config, fs.LockFileName) || p == filepath.Clean(f.DB)
+	}
+	if err := addFolder(tw, f.Config, configDir, skip); err != nil {
+		return err
+	}
+	if exists, _ := fs.Exists(f.DB); exists {
+		if err := addFolder(tw, f.DB, dbDir, func(string) bool { return false }); err != nil {
+			return err
+		}
+	}
+	for i, p := range f.ShareParts {
+		// a missing part is tolerated as long as enough of them remain
+		if exists, _ := fs.Exists(p); !exists {
+			continue
+		}
+		if err := addFile(tw, p, path.Join(partsDir, strconv.Itoa(i))); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	return sw.Close()
+}
+
+// Restore decrypts the archive read from in, verifies the consistency of the
+// cryptographic material it contains and then replaces the content of the
+// given folders with the one of the archive. The folders are left untouched if
+// the archive is invalid or if any of them can not be replaced. The caller
+// must make sure no drand daemon is using the folders at the same time.
+func Restore(in io.Reader, f Folders, passphrase []byte) error {
+	plain, err := openArchive(in, passphrase)
+	if err != nil {
+		return err
+	}
+
+	// extract first in a temporary folder next to the config folder so the
+	// current state is only replaced once the archive is known to be valid
+	if err := os.MkdirAll(filepath.Dir(filepath.Clean(f.Config)), 0700); err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempDir(filepath.Dir(filepath.Clean(f.Config)), ".drand-restore")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	if err := extract(plain, tmp); err != nil {
+		if errors.Is(err, ErrInvalidArchive) {
+			return ErrInvalidArchive
+		}
+		return err
+	}
+	if err := Verify(filepath.Join(tmp, configDir)); err != nil {
+		return err
+	}
+	parts, err := archivedParts(filepath.Join(tmp, partsDir), len(f.ShareParts))
+	if err != nil {
+		return err
+	}
+
+	var moves []move
+	entries, err := ioutil.ReadDir(filepath.Join(tmp, configDir))
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		moves = append(moves, move{filepath.Join(tmp, configDir, e.Name()), filepath.Join(f.Config, e.Name())})
+	}
+	restoredDB := filepath.Join(tmp, dbDir)
+	if exists, _ := fs.Exists(restoredDB); exists {
+		moves = append(moves, move{restoredDB, f.DB})
+	}
+	for i, p := range parts {
+		moves = append(moves, move{p, f.ShareParts[i]})
+	}
+
+	if fs.CreateSecureFolder(f.Config) == "" {
+		return fmt.Errorf("backup: can't create config folder %s", f.Config)
+	}
+	for i := range parts {
+		if fs.CreateSecureFolder(filepath.Dir(f.ShareParts[i])) == "" {
+			return fmt.Errorf("backup: can't create share location %s", filepath.Dir(f.ShareParts[i]))
+		}
+	}
+	return install(moves)
+}
+
+// archivedParts returns the files of the share parts extracted in folder,
+// indexed like the locations they must be restored to.
+func archivedParts(folder string, locations int) (map[int]string, error) {
+	parts := make(map[int]string)
+	entries, err := ioutil.ReadDir(folder)
+	if os.IsNotExist(err) {
+		return parts, nil
+	} else if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		i, err := strconv.Atoi(e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("backup: invalid share part in archive: %s", e.Name())
+		}
+		if i >= locations {
+			return nil, fmt.Errorf("backup: archive holds share part %d but only %d locations are given", i, locations)
+		}
+		parts[i] = filepath.Join(folder, e.Name())
+	}
+	return parts, nil
+}
+
+// Verify checks that the key material stored in the given configuration folder
+// is consistent: the key pair must be correctly self signed and, if a share
+// and a group are present, the share must belong to the distributed key of the
+// group.
+func Verify(configFolder string) error {
+	store := key.NewFileStore(configFolder)
+	pair, err := store.LoadKeyPair()
+	if err != nil {
+		return fmt.Errorf("backup: invalid key pair: %w", err)
+	}
+	if err := pair.Public.ValidSignature(); err != nil {
+		return fmt.Errorf("backup: invalid key pair signature: %w", err)
+	}
+	group, errG := store.LoadGroup()
+	share, errS := store.LoadShare()
+	if errG != nil || errS != nil {
+		// no DKG ran yet
+		return nil
+	}
+	if group.Find(pair.Public) == nil {
+		return errors.New("backup: key pair not included in the group")
+	}
+	if group.PublicKey != nil && !group.PublicKey.Equal(share.Public()) {
+		return errors.New("backup: share does not match the distributed key of the group")
+	}
+	priv := share.PrivateShare()
+	expected := share.PubPoly().Eval(priv.I).V
+	if !expected.Equal(key.KeyGroup.Point().Mul(priv.V, nil)) {
+		return errors.New("backup: private share does not match its public commitment")
+	}
+	return nil
+}
+
+func newAEAD(passphrase, salt []byte) (cipher.AEAD, error) {
+	k, err := scrypt.Key(passphrase, salt, scryptN, scryptR, scryptP, keySize)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// openArchive reads the header of the archive and returns the reader of its
+// plaintext.
+func openArchive(in io.Reader, passphrase []byte) (io.Reader, error) {
+	head := make([]byte, len(magic)+saltSize)
+	if _, err := io.ReadFull(in, head); err != nil || string(head[:len(magic)]) != magic {
+		return nil, errors.New("backup: not a drand backup archive")
+	}
+	aead, err := newAEAD(passphrase, head[len(magic):])
+	if err != nil {
+		return nil, err
+	}
+	prefix := make([]byte, prefixSize)
+	if _, err := io.ReadFull(in, prefix); err != nil {
+		return nil, ErrInvalidArchive
+	}
+	return newOpenReader(in, aead, prefix, append(head, prefix...)), nil
+}
+
+// addFolder adds recursively all files of folder to the archive under the
+// given prefix.
+func addFolder(tw *tar.Writer, folder, prefix string, skip func(string) bool) error {
+	return filepath.Walk(folder, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if skip(p) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(folder, p)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = filepath.ToSlash(filepath.Join(prefix, rel))
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		fd, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer fd.Close()
+		_, err = io.Copy(tw, fd)
+		return err
+	})
+}
+
+// addFile adds a single file to the archive under the given name.
+func addFile(tw *tar.Writer, file, name string) error {
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+	hdr, err := tar.FileInfoHeader(info, "")
+	if err != nil {
+		return err
+	}
+	hdr.Name = name
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	fd, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	_, err = io.Copy(tw, fd)
+	return err
+}
+
+// extract writes the files of the archive read from plain to folder. The whole
+// plaintext is read so that its end is authenticated as well.
+func extract(plain io.Reader, folder string) error {
+	gz, err := gzip.NewReader(plain)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			if _, err := io.Copy(ioutil.Discard, gz); err != nil {
+				return err
+			}
+			_, err = io.Copy(ioutil.Discard, plain)
+			return err
+		} else if err != nil {
+			return err
+		}
+		name := filepath.Clean(filepath.FromSlash(hdr.Name))
+		if filepath.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return fmt.Errorf("backup: invalid path in archive: %s", hdr.Name)
+		}
+		target := filepath.Join(folder, name)
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, 0700); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+				return err
+			}
+			fd, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, os.FileMode(hdr.Mode).Perm())
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(fd, tr); err != nil {
+				fd.Close()
+				return err
+			}
+			if err := fd.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// move is a file or folder restored from src to dst.
+type move struct {
+	src, dst string
+}
+
+// install moves the restored files to their destinations. The files they
+// replace are moved aside first and put back if any move fails, so that the
+// folders are either fully restored or left as they were.
+func install(moves []move) (err error) {
+	// done holds, for each destination replaced, where its previous content
+	// has been moved aside, if it existed
+	var done []move
+	defer func() {
+		if err == nil {
+			for _, m := range done {
+				if m.src != "" {
+					os.RemoveAll(m.src)
+				}
+			}
+			return
+		}
+		for i := len(done) - 1; i >= 0; i-- {
+			os.RemoveAll(done[i].dst)
+			if done[i].src != "" {
+				_ = os.Rename(done[i].src, done[i].dst)
+			}
+		}
+	}()
+	for _, m := range moves {
+		if err := os.MkdirAll(filepath.Dir(m.dst), 0700); err != nil {
+			return err
+		}
+		aside := ""
+		if _, err := os.Lstat(m.dst); err == nil {
+			aside = m.dst + ".drand-restore-old"
+			if err := os.RemoveAll(aside); err != nil {
+				return err
+			}
+			if err := os.Rename(m.dst, aside); err != nil {
+				return err
+			}
+		}
+		done = append(done, move{src: aside, dst: m.dst})
+		if err := os.Rename(m.src, m.dst); err != nil {
+			return err
+		}
+	}
+	return nil
+}
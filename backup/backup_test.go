@@ -0,0 +1,147 @@
+package backup
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupRestore(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	src := Folders{
+		Config: filepath.Join(tmp, "src"),
+	}
+	src.DB = filepath.Join(src.Config, "db")
+	store := key.NewFileStore(src.Config)
+	pair := key.NewKeyPair("127.0.0.1:8080")
+	require.NoError(t, store.SaveKeyPair(pair))
+	require.NoError(t, os.MkdirAll(src.DB, 0700))
+	dbContent := []byte("beacons")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src.DB, "drand.db"), dbContent, 0600))
+
+	pass := []byte("correct horse battery staple")
+	var archive bytes.Buffer
+	require.NoError(t, Create(&archive, src, pass))
+	require.Error(t, Create(new(bytes.Buffer), src, []byte("short")))
+
+	dst := Folders{
+		Config: filepath.Join(tmp, "dst"),
+		DB:     filepath.Join(tmp, "dstdb"),
+	}
+	// wrong passphrase
+	err = Restore(bytes.NewReader(archive.Bytes()), dst, []byte("not the right passphrase"))
+	require.Equal(t, ErrInvalidArchive, err)
+	// tampered archive
+	tampered := append([]byte{}, archive.Bytes()...)
+	tampered[len(tampered)-1] ^= 0x01
+	err = Restore(bytes.NewReader(tampered), dst, pass)
+	require.Equal(t, ErrInvalidArchive, err)
+
+	require.NoError(t, Restore(bytes.NewReader(archive.Bytes()), dst, pass))
+	restored, err := key.NewFileStore(dst.Config).LoadKeyPair()
+	require.NoError(t, err)
+	require.True(t, restored.Public.Equal(pair.Public))
+	buff, err := ioutil.ReadFile(filepath.Join(dst.DB, "drand.db"))
+	require.NoError(t, err)
+	require.Equal(t, dbContent, buff)
+}
+
+func TestBackupRestoreShareParts(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	src := Folders{
+		Config:     filepath.Join(tmp, "src"),
+		DB:         filepath.Join(tmp, "srcdb"),
+		ShareParts: []string{filepath.Join(tmp, "a", "part"), filepath.Join(tmp, "b", "part")},
+	}
+	require.NoError(t, key.NewFileStore(src.Config).SaveKeyPair(key.NewKeyPair("127.0.0.1:8080")))
+	require.NoError(t, os.MkdirAll(filepath.Join(tmp, "a"), 0700))
+	partContent := []byte("share part")
+	// the second part is missing
+	require.NoError(t, ioutil.WriteFile(src.ShareParts[0], partContent, 0600))
+
+	pass := []byte("correct horse battery staple")
+	var archive bytes.Buffer
+	require.NoError(t, Create(&archive, src, pass))
+
+	dst := Folders{
+		Config: filepath.Join(tmp, "dst"),
+		DB:     filepath.Join(tmp, "dstdb"),
+	}
+	// the locations of the parts must be given
+	require.Error(t, Restore(bytes.NewReader(archive.Bytes()), dst, pass))
+	dst.ShareParts = []string{filepath.Join(tmp, "c", "part"), filepath.Join(tmp, "d", "part")}
+	require.NoError(t, Restore(bytes.NewReader(archive.Bytes()), dst, pass))
+	buff, err := ioutil.ReadFile(dst.ShareParts[0])
+	require.NoError(t, err)
+	require.Equal(t, partContent, buff)
+	_, err = os.Stat(dst.ShareParts[1])
+	require.True(t, os.IsNotExist(err))
+}
+
+func TestBackupStreamChunks(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	src := Folders{
+		Config: filepath.Join(tmp, "src"),
+		DB:     filepath.Join(tmp, "srcdb"),
+	}
+	require.NoError(t, key.NewFileStore(src.Config).SaveKeyPair(key.NewKeyPair("127.0.0.1:8080")))
+	require.NoError(t, os.MkdirAll(src.DB, 0700))
+	// incompressible content spanning several chunks
+	dbContent := make([]byte, 3*chunkSize)
+	_, err = rand.Read(dbContent)
+	require.NoError(t, err)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(src.DB, "drand.db"), dbContent, 0600))
+
+	pass := []byte("correct horse battery staple")
+	var archive bytes.Buffer
+	require.NoError(t, Create(&archive, src, pass))
+
+	dst := Folders{
+		Config: filepath.Join(tmp, "dst"),
+		DB:     filepath.Join(tmp, "dstdb"),
+	}
+	require.NoError(t, os.MkdirAll(dst.DB, 0700))
+	previous := []byte("previous beacons")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dst.DB, "drand.db"), previous, 0600))
+
+	// an archive truncated at the end of a chunk is detected, and nothing is
+	// replaced
+	headerLen := len(magic) + saltSize + prefixSize
+	sealedLen := chunkSize + 16
+	truncated := archive.Bytes()[:headerLen+2*sealedLen]
+	require.Equal(t, ErrInvalidArchive, Restore(bytes.NewReader(truncated), dst, pass))
+	buff, err := ioutil.ReadFile(filepath.Join(dst.DB, "drand.db"))
+	require.NoError(t, err)
+	require.Equal(t, previous, buff)
+	// so are swapped chunks
+	swapped := append([]byte{}, archive.Bytes()...)
+	copy(swapped[headerLen:], archive.Bytes()[headerLen+sealedLen:headerLen+2*sealedLen])
+	copy(swapped[headerLen+sealedLen:], archive.Bytes()[headerLen:headerLen+sealedLen])
+	require.Equal(t, ErrInvalidArchive, Restore(bytes.NewReader(swapped), dst, pass))
+
+	require.NoError(t, Restore(bytes.NewReader(archive.Bytes()), dst, pass))
+	buff, err = ioutil.ReadFile(filepath.Join(dst.DB, "drand.db"))
+	require.NoError(t, err)
+	require.Equal(t, dbContent, buff)
+	// the replaced content is removed
+	entries, err := ioutil.ReadDir(tmp)
+	require.NoError(t, err)
+	for _, e := range entries {
+		require.NotContains(t, e.Name(), "drand-restore")
+	}
+}
@@ -0,0 +1,157 @@
+package backup
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// The archive is encrypted by chunks so that it is streamed instead of being
+// held in memory: each chunk of chunkSize bytes of plaintext is sealed with a
+// nonce made of the random prefix of the archive, the index of the chunk and a
+// flag set on the last chunk only. The chunks can thus be neither reordered
+// nor dropped, and a truncated archive is detected since its last chunk is not
+// flagged.
+const (
+	chunkSize   = 64 << 10
+	prefixSize  = 7
+	maxChunks   = 1<<32 - 1
+	lastChunk   = 1
+	otherChunks = 0
+)
+
+// chunkNonce returns the nonce of the i-th chunk.
+func chunkNonce(prefix []byte, i uint32, last bool) []byte {
+	nonce := make([]byte, prefixSize+5)
+	copy(nonce, prefix)
+	binary.BigEndian.PutUint32(nonce[prefixSize:], i)
+	if last {
+		nonce[prefixSize+4] = lastChunk
+	} else {
+		nonce[prefixSize+4] = otherChunks
+	}
+	return nonce
+}
+
+// sealWriter encrypts what is written to it chunk by chunk. Close must be
+// called to write the last chunk.
+type sealWriter struct {
+	out    io.Writer
+	aead   cipher.AEAD
+	prefix []byte
+	header []byte
+	buff   []byte
+	index  uint32
+}
+
+func newSealWriter(out io.Writer, aead cipher.AEAD, prefix, header []byte) *sealWriter {
+	return &sealWriter{
+		out:    out,
+		aead:   aead,
+		prefix: prefix,
+		header: header,
+		buff:   make([]byte, 0, chunkSize),
+	}
+}
+
+func (w *sealWriter) Write(p []byte) (int, error) {
+	n := 0
+	for len(p) > 0 {
+		if len(w.buff) == chunkSize {
+			if err := w.seal(false); err != nil {
+				return n, err
+			}
+		}
+		c := copy(w.buff[len(w.buff):chunkSize], p)
+		w.buff = w.buff[:len(w.buff)+c]
+		p = p[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// Close writes the last chunk, which may be empty.
+func (w *sealWriter) Close() error {
+	return w.seal(true)
+}
+
+func (w *sealWriter) seal(last bool) error {
+	if w.index == maxChunks {
+		return errors.New("backup: archive too large")
+	}
+	sealed := w.aead.Seal(nil, chunkNonce(w.prefix, w.index, last), w.buff, w.header)
+	w.index++
+	w.buff = w.buff[:0]
+	_, err := w.out.Write(sealed)
+	return err
+}
+
+// openReader decrypts the chunks read from in. It returns ErrInvalidArchive as
+// soon as a chunk does not decrypt, and only returns io.EOF after the last
+// chunk has been authenticated.
+type openReader struct {
+	in     *bufio.Reader
+	aead   cipher.AEAD
+	prefix []byte
+	header []byte
+	sealed []byte
+	plain  []byte
+	index  uint32
+	done   bool
+}
+
+func newOpenReader(in io.Reader, aead cipher.AEAD, prefix, header []byte) *openReader {
+	return &openReader{
+		in:     bufio.NewReader(in),
+		aead:   aead,
+		prefix: prefix,
+		header: header,
+		sealed: make([]byte, chunkSize+aead.Overhead()),
+	}
+}
+
+func (r *openReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		if err := r.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// open decrypts the next chunk, the last one being the one that is not
+// followed by any byte.
+func (r *openReader) open() error {
+	n, err := io.ReadFull(r.in, r.sealed)
+	last := false
+	switch {
+	case err == io.EOF || err == io.ErrUnexpectedEOF:
+		last = true
+	case err != nil:
+		return err
+	default:
+		if _, err := r.in.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	if r.index == maxChunks {
+		return ErrInvalidArchive
+	}
+	plain, err := r.aead.Open(r.sealed[:0:0], chunkNonce(r.prefix, r.index, last), r.sealed[:n], r.header)
+	if err != nil {
+		return ErrInvalidArchive
+	}
+	r.index++
+	r.plain = plain
+	r.done = last
+	return nil
+}
@@ -20,9 +20,15 @@ type Beacon struct {
 	Round uint64
 	// Signature is the BLS deterministic signature over Round || PreviousRand
 	Signature []byte
+	// Contributors is the bitmap of the indices of the nodes whose partial
+	// signature the aggregating node held, see ContributorsBitmap. It is not
+	// covered by the signature and depends on the node that aggregated the
+	// beacon.
+	Contributors []byte `json:",omitempty"`
 }
 
-// Equal indicates if two beacons are equal
+// Equal indicates if two beacons are equal. The contributors are not compared
+// since different nodes can aggregate the same beacon from different partials.
 func (b *Beacon) Equal(b2 *Beacon) bool {
 	return bytes.Equal(b.PreviousSig, b2.PreviousSig) &&
 		b.Round == b2.Round &&
@@ -50,6 +56,35 @@ func (b *Beacon) GetRound() uint64 {
 	return b.Round
 }
 
+// ContributorsBitmap returns the bitmap where the bit i%8 of the byte i/8 is
+// set for each index i.
+func ContributorsBitmap(indices []int) []byte {
+	var bitmap []byte
+	for _, i := range indices {
+		if i < 0 {
+			continue
+		}
+		for len(bitmap) <= i/8 {
+			bitmap = append(bitmap, 0)
+		}
+		bitmap[i/8] |= 1 << uint(i%8)
+	}
+	return bitmap
+}
+
+// ContributorIndices returns the sorted indices set in a contributors bitmap.
+func ContributorIndices(bitmap []byte) []int {
+	var indices []int
+	for i, b := range bitmap {
+		for j := 0; j < 8; j++ {
+			if b&(1<<uint(j)) != 0 {
+				indices = append(indices, i*8+j)
+			}
+		}
+	}
+	return indices
+}
+
 // RandomnessFromSignature derives the round randomness from its signature
 func RandomnessFromSignature(sig []byte) []byte {
 	out := sha256.Sum256(sig)
@@ -64,6 +99,8 @@ func (b *Beacon) String() string {
 // public key. The public key "point" can be obtained from the
 // `key.DistPublic.Key()` method. The distributed public is the one written in
 // the configuration file of the network.
+// It only supports the original message format, use Info.VerifyBeacon for
+// chains that migrated to the domain separated format.
 func VerifyBeacon(pubkey kyber.Point, b *Beacon) error {
 	prevSig := b.PreviousSig
 	round := b.Round
@@ -82,7 +119,7 @@ func Verify(pubkey kyber.Point, prevSig, signature []byte, round uint64) error {
 }
 
 // Message returns a slice of bytes as the message to sign or to verify
-// alongside a beacon signature.
+// alongside a beacon signature, in the original format.
 // H ( prevSig || currRound)
 func Message(currRound uint64, prevSig []byte) []byte {
 	h := sha256.New()
@@ -91,6 +128,23 @@ func Message(currRound uint64, prevSig []byte) []byte {
 	return h.Sum(nil)
 }
 
+// MessageDomainTag separates the messages signed by drand beacons from the
+// ones of any other protocol using the same keys.
+const MessageDomainTag = "drand:beacon:v1"
+
+// MessageV1 returns the domain separated message to sign or to verify
+// alongside a beacon signature. It binds the signature to the chain it belongs
+// to.
+// H ( tag || chainHash || currRound || prevSig )
+func MessageV1(chainHash []byte, currRound uint64, prevSig []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(MessageDomainTag))
+	_, _ = h.Write(chainHash)
+	_, _ = h.Write(RoundToBytes(currRound))
+	_, _ = h.Write(prevSig)
+	return h.Sum(nil)
+}
+
 func shortSigStr(sig []byte) string {
 	max := 3
 	if len(sig) < max {
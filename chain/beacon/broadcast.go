@@ -0,0 +1,97 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/metrics"
+	proto "github.com/drand/drand/protobuf/drand"
+)
+
+// errNotSent is the result of the nodes the partial was not sent to before the
+// end of the round.
+var errNotSent = errors.New("partial not sent in time")
+
+// PeerResult is the outcome of sending a partial signature to a node.
+type PeerResult struct {
+	// Err is nil if the node accepted the partial
+	Err error
+	// Latency is the time the node took to answer
+	Latency time.Duration
+}
+
+// broadcastPartial sends the packet to the other nodes, in a random order,
+// through the fanout, and returns the result of each node indexed by address.
+// The nodes the packet could not be sent to before the context is done, or
+// before the partial of a later round replaced it, get an errNotSent result.
+func (h *Handler) broadcastPartial(ctx context.Context, nodes []*key.Node, packet *proto.PartialBeaconPacket) map[string]PeerResult {
+	var lock sync.Mutex
+	results := make(map[string]PeerResult, len(nodes))
+	var sent sync.WaitGroup
+	for _, i := range rand.Perm(len(nodes)) {
+		id := nodes[i].Identity
+		if h.addr == id.Address() {
+			continue
+		}
+		sent.Add(1)
+		h.fanout.push(ctx, id.Address(), packet.GetRound(), func() {
+			defer sent.Done()
+			res := h.sendPartial(ctx, id, packet)
+			lock.Lock()
+			results[id.Address()] = res
+			lock.Unlock()
+		}, sent.Done)
+	}
+	sent.Wait()
+	for _, n := range nodes {
+		if _, ok := results[n.Address()]; !ok && n.Address() != h.addr {
+			results[n.Address()] = PeerResult{Err: errNotSent}
+		}
+	}
+	return results
+}
+
+// reportBroadcast logs the nodes that did not receive the partial of the
+// round, updates the metrics of each node and keeps the results for the halt
+// alerts.
+func (h *Handler) reportBroadcast(round uint64, results map[string]PeerResult) {
+	var failed []string
+	for addr, res := range results {
+		if res.Err != nil {
+			metrics.PartialSendFailures.WithLabelValues(addr).Inc()
+			failed = append(failed, fmt.Sprintf("%s (%s)", addr, res.Err))
+			continue
+		}
+		metrics.PartialSendLatency.WithLabelValues(addr).Observe(res.Latency.Seconds())
+	}
+	h.Lock()
+	h.lastBroadcast = results
+	h.lastBroadcastRound = round
+	h.Unlock()
+	if len(failed) == 0 {
+		h.l.Debug("beacon_round", round, "partial_delivered", len(results))
+		return
+	}
+	sort.Strings(failed)
+	h.l.Error("beacon_round", round, "partial_not_delivered", len(failed), "nodes", len(results),
+		"unreachable", strings.Join(failed, ", "))
+}
+
+// LastBroadcast returns the last round whose partial this node sent and the
+// result of each node, indexed by address.
+func (h *Handler) LastBroadcast() (uint64, map[string]PeerResult) {
+	h.Lock()
+	defer h.Unlock()
+	results := make(map[string]PeerResult, len(h.lastBroadcast))
+	for addr, res := range h.lastBroadcast {
+		results[addr] = res
+	}
+	return h.lastBroadcastRound, results
+}
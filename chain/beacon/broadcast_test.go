@@ -0,0 +1,73 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+// partialTestClient fails to send the partials to the unreachable nodes.
+type partialTestClient struct {
+	net.ProtocolClient
+	unreachable string
+}
+
+func (c *partialTestClient) PartialBeacon(ctx context.Context, p net.Peer, in *drand.PartialBeaconPacket, opts ...net.CallOption) error {
+	if p.Address() == c.unreachable {
+		return errors.New("connection refused")
+	}
+	return nil
+}
+
+func (c *partialTestClient) NewBeacon(ctx context.Context, p net.Peer, in *drand.PartialBeaconPacket, opts ...net.CallOption) (*drand.BeaconResponse, error) {
+	if err := c.PartialBeacon(ctx, p, in, opts...); err != nil {
+		return nil, err
+	}
+	return new(drand.BeaconResponse), nil
+}
+
+func TestBroadcastPartialResults(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_, group := test.BatchIdentities(4)
+	nodes := group.Nodes
+	h := &Handler{
+		conf:   &Config{Clock: clock.NewRealClock()},
+		client: &partialTestClient{unreachable: nodes[2].Address()},
+		fanout: newFanout(ctx, 2),
+		addr:   nodes[0].Address(),
+		l:      log.DefaultLogger(),
+	}
+
+	results := h.broadcastPartial(ctx, nodes, &drand.PartialBeaconPacket{Round: 5})
+	require.Len(t, results, 3)
+	require.NoError(t, results[nodes[1].Address()].Err)
+	require.Error(t, results[nodes[2].Address()].Err)
+	require.NoError(t, results[nodes[3].Address()].Err)
+
+	h.reportBroadcast(5, results)
+	round, last := h.LastBroadcast()
+	require.Equal(t, uint64(5), round)
+	require.Equal(t, results, last)
+
+	// the nodes the partial was not sent to before the end of the round fail
+	block := make(chan struct{})
+	defer close(block)
+	for i := 0; i < 2; i++ {
+		h.fanout.push(ctx, nodes[i+1].Address(), 5, func() { <-block }, func() {})
+	}
+	done, stop := context.WithCancel(ctx)
+	stop()
+	results = h.broadcastPartial(done, nodes, &drand.PartialBeaconPacket{Round: 6})
+	require.Len(t, results, 3)
+	for _, res := range results {
+		require.Equal(t, errNotSent, res.Err)
+	}
+}
@@ -3,11 +3,15 @@ package beacon
 import (
 	"bytes"
 	"encoding/binary"
+	"fmt"
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/sign/tbls"
 )
 
 // partialCache is a cache that stores (or not) all the partials the node
@@ -36,15 +40,16 @@ func roundID(round uint64, previous []byte) string {
 	return buff.String()
 }
 
-// Append adds a partial signature to the cache.
-func (c *partialCache) Append(p *drand.PartialBeaconPacket) {
+// Append adds a partial signature to the cache, keeping at most thr shares per
+// round for the recovery.
+func (c *partialCache) Append(p *drand.PartialBeaconPacket, thr int) {
 	id := roundID(p.GetRound(), p.GetPreviousSig())
 	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
 	round := c.getCache(id, p)
 	if round == nil {
 		return
 	}
-	if round.append(p) {
+	if round.append(p, thr) {
 		// we increment the counter of that node index
 		c.rcvd[idx] = append(c.rcvd[idx], id)
 	}
@@ -60,7 +65,7 @@ func (c *partialCache) FlushRounds(round uint64) {
 		// delete the cache entry
 		delete(c.rounds, id)
 		// delete the counter of each nodes that participated in that round
-		for idx := range cache.sigs {
+		for _, idx := range cache.Indices() {
 			var idSlice = c.rcvd[idx][:0]
 			for _, idd := range c.rcvd[idx] {
 				if idd == id {
@@ -82,79 +87,150 @@ func (c *partialCache) GetRoundCache(round uint64, previous []byte) *roundCache
 	return c.rounds[id]
 }
 
-// newRoundCache creates a new round cache given p. If the signer of the partial
-// already has more than `
+// getCache returns the cache of the round of p, creating it if needed. A node
+// that already has MaxPartialsPerNode partials in the cache gets a new round
+// only if one of its partials is a duplicate that can be evicted, otherwise
+// the partial is dropped: the partials whose share is kept for the recovery
+// are never evicted.
 func (c *partialCache) getCache(id string, p *drand.PartialBeaconPacket) *roundCache {
 	if round, ok := c.rounds[id]; ok {
 		return round
 	}
 	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
-	if len(c.rcvd[idx]) >= MaxPartialsPerNode {
-		// this node has submitted too many partials - we take the last one off
-		toEvict := c.rcvd[idx][0]
-		round, ok := c.rounds[toEvict]
-		if !ok {
-			c.l.Error("cache", "miss", "node", idx, "not_present_for", p.GetRound())
-			return nil
-		}
-		round.flushIndex(idx)
-		c.rcvd[idx] = append(c.rcvd[idx][1:], id)
-		// if the round is now empty, delete it
-		if round.Len() == 0 {
-			delete(c.rounds, toEvict)
-		}
+	if len(c.rcvd[idx]) >= MaxPartialsPerNode && !c.evictDuplicate(idx) {
+		c.l.Debug("cache", "too_many_partials", "node", idx, "dropped_round", p.GetRound())
+		return nil
 	}
 	round := newRoundCache(id, p)
 	c.rounds[id] = round
 	return round
 }
 
+// evictDuplicate removes the oldest partial of the node that is not needed for
+// the recovery of its round: its round is gone, or the round already held the
+// shares of other nodes when it arrived. It returns false if there is none.
+func (c *partialCache) evictDuplicate(idx int) bool {
+	for i, id := range c.rcvd[idx] {
+		round, ok := c.rounds[id]
+		if ok && round.holds(idx) {
+			continue
+		}
+		c.rcvd[idx] = append(c.rcvd[idx][:i:i], c.rcvd[idx][i+1:]...)
+		if ok {
+			round.flushIndex(idx)
+			if round.Len() == 0 {
+				delete(c.rounds, id)
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// roundCache aggregates the partials of a round as they arrive. Only the
+// shares of the first threshold partials are kept to recover the signature, the
+// following partials are recorded as contributions and dropped, so the memory
+// used by a round does not grow with the size of the group.
 type roundCache struct {
 	round uint64
 	prev  []byte
 	id    string
-	sigs  map[int][]byte
+	// shares are the decoded partial signatures used for the recovery
+	shares map[int]kyber.Point
+	// contributors is the bitmap of the indices of the partials received
+	contributors []byte
+	count        int
+	// waiting is true while the aggregation waits for more partials
+	waiting bool
 }
 
 func newRoundCache(id string, p *drand.PartialBeaconPacket) *roundCache {
 	return &roundCache{
-		round: p.GetRound(),
-		prev:  p.GetPreviousSig(),
-		id:    id,
-		sigs:  make(map[int][]byte),
+		round:  p.GetRound(),
+		prev:   p.GetPreviousSig(),
+		id:     id,
+		shares: make(map[int]kyber.Point),
 	}
 }
 
-// append stores the partial and returns true if the partial is not stored . It
-// returns false if the cache is already caching this partial signature.
-func (r *roundCache) append(p *drand.PartialBeaconPacket) bool {
-	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
-	if _, seen := r.sigs[idx]; seen {
+// append records the partial and returns true if it is new. It returns false
+// if the cache already received the partial of this node. The share of the
+// partial is kept for the recovery only while less than thr shares are held.
+func (r *roundCache) append(p *drand.PartialBeaconPacket, thr int) bool {
+	sig := tbls.SigShare(p.GetPartialSig())
+	idx, err := sig.Index()
+	if err != nil || r.has(idx) {
 		return false
 	}
-	r.sigs[idx] = p.GetPartialSig()
+	if len(r.shares) < thr {
+		point := key.SigGroup.Point()
+		if err := point.UnmarshalBinary(sig.Value()); err != nil {
+			return false
+		}
+		r.shares[idx] = point
+	}
+	for len(r.contributors) <= idx/8 {
+		r.contributors = append(r.contributors, 0)
+	}
+	r.contributors[idx/8] |= 1 << uint(idx%8)
+	r.count++
 	return true
 }
 
-// Len shows how many items are in the cache
+// has returns true if the partial of the given index has been received
+func (r *roundCache) has(idx int) bool {
+	return idx/8 < len(r.contributors) && r.contributors[idx/8]&(1<<uint(idx%8)) != 0
+}
+
+// Len shows how many partials have been received
 func (r *roundCache) Len() int {
-	return len(r.sigs)
+	return r.count
+}
+
+// Shares returns the number of shares held for the recovery
+func (r *roundCache) Shares() int {
+	return len(r.shares)
+}
+
+// holds returns true if the share of the given index is kept for the recovery
+func (r *roundCache) holds(idx int) bool {
+	_, ok := r.shares[idx]
+	return ok
 }
 
-// Msg provides the chain for the current round
-func (r *roundCache) Msg() []byte {
-	return chain.Message(r.round, r.prev)
+// Msg provides the message signed for the current round by the given chain
+func (r *roundCache) Msg(info *chain.Info) []byte {
+	return info.Message(r.round, r.prev)
 }
 
-// Partials provides all cached partial signatures
-func (r *roundCache) Partials() [][]byte {
-	partials := make([][]byte, 0, len(r.sigs))
-	for _, sig := range r.sigs {
-		partials = append(partials, sig)
+// Recover interpolates the full signature from the shares kept. The partials
+// are verified before reaching the cache so, unlike key.Scheme.Recover, the
+// shares are not verified again: the recovered signature must be.
+func (r *roundCache) Recover(thr, n int) ([]byte, error) {
+	if len(r.shares) < thr {
+		return nil, fmt.Errorf("not enough partial signatures: %d/%d", len(r.shares), thr)
 	}
-	return partials
+	shares := make([]*share.PubShare, 0, len(r.shares))
+	for idx, point := range r.shares {
+		shares = append(shares, &share.PubShare{I: idx, V: point})
+	}
+	sig, err := share.RecoverCommit(key.SigGroup, shares, thr, n)
+	if err != nil {
+		return nil, err
+	}
+	return sig.MarshalBinary()
+}
+
+// Indices returns the indices of the nodes whose partial has been received
+func (r *roundCache) Indices() []int {
+	return chain.ContributorIndices(r.contributors)
 }
 
 func (r *roundCache) flushIndex(idx int) {
-	delete(r.sigs, idx)
+	if !r.has(idx) {
+		return
+	}
+	delete(r.shares, idx)
+	r.contributors[idx/8] &^= 1 << uint(idx%8)
+	r.count--
 }
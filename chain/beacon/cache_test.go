@@ -8,6 +8,7 @@ import (
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,18 +36,64 @@ func TestCacheRound(t *testing.T) {
 	partial := generatePartial(1, round, prev)
 	p2 := generatePartial(2, round, prev)
 	cache := newRoundCache(id, partial)
-	require.True(t, cache.append(partial))
-	require.False(t, cache.append(partial))
+	require.True(t, cache.append(partial, 2))
+	require.False(t, cache.append(partial, 2))
 	require.Equal(t, 1, cache.Len())
-	require.Equal(t, msg, cache.Msg())
+	require.Equal(t, msg, cache.Msg(&chain.Info{}))
 
-	require.True(t, cache.append(p2))
+	require.True(t, cache.append(p2, 2))
 	require.Equal(t, 2, cache.Len())
-	require.Contains(t, cache.Partials(), partial.GetPartialSig())
-	require.Contains(t, cache.Partials(), p2.GetPartialSig())
+	require.Contains(t, cache.shares, 1)
+	require.Contains(t, cache.shares, 2)
+	require.Equal(t, []int{1, 2}, cache.Indices())
 	cache.flushIndex(2)
 	require.Equal(t, 1, cache.Len())
-	require.Nil(t, cache.sigs[2])
+	require.Nil(t, cache.shares[2])
+	require.Equal(t, []int{1}, cache.Indices())
+
+	// only the shares needed for the recovery are kept
+	require.True(t, cache.append(p2, 2))
+	require.True(t, cache.append(generatePartial(3, round, prev), 2))
+	require.Equal(t, 3, cache.Len())
+	require.Len(t, cache.shares, 2)
+	require.Equal(t, []int{1, 2, 3}, cache.Indices())
+}
+
+func TestCacheRoundRecover(t *testing.T) {
+	n, thr := 7, 4
+	secret := key.KeyGroup.Scalar().Pick(random.New())
+	priv := share.NewPriPoly(key.KeyGroup, thr, secret, random.New())
+	pub := priv.Commit(key.KeyGroup.Point().Base())
+	var round uint64 = 10
+	prev := []byte("previous signature")
+	msg := chain.Message(round, prev)
+
+	var partials [][]byte
+	var cache *roundCache
+	for _, sh := range priv.Shares(n) {
+		sig, err := key.Scheme.Sign(sh, msg)
+		require.NoError(t, err)
+		partials = append(partials, sig)
+		p := &drand.PartialBeaconPacket{Round: round, PreviousSig: prev, PartialSig: sig}
+		if cache == nil {
+			cache = newRoundCache(roundID(round, prev), p)
+		}
+		cache.append(p, thr)
+	}
+	require.Equal(t, n, cache.Len())
+	require.Len(t, cache.shares, thr)
+
+	sig, err := cache.Recover(thr, n)
+	require.NoError(t, err)
+	require.NoError(t, key.Scheme.VerifyRecovered(pub.Commit(), msg, sig))
+	expected, err := key.Scheme.Recover(pub, msg, partials, thr, n)
+	require.NoError(t, err)
+	require.Equal(t, expected, sig)
+
+	cache.flushIndex(cache.Indices()[0])
+	cache.flushIndex(cache.Indices()[0])
+	_, err = cache.Recover(thr, n)
+	require.Error(t, err)
 }
 
 func TestCachePartial(t *testing.T) {
@@ -57,11 +104,11 @@ func TestCachePartial(t *testing.T) {
 
 	id := roundID(round, prev)
 	p1 := generatePartial(1, round, prev)
-	cache.Append(p1)
+	cache.Append(p1, 2)
 	require.Equal(t, 1, len(cache.rcvd))
 	require.Equal(t, 1, cache.GetRoundCache(round, prev).Len())
 	// duplicate entry shouldn't change anything
-	cache.Append(p1)
+	cache.Append(p1, 2)
 	require.Equal(t, 1, len(cache.rcvd))
 	require.Equal(t, 1, len(cache.rcvd[1]))
 	require.Equal(t, 1, cache.GetRoundCache(round, prev).Len())
@@ -72,20 +119,45 @@ func TestCachePartial(t *testing.T) {
 		newPrev := []byte{1, 9, 6, 9, byte(i)}
 		newID := roundID(round, newPrev)
 		p1bis := generatePartial(1, round, newPrev)
-		cache.Append(p1bis)
-		require.Contains(t, cache.rcvd[1], newID)
+		cache.Append(p1bis, 2)
+		if i < MaxPartialsPerNode-1 {
+			require.Contains(t, cache.rcvd[1], newID)
+		} else {
+			// the partials held for the recovery are never evicted, the new
+			// ones are dropped instead
+			require.NotContains(t, cache.rcvd[1], newID)
+		}
 	}
-	// the cache should have dropped the first ID entered by this node
-	require.NotContains(t, cache.rcvd[1], id)
+	require.Contains(t, cache.rcvd[1], id)
 	// only one signer pushed things, so there should always be this number
 	// maximum of partials
 	require.Equal(t, MaxPartialsPerNode, len(cache.rounds))
+	require.Len(t, cache.rcvd[1], MaxPartialsPerNode)
+
+	// a partial of the signer arriving once the round holds enough shares is
+	// a duplicate, evicted in favour of a new round
+	dupPrev := []byte{1, 9, 6, 9, 0}
+	dupCache := newPartialCache(l)
+	dupCache.Append(generatePartial(2, round, dupPrev), 1)
+	dupCache.Append(generatePartial(1, round, dupPrev), 1)
+	require.Equal(t, 1, dupCache.GetRoundCache(round, dupPrev).Shares())
+	require.Equal(t, 2, dupCache.GetRoundCache(round, dupPrev).Len())
+	for i := 1; i < MaxPartialsPerNode; i++ {
+		dupCache.Append(generatePartial(1, round, []byte{byte(i)}), 1)
+	}
+	require.Len(t, dupCache.rcvd[1], MaxPartialsPerNode)
+	newPrev := []byte("new round")
+	dupCache.Append(generatePartial(1, round, newPrev), 1)
+	require.Contains(t, dupCache.rcvd[1], roundID(round, newPrev))
+	require.NotContains(t, dupCache.rcvd[1], roundID(round, dupPrev))
+	require.Equal(t, 1, dupCache.GetRoundCache(round, dupPrev).Shares())
+	require.Equal(t, 1, dupCache.GetRoundCache(round, dupPrev).Len())
 
 	// insert some previous rounds and then flush
 	toFlush := 20
 	for i := 1; i <= toFlush; i++ {
 		p := generatePartial(i+1, round-uint64(i), prev)
-		cache.Append(p)
+		cache.Append(p, 2)
 	}
 	total := MaxPartialsPerNode + toFlush
 	require.Equal(t, total, len(cache.rounds))
@@ -97,3 +169,16 @@ func TestCachePartial(t *testing.T) {
 		require.Nil(t, cache.rcvd[i+1], "failed for signer %d", i+1)
 	}
 }
+
+func TestCheckPartialLength(t *testing.T) {
+	seed := make([]byte, 32)
+	prev := make([]byte, key.SigGroup.PointLen())
+	require.NoError(t, checkPartialLength(generatePartial(1, 10, prev), len(seed)))
+	// the first round is signed over the genesis seed
+	require.NoError(t, checkPartialLength(generatePartial(1, 1, seed), len(seed)))
+	require.Error(t, checkPartialLength(generatePartial(1, 10, seed), len(seed)))
+
+	p := generatePartial(1, 10, prev)
+	p.PartialSig = p.PartialSig[1:]
+	require.Error(t, checkPartialLength(p, len(seed)))
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -46,3 +47,30 @@ func checkOne(ch chan bool) bool {
 		return false
 	}
 }
+
+func TestQuotaStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bbstore, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	l := log.NewLogger(nil, log.LogNone)
+
+	unlimited := newQuotaStore(bbstore, l, 0)
+	require.NoError(t, unlimited.Put(&chain.Beacon{Round: 1}))
+
+	size := bbstore.(sizer).Size()
+	require.True(t, size > 0)
+	full := newQuotaStore(bbstore, l, size)
+	require.Equal(t, ErrStoreFull, full.Put(&chain.Beacon{Round: 2}))
+	require.Equal(t, 1, bbstore.Len())
+
+	roomy := newQuotaStore(bbstore, l, size*2)
+	require.NoError(t, roomy.Put(&chain.Beacon{Round: 2}))
+
+	// a maximum size can't be enforced on a store unable to report its size
+	unsized := struct{ chain.Store }{bbstore}
+	_, err = NewHandler(nil, unsized, &Config{MaxStoreSize: size}, l)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum store size")
+}
@@ -0,0 +1,154 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/net"
+	proto "github.com/drand/drand/protobuf/drand"
+	protobuf "github.com/golang/protobuf/proto"
+)
+
+// syncChunk is a range of rounds fetched from a single peer during a parallel
+// catch up.
+type syncChunk struct {
+	from, to uint64
+	peer     net.Peer
+	// beacons holds the round before from, which is the last round of the
+	// previous chunk, followed by the rounds of the chunk.
+	beacons []*chain.Beacon
+	err     error
+}
+
+// catchup fetches the rounds following the last stored one up to target as
+// disjoint chunks from several nodes in parallel, and stores them in order.
+// Each chunk starts with the last round of the previous chunk, fetched from
+// another node, so the nodes are cross-checked. The nodes failing to deliver a
+// valid chunk are not used anymore. It returns the last beacon stored.
+func (s *syncer) catchup(ctx context.Context, target uint64, nodes []net.Peer) (*chain.Beacon, error) {
+	last, err := s.store.Last()
+	if err != nil {
+		return nil, err
+	}
+	peers := make([]net.Peer, 0, len(nodes))
+	throttles := make(map[string]*throttle, len(nodes))
+	for _, i := range rand.Perm(len(nodes)) {
+		peers = append(peers, nodes[i])
+		throttles[nodes[i].Address()] = newThrottle(s.clock, s.limits)
+	}
+	size := s.limits.chunkSize()
+	s.l.Debug("syncer", "parallel_catchup", "from_round", last.Round+1, "to_round", target, "nodes", peersToString(peers))
+	for last.Round < target && len(peers) > 0 {
+		if err := ctx.Err(); err != nil {
+			return last, err
+		}
+		chunks := make([]syncChunk, 0, s.limits.parallel())
+		for from := last.Round + 1; from <= target && len(chunks) < cap(chunks) && len(chunks) < len(peers); {
+			to := from + size - 1
+			if to > target {
+				to = target
+			}
+			chunks = append(chunks, syncChunk{from: from, to: to, peer: peers[len(chunks)]})
+			from = to + 1
+		}
+		var wg sync.WaitGroup
+		for i := range chunks {
+			wg.Add(1)
+			go func(c *syncChunk, t *throttle) {
+				defer wg.Done()
+				c.beacons, c.err = s.fetchChunk(ctx, c.peer, c.from, c.to, t)
+			}(&chunks[i], throttles[chunks[i].peer.Address()])
+		}
+		wg.Wait()
+
+		// store the chunks in order until the first invalid one, which is
+		// fetched again from another node
+		failed := make(map[string]bool)
+		stored := true
+		for i := range chunks {
+			c := &chunks[i]
+			if c.err == nil && stored && !c.beacons[0].Equal(last) {
+				c.err = fmt.Errorf("round %d differs from the one of another node", last.Round)
+			}
+			if c.err != nil {
+				s.l.Debug("syncer", "invalid_chunk", "with_peer", c.peer.Address(), "from_round", c.from, "to_round", c.to, "err", c.err)
+				failed[c.peer.Address()] = true
+				stored = false
+			}
+			if !stored {
+				continue
+			}
+			for _, b := range c.beacons[1:] {
+				if err := s.store.Put(b); err != nil {
+					return last, err
+				}
+				last = b
+			}
+		}
+		var valid []net.Peer
+		for _, p := range peers {
+			if !failed[p.Address()] {
+				valid = append(valid, p)
+			}
+		}
+		peers = valid
+	}
+	if last.Round < target {
+		return last, fmt.Errorf("no node left to fetch the rounds from %d to %d", last.Round+1, target)
+	}
+	return last, nil
+}
+
+// fetchChunk returns the beacons from the round before from up to the round
+// to, verified and chained, streamed from the given node.
+func (s *syncer) fetchChunk(ctx context.Context, n net.Peer, from, to uint64, t *throttle) ([]*chain.Beacon, error) {
+	cnode, cancel := context.WithCancel(ctx)
+	defer cancel()
+	// give up on a node that stops sending beacons
+	stall := time.AfterFunc(SyncStallTimeout, cancel)
+	defer stall.Stop()
+	hash := s.info().Hash()
+	beaconCh, err := s.client.SyncChain(cnode, n, &proto.SyncRequest{
+		FromRound: from - 1,
+		ChainHash: hash,
+		UpTo:      to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	beacons := make([]*chain.Beacon, 0, to-from+2)
+	for beaconPacket := range beaconCh {
+		if ch := beaconPacket.GetChainHash(); len(ch) > 0 && !bytes.Equal(ch, hash) {
+			return nil, errors.New("beacon from another chain")
+		}
+		b := protoToBeacon(beaconPacket)
+		if expected := from - 1 + uint64(len(beacons)); b.Round != expected {
+			return nil, fmt.Errorf("received round %d instead of %d", b.Round, expected)
+		}
+		if len(beacons) > 0 && !bytes.Equal(b.PreviousSig, beacons[len(beacons)-1].Signature) {
+			return nil, fmt.Errorf("round %d does not follow the previous round", b.Round)
+		}
+		// the genesis beacon is not signed
+		if b.Round > 0 {
+			if err := s.info().VerifyBeacon(b); err != nil {
+				return nil, fmt.Errorf("invalid beacon for round %d: %s", b.Round, err)
+			}
+		}
+		beacons = append(beacons, b)
+		if b.Round == to {
+			return beacons, nil
+		}
+		stall.Stop()
+		if err := t.wait(cnode, protobuf.Size(beaconPacket)); err != nil {
+			return nil, err
+		}
+		stall.Reset(SyncStallTimeout)
+	}
+	return nil, fmt.Errorf("stream ended after %d of %d rounds", len(beacons), to-from+2)
+}
@@ -1,8 +1,12 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"sync/atomic"
+	"time"
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/key"
@@ -21,6 +25,8 @@ const (
 // be inserted in the database and for replying to beacon requests.
 type chainStore struct {
 	CallbackStore
+	// ctx is done when the handler stops
+	ctx         context.Context
 	l           log.Logger
 	conf        *Config
 	client      net.ProtocolClient
@@ -35,19 +41,32 @@ type chainStore struct {
 	// all beacons finally inserted into the store are sent over this cannel for
 	// the aggregation loop to know
 	beaconStoredAgg chan *chain.Beacon
+	// reports keeps track of the partials received for the last rounds
+	reports *roundReports
+	// rounds whose aggregation grace period expired
+	graceExpired chan *roundCache
+	// the degraded nodes are not waited for during the grace period
+	skews *skewTracker
+	// fetching is set while missing previous beacons are being fetched
+	fetching int32
 }
 
-func newChainStore(l log.Logger, cf *Config, cl net.ProtocolClient, c *cryptoStore, store chain.Store, t *ticker) *chainStore {
+func newChainStore(ctx context.Context, l log.Logger, cf *Config, cl net.ProtocolClient, c *cryptoStore, store chain.Store, t *ticker, skews *skewTracker) *chainStore {
+	// we make sure the database doesn't grow beyond its allowed size
+	qs := newQuotaStore(store, l, cf.MaxStoreSize)
 	// we make sure the chain is increasing monotically
-	as := newAppendStore(store)
+	as := newAppendStore(qs)
 	// we write some stats about the timing when new beacon is saved
-	ds := newDiscrepancyStore(as, l, c.GetGroup())
+	ds := newDiscrepancyStore(as, l, t.Schedule)
+	// we keep the latest beacons in memory for the readers
+	lc := newCacheStore(ds, cf.CacheSize)
 	// we can register callbacks on it
-	cbs := NewCallbackStore(ds)
+	cbs := NewCallbackStore(lc)
 	// we give the final append store to the syncer
-	syncer := NewSyncer(l, cbs, c.chain, cl)
+	syncer := newSyncer(l, cbs, c.GetInfo, cl, cf.Clock, cf.SyncLimits)
 	cs := &chainStore{
 		CallbackStore:   cbs,
+		ctx:             ctx,
 		l:               l,
 		conf:            cf,
 		client:          cl,
@@ -58,6 +77,9 @@ func newChainStore(l log.Logger, cf *Config, cl net.ProtocolClient, c *cryptoSto
 		newPartials:     make(chan partialInfo, defaultPartialChanBuffer),
 		catchupBeacons:  make(chan *chain.Beacon, 1),
 		beaconStoredAgg: make(chan *chain.Beacon, defaultNewBeaconBuffer),
+		reports:         newRoundReports(t.Schedule),
+		graceExpired:    make(chan *roundCache, defaultPartialChanBuffer),
+		skews:           skews,
 	}
 	// we add callbacks to notify each time a final beacon is stored on the
 	// database so to update the latest view
@@ -101,6 +123,7 @@ func (c *chainStore) runAggregator() {
 			return
 		case lastBeacon = <-c.beaconStoredAgg:
 			cache.FlushRounds(lastBeacon.Round)
+			c.reports.Flush(lastBeacon.Round)
 			break
 		case partial := <-c.newPartials:
 			// look if we have info for this round first
@@ -109,67 +132,198 @@ func (c *chainStore) runAggregator() {
 			isNotInPast := pRound > lastBeacon.Round
 			isNotTooFar := pRound <= lastBeacon.Round+uint64(partialCacheStoreLimit+1)
 			shouldStore := isNotInPast && isNotTooFar
+			idx, _ := key.Scheme.IndexOf(partial.p.GetPartialSig())
+			if pRound == lastBeacon.Round {
+				c.reports.late(pRound, idx, c.conf.Clock.Now())
+			} else if shouldStore {
+				c.reports.received(pRound, idx, c.conf.Clock.Now())
+			}
 			// check if we can reconstruct
 			if !shouldStore {
 				c.l.Debug("ignoring_partial", partial.p.GetRound(), "last_beacon_stored", lastBeacon.Round)
 				break
 			}
+			if pRound > lastBeacon.Round+1 {
+				// the sender signed over a previous signature we don't have
+				// yet: fetch the missing beacons directly from it instead of
+				// falling further behind.
+				c.fetchPrevious(partial, lastBeacon.Round, pRound-1)
+			}
 			// NOTE: This line means we can only verify partial signatures of
 			// the current group we are in as only current members should
 			// participate in the randomness generation. Previous beacons can be
 			// verified using the single distributed public key point from the
 			// crypto store.
-			thr := c.crypto.GetGroup().Threshold
-			n := c.crypto.GetGroup().Len()
-			cache.Append(partial.p)
+			group := c.crypto.GetGroupAt(pRound)
+			thr := group.Threshold
+			n := group.Len()
+			cache.Append(partial.p, thr)
 			roundCache := cache.GetRoundCache(partial.p.GetRound(), partial.p.GetPreviousSig())
 			if roundCache == nil {
-				c.l.Error("store_partial", partial.addr, "no_round_cache", partial.p.GetRound())
-				break
-			}
-
-			c.l.Debug("store_partial", partial.addr, "round", roundCache.round, "len_partials", fmt.Sprintf("%d/%d", roundCache.Len(), thr))
-			if roundCache.Len() < thr {
+				c.l.Debug("store_partial", partial.addr, "no_round_cache", partial.p.GetRound())
 				break
 			}
 
-			msg := roundCache.Msg()
-			finalSig, err := key.Scheme.Recover(c.crypto.GetPub(), msg, roundCache.Partials(), thr, n)
-			if err != nil {
-				c.l.Debug("invalid_recovery", err, "round", pRound, "got", fmt.Sprintf("%d/%d", roundCache.Len(), n))
+			c.l.Debug("store_partial", partial.addr, "round", roundCache.round, "len_partials", fmt.Sprintf("%d/%d", roundCache.Shares(), thr))
+			if roundCache.Shares() < thr {
 				break
 			}
-			if err := key.Scheme.VerifyRecovered(c.crypto.GetPub().Commit(), msg, finalSig); err != nil {
-				c.l.Error("invalid_sig", err, "round", pRound)
+			// wait for the partials of all the nodes not degraded during the
+			// grace period
+			if grace := c.aggregationGrace(); grace > 0 && roundCache.Len() < n-c.skews.countDegraded() {
+				if !roundCache.waiting {
+					roundCache.waiting = true
+					go c.expireGrace(roundCache, grace)
+				}
 				break
 			}
-			cache.FlushRounds(partial.p.GetRound())
-			newBeacon := &chain.Beacon{
-				Round:       roundCache.round,
-				PreviousSig: roundCache.prev,
-				Signature:   finalSig,
-			}
-			c.l.Info("aggregated_beacon", newBeacon.Round)
-			if c.tryAppend(lastBeacon, newBeacon) {
-				lastBeacon = newBeacon
+			lastBeacon = c.aggregate(cache, roundCache, lastBeacon)
+		case roundCache := <-c.graceExpired:
+			// the round may have been aggregated or flushed since
+			if cache.GetRoundCache(roundCache.round, roundCache.prev) != roundCache {
 				break
 			}
-			// XXX store them for lfutur usage if it's a later round than what
-			// we have
-			c.l.Debug("new_aggregated", "not_appendable", "last", lastBeacon.String(), "new", newBeacon.String())
-			if c.shouldSync(lastBeacon, newBeacon) {
-				peers := toPeers(c.crypto.GetGroup().Nodes)
-				go func() {
-					// XXX Could do something smarter with context and cancellation
-					// if we got to the right round
-					if err := c.sync.Follow(context.Background(), newBeacon.Round, peers); err != nil {
-						c.l.Debug("chain_store", "unable to follow", "err", err)
-					}
-				}()
+			c.l.Debug("aggregation_grace", "expired", "round", roundCache.round, "len_partials", roundCache.Len())
+			lastBeacon = c.aggregate(cache, roundCache, lastBeacon)
+		}
+	}
+}
+
+// aggregationGrace returns the time to wait for more partials once the
+// threshold is reached, bounded by half a period.
+func (c *chainStore) aggregationGrace() time.Duration {
+	grace := c.conf.AggregationGrace
+	if max := c.currentPeriod() / 2; grace > max {
+		grace = max
+	}
+	return grace
+}
+
+// currentPeriod returns the period of the current round
+func (c *chainStore) currentPeriod() time.Duration {
+	return c.ticker.Schedule().PeriodAt(c.ticker.CurrentRound())
+}
+
+func (c *chainStore) expireGrace(r *roundCache, grace time.Duration) {
+	select {
+	case <-c.conf.Clock.After(grace):
+	case <-c.done:
+		return
+	}
+	select {
+	case c.graceExpired <- r:
+	case <-c.done:
+	}
+}
+
+// aggregate recovers the beacon of the round from the cached partials and
+// appends it to the chain. It returns the last beacon of the chain.
+func (c *chainStore) aggregate(cache *partialCache, roundCache *roundCache, lastBeacon *chain.Beacon) *chain.Beacon {
+	group := c.crypto.GetGroupAt(roundCache.round)
+	thr := group.Threshold
+	n := group.Len()
+	msg := roundCache.Msg(c.crypto.GetInfo())
+	finalSig, err := roundCache.Recover(thr, n)
+	if err != nil {
+		c.l.Debug("invalid_recovery", err, "round", roundCache.round, "got", fmt.Sprintf("%d/%d", roundCache.Len(), n))
+		return lastBeacon
+	}
+	if err := key.Scheme.VerifyRecovered(c.crypto.GetPub().Commit(), msg, finalSig); err != nil {
+		c.l.Error("invalid_sig", err, "round", roundCache.round)
+		return lastBeacon
+	}
+	cache.FlushRounds(roundCache.round)
+	newBeacon := &chain.Beacon{
+		Round:        roundCache.round,
+		PreviousSig:  roundCache.prev,
+		Signature:    finalSig,
+		Contributors: chain.ContributorsBitmap(roundCache.Indices()),
+	}
+	c.l.Info("aggregated_beacon", newBeacon.Round)
+	if c.tryAppend(lastBeacon, newBeacon) {
+		c.reports.aggregated(newBeacon.Round, c.conf.Clock.Now())
+		return newBeacon
+	}
+	// XXX store them for lfutur usage if it's a later round than what
+	// we have
+	c.l.Debug("new_aggregated", "not_appendable", "last", lastBeacon.String(), "new", newBeacon.String())
+	if c.shouldSync(lastBeacon, newBeacon) {
+		peers := toPeers(c.crypto.GetGroup().Nodes)
+		go func() {
+			if err := c.sync.Follow(c.ctx, newBeacon.Round, peers); err != nil {
+				c.l.Debug("chain_store", "unable to follow", "err", err)
 			}
-			break
+		}()
+	}
+	return lastBeacon
+}
+
+// fetchPrevious fetches the beacons from last+1 up to round upTo, one by one,
+// from the node that sent the given partial. Each beacon is verified before
+// being stored. It is a no-op if a sync or another fetch is already in
+// progress.
+func (c *chainStore) fetchPrevious(partial partialInfo, last, upTo uint64) {
+	if c.sync.Syncing() {
+		return
+	}
+	idx, err := key.Scheme.IndexOf(partial.p.GetPartialSig())
+	if err != nil {
+		return
+	}
+	node := c.crypto.GetGroup().Node(key.Index(idx))
+	if node == nil {
+		return
+	}
+	// the flag is set before the fetch starts so the next partials don't start
+	// another one in the meantime
+	if !atomic.CompareAndSwapInt32(&c.fetching, 0, 1) {
+		return
+	}
+	c.l.Debug("chain_store", "missing_previous", "last", last, "up_to", upTo, "from", node.Address())
+	period := c.currentPeriod()
+	go func() {
+		defer atomic.StoreInt32(&c.fetching, 0)
+		ctx, cancel := context.WithTimeout(c.ctx, period)
+		defer cancel()
+		if err := c.fetchRounds(ctx, node.Identity, upTo); err != nil {
+			c.l.Debug("chain_store", "unable to fetch previous", "err", err)
+		}
+	}()
+}
+
+// fetchRounds requests the rounds following the last stored beacon up to upTo
+// from the given peer, and appends each of them once verified.
+func (c *chainStore) fetchRounds(ctx context.Context, p net.Peer, upTo uint64) error {
+	last, err := c.Last()
+	if err != nil {
+		return err
+	}
+	info := c.crypto.GetInfo()
+	hash := info.Hash()
+	for last.Round < upTo {
+		packet, err := c.client.GetBeacon(ctx, p, &drand.GetBeaconRequest{Round: last.Round + 1, ChainHash: hash})
+		if err != nil {
+			return err
+		}
+		if ch := packet.GetChainHash(); len(ch) > 0 && !bytes.Equal(ch, hash) {
+			return errors.New("beacon from another chain")
+		}
+		b := protoToBeacon(packet)
+		if b.Round != last.Round+1 {
+			return fmt.Errorf("received round %d instead of %d", b.Round, last.Round+1)
+		}
+		if !bytes.Equal(b.PreviousSig, last.Signature) {
+			return fmt.Errorf("round %d does not follow the previous round", b.Round)
+		}
+		if err := info.VerifyBeacon(b); err != nil {
+			return fmt.Errorf("invalid beacon for round %d: %s", b.Round, err)
+		}
+		if !c.tryAppend(last, b) {
+			return fmt.Errorf("unable to store round %d", b.Round)
 		}
+		last = b
 	}
+	return nil
 }
 
 func (c *chainStore) tryAppend(last, newB *chain.Beacon) bool {
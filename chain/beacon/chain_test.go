@@ -0,0 +1,165 @@
+package beacon
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	"github.com/drand/kyber/share"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+// idleSyncer never reports it is syncing.
+type idleSyncer struct {
+	Syncer
+}
+
+func (s *idleSyncer) Syncing() bool {
+	return false
+}
+
+// beaconTestClient serves the beacons of a chain one by one. If release is
+// set, the requests block until it is closed.
+type beaconTestClient struct {
+	net.ProtocolClient
+	chain    []*chain.Beacon
+	requests int32
+	release  chan struct{}
+}
+
+func (c *beaconTestClient) GetBeacon(ctx context.Context, p net.Peer, in *drand.GetBeaconRequest, opts ...net.CallOption) (*drand.BeaconPacket, error) {
+	atomic.AddInt32(&c.requests, 1)
+	if c.release != nil {
+		select {
+		case <-c.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if in.GetRound() >= uint64(len(c.chain)) {
+		return nil, errors.New("no beacon stored")
+	}
+	return beaconToProto(c.chain[in.GetRound()], nil), nil
+}
+
+func TestChainStoreFetchPreviousOnce(t *testing.T) {
+	shares, commits := dkgShares(3, 2)
+	_, group := test.BatchIdentities(3)
+	group.Threshold = 2
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+
+	dir, err := ioutil.TempDir("", "drand-chain")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	require.NoError(t, store.Put(&chain.Beacon{Round: 0, Signature: []byte("genesis")}))
+
+	l := log.DefaultLogger()
+	conf := &Config{Group: group, Share: shares[0], Clock: clock.NewFakeClock()}
+	ticker := newTicker(conf.Clock, chain.NewSchedule(group), 0, l)
+	defer ticker.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs := newChainStore(ctx, l, conf, nil, newCryptoStore(group, shares[0]), store, ticker, newSkewTracker(0))
+	defer cs.Stop()
+	cs.sync = &idleSyncer{}
+	client := &beaconTestClient{release: make(chan struct{})}
+	cs.client = client
+
+	// the partials of round 3 are signed over a beacon we don't have
+	sig, err := key.Scheme.Sign(shares[1].PrivateShare(), []byte("round 3"))
+	require.NoError(t, err)
+	partial := &drand.PartialBeaconPacket{Round: 3, PreviousSig: []byte("round 2"), PartialSig: sig}
+	for i := 0; i < 5; i++ {
+		cs.NewValidPartial(group.Nodes[1].Address(), partial)
+	}
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&client.requests) == 1
+	}, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, int32(1), atomic.LoadInt32(&client.requests))
+
+	// another fetch can start once the first one is done
+	close(client.release)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cs.fetching) == 0
+	}, time.Second, 10*time.Millisecond)
+	cs.NewValidPartial(group.Nodes[1].Address(), partial)
+	require.Eventually(t, func() bool {
+		return atomic.LoadInt32(&client.requests) == 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestChainStoreFetchPrevious(t *testing.T) {
+	shares, commits := dkgShares(3, 2)
+	_, group := test.BatchIdentities(3)
+	group.Threshold = 2
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+
+	dir, err := ioutil.TempDir("", "drand-chain")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	store, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	genesis := &chain.Beacon{Round: 0, Signature: []byte("genesis")}
+	require.NoError(t, store.Put(genesis))
+
+	l := log.DefaultLogger()
+	conf := &Config{Group: group, Share: shares[0], Clock: clock.NewFakeClock()}
+	ticker := newTicker(conf.Clock, chain.NewSchedule(group), 0, l)
+	defer ticker.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cs := newChainStore(ctx, l, conf, nil, newCryptoStore(group, shares[0]), store, ticker, newSkewTracker(0))
+	defer cs.Stop()
+	cs.sync = &idleSyncer{}
+
+	// the peer has the rounds 1 and 2 we are missing
+	info := cs.crypto.GetInfo()
+	pubPoly := share.NewPubPoly(key.KeyGroup, nil, commits)
+	beacons := []*chain.Beacon{genesis}
+	for round := uint64(1); round <= 2; round++ {
+		prev := beacons[round-1]
+		msg := info.Message(round, prev.Signature)
+		var partials [][]byte
+		for _, s := range shares[:2] {
+			partial, err := key.Scheme.Sign(s.PrivateShare(), msg)
+			require.NoError(t, err)
+			partials = append(partials, partial)
+		}
+		sig, err := key.Scheme.Recover(pubPoly, msg, partials, 2, 3)
+		require.NoError(t, err)
+		beacons = append(beacons, &chain.Beacon{Round: round, PreviousSig: prev.Signature, Signature: sig})
+	}
+	client := &beaconTestClient{chain: beacons}
+	cs.client = client
+
+	sig, err := key.Scheme.Sign(shares[1].PrivateShare(), info.Message(3, beacons[2].Signature))
+	require.NoError(t, err)
+	partial := &drand.PartialBeaconPacket{Round: 3, PreviousSig: beacons[2].Signature, PartialSig: sig}
+	cs.NewValidPartial(group.Nodes[1].Address(), partial)
+	require.Eventually(t, func() bool {
+		last, err := cs.Last()
+		return err == nil && last.Round == 2
+	}, time.Second, 10*time.Millisecond)
+	for _, b := range beacons[1:] {
+		stored, err := cs.Get(b.Round)
+		require.NoError(t, err)
+		require.True(t, b.Equal(stored))
+	}
+	// a single beacon is requested per missing round
+	require.Equal(t, int32(2), atomic.LoadInt32(&client.requests))
+}
@@ -20,3 +20,55 @@ const MaxCatchupBuffer = 1000
 // CallbackWorkerQueue is the length of the channel that the callback worker
 // uses to dispatch beacons to its workers.
 const CallbackWorkerQueue = 100
+
+// partialIndexLen is the length of the index prefixed to each partial
+// signature by the threshold signature scheme.
+const partialIndexLen = 2
+
+// MaxRoundReports is the number of rounds for which the node keeps a report
+// of the partials received and the aggregation time.
+const MaxRoundReports = 100
+
+// MaxPartialRequests is the maximum number of partial beacons being sent to
+// other nodes at the same time. It bounds the number of goroutines and
+// requests in flight for large groups.
+const MaxPartialRequests = 100
+
+// DefaultMaxClockSkew is the maximum difference between the time at which a
+// node sends a partial and the time at which it is received, when not
+// configured.
+const DefaultMaxClockSkew = time.Second
+
+// DefaultMaxClockJump is the largest difference between the time slept by the
+// ticker measured by the wall clock and by the monotonic clock before the
+// ticker considers that the clock of the host jumped, when not configured.
+const DefaultMaxClockJump = 2 * time.Second
+
+// DefaultPartialWindow is the number of rounds before the last stored beacon
+// for which the partials are still processed, when not configured.
+const DefaultPartialWindow = 2
+
+// DefaultCacheSize is the number of beacons kept in memory in front of the
+// database, when not configured.
+const DefaultCacheSize = 128
+
+// DefaultPreviousEpochRounds is the number of rounds after a resharing during
+// which the keys of the previous group are kept, when not configured.
+const DefaultPreviousEpochRounds = 10
+
+// SkewStrikes is the number of consecutive partials exceeding the maximum
+// clock skew after which a node is degraded.
+const SkewStrikes = 3
+
+// SyncChunkSize is the number of rounds fetched from each peer when catching
+// up with the chain from several peers in parallel, unless a batch size is
+// set.
+const SyncChunkSize = 200
+
+// DefaultSyncParallel is the number of peers the chain is fetched from in
+// parallel when catching up, when not configured.
+const DefaultSyncParallel = 4
+
+// SyncStallTimeout is the time after which a peer not sending any beacon of
+// the range requested during a parallel catch up is given up.
+var SyncStallTimeout = 10 * time.Second
@@ -5,18 +5,21 @@ import (
 	proto "github.com/drand/drand/protobuf/drand"
 )
 
-func beaconToProto(b *chain.Beacon) *proto.BeaconPacket {
+func beaconToProto(b *chain.Beacon, chainHash []byte) *proto.BeaconPacket {
 	return &proto.BeaconPacket{
-		PreviousSig: b.PreviousSig,
-		Round:       b.Round,
-		Signature:   b.Signature,
+		PreviousSig:  b.PreviousSig,
+		Round:        b.Round,
+		Signature:    b.Signature,
+		ChainHash:    chainHash,
+		Contributors: b.Contributors,
 	}
 }
 
 func protoToBeacon(p *proto.BeaconPacket) *chain.Beacon {
 	return &chain.Beacon{
-		Round:       p.GetRound(),
-		Signature:   p.GetSignature(),
-		PreviousSig: p.GetPreviousSig(),
+		Round:        p.GetRound(),
+		Signature:    p.GetSignature(),
+		PreviousSig:  p.GetPreviousSig(),
+		Contributors: p.GetContributors(),
 	}
 }
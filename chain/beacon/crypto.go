@@ -17,6 +17,13 @@ type CryptoSafe interface {
 // cryptoStore stores the information necessary to validate partial beacon, full
 // beacons and to sign new partial beacons (it implements CryptoSafe interface).
 // cryptoStore is thread safe when using the methods.
+//
+// cryptoStore is a key ring: the keys of the next epoch are installed as soon
+// as a resharing is done, keyed by the round the new group starts at, and the
+// keys of each round are selected by its number. After the transition, the
+// share and group of the previous epoch are kept, read only, so the partials
+// of the rounds before the transition can still be signed and verified while
+// the new share is used for the following rounds.
 type cryptoStore struct {
 	sync.Mutex
 	// current share of the node
@@ -25,13 +32,32 @@ type cryptoStore struct {
 	pub *share.PubPoly
 	// chian info to verify final random beacon
 	chain *chain.Info
+	// hash of the chain, stamped on the packets sent
+	hash []byte
 	// to know the threshold, transition time etc
 	group *key.Group
+	// previous is the epoch before the last resharing, nil if there is none
+	// or it expired
+	previous *epoch
+	// next is the epoch of the resharing to come, nil if there is none
+	next *epoch
+}
+
+// epoch holds the keys of a group, used from round first to round last
+// included.
+type epoch struct {
+	share *key.Share
+	group *key.Group
+	pub   *share.PubPoly
+	first uint64
+	last  uint64
 }
 
 func newCryptoStore(currentGroup *key.Group, ks *key.Share) *cryptoStore {
+	info := chain.NewChainInfo(currentGroup)
 	return &cryptoStore{
-		chain: chain.NewChainInfo(currentGroup),
+		chain: info,
+		hash:  info.Hash(),
 		share: ks,
 		pub:   currentGroup.PublicKey.PubPoly(),
 		group: currentGroup,
@@ -45,6 +71,20 @@ func (c *cryptoStore) GetGroup() *key.Group {
 	return c.group
 }
 
+// GetInfo returns the information of the chain
+func (c *cryptoStore) GetInfo() *chain.Info {
+	c.Lock()
+	defer c.Unlock()
+	return c.chain
+}
+
+// GetChainHash returns the hash of the chain
+func (c *cryptoStore) GetChainHash() []byte {
+	c.Lock()
+	defer c.Unlock()
+	return c.hash
+}
+
 func (c *cryptoStore) GetPub() *share.PubPoly {
 	c.Lock()
 	defer c.Unlock()
@@ -63,11 +103,91 @@ func (c *cryptoStore) Index() int {
 	return c.share.Share.I
 }
 
-func (c *cryptoStore) SetInfo(newGroup *key.Group, ks *key.Share) {
+// SetNext installs the keys of the new group for the rounds from round first,
+// before the transition happens.
+func (c *cryptoStore) SetNext(newGroup *key.Group, ks *key.Share, first uint64) {
+	c.Lock()
+	defer c.Unlock()
+	c.next = &epoch{share: ks, group: newGroup, pub: newGroup.PublicKey.PubPoly(), first: first}
+}
+
+// SwitchNext makes the next epoch starting at round first the current one.
+// The current keys are kept as the previous epoch until DropPrevious is
+// called. It does nothing if no such epoch is installed, e.g. if the switch
+// already happened.
+func (c *cryptoStore) SwitchNext(first uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if c.next == nil || c.next.first != first {
+		return
+	}
+	c.previous = &epoch{share: c.share, group: c.group, pub: c.pub, last: first - 1}
+	c.share = c.next.share
+	c.group = c.next.group
+	c.pub = c.next.pub
+	c.next = nil
+	// the chain info is constant except for the round at which the message
+	// format changes, which can be set during a resharing
+	c.chain = chain.NewChainInfo(c.group)
+	c.hash = c.chain.Hash()
+}
+
+// DropPrevious forgets the keys of the previous epoch if it ended at round
+// last, so that the keys of a later epoch are never dropped instead.
+func (c *cryptoStore) DropPrevious(last uint64) {
+	c.Lock()
+	defer c.Unlock()
+	if c.previous != nil && c.previous.last == last {
+		c.previous = nil
+	}
+}
+
+// at returns the epoch of the given round: the next one from its first round,
+// the previous one up to its last round if still kept, the current one
+// otherwise. It must be called with the lock held.
+func (c *cryptoStore) at(round uint64) epoch {
+	if c.next != nil && round >= c.next.first {
+		return *c.next
+	}
+	if c.previous != nil && round <= c.previous.last {
+		return *c.previous
+	}
+	return epoch{share: c.share, group: c.group, pub: c.pub}
+}
+
+// GetGroupAt returns the group signing the given round
+func (c *cryptoStore) GetGroupAt(round uint64) *key.Group {
+	c.Lock()
+	defer c.Unlock()
+	return c.at(round).group
+}
+
+// GetPubAt returns the public polynomial verifying the partials of the given
+// round
+func (c *cryptoStore) GetPubAt(round uint64) *share.PubPoly {
+	c.Lock()
+	defer c.Unlock()
+	return c.at(round).pub
+}
+
+// IndexAt returns the index of the share signing the given round
+func (c *cryptoStore) IndexAt(round uint64) int {
+	c.Lock()
+	defer c.Unlock()
+	return c.at(round).share.Share.I
+}
+
+// ShareAt returns the share signing the given round
+func (c *cryptoStore) ShareAt(round uint64) *key.Share {
+	c.Lock()
+	defer c.Unlock()
+	return c.at(round).share
+}
+
+// SignPartialAt returns the partial signature of the message of the given
+// round, with the share of the epoch of the round.
+func (c *cryptoStore) SignPartialAt(round uint64, msg []byte) ([]byte, error) {
 	c.Lock()
 	defer c.Unlock()
-	c.share = ks
-	c.group = newGroup
-	c.pub = newGroup.PublicKey.PubPoly()
-	// chain info is constant
+	return key.Scheme.Sign(c.at(round).share.PrivateShare(), msg)
 }
@@ -0,0 +1,61 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCryptoStoreEpochs(t *testing.T) {
+	oldShares, oldCommits := dkgShares(3, 2)
+	_, oldGroup := test.BatchIdentities(3)
+	oldGroup.Threshold = 2
+	oldGroup.PublicKey = &key.DistPublic{Coefficients: oldCommits}
+	newShares, newCommits := dkgShares(4, 3)
+	_, newGroup := test.BatchIdentities(4)
+	newGroup.Threshold = 3
+	newGroup.PublicKey = &key.DistPublic{Coefficients: newCommits}
+
+	cs := newCryptoStore(oldGroup, oldShares[0])
+	require.Equal(t, oldGroup, cs.GetGroupAt(20))
+	msg := []byte("round message")
+
+	// the keys of the next epoch are used from its first round as soon as
+	// they are installed
+	cs.SetNext(newGroup, newShares[1], 11)
+	require.Equal(t, oldGroup, cs.GetGroup())
+	require.Equal(t, oldGroup, cs.GetGroupAt(10))
+	require.Equal(t, newGroup, cs.GetGroupAt(11))
+	require.Equal(t, 1, cs.IndexAt(11))
+	sig, err := cs.SignPartialAt(11, msg)
+	require.NoError(t, err)
+	require.NoError(t, key.Scheme.VerifyPartial(cs.GetPubAt(11), msg, sig))
+
+	cs.SwitchNext(11)
+	// switching again does nothing
+	cs.SwitchNext(11)
+
+	// the rounds up to the transition use the keys of the previous epoch
+	require.Equal(t, oldGroup, cs.GetGroupAt(10))
+	require.Equal(t, 0, cs.IndexAt(10))
+	sig, err = cs.SignPartialAt(10, msg)
+	require.NoError(t, err)
+	require.NoError(t, key.Scheme.VerifyPartial(cs.GetPubAt(10), msg, sig))
+	require.Error(t, key.Scheme.VerifyPartial(cs.GetPubAt(11), msg, sig))
+
+	require.Equal(t, newGroup, cs.GetGroupAt(11))
+	require.Equal(t, newGroup, cs.GetGroup())
+	require.Equal(t, 1, cs.IndexAt(11))
+	sig, err = cs.SignPartialAt(11, msg)
+	require.NoError(t, err)
+	require.NoError(t, key.Scheme.VerifyPartial(cs.GetPubAt(11), msg, sig))
+
+	// only the epoch ending at the given round is dropped
+	cs.DropPrevious(9)
+	require.Equal(t, oldGroup, cs.GetGroupAt(10))
+	cs.DropPrevious(10)
+	require.Equal(t, newGroup, cs.GetGroupAt(10))
+	require.Equal(t, 1, cs.IndexAt(10))
+}
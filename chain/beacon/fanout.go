@@ -0,0 +1,136 @@
+package beacon
+
+import (
+	"context"
+	"sync"
+)
+
+// fanout sends the requests to the other nodes through one queue per node, so
+// that a slow node only delays its own requests. A queue holds at most one
+// request waiting to be sent besides the one in flight: a request for a later
+// round replaces it since the partial of an older round is not useful
+// anymore. The number of requests in flight is bounded across all the nodes,
+// so it does not grow with the size of the group. The connection to each node
+// is kept by the client and reused from one round to the next.
+type fanout struct {
+	sync.Mutex
+	ctx    context.Context
+	slots  chan struct{}
+	queues map[string]*peerQueue
+}
+
+// fanoutJob is a request to a node. run sends it, drop is called instead when
+// it is not sent.
+type fanoutJob struct {
+	ctx   context.Context
+	round uint64
+	run   func()
+	drop  func()
+}
+
+// peerQueue holds the request waiting to be sent to a node.
+type peerQueue struct {
+	sync.Mutex
+	pending *fanoutJob
+	wake    chan struct{}
+	// closed is set once the fanout is stopped, the jobs are then dropped
+	closed bool
+}
+
+// newFanout returns a fanout running at most the given number of requests at
+// once, until ctx is done.
+func newFanout(ctx context.Context, inFlight int) *fanout {
+	return &fanout{
+		ctx:    ctx,
+		slots:  make(chan struct{}, inFlight),
+		queues: make(map[string]*peerQueue),
+	}
+}
+
+// push queues the job of the round for the node at addr. The job waiting for
+// the node, if any, is dropped if it is for an earlier round; otherwise the
+// new job is. A job whose context is done before it runs is dropped.
+func (f *fanout) push(ctx context.Context, addr string, round uint64, run, drop func()) {
+	q := f.queue(addr)
+	job := &fanoutJob{ctx: ctx, round: round, run: run, drop: drop}
+	q.Lock()
+	if q.closed || ctx.Err() != nil {
+		q.Unlock()
+		drop()
+		return
+	}
+	stale := q.pending
+	if stale != nil && stale.round > round {
+		stale, job = job, stale
+	}
+	q.pending = job
+	q.Unlock()
+	if stale != nil {
+		stale.drop()
+	}
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// queue returns the queue of the node at addr, starting its worker the first
+// time.
+func (f *fanout) queue(addr string) *peerQueue {
+	f.Lock()
+	defer f.Unlock()
+	q, ok := f.queues[addr]
+	if !ok {
+		q = &peerQueue{wake: make(chan struct{}, 1)}
+		f.queues[addr] = q
+		go f.work(q)
+	}
+	return q
+}
+
+// work sends the jobs of a queue one at a time until the fanout is stopped.
+func (f *fanout) work(q *peerQueue) {
+	for {
+		select {
+		case <-q.wake:
+		case <-f.ctx.Done():
+			q.Lock()
+			job := q.pending
+			q.pending = nil
+			q.closed = true
+			q.Unlock()
+			if job != nil {
+				job.drop()
+			}
+			return
+		}
+		q.Lock()
+		job := q.pending
+		q.pending = nil
+		q.Unlock()
+		if job != nil {
+			f.run(job)
+		}
+	}
+}
+
+// run waits for a slot to run the job, or drops it if its context is done
+// or the fanout is stopped before.
+func (f *fanout) run(job *fanoutJob) {
+	select {
+	case f.slots <- struct{}{}:
+	case <-job.ctx.Done():
+		job.drop()
+		return
+	case <-f.ctx.Done():
+		job.drop()
+		return
+	}
+	defer func() { <-f.slots }()
+	// the slot may be free while the fanout just stopped
+	if job.ctx.Err() != nil || f.ctx.Err() != nil {
+		job.drop()
+		return
+	}
+	job.run()
+}
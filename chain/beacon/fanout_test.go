@@ -0,0 +1,174 @@
+package beacon
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	testnet "github.com/drand/drand/test/net"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFanoutBounded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	inFlight := 4
+	f := newFanout(ctx, inFlight)
+
+	var running, max int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		f.push(ctx, fmt.Sprintf("node%d", i), 1, func() {
+			defer wg.Done()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		}, func() { t.Error("job dropped") })
+	}
+	wg.Wait()
+	require.Equal(t, int32(inFlight), atomic.LoadInt32(&max))
+
+	// a job is not run once its context is done
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	dropped := make(chan struct{})
+	f.push(done, "node0", 2, func() { t.Error("job run") }, func() { close(dropped) })
+	<-dropped
+}
+
+func TestFanoutStaleRounds(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f := newFanout(ctx, 10)
+
+	// the node is busy with round 1, the later rounds wait for it
+	block := make(chan struct{})
+	started := make(chan struct{})
+	f.push(ctx, "slow", 1, func() {
+		close(started)
+		<-block
+	}, func() { t.Error("round 1 dropped") })
+	<-started
+
+	var mu sync.Mutex
+	var run, dropped []uint64
+	var wg sync.WaitGroup
+	job := func(round uint64) {
+		wg.Add(1)
+		f.push(ctx, "slow", round, func() {
+			defer wg.Done()
+			mu.Lock()
+			run = append(run, round)
+			mu.Unlock()
+		}, func() {
+			defer wg.Done()
+			mu.Lock()
+			dropped = append(dropped, round)
+			mu.Unlock()
+		})
+	}
+	// only the latest round waiting is kept, an older one pushed late is
+	// dropped right away
+	job(2)
+	job(4)
+	job(3)
+	// the other nodes are not delayed by the slow one
+	fast := make(chan struct{})
+	f.push(ctx, "fast", 4, func() { close(fast) }, func() { t.Error("fast node dropped") })
+	select {
+	case <-fast:
+	case <-time.After(time.Second):
+		t.Fatal("fast node delayed by the slow one")
+	}
+
+	close(block)
+	wg.Wait()
+	require.Equal(t, []uint64{4}, run)
+	require.ElementsMatch(t, []uint64{2, 3}, dropped)
+
+	// the pending jobs are dropped when the fanout stops
+	block = make(chan struct{})
+	started = make(chan struct{})
+	f.push(ctx, "slow", 5, func() {
+		close(started)
+		<-block
+	}, func() {})
+	<-started
+	droppedCh := make(chan struct{})
+	f.push(context.Background(), "slow", 6, func() { t.Error("round 6 run") }, func() { close(droppedCh) })
+	cancel()
+	close(block)
+	<-droppedCh
+}
+
+// fanoutServer accepts all the partials.
+type fanoutServer struct {
+	testnet.EmptyServer
+}
+
+func (s *fanoutServer) NewBeacon(context.Context, *drand.PartialBeaconPacket) (*drand.BeaconResponse, error) {
+	return new(drand.BeaconResponse), nil
+}
+
+// BenchmarkFanoutPartials sends a partial to the other nodes of a 500 nodes
+// group through the gRPC client, each node listening on localhost. With a 3s
+// period, all the partials must be sent well within the period.
+func BenchmarkFanoutPartials(b *testing.B) {
+	nodes := 500
+	privs, group := test.BatchIdentities(nodes)
+	var listeners []net.Listener
+	for _, priv := range privs[1:] {
+		l, err := net.NewGRPCListenerForPrivate(context.Background(), priv.Public.Address(), "", "", &fanoutServer{}, true)
+		require.NoError(b, err)
+		go l.Start()
+		listeners = append(listeners, l)
+	}
+	defer func() {
+		for _, l := range listeners {
+			l.Stop(context.Background())
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	h := &Handler{
+		conf:   &Config{Clock: clock.NewRealClock()},
+		client: net.NewGrpcClient(),
+		fanout: newFanout(ctx, MaxPartialRequests),
+		addr:   privs[0].Public.Address(),
+		l:      log.NewLogger(nil, log.LogInfo),
+	}
+	packet := &drand.PartialBeaconPacket{PreviousSig: []byte("previous"), PartialSig: []byte("partial")}
+	// the connections are opened once and reused by the following rounds
+	results := h.broadcastPartial(ctx, group.Nodes, packet)
+	for addr, res := range results {
+		require.NoError(b, res.Err, addr)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		packet.Round = uint64(i + 1)
+		rctx, rcancel := context.WithTimeout(ctx, 3*time.Second)
+		results := h.broadcastPartial(rctx, group.Nodes, packet)
+		rcancel()
+		for addr, res := range results {
+			if res.Err != nil {
+				b.Fatalf("partial not sent to %s: %s", addr, res.Err)
+			}
+		}
+	}
+}
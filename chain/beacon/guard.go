@@ -0,0 +1,78 @@
+package beacon
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
+)
+
+// errSignedLater is returned when a round later than the one to sign was
+// already signed, e.g. by a catchup started before the last beacons arrived.
+var errSignedLater = errors.New("a later round was already signed")
+
+// signGuard prevents the node from signing two different messages for the
+// same round, which would let two different beacons be produced for it. It
+// remembers the highest round signed and the message signed for it, saved in
+// the store when it can keep them so that the guard survives a restart.
+type signGuard struct {
+	sync.Mutex
+	// store saves the last round signed, nil if the store can't
+	store chain.SignedStore
+	last  *chain.SignedRound
+	l     log.Logger
+}
+
+func newSignGuard(s chain.Store, l log.Logger) (*signGuard, error) {
+	g := &signGuard{l: l}
+	ss, ok := s.(chain.SignedStore)
+	if !ok {
+		return g, nil
+	}
+	last, err := ss.LastSigned()
+	if err != nil {
+		return nil, fmt.Errorf("beacon: can't load the last round signed: %s", err)
+	}
+	g.store = ss
+	g.last = last
+	return g, nil
+}
+
+// allow returns nil if the node can sign the message for the round, and
+// records it as the last round signed. It returns an error, and raises an
+// equivocation alert, if the node already signed a different message for the
+// round. The rounds before the last one signed are refused with
+// errSignedLater since the message signed for them is not known anymore, as
+// is the last one when its record was rewound without a message.
+func (g *signGuard) allow(round uint64, msg []byte) error {
+	g.Lock()
+	defer g.Unlock()
+	if g.last != nil {
+		switch {
+		case round < g.last.Round:
+			return errSignedLater
+		case round == g.last.Round && g.last.Message == nil:
+			return errSignedLater
+		case round == g.last.Round && bytes.Equal(msg, g.last.Message):
+			return nil
+		case round == g.last.Round:
+			metrics.EquivocationAlerts.Inc()
+			g.l.Error("sign_guard", "equivocation", "round", round, "signed", shortSigStr(g.last.Message), "refused", shortSigStr(msg))
+			return fmt.Errorf("equivocation: another message was already signed for round %d", round)
+		}
+	}
+	signed := &chain.SignedRound{Round: round, Message: msg}
+	if g.store != nil {
+		// the round must be saved before the partial is emitted, otherwise a
+		// restart could sign it again
+		if err := g.store.PutSigned(signed); err != nil {
+			return fmt.Errorf("can't save the round signed: %s", err)
+		}
+	}
+	g.last = signed
+	return nil
+}
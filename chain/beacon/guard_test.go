@@ -0,0 +1,76 @@
+package beacon
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignGuard(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	g, err := newSignGuard(store, log.DefaultLogger())
+	require.NoError(t, err)
+
+	require.NoError(t, g.allow(5, []byte("msg5")))
+	// the same message can be signed again, e.g. to rebroadcast it
+	require.NoError(t, g.allow(5, []byte("msg5")))
+	alerts := testutil.ToFloat64(metrics.EquivocationAlerts)
+	require.Error(t, g.allow(5, []byte("other")))
+	require.Equal(t, alerts+1, testutil.ToFloat64(metrics.EquivocationAlerts))
+	require.NoError(t, g.allow(6, []byte("msg6")))
+	require.Equal(t, errSignedLater, g.allow(5, []byte("msg5")))
+
+	// the guard survives a restart
+	store.Close()
+	store, err = boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	g, err = newSignGuard(store, log.DefaultLogger())
+	require.NoError(t, err)
+	require.NoError(t, g.allow(6, []byte("msg6")))
+	require.Error(t, g.allow(6, []byte("other")))
+	require.NoError(t, g.allow(7, []byte("msg7")))
+}
+
+func TestSignGuardRewind(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	g, err := newSignGuard(store, log.DefaultLogger())
+	require.NoError(t, err)
+	for round := uint64(1); round <= 5; round++ {
+		require.NoError(t, store.Put(&chain.Beacon{Round: round, Signature: []byte("sig")}))
+		require.NoError(t, g.allow(round, []byte("msg")))
+	}
+
+	// the beacons from round 4 are deleted, as del-beacon does
+	require.NoError(t, store.Del(4))
+	require.NoError(t, store.Del(5))
+	require.NoError(t, chain.RewindSigned(store, 4))
+	store.Close()
+
+	// after a restart, the deleted rounds are signed again, with another
+	// message since the chain changed
+	store, err = boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	g, err = newSignGuard(store, log.DefaultLogger())
+	require.NoError(t, err)
+	require.Equal(t, errSignedLater, g.allow(3, []byte("other")))
+	require.NoError(t, g.allow(4, []byte("other")))
+	require.NoError(t, g.allow(5, []byte("other")))
+	require.Error(t, g.allow(5, []byte("msg")))
+}
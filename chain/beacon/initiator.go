@@ -0,0 +1,96 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/drand/drand/chain"
+)
+
+// RoundInitiator returns the index of the node initiating the round following
+// the beacon with the given signature, in a group of n nodes. The initiator
+// changes with every round and cannot be predicted before the previous
+// signature is known.
+func RoundInitiator(previousSig []byte, n int) int {
+	h := sha256.Sum256(previousSig)
+	return int(binary.BigEndian.Uint64(h[:8]) % uint64(n))
+}
+
+// initiation keeps track of the rounds for which a partial has been received,
+// so that the nodes not initiating a round can answer the initiator.
+type initiation struct {
+	sync.Mutex
+	received uint64
+	waiting  map[uint64]chan struct{}
+}
+
+func newInitiation() *initiation {
+	return &initiation{waiting: make(map[uint64]chan struct{})}
+}
+
+// wait returns a channel closed once a partial for the round is received.
+func (i *initiation) wait(round uint64) <-chan struct{} {
+	i.Lock()
+	defer i.Unlock()
+	ch, ok := i.waiting[round]
+	if !ok {
+		ch = make(chan struct{})
+		if round <= i.received {
+			close(ch)
+			return ch
+		}
+		i.waiting[round] = ch
+	}
+	return ch
+}
+
+// receive marks the round, and thus all the previous ones, as started.
+func (i *initiation) receive(round uint64) {
+	i.Lock()
+	defer i.Unlock()
+	if round > i.received {
+		i.received = round
+	}
+	for r, ch := range i.waiting {
+		if r <= round {
+			close(ch)
+			delete(i.waiting, r)
+		}
+	}
+}
+
+// forget stops waiting for the round.
+func (i *initiation) forget(round uint64) {
+	i.Lock()
+	defer i.Unlock()
+	delete(i.waiting, round)
+}
+
+// initiates returns true if this node initiates the round following upon.
+func (h *Handler) initiates(upon *chain.Beacon) bool {
+	round := upon.Round + 1
+	n := len(h.crypto.GetGroupAt(round).Nodes)
+	return RoundInitiator(upon.Signature, n) == h.crypto.IndexAt(round)
+}
+
+// answerInitiator broadcasts the partial of the round once the initiator, or
+// any other node, sent its own, or after the initiator timeout if none did.
+func (h *Handler) answerInitiator(ctx context.Context, current roundInfo, upon *chain.Beacon) {
+	timeout := h.conf.InitiatorTimeout
+	if timeout == 0 {
+		timeout = h.ticker.Schedule().PeriodAt(current.round) / 4
+	}
+	started := h.initiation.wait(current.round)
+	select {
+	case <-started:
+	case <-h.conf.Clock.After(timeout):
+		h.initiation.forget(current.round)
+		h.l.Debug("beacon_round", current.round, "initiator", "timeout")
+	case <-ctx.Done():
+		h.initiation.forget(current.round)
+		return
+	}
+	h.broadcastNextPartial(ctx, current, upon)
+}
@@ -0,0 +1,45 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundInitiator(t *testing.T) {
+	n := 5
+	counts := make([]int, n)
+	for i := 0; i < 1000; i++ {
+		sig := []byte{byte(i), byte(i >> 8)}
+		idx := RoundInitiator(sig, n)
+		require.Equal(t, idx, RoundInitiator(sig, n))
+		counts[idx]++
+	}
+	// every node initiates some rounds
+	for _, c := range counts {
+		require.True(t, c > 100, "unbalanced initiators: %v", counts)
+	}
+}
+
+func TestInitiation(t *testing.T) {
+	closed := func(ch <-chan struct{}) bool {
+		select {
+		case <-ch:
+			return true
+		case <-time.After(50 * time.Millisecond):
+			return false
+		}
+	}
+	i := newInitiation()
+	r3 := i.wait(3)
+	r4 := i.wait(4)
+	require.False(t, closed(r3))
+	i.receive(3)
+	require.True(t, closed(r3))
+	require.False(t, closed(r4))
+	// partials received before waiting start the round right away
+	require.True(t, closed(i.wait(2)))
+	i.forget(4)
+	require.Empty(t, i.waiting)
+}
@@ -1,18 +1,27 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"io"
+	"runtime/debug"
+	"runtime/pprof"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
 	proto "github.com/drand/drand/protobuf/drand"
 	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/net"
@@ -29,6 +38,64 @@ type Config struct {
 	Group *key.Group
 	// Clock to use - useful to testing
 	Clock clock.Clock
+	// MaxStoreSize is the maximum size in bytes of the beacon database. New
+	// beacons are refused once it is reached. Zero means no limit.
+	MaxStoreSize int64
+	// RotateInitiator makes only the initiator of each round, derived from
+	// the previous signature, broadcast its partial at the round time. The
+	// other nodes broadcast theirs once they receive a partial for the round,
+	// or after InitiatorTimeout.
+	RotateInitiator bool
+	// InitiatorTimeout is the time after which a node broadcasts its partial
+	// when the initiator of the round did not. Defaults to a quarter of the
+	// period.
+	InitiatorTimeout time.Duration
+	// AggregationGrace is the time the aggregation of a round waits for the
+	// partials of all the nodes once the threshold is reached, bounded by half
+	// a period. Zero aggregates as soon as the threshold is reached.
+	AggregationGrace time.Duration
+	// MaxClockSkew is the maximum difference between the time at which a node
+	// sends a partial and the time at which it is received. The nodes
+	// chronically exceeding it are degraded and not counted on to reach the
+	// threshold. It defaults to DefaultMaxClockSkew.
+	MaxClockSkew time.Duration
+	// MaxClockJump is the largest difference between the time slept by the
+	// ticker measured by the wall clock and by the monotonic clock. Beyond it,
+	// the clock of the host is considered to have jumped, e.g. on a step of
+	// NTP, and the jump is reported; a late wake up is not a jump. The round
+	// due at the wake up is ticked either way. It defaults to
+	// DefaultMaxClockJump.
+	MaxClockJump time.Duration
+	// SyncLimits bounds the rate at which the node catches up with the chain
+	// from its peers and the number of peers syncing from it.
+	SyncLimits SyncLimits
+	// PartialWindow is the number of rounds before the last stored beacon for
+	// which the partials are still processed. The older partials are dropped
+	// before their verification. It defaults to DefaultPartialWindow.
+	PartialWindow uint64
+	// VerifyPeer, if not nil, checks that an inbound request comes from the
+	// node listening at the given address. The partials are then only
+	// accepted from the node holding the share that signed them.
+	VerifyPeer func(ctx context.Context, addr string) error
+	// OnEquivocation, if not nil, is called with the index and the evidence
+	// of a node that sent two valid partials signing different messages for
+	// the same round.
+	OnEquivocation func(index int, e *proto.EquivocationPacket)
+	// OnPartialSigned, if not nil, is called with the round and the share of
+	// each partial signature produced by the node.
+	OnPartialSigned func(round uint64, ks *key.Share)
+	// PreviousEpochRounds is the number of rounds after a resharing during
+	// which the keys of the previous group are kept to sign and verify the
+	// partials of its rounds. It defaults to DefaultPreviousEpochRounds.
+	PreviousEpochRounds uint64
+	// ReadOnly makes the handler only serve the beacons of its store: it never
+	// signs nor broadcasts a partial and refuses the partials of the other
+	// nodes. The share is not needed and the node needs not be in the group.
+	ReadOnly bool
+	// CacheSize is the number of beacons kept in memory in front of the
+	// database, for the latest rounds requested through the public API. It
+	// defaults to DefaultCacheSize, a negative size disables the cache.
+	CacheSize int
 }
 
 // Handler holds the logic to initiate, and react to the TBLS protocol. Each time
@@ -43,17 +110,53 @@ type Handler struct {
 	// main logic that treats incoming packet / new beacons created
 	chain  *chainStore
 	ticker *ticker
+	// sends the partial beacons to the other nodes
+	fanout *fanout
+	// rounds started by their initiator, when rotating initiators
+	initiation *initiation
+	// partials already verified
+	replays *replayCache
+	// clock skew of the other nodes
+	skews *skewTracker
+	// refuses to sign two messages for the same round
+	guard *signGuard
+	// last partial signed by the node, sent back to the nodes sending theirs
+	signed *proto.PartialBeaconPacket
+	// results of the last partial sent to the other nodes
+	lastBroadcast      map[string]PeerResult
+	lastBroadcastRound uint64
 
-	close   chan bool
+	// ctx is cancelled when the handler stops, ending the beacon loop and all
+	// the operations it started
+	ctx     context.Context
+	cancel  context.CancelFunc
 	addr    string
 	started bool
 	stopped bool
 	l       log.Logger
+
+	// first round the beacon loop handles and last round it handled, read
+	// atomically by the watchdog
+	startRound uint64
+	lastTick   uint64
+	// lastStored is the round of the last beacon stored, read atomically to
+	// drop the expired partials
+	lastStored uint64
+	// paused is 1 when the node does not send its partials, read atomically
+	paused uint32
+	// epochs records the resharings of the chain, nil if the store can't
+	epochs chain.EpochStore
 }
 
 // NewHandler returns a fresh handler ready to serve and create randomness
 // beacon
 func NewHandler(c net.ProtocolClient, s chain.Store, conf *Config, l log.Logger) (*Handler, error) {
+	if conf.ReadOnly {
+		return newReadOnlyHandler(c, s, conf, l)
+	}
+	if _, ok := s.(sizer); !ok && conf.MaxStoreSize > 0 {
+		return nil, errors.New("beacon: the store can't report its size to enforce the maximum store size")
+	}
 	if conf.Share == nil || conf.Group == nil {
 		return nil, errors.New("beacon: invalid configuration")
 	}
@@ -62,27 +165,88 @@ func NewHandler(c net.ProtocolClient, s chain.Store, conf *Config, l log.Logger)
 	if node == nil {
 		return nil, errors.New("beacon: keypair not included in the given group")
 	}
-	addr := conf.Public.Address()
-	logger := l
 	crypto := newCryptoStore(conf.Group, conf.Share)
 	// insert genesis beacon
-	if err := s.Put(chain.GenesisBeacon(crypto.chain)); err != nil {
+	if err := s.Put(chain.GenesisBeacon(crypto.GetInfo())); err != nil {
+		return nil, err
+	}
+
+	guard, err := newSignGuard(s, l)
+	if err != nil {
 		return nil, err
 	}
+	return newHandler(c, s, conf, crypto, guard, l), nil
+}
+
+// errReadOnly is returned by a read-only handler asked to take part in the
+// chain.
+var errReadOnly = errors.New("beacon: read-only node")
 
-	ticker := newTicker(conf.Clock, conf.Group.Period, conf.Group.GenesisTime)
-	store := newChainStore(logger, conf, c, crypto, s, ticker)
+// newReadOnlyHandler returns a handler serving the beacons of an existing
+// store, without any write to it.
+func newReadOnlyHandler(c net.ProtocolClient, s chain.Store, conf *Config, l log.Logger) (*Handler, error) {
+	if conf.Group == nil {
+		return nil, errors.New("beacon: invalid configuration")
+	}
+	if _, err := s.Last(); err != nil {
+		return nil, fmt.Errorf("beacon: read-only node needs an existing chain: %w", err)
+	}
+	return newHandler(c, s, conf, newCryptoStore(conf.Group, conf.Share), nil, l), nil
+}
+
+func newHandler(c net.ProtocolClient, s chain.Store, conf *Config, crypto *cryptoStore, guard *signGuard,
+	logger log.Logger) *Handler {
+	addr := conf.Public.Address()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ticker := newTicker(conf.Clock, chain.NewSchedule(conf.Group), conf.MaxClockJump, logger)
+	skews := newSkewTracker(conf.MaxClockSkew)
+	store := newChainStore(ctx, logger, conf, c, crypto, s, ticker, skews)
 	handler := &Handler{
 		conf:   conf,
 		client: c,
 		crypto: crypto,
 		chain:  store,
 		ticker: ticker,
+		fanout: newFanout(ctx, MaxPartialRequests),
 		addr:   addr,
-		close:  make(chan bool),
+		ctx:    ctx,
+		cancel: cancel,
 		l:      logger,
+
+		initiation: newInitiation(),
+		replays:    newReplayCache(),
+		skews:      skews,
+		guard:      guard,
+	}
+	if last, err := s.Last(); err == nil {
+		handler.lastStored = last.Round
 	}
-	return handler, nil
+	if es, ok := s.(chain.EpochStore); ok {
+		handler.epochs = es
+	}
+	store.AddCallback("partial_window", handler.stored)
+	return handler
+}
+
+// stored keeps the round of the last beacon stored.
+func (h *Handler) stored(b *chain.Beacon) {
+	for {
+		last := atomic.LoadUint64(&h.lastStored)
+		if b.Round <= last || atomic.CompareAndSwapUint64(&h.lastStored, last, b.Round) {
+			return
+		}
+	}
+}
+
+// expired returns true if the partials of the round are not useful anymore:
+// the round is more than the partial window before the last stored beacon.
+func (h *Handler) expired(round uint64) bool {
+	window := h.conf.PartialWindow
+	if window == 0 {
+		window = DefaultPartialWindow
+	}
+	return round+window < atomic.LoadUint64(&h.lastStored)
 }
 
 var errOutOfRound = "out-of-round beacon request"
@@ -90,10 +254,49 @@ var errOutOfRound = "out-of-round beacon request"
 // ProcessPartialBeacon receives a request for a beacon partial signature. It
 // forwards it to the round manager if it is a valid beacon.
 func (h *Handler) ProcessPartialBeacon(c context.Context, p *proto.PartialBeaconPacket) (*proto.Empty, error) {
+	if h.conf.ReadOnly {
+		return nil, errReadOnly
+	}
 	addr := net.RemoteAddress(c)
 	h.l.Debug("received", "request", "from", addr, "round", p.GetRound())
+	if err := h.processPartial(addr, p, h.requestSender(c)); err != nil {
+		return nil, err
+	}
+	return new(proto.Empty), nil
+}
+
+// ProcessNewBeacon processes the partial signature of another node like
+// ProcessPartialBeacon, and answers with the partial of this node for the
+// same round and previous signature if it already signed it.
+func (h *Handler) ProcessNewBeacon(c context.Context, p *proto.PartialBeaconPacket) (*proto.BeaconResponse, error) {
+	if _, err := h.ProcessPartialBeacon(c, p); err != nil {
+		return nil, err
+	}
+	resp := new(proto.BeaconResponse)
+	h.Lock()
+	if own := h.signed; own != nil && own.GetRound() == p.GetRound() && bytes.Equal(own.GetPreviousSig(), p.GetPreviousSig()) {
+		resp.Partial = own
+	}
+	h.Unlock()
+	return resp, nil
+}
+
+// requestSender returns the check of the sender of an inbound request, nil if
+// the senders are not verified.
+func (h *Handler) requestSender(c context.Context) func(round uint64, idx int) error {
+	if h.conf.VerifyPeer == nil {
+		return nil
+	}
+	return func(round uint64, idx int) error {
+		return h.verifySender(c, round, idx)
+	}
+}
 
-	nextRound, _ := chain.NextRound(h.conf.Clock.Now().Unix(), h.conf.Group.Period, h.conf.Group.GenesisTime)
+// processPartial verifies a partial received from addr and forwards it to
+// the round manager. checkSender, if not nil, checks that the node of the
+// index of the partial sent it.
+func (h *Handler) processPartial(addr string, p *proto.PartialBeaconPacket, checkSender func(round uint64, idx int) error) error {
+	nextRound, _ := h.ticker.Schedule().NextRound(h.conf.Clock.Now().Unix())
 	currentRound := nextRound - 1
 
 	// we allow one round off in the future because of small clock drifts
@@ -101,39 +304,176 @@ func (h *Handler) ProcessPartialBeacon(c context.Context, p *proto.PartialBeacon
 	// clock passed to the next round
 	if p.GetRound() > nextRound {
 		h.l.Error("process_partial", addr, "invalid_future_round", p.GetRound(), "current_round", currentRound)
-		return nil, fmt.Errorf("invalid round: %d instead of %d", p.GetRound(), currentRound)
+		return fmt.Errorf("invalid round: %d instead of %d", p.GetRound(), currentRound)
+	}
+
+	if hash := p.GetChainHash(); len(hash) > 0 && !bytes.Equal(hash, h.crypto.GetChainHash()) {
+		h.l.Error("process_partial", addr, "err", "partial from another chain", "chain_hash", shortSigStr(hash))
+		return errors.New("partial beacon for another chain")
+	}
+
+	info := h.crypto.GetInfo()
+	if err := checkPartialLength(p, len(info.GroupHash)); err != nil {
+		h.l.Error("process_partial", addr, "err", err)
+		return err
+	}
+	if p.GetRound() == 1 && !bytes.Equal(p.GetPreviousSig(), info.GroupHash) {
+		h.l.Error("process_partial", addr, "err", "first round not chained from the genesis seed", "previous_sig", shortSigStr(p.GetPreviousSig()))
+		return errors.New("partial beacon of the first round does not chain from the genesis seed")
+	}
+
+	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
+	if checkSender != nil {
+		if err := checkSender(p.GetRound(), idx); err != nil {
+			h.l.Error("process_partial", addr, "index", idx, "err", err)
+			return err
+		}
+	}
+	if h.expired(p.GetRound()) {
+		h.l.Debug("process_partial", addr, "expired_partial", p.GetRound(), "index", idx)
+		metrics.PartialsExpired.WithLabelValues(strconv.Itoa(idx)).Inc()
+		return nil
+	}
+	if h.replays.replayed(p.GetRound(), idx, p.GetPartialSig()) {
+		h.l.Debug("process_partial", addr, "replayed_partial", p.GetRound(), "index", idx)
+		return nil
 	}
 
-	msg := chain.Message(p.GetRound(), p.GetPreviousSig())
+	msg := info.Message(p.GetRound(), p.GetPreviousSig())
 	// XXX Remove that evaluation - find another way to show the current dist.
 	// key being used
 	shortPub := h.crypto.GetPub().Eval(1).V.String()[14:19]
 	// verify if request is valid
-	if err := key.Scheme.VerifyPartial(h.crypto.GetPub(), msg, p.GetPartialSig()); err != nil {
+	if err := key.Scheme.VerifyPartial(h.crypto.GetPubAt(p.GetRound()), msg, p.GetPartialSig()); err != nil {
 		h.l.Error("process_partial", addr, "err", err,
 			"prev_sig", shortSigStr(p.GetPreviousSig()),
 			"curr_round", currentRound,
 			"msg_sign", shortSigStr(msg),
 			"short_pub", shortPub)
-		return nil, err
+		return err
 	}
 	h.l.Debug("process_partial", addr,
 		"prev_sig", shortSigStr(p.GetPreviousSig()),
 		"curr_round", currentRound, "msg_sign",
 		shortSigStr(msg), "short_pub", shortPub,
 		"status", "OK")
-	idx, _ := key.Scheme.IndexOf(p.GetPartialSig())
-	if idx == h.crypto.Index() {
+	if first := h.replays.verified(currentRound, idx, p); first != nil {
+		h.l.Error("process_partial", addr, "index", idx, "equivocation", p.GetRound(),
+			"first_prev_sig", shortSigStr(first.GetPreviousSig()),
+			"second_prev_sig", shortSigStr(p.GetPreviousSig()))
+		if h.conf.OnEquivocation != nil {
+			h.conf.OnEquivocation(idx, &proto.EquivocationPacket{
+				First:     first,
+				Second:    p,
+				ChainHash: h.crypto.GetChainHash(),
+			})
+		}
+		return fmt.Errorf("partial %d signs another message than the one it signed for round %d", idx, p.GetRound())
+	}
+	if ts := p.GetTimestamp(); ts != 0 {
+		h.skews.observe(idx, time.Unix(0, ts*int64(time.Millisecond)), h.conf.Clock.Now())
+	}
+	if ourIdx := h.crypto.IndexAt(p.GetRound()); idx == ourIdx {
 		h.l.Error("process_partial", addr,
 			"index_got", idx,
-			"index_our", h.crypto.Index(),
+			"index_our", ourIdx,
 			"advance_packet", p.GetRound(),
 			"pub", shortPub)
 		// XXX error or not ?
-		return new(proto.Empty), nil
+		return nil
 	}
 	h.chain.NewValidPartial(addr, p)
-	return new(proto.Empty), nil
+	h.initiation.receive(p.GetRound())
+	return nil
+}
+
+// verifySender checks that the request comes from the node of the given index
+// in the group of the round.
+func (h *Handler) verifySender(c context.Context, round uint64, idx int) error {
+	node := h.crypto.GetGroupAt(round).Node(uint32(idx))
+	if node == nil {
+		return fmt.Errorf("partial of unknown index %d", idx)
+	}
+	if err := h.conf.VerifyPeer(c, node.Address()); err != nil {
+		return fmt.Errorf("partial %d not sent by its signer: %w", idx, err)
+	}
+	return nil
+}
+
+// VerifyEquivocation checks the evidence that a node signed two different
+// messages for the same round and returns the index of the node: both
+// partials must be valid and sign different previous signatures.
+func (h *Handler) VerifyEquivocation(e *proto.EquivocationPacket) (int, error) {
+	first, second := e.GetFirst(), e.GetSecond()
+	if first == nil || second == nil {
+		return 0, errors.New("incomplete equivocation evidence")
+	}
+	if !bytes.Equal(e.GetChainHash(), h.crypto.GetChainHash()) {
+		return 0, errors.New("equivocation evidence for another chain")
+	}
+	if first.GetRound() != second.GetRound() {
+		return 0, fmt.Errorf("partials of different rounds: %d and %d", first.GetRound(), second.GetRound())
+	}
+	if bytes.Equal(first.GetPreviousSig(), second.GetPreviousSig()) {
+		return 0, errors.New("partials signing the same message")
+	}
+	info := h.crypto.GetInfo()
+	pub := h.crypto.GetPubAt(first.GetRound())
+	idx := -1
+	for _, p := range []*proto.PartialBeaconPacket{first, second} {
+		if err := checkPartialLength(p, len(info.GroupHash)); err != nil {
+			return 0, err
+		}
+		i, _ := key.Scheme.IndexOf(p.GetPartialSig())
+		if idx >= 0 && i != idx {
+			return 0, fmt.Errorf("partials of different nodes: %d and %d", idx, i)
+		}
+		idx = i
+		msg := info.Message(p.GetRound(), p.GetPreviousSig())
+		if err := key.Scheme.VerifyPartial(pub, msg, p.GetPartialSig()); err != nil {
+			return 0, fmt.Errorf("invalid partial: %s", err)
+		}
+	}
+	return idx, nil
+}
+
+// checkPartialLength verifies the length of the fields of a partial beacon
+// before any deserialization happens. The previous signature is either a full
+// signature or the genesis seed for the first round.
+func checkPartialLength(p *proto.PartialBeaconPacket, seedLen int) error {
+	sigLen := key.SigGroup.PointLen()
+	if l := len(p.GetPartialSig()); l != partialIndexLen+sigLen {
+		return fmt.Errorf("invalid partial signature length: %d", l)
+	}
+	prevLen := len(p.GetPreviousSig())
+	if prevLen != sigLen && !(p.GetRound() == 1 && prevLen == seedLen) {
+		return fmt.Errorf("invalid previous signature length: %d", prevLen)
+	}
+	return nil
+}
+
+// RoundReports returns the reports of up to the n last rounds aggregated by
+// this node, from the oldest to the newest. If n is zero, it returns all the
+// reports kept.
+func (h *Handler) RoundReports(n int) []RoundReport {
+	nodes := h.crypto.GetGroup().Nodes
+	indices := make([]int, len(nodes))
+	for i, node := range nodes {
+		indices[i] = int(node.Index)
+	}
+	return h.chain.reports.Last(n, indices)
+}
+
+// PeerSkews returns the clock skew of the nodes a partial was received from.
+func (h *Handler) PeerSkews() []PeerSkew {
+	return h.skews.Skews()
+}
+
+// ThresholdReachable returns false when too many nodes are degraded for the
+// others to reach the threshold.
+func (h *Handler) ThresholdReachable() bool {
+	group := h.crypto.GetGroup()
+	return group.Len()-h.skews.countDegraded() >= group.Threshold
 }
 
 // Store returns the store associated with this beacon handler
@@ -141,6 +481,17 @@ func (h *Handler) Store() chain.Store {
 	return h.chain
 }
 
+// ChainHash returns the hash of the chain produced by this beacon handler
+func (h *Handler) ChainHash() []byte {
+	return h.crypto.GetChainHash()
+}
+
+// ChainInfo returns the information of the chain produced by this beacon
+// handler
+func (h *Handler) ChainInfo() *chain.Info {
+	return h.crypto.GetInfo()
+}
+
 // Start runs the beacon protocol (threshold BLS signature). The first round
 // will sign the message returned by the config.FirstRound() function. If the
 // genesis time specified in the group is already passed, Start returns an
@@ -149,13 +500,16 @@ func (h *Handler) Store() chain.Store {
 // Round 0 = genesis seed - fixed
 // Round 1 starts at genesis time, and is signing over the genesis seed
 func (h *Handler) Start() error {
+	if h.conf.ReadOnly {
+		return errReadOnly
+	}
 	if h.conf.Clock.Now().Unix() > h.conf.Group.GenesisTime {
 		h.l.Error("genesis_time", "past", "call", "catchup")
 		return errors.New("beacon: genesis time already passed. Call Catchup()")
 	}
-	_, tTime := chain.NextRound(h.conf.Clock.Now().Unix(), h.conf.Group.Period, h.conf.Group.GenesisTime)
+	_, tTime := h.ticker.Schedule().NextRound(h.conf.Clock.Now().Unix())
 	h.l.Info("beacon", "start")
-	go h.run(tTime)
+	go h.run(h.ctx, tTime)
 	return nil
 }
 
@@ -165,9 +519,13 @@ func (h *Handler) Start() error {
 // it sync its local chain with other nodes to be able to participate in the
 // next upcoming round.
 func (h *Handler) Catchup() {
-	nRound, tTime := chain.NextRound(h.conf.Clock.Now().Unix(), h.conf.Group.Period, h.conf.Group.GenesisTime)
-	go h.run(tTime)
-	h.chain.RunSync(context.Background(), nRound, nil)
+	if h.conf.ReadOnly {
+		h.l.Error("beacon", "catchup", "err", errReadOnly)
+		return
+	}
+	nRound, tTime := h.ticker.Schedule().NextRound(h.conf.Clock.Now().Unix())
+	go h.run(h.ctx, tTime)
+	h.chain.RunSync(h.ctx, nRound, nil)
 }
 
 // Transition makes this beacon continuously sync until the time written in the
@@ -175,57 +533,116 @@ func (h *Handler) Catchup() {
 // randomness. To sync, he contact the nodes listed in the previous group file
 // given.
 func (h *Handler) Transition(prevGroup *key.Group) error {
+	if h.conf.ReadOnly {
+		return errReadOnly
+	}
 	targetTime := h.conf.Group.TransitionTime
-	tRound := chain.CurrentRound(targetTime, h.conf.Group.Period, h.conf.Group.GenesisTime)
-	tTime := chain.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, tRound)
+	sched := h.ticker.Schedule()
+	tRound := sched.CurrentRound(targetTime)
+	tTime := sched.TimeOfRound(tRound)
 	if tTime != targetTime {
 		h.l.Fatal("transition_time", "invalid_offset", "expected_time", tTime, "got_time", targetTime)
 		return nil
 	}
-	go h.run(targetTime)
+	h.recordEpoch(chain.NewEpoch(tRound, prevGroup, h.conf.Group))
+	go h.run(h.ctx, targetTime)
 	// we run the sync up until (inclusive) one round before the transition
 	h.l.Debug("new_node", "following chain", "to_round", tRound-1)
-	h.chain.RunSync(context.Background(), tRound-1, toPeers(prevGroup.Nodes))
+	h.chain.RunSync(h.ctx, tRound-1, toPeers(prevGroup.Nodes))
 	return nil
 }
 
 // TransitionNewGroup prepares the node to transition to the new group
 func (h *Handler) TransitionNewGroup(newShare *key.Share, newGroup *key.Group) {
 	targetTime := newGroup.TransitionTime
-	tRound := chain.CurrentRound(targetTime, h.conf.Group.Period, h.conf.Group.GenesisTime)
-	tTime := chain.TimeOfRound(h.conf.Group.Period, h.conf.Group.GenesisTime, tRound)
+	// the schedule of the new group gives the same times until the
+	// transition, from which the new group may have changed the period
+	sched := chain.NewSchedule(newGroup)
+	tRound := sched.CurrentRound(targetTime)
+	tTime := sched.TimeOfRound(tRound)
 	if tTime != targetTime {
 		h.l.Fatal("transition_time", "invalid_offset", "expected_time", tTime, "got_time", targetTime)
 		return
 	}
+	h.recordEpoch(chain.NewEpoch(tRound, h.crypto.GetGroup(), newGroup))
+	h.ticker.SetSchedule(sched)
 	h.l.Debug("transition", "new_group", "at_round", tRound)
+	// the keys of the new group sign and verify the rounds from the
+	// transition on, even before the switch
+	h.crypto.SetNext(newGroup, newShare, tRound)
 	// register a callback such that when the round happening just before the
-	// transition is stored, then it switches the current share to the new one
+	// transition is stored, then it switches the current share to the new one.
+	// The keys of the old group are kept for the late partials of its last
+	// rounds. Both are done in the same callback so that a sync storing a
+	// later round first can't drop the keys before the switch.
 	targetRound := tRound - 1
+	keep := h.conf.PreviousEpochRounds
+	if keep == 0 {
+		keep = DefaultPreviousEpochRounds
+	}
 	h.chain.AddCallback("transition", func(b *chain.Beacon) {
 		if b.Round < targetRound {
 			return
 		}
-		h.crypto.SetInfo(newGroup, newShare)
+		h.crypto.SwitchNext(tRound)
+		if b.Round <= targetRound+keep {
+			return
+		}
+		h.crypto.DropPrevious(targetRound)
 		h.chain.RemoveCallback("transition")
 	})
 }
 
-// run will wait until it is supposed to start
-func (h *Handler) run(startTime int64) {
+// recordEpoch saves the epoch in the store, if it keeps them.
+func (h *Handler) recordEpoch(e *chain.Epoch) {
+	if h.epochs == nil {
+		return
+	}
+	if err := h.epochs.PutEpoch(e); err != nil {
+		h.l.Error("transition", "can't save epoch", "round", e.Round, "err", err)
+	}
+}
+
+// Epochs returns the resharings of the chain known to this node, in increasing
+// round order. It returns nil if the store does not keep them.
+func (h *Handler) Epochs() ([]*chain.Epoch, error) {
+	if h.epochs == nil {
+		return nil, nil
+	}
+	return h.epochs.Epochs()
+}
+
+// run will wait until it is supposed to start and runs until ctx is done
+func (h *Handler) run(ctx context.Context, startTime int64) {
+	defer func() {
+		// the watchdog restarts the loop, if enabled
+		if err := recover(); err != nil {
+			h.l.Error("beacon_loop", "panic", "err", err, "stack", string(debug.Stack()))
+		}
+	}()
+	atomic.StoreUint64(&h.startRound, h.ticker.Schedule().CurrentRound(startTime))
 	chanTick := h.ticker.ChannelAt(startTime)
 	h.l.Debug("run_round", "wait", "until", startTime)
 	var current roundInfo
 	for {
 		select {
 		case current = <-chanTick:
+			atomic.StoreUint64(&h.lastTick, current.round)
 			lastBeacon, err := h.chain.Last()
 			if err != nil {
 				h.l.Error("beacon_loop", "loading_last", "err", err)
 				break
 			}
 			h.l.Debug("beacon_loop", "new_round", "round", current.round, "lastbeacon", lastBeacon.Round)
-			h.broadcastNextPartial(current, lastBeacon)
+			if !h.ThresholdReachable() {
+				h.l.Warn("beacon_loop", "threshold_unreachable", "round", current.round, "degraded", h.skews.countDegraded())
+			}
+			// when the chain is late, all the nodes broadcast right away
+			if h.conf.RotateInitiator && lastBeacon.Round+1 == current.round && !h.initiates(lastBeacon) {
+				go h.answerInitiator(ctx, current, lastBeacon)
+			} else {
+				h.broadcastNextPartial(ctx, current, lastBeacon)
+			}
 			// if the next round of the last beacon we generated is not the round we
 			// are now, that means there is a gap between the two rounds. In other
 			// words, the chain has halted for that amount of rounds or our
@@ -238,7 +655,7 @@ func (h *Handler) run(startTime int64) {
 				// XXX find a way to start the catchup as soon as the runsync is
 				// done. Not critical but leads to faster network recovery.
 				h.l.Debug("beacon_loop", "run_sync_catchup", "last_is", lastBeacon, "should_be", current.round)
-				go h.chain.RunSync(context.Background(), current.round, nil)
+				go h.chain.RunSync(ctx, current.round, nil)
 			}
 		case b := <-h.chain.AppendedBeaconNoSync():
 			if b.Round < current.round {
@@ -252,19 +669,25 @@ func (h *Handler) run(startTime int64) {
 				// channel will trigger again etc until we arrive at the correct
 				// round.
 				go func(c roundInfo, latest *chain.Beacon) {
-					h.conf.Clock.Sleep(h.conf.Group.CatchupPeriod)
-					h.broadcastNextPartial(c, latest)
+					select {
+					case <-h.conf.Clock.After(h.conf.Group.CatchupPeriod):
+						h.broadcastNextPartial(ctx, c, latest)
+					case <-ctx.Done():
+					}
 				}(current, b)
 			}
-		case <-h.close:
+		case <-ctx.Done():
 			h.l.Debug("beacon_loop", "finished")
 			return
 		}
 	}
 }
 
-func (h *Handler) broadcastNextPartial(current roundInfo, upon *chain.Beacon) {
-	ctx := context.Background()
+// broadcastNextPartial signs the round following upon and sends the partial
+// to the other nodes, in a random order, through the fanout. The partial is
+// only useful during one period so the requests not done by then are
+// cancelled. The result of each node is reported once all are done.
+func (h *Handler) broadcastNextPartial(ctx context.Context, current roundInfo, upon *chain.Beacon) {
 	previousSig := upon.Signature
 	round := upon.Round + 1
 	if current.round == upon.Round {
@@ -276,35 +699,78 @@ func (h *Handler) broadcastNextPartial(current roundInfo, upon *chain.Beacon) {
 		previousSig = upon.PreviousSig
 		round = current.round
 	}
-	msg := chain.Message(round, previousSig)
-	currSig, err := h.crypto.SignPartial(msg)
+	if h.Paused() {
+		h.l.Debug("beacon_round", round, "skip_sign", "paused")
+		return
+	}
+	msg := h.crypto.GetInfo().Message(round, previousSig)
+	if err := h.guard.allow(round, msg); err == errSignedLater {
+		h.l.Debug("beacon_round", round, "skip_sign", err)
+		return
+	} else if err != nil {
+		h.l.Error("beacon_round", round, "refuse_sign", err)
+		return
+	}
+	currSig, err := h.crypto.SignPartialAt(round, msg)
 	if err != nil {
 		h.l.Fatal("beacon_round", "err creating signature", "err", err, "round", round)
 		return
 	}
+	if h.conf.OnPartialSigned != nil {
+		h.conf.OnPartialSigned(round, h.crypto.ShareAt(round))
+	}
 	h.l.Debug("broadcast_partial", round, "from_prev_sig", shortSigStr(previousSig), "msg_sign", shortSigStr(msg))
 	packet := &proto.PartialBeaconPacket{
 		Round:       round,
 		PreviousSig: previousSig,
 		PartialSig:  currSig,
+		ChainHash:   h.crypto.GetChainHash(),
+		Timestamp:   h.conf.Clock.Now().UnixNano() / int64(time.Millisecond),
 	}
 	h.chain.NewValidPartial(h.addr, packet)
-	for _, id := range h.crypto.GetGroup().Nodes {
-		if h.addr == id.Address() {
-			continue
-		}
-		go func(i *key.Identity) {
-			h.l.Debug("beacon_round", round, "send_to", i.Address())
-			err := h.client.PartialBeacon(ctx, i, packet)
-			if err != nil {
-				h.l.Error("beacon_round", round, "err_request", err, "from", i.Address())
-				if strings.Contains(err.Error(), errOutOfRound) {
-					h.l.Error("beacon_round", round, "node", i.Addr, "reply", "out-of-round")
-				}
-				return
-			}
-		}(id.Identity)
+	h.Lock()
+	h.signed = packet
+	h.Unlock()
+	ctx, cancel := context.WithTimeout(ctx, h.ticker.Schedule().PeriodAt(round))
+	nodes := h.crypto.GetGroupAt(round).Nodes
+	go func() {
+		defer cancel()
+		h.reportBroadcast(round, h.broadcastPartial(ctx, nodes, packet))
+	}()
+}
+
+func (h *Handler) sendPartial(ctx context.Context, i *key.Identity, packet *proto.PartialBeaconPacket) PeerResult {
+	h.l.Debug("beacon_round", packet.Round, "send_to", i.Address())
+	start := h.conf.Clock.Now()
+	resp, err := h.client.NewBeacon(ctx, i, packet)
+	if status.Code(err) == codes.Unimplemented {
+		// the node does not answer with its partial yet
+		err = h.client.PartialBeacon(ctx, i, packet)
+	}
+	if err != nil && strings.Contains(err.Error(), errOutOfRound) {
+		h.l.Error("beacon_round", packet.Round, "node", i.Addr, "reply", "out-of-round")
 	}
+	latency := h.conf.Clock.Since(start)
+	if err == nil && resp.GetPartial() != nil {
+		err = h.processResponse(i, packet, resp.GetPartial())
+	}
+	return PeerResult{Err: err, Latency: latency}
+}
+
+// processResponse verifies the partial a node answered to the partial sent
+// to it, and forwards it to the round manager. The partial must be signed by
+// the node for the round and the previous signature sent.
+func (h *Handler) processResponse(i *key.Identity, sent, p *proto.PartialBeaconPacket) error {
+	if p.GetRound() != sent.GetRound() || !bytes.Equal(p.GetPreviousSig(), sent.GetPreviousSig()) {
+		return fmt.Errorf("answered a partial for another round or previous signature: round %d", p.GetRound())
+	}
+	return h.processPartial(i.Address(), p, func(round uint64, idx int) error {
+		node := h.crypto.GetGroupAt(round).Node(uint32(idx))
+		if node == nil || node.Address() != i.Address() {
+			return fmt.Errorf("answered the partial of index %d", idx)
+		}
+		return nil
+	})
 }
 
 // Stop the beacon loop from aggregating  further randomness, but it
@@ -315,13 +781,61 @@ func (h *Handler) Stop() {
 	if h.stopped {
 		return
 	}
-	close(h.close)
+	h.cancel()
 	h.chain.Stop()
 	h.ticker.Stop()
 	h.stopped = true
 	h.l.Info("beacon", "stop")
 }
 
+// SetPaused stops or resumes the signature of partials by the node. A paused
+// node keeps aggregating the partials of the other nodes and syncing the
+// chain, so it can resume at any round.
+func (h *Handler) SetPaused(paused bool) {
+	var v uint32
+	if paused {
+		v = 1
+	}
+	if atomic.SwapUint32(&h.paused, v) != v {
+		h.l.Info("beacon", "paused", paused)
+	}
+}
+
+// Paused returns true if the node does not send its partials.
+func (h *Handler) Paused() bool {
+	return atomic.LoadUint32(&h.paused) == 1
+}
+
+// Stalled returns true if the beacon loop has not handled any of the last
+// given number of rounds it should have, for example because it panicked or
+// is blocked. It returns false if the loop is stopped or has not started yet.
+func (h *Handler) Stalled(rounds uint64) bool {
+	h.Lock()
+	stopped := h.stopped
+	h.Unlock()
+	start := atomic.LoadUint64(&h.startRound)
+	if stopped || start == 0 {
+		return false
+	}
+	last := atomic.LoadUint64(&h.lastTick)
+	if last < start {
+		last = start - 1
+	}
+	current := h.ticker.Schedule().CurrentRound(h.conf.Clock.Now().Unix())
+	return current > last && current-last >= rounds
+}
+
+// Dump writes the state of the beacon loop and the stack of all goroutines to
+// w, to diagnose a stalled loop. It does not access the store since it may be
+// the reason of the stall.
+func (h *Handler) Dump(w io.Writer) {
+	current := h.ticker.Schedule().CurrentRound(h.conf.Clock.Now().Unix())
+	fmt.Fprintf(w, "current round: %d\n", current)
+	fmt.Fprintf(w, "loop start round: %d\n", atomic.LoadUint64(&h.startRound))
+	fmt.Fprintf(w, "loop last round: %d\n", atomic.LoadUint64(&h.lastTick))
+	_ = pprof.Lookup("goroutine").WriteTo(w, 1)
+}
+
 // StopAt will stop the handler at the given time. It is useful when
 // transitionining for a resharing.
 func (h *Handler) StopAt(stopTime int64) error {
@@ -352,6 +866,11 @@ func (h *Handler) SyncChain(req *proto.SyncRequest, stream proto.Protocol_SyncCh
 	return h.chain.sync.SyncChain(req, stream)
 }
 
+// GetBeacon is a proxy method to get a single beacon of the chain
+func (h *Handler) GetBeacon(c context.Context, req *proto.GetBeaconRequest) (*proto.BeaconPacket, error) {
+	return h.chain.sync.GetBeacon(c, req)
+}
+
 func shortSigStr(sig []byte) string {
 	max := 3
 	if len(sig) < max {
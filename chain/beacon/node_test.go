@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -8,6 +9,7 @@ import (
 	"os"
 	"path"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/drand/drand/chain/boltdb"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/drand/test"
@@ -23,6 +26,7 @@ import (
 	"github.com/drand/kyber/share"
 	"github.com/drand/kyber/util/random"
 	clock "github.com/jonboulle/clockwork"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/require"
 )
 
@@ -33,6 +37,8 @@ type testBeaconServer struct {
 	disable bool
 	*testnet.EmptyServer
 	h *Handler
+	// bt, if set, drops the answers to the nodes whose reception is disabled
+	bt *BeaconTest
 }
 
 func (t *testBeaconServer) PartialBeacon(c context.Context, in *drand.PartialBeaconPacket) (*drand.Empty, error) {
@@ -42,6 +48,22 @@ func (t *testBeaconServer) PartialBeacon(c context.Context, in *drand.PartialBea
 	return t.h.ProcessPartialBeacon(c, in)
 }
 
+func (t *testBeaconServer) NewBeacon(c context.Context, in *drand.PartialBeaconPacket) (*drand.BeaconResponse, error) {
+	if t.disable {
+		return nil, errors.New("disabled server")
+	}
+	resp, err := t.h.ProcessNewBeacon(c, in)
+	if err != nil || t.bt == nil {
+		return resp, err
+	}
+	// a node whose reception is disabled doesn't receive the answer either
+	idx, _ := key.Scheme.IndexOf(in.GetPartialSig())
+	if n, ok := t.bt.nodes[idx]; ok && n.server != nil && n.server.disable {
+		resp.Partial = nil
+	}
+	return resp, nil
+}
+
 func (t *testBeaconServer) SyncChain(req *drand.SyncRequest, p drand.Protocol_SyncChainServer) error {
 	if t.disable {
 		return errors.New("disabled server")
@@ -131,7 +153,7 @@ type BeaconTest struct {
 func NewBeaconTest(n, thr int, period time.Duration, genesisTime int64) *BeaconTest {
 	prefix, err := ioutil.TempDir(os.TempDir(), "beacon-test")
 	checkErr(err)
-	paths := createBoltStores(prefix, n)
+	paths := createStores(prefix, n)
 	shares, commits := dkgShares(n, thr)
 	privs, group := test.BatchIdentities(n)
 	group.Threshold = thr
@@ -228,7 +250,8 @@ func (b *BeaconTest) CreateNode(i int) {
 func (b *BeaconTest) ServeBeacon(i int) {
 	j := b.searchNode(i)
 	beaconServer := &testBeaconServer{
-		h: b.nodes[j].handler,
+		h:  b.nodes[j].handler,
+		bt: b,
 	}
 	b.nodes[j].server = beaconServer
 	var err error
@@ -338,7 +361,7 @@ func checkErr(e error) {
 	}
 }
 
-func createBoltStores(prefix string, n int) []string {
+func createStores(prefix string, n int) []string {
 	paths := make([]string, n)
 	for i := 0; i < n; i++ {
 		paths[i] = path.Join(prefix, fmt.Sprintf("drand-%d", i))
@@ -482,6 +505,127 @@ func TestBeaconSimple(t *testing.T) {
 	checkWait(counter)
 }
 
+func TestBeaconPause(t *testing.T) {
+	n := 3
+	thr := n/2 + 1
+	period := 2 * time.Second
+
+	var genesisTime int64 = clock.NewFakeClock().Now().Unix() + 2
+
+	bt := NewBeaconTest(n, thr, period, genesisTime)
+	defer bt.CleanUp()
+
+	var counter = &sync.WaitGroup{}
+	counter.Add(n)
+	for i := 0; i < n; i++ {
+		bt.CallbackFor(i, func(b *chain.Beacon) {
+			require.NoError(t, chain.VerifyBeacon(bt.dpublic, b))
+			counter.Done()
+		})
+		bt.ServeBeacon(i)
+	}
+	bt.StartBeacons(n)
+	bt.MoveTime(2 * time.Second)
+	checkWait(counter)
+
+	lastSigned := func(h *Handler) uint64 {
+		h.guard.Lock()
+		defer h.guard.Unlock()
+		return h.guard.last.Round
+	}
+	paused := bt.nodes[0].handler
+	paused.SetPaused(true)
+	require.True(t, paused.Paused())
+	// the paused node still stores the beacons aggregated by the others
+	counter.Add(n)
+	bt.MoveTime(period)
+	checkWait(counter)
+	require.Equal(t, uint64(1), lastSigned(paused))
+
+	paused.SetPaused(false)
+	counter.Add(n)
+	bt.MoveTime(period)
+	checkWait(counter)
+	require.Equal(t, uint64(3), lastSigned(paused))
+}
+
+func TestBeaconRotateInitiator(t *testing.T) {
+	n := 4
+	thr := n/2 + 1
+	period := 2 * time.Second
+
+	var genesisTime int64 = clock.NewFakeClock().Now().Unix() + 2
+
+	bt := NewBeaconTest(n, thr, period, genesisTime)
+	defer bt.CleanUp()
+
+	var counter = &sync.WaitGroup{}
+	myCallBack := func(b *chain.Beacon) {
+		require.NoError(t, chain.VerifyBeacon(bt.dpublic, b))
+		counter.Done()
+	}
+	for i := 0; i < n; i++ {
+		bt.nodes[i].handler.conf.RotateInitiator = true
+		bt.CallbackFor(i, myCallBack)
+		bt.ServeBeacon(i)
+	}
+	bt.StartBeacons(n)
+
+	// the clocks never reach the initiator timeout: the other nodes only
+	// broadcast their partial in answer to the initiator
+	counter.Add(n)
+	bt.MoveTime(2 * time.Second)
+	checkWait(counter)
+	for r := 0; r < 3; r++ {
+		counter.Add(n)
+		bt.MoveTime(period)
+		checkWait(counter)
+	}
+}
+
+func TestBeaconAggregationGrace(t *testing.T) {
+	n := 3
+	thr := n/2 + 1
+	period := 2 * time.Second
+	grace := period / 4
+
+	var genesisTime int64 = clock.NewFakeClock().Now().Unix() + 2
+
+	bt := NewBeaconTest(n, thr, period, genesisTime)
+	defer bt.CleanUp()
+
+	beacons := make(chan *chain.Beacon, n)
+	// the last node is down so the others only ever get a threshold of
+	// partials and aggregate once the grace period expired
+	for i := 0; i < n-1; i++ {
+		bt.nodes[i].handler.conf.AggregationGrace = grace
+		bt.CallbackFor(i, func(b *chain.Beacon) {
+			require.NoError(t, chain.VerifyBeacon(bt.dpublic, b))
+			beacons <- b
+		})
+		bt.ServeBeacon(i)
+	}
+	bt.StartBeacons(n - 1)
+
+	bt.MoveTime(2 * time.Second)
+	select {
+	case b := <-beacons:
+		t.Fatalf("round %d aggregated before the grace period", b.Round)
+	default:
+	}
+
+	bt.MoveTime(grace)
+	for i := 0; i < n-1; i++ {
+		select {
+		case b := <-beacons:
+			require.Equal(t, uint64(1), b.Round)
+			require.Equal(t, []int{0, 1}, chain.ContributorIndices(b.Contributors))
+		case <-time.After(10 * time.Second):
+			t.Fatal("round not aggregated after the grace period")
+		}
+	}
+}
+
 func TestBeaconThreshold(t *testing.T) {
 	n := 3
 	thr := n/2 + 1
@@ -570,3 +714,223 @@ func (b *BeaconTest) CallbackFor(i int, fn func(*chain.Beacon)) {
 	j := b.searchNode(i)
 	b.nodes[j].handler.AddCallback(b.nodes[j].private.Public.Address(), fn)
 }
+
+func TestBeaconStalled(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	fake := bt.nodes[0].clock
+
+	// loop not started
+	require.False(t, h.Stalled(2))
+	atomic.StoreUint64(&h.startRound, 1)
+	require.False(t, h.Stalled(2))
+	// rounds 1 and 2 not handled
+	fake.Advance(period)
+	require.True(t, h.Stalled(2))
+	atomic.StoreUint64(&h.lastTick, 2)
+	require.False(t, h.Stalled(2))
+	fake.Advance(2 * period)
+	require.True(t, h.Stalled(2))
+
+	var dump bytes.Buffer
+	h.Dump(&dump)
+	require.Contains(t, dump.String(), "loop last round: 2")
+
+	// stopping the handler right away races with its aggregator loading the
+	// last beacon, only mark it stopped
+	h.Lock()
+	h.stopped = true
+	h.Unlock()
+	require.False(t, h.Stalled(2))
+}
+
+func TestBeaconChainHash(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	require.Equal(t, chain.NewChainInfo(bt.group).Hash(), h.ChainHash())
+
+	// partials stamped with another chain are rejected before being verified
+	partial := &drand.PartialBeaconPacket{Round: 1, ChainHash: []byte("another chain")}
+	_, err := h.ProcessPartialBeacon(context.Background(), partial)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "another chain")
+	partial.ChainHash = h.ChainHash()
+	_, err = h.ProcessPartialBeacon(context.Background(), partial)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "another chain")
+
+	h.Lock()
+	h.stopped = true
+	h.Unlock()
+}
+
+func TestBeaconPartialWindow(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	h.conf.Clock.(clock.FakeClock).Advance(10 * period)
+	h.stored(&chain.Beacon{Round: 10})
+	h.stored(&chain.Beacon{Round: 9})
+	require.Equal(t, uint64(10), atomic.LoadUint64(&h.lastStored))
+
+	sigLen := key.SigGroup.PointLen()
+	partial := func(round uint64) *drand.PartialBeaconPacket {
+		sig := make([]byte, partialIndexLen+sigLen)
+		sig[1] = 1
+		return &drand.PartialBeaconPacket{Round: round, PreviousSig: make([]byte, sigLen), PartialSig: sig}
+	}
+	// partials older than the window are dropped without being verified
+	before := testutil.ToFloat64(metrics.PartialsExpired.WithLabelValues("1"))
+	_, err := h.ProcessPartialBeacon(context.Background(), partial(7))
+	require.NoError(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(metrics.PartialsExpired.WithLabelValues("1")))
+	_, err = h.ProcessPartialBeacon(context.Background(), partial(8))
+	require.Error(t, err)
+	require.Equal(t, before+1, testutil.ToFloat64(metrics.PartialsExpired.WithLabelValues("1")))
+
+	h.Lock()
+	h.stopped = true
+	h.Unlock()
+}
+
+func TestBeaconGenesisSeed(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	seed := h.crypto.GetInfo().GroupHash
+
+	// the first round must chain from the genesis seed of the group
+	sig := make([]byte, partialIndexLen+key.SigGroup.PointLen())
+	sig[1] = 1
+	wrong := append([]byte{}, seed...)
+	wrong[0] ^= 0xff
+	partial := &drand.PartialBeaconPacket{Round: 1, PreviousSig: wrong, PartialSig: sig, ChainHash: h.ChainHash()}
+	_, err := h.ProcessPartialBeacon(context.Background(), partial)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "genesis seed")
+	partial.PreviousSig = seed
+	_, err = h.ProcessPartialBeacon(context.Background(), partial)
+	require.Error(t, err)
+	require.NotContains(t, err.Error(), "genesis seed")
+
+	h.Lock()
+	h.stopped = true
+	h.Unlock()
+}
+
+func TestBeaconEquivocation(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	signer := bt.nodes[1].handler
+	var evidence *drand.EquivocationPacket
+	h.conf.OnEquivocation = func(idx int, e *drand.EquivocationPacket) {
+		require.Equal(t, 1, idx)
+		evidence = e
+	}
+
+	partial := func(prev byte) *drand.PartialBeaconPacket {
+		prevSig := make([]byte, key.SigGroup.PointLen())
+		prevSig[0] = prev
+		msg := signer.crypto.GetInfo().Message(2, prevSig)
+		sig, err := signer.crypto.SignPartialAt(2, msg)
+		require.NoError(t, err)
+		return &drand.PartialBeaconPacket{Round: 2, PreviousSig: prevSig, PartialSig: sig, ChainHash: h.ChainHash()}
+	}
+	first, second := partial(1), partial(2)
+	_, err := h.ProcessPartialBeacon(context.Background(), first)
+	require.NoError(t, err)
+	require.Nil(t, evidence)
+	_, err = h.ProcessPartialBeacon(context.Background(), second)
+	require.Error(t, err)
+	require.NotNil(t, evidence)
+	require.Equal(t, first, evidence.GetFirst())
+	require.Equal(t, second, evidence.GetSecond())
+
+	// the evidence can be verified by the other nodes
+	idx, err := bt.nodes[2].handler.VerifyEquivocation(evidence)
+	require.NoError(t, err)
+	require.Equal(t, 1, idx)
+	_, err = bt.nodes[2].handler.VerifyEquivocation(&drand.EquivocationPacket{
+		First:     first,
+		Second:    first,
+		ChainHash: h.ChainHash(),
+	})
+	require.Error(t, err)
+	forged := partial(3)
+	forged.PartialSig = second.PartialSig
+	_, err = bt.nodes[2].handler.VerifyEquivocation(&drand.EquivocationPacket{
+		First:     first,
+		Second:    forged,
+		ChainHash: h.ChainHash(),
+	})
+	require.Error(t, err)
+
+	for _, n := range bt.nodes {
+		n.handler.Lock()
+		n.handler.stopped = true
+		n.handler.Unlock()
+	}
+}
+
+func TestBeaconNewBeacon(t *testing.T) {
+	period := 2 * time.Second
+	genesisTime := clock.NewFakeClock().Now().Unix()
+	bt := NewBeaconTest(3, 2, period, genesisTime)
+	defer bt.CleanUp()
+	h := bt.nodes[0].handler
+	peer := bt.nodes[1].handler
+
+	prevSig := make([]byte, key.SigGroup.PointLen())
+	partial := func(s *Handler, prevSig []byte) *drand.PartialBeaconPacket {
+		msg := s.crypto.GetInfo().Message(2, prevSig)
+		sig, err := s.crypto.SignPartialAt(2, msg)
+		require.NoError(t, err)
+		return &drand.PartialBeaconPacket{Round: 2, PreviousSig: prevSig, PartialSig: sig, ChainHash: h.ChainHash()}
+	}
+	sent := partial(h, prevSig)
+
+	// the peer did not sign the round yet
+	resp, err := peer.ProcessNewBeacon(context.Background(), sent)
+	require.NoError(t, err)
+	require.Nil(t, resp.GetPartial())
+
+	// once it signed it, it answers with its partial
+	peer.Lock()
+	peer.signed = partial(peer, prevSig)
+	peer.Unlock()
+	resp, err = peer.ProcessNewBeacon(context.Background(), sent)
+	require.NoError(t, err)
+	require.NotNil(t, resp.GetPartial())
+
+	// the answer is verified against the node asked
+	other := bt.nodes[2].handler.conf.Public.Identity
+	require.Error(t, h.processResponse(other, sent, resp.GetPartial()))
+	require.NoError(t, h.processResponse(peer.conf.Public.Identity, sent, resp.GetPartial()))
+
+	// a partial over another previous signature is not answered
+	otherSig := make([]byte, key.SigGroup.PointLen())
+	otherSig[0] = 1
+	resp, err = peer.ProcessNewBeacon(context.Background(), partial(bt.nodes[2].handler, otherSig))
+	require.NoError(t, err)
+	require.Nil(t, resp.GetPartial())
+	require.Error(t, h.processResponse(peer.conf.Public.Identity, partial(h, otherSig), partial(peer, prevSig)))
+
+	for _, n := range bt.nodes {
+		n.handler.Lock()
+		n.handler.stopped = true
+		n.handler.Unlock()
+	}
+}
@@ -0,0 +1,73 @@
+package beacon
+
+import (
+	"bytes"
+	"strconv"
+	"sync"
+
+	"github.com/drand/drand/metrics"
+	proto "github.com/drand/drand/protobuf/drand"
+)
+
+// replayWindow is the number of rounds before the current one for which the
+// verified partials are remembered.
+const replayWindow = 2
+
+type partialID struct {
+	round uint64
+	index int
+}
+
+// replayCache remembers the partials already verified for the last rounds so
+// that the copies a peer resends are dropped before the pairing verification.
+type replayCache struct {
+	sync.Mutex
+	seen map[partialID]*proto.PartialBeaconPacket
+}
+
+func newReplayCache() *replayCache {
+	return &replayCache{seen: make(map[partialID]*proto.PartialBeaconPacket)}
+}
+
+// replayed returns true if exactly this partial has already been verified for
+// the round, and counts the suppressed replay.
+func (r *replayCache) replayed(round uint64, index int, partial []byte) bool {
+	r.Lock()
+	defer r.Unlock()
+	p, ok := r.seen[partialID{round, index}]
+	if !ok || !bytes.Equal(p.GetPartialSig(), partial) {
+		return false
+	}
+	metrics.PartialReplays.WithLabelValues(strconv.Itoa(index)).Inc()
+	return true
+}
+
+// verified remembers a verified partial of a round not older than the replay
+// window, and forgets the ones of the rounds outside of it. If a partial of
+// the same index signing another previous signature was already verified for
+// the round, it is kept and returned: the node signed two different messages.
+func (r *replayCache) verified(current uint64, index int, p *proto.PartialBeaconPacket) *proto.PartialBeaconPacket {
+	r.Lock()
+	defer r.Unlock()
+	for id := range r.seen {
+		if id.round+replayWindow < current {
+			delete(r.seen, id)
+		}
+	}
+	if p.GetRound()+replayWindow < current {
+		return nil
+	}
+	id := partialID{p.GetRound(), index}
+	if prev, ok := r.seen[id]; ok && !bytes.Equal(prev.GetPreviousSig(), p.GetPreviousSig()) {
+		return prev
+	}
+	r.seen[id] = p
+	return nil
+}
+
+// Len returns the number of partials remembered
+func (r *replayCache) Len() int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.seen)
+}
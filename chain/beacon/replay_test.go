@@ -0,0 +1,36 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/drand/drand/metrics"
+	proto "github.com/drand/drand/protobuf/drand"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayCache(t *testing.T) {
+	r := newReplayCache()
+	partial := []byte("partial of node 3")
+	require.False(t, r.replayed(10, 3, partial))
+
+	p := &proto.PartialBeaconPacket{Round: 10, PreviousSig: []byte("sig 9"), PartialSig: partial}
+	require.Nil(t, r.verified(10, 3, p))
+	before := testutil.ToFloat64(metrics.PartialReplays.WithLabelValues("3"))
+	require.True(t, r.replayed(10, 3, partial))
+	require.Equal(t, before+1, testutil.ToFloat64(metrics.PartialReplays.WithLabelValues("3")))
+	// a different partial for the same round and index is verified
+	require.False(t, r.replayed(10, 3, []byte("another partial")))
+	require.False(t, r.replayed(11, 3, partial))
+
+	// a partial of the same node signing another message for the round is
+	// an equivocation
+	other := &proto.PartialBeaconPacket{Round: 10, PreviousSig: []byte("other sig 9"), PartialSig: []byte("another partial")}
+	require.Equal(t, p, r.verified(10, 3, other))
+	require.True(t, r.replayed(10, 3, partial))
+
+	// partials too old are not remembered and the old ones are forgotten
+	require.Nil(t, r.verified(20, 4, p))
+	require.False(t, r.replayed(10, 4, partial))
+	require.Equal(t, 0, r.Len())
+}
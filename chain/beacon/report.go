@@ -0,0 +1,174 @@
+package beacon
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/metrics"
+)
+
+// PartialReport describes the partial signature a node sent for a round.
+type PartialReport struct {
+	Index int
+	// Delay is the time between the start of the round and the reception of
+	// the partial signature.
+	Delay time.Duration
+	// Late is true if the partial was received after the beacon was
+	// aggregated.
+	Late bool
+}
+
+// RoundReport describes how the group produced a round: which nodes sent their
+// partial signature and how long it took to aggregate the beacon.
+type RoundReport struct {
+	Round    uint64
+	Partials []PartialReport
+	// Missing lists the indices of the nodes of the group that did not send
+	// any partial for this round.
+	Missing []int
+	// Aggregation is the time between the start of the round and the
+	// aggregation of its beacon.
+	Aggregation time.Duration
+}
+
+// roundReports keeps the reports of the last rounds aggregated by this node.
+// Partials are recorded as they come, and the report is kept once the round
+// is aggregated.
+type roundReports struct {
+	sync.Mutex
+	schedule func() chain.Schedule
+	// pending are the partials received for rounds not yet aggregated
+	pending map[uint64][]PartialReport
+	// reports is a ring of the last MaxRoundReports reports
+	reports []RoundReport
+	next    int
+}
+
+func newRoundReports(schedule func() chain.Schedule) *roundReports {
+	return &roundReports{
+		schedule: schedule,
+		pending:  make(map[uint64][]PartialReport),
+		reports:  make([]RoundReport, 0, MaxRoundReports),
+	}
+}
+
+func (r *roundReports) delay(round uint64, now time.Time) time.Duration {
+	start := r.schedule().TimeOfRound(round)
+	return now.Sub(time.Unix(start, 0))
+}
+
+// received records the partial of the given node for a round not aggregated
+// yet. It is a no-op if the partial was already recorded.
+func (r *roundReports) received(round uint64, idx int, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	if hasPartial(r.pending[round], idx) {
+		return
+	}
+	partial := PartialReport{Index: idx, Delay: r.delay(round, now)}
+	r.pending[round] = append(r.pending[round], partial)
+	r.observe(partial)
+}
+
+// late records the partial of the given node received after the aggregation
+// of the round. It is a no-op if the round is not the last one aggregated by
+// this node or if the partial was already recorded.
+func (r *roundReports) late(round uint64, idx int, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	last := r.last()
+	if last == nil || last.Round != round || hasPartial(last.Partials, idx) {
+		return
+	}
+	partial := PartialReport{Index: idx, Delay: r.delay(round, now), Late: true}
+	last.Partials = append(last.Partials, partial)
+	r.observe(partial)
+}
+
+// aggregated keeps the report of a round whose beacon was just aggregated.
+func (r *roundReports) aggregated(round uint64, now time.Time) {
+	r.Lock()
+	defer r.Unlock()
+	report := RoundReport{
+		Round:       round,
+		Partials:    r.pending[round],
+		Aggregation: r.delay(round, now),
+	}
+	r.flush(round)
+	metrics.BeaconAggregationLatency.Set(float64(report.Aggregation.Milliseconds()))
+	if len(r.reports) < MaxRoundReports {
+		r.reports = append(r.reports, report)
+	} else {
+		r.reports[r.next] = report
+	}
+	r.next = (r.next + 1) % MaxRoundReports
+}
+
+// flush deletes the partials of all the pending rounds up to the given one.
+func (r *roundReports) flush(round uint64) {
+	for pr := range r.pending {
+		if pr <= round {
+			delete(r.pending, pr)
+		}
+	}
+}
+
+// Flush deletes the partials pending for rounds that are already stored.
+func (r *roundReports) Flush(round uint64) {
+	r.Lock()
+	defer r.Unlock()
+	r.flush(round)
+}
+
+// last returns the most recent report, if any. It must be called with the
+// lock held.
+func (r *roundReports) last() *RoundReport {
+	if len(r.reports) == 0 {
+		return nil
+	}
+	return &r.reports[(r.next+len(r.reports)-1)%len(r.reports)]
+}
+
+// Last returns up to n of the most recent reports, from the oldest to the
+// newest, with the missing nodes computed from the given indices.
+func (r *roundReports) Last(n int, indices []int) []RoundReport {
+	r.Lock()
+	defer r.Unlock()
+	if n <= 0 || n > len(r.reports) {
+		n = len(r.reports)
+	}
+	out := make([]RoundReport, 0, n)
+	for i := len(r.reports) - n; i < len(r.reports); i++ {
+		report := r.reports[(r.next+i)%len(r.reports)]
+		report.Partials = append([]PartialReport(nil), report.Partials...)
+		sort.Slice(report.Partials, func(i, j int) bool {
+			return report.Partials[i].Index < report.Partials[j].Index
+		})
+		report.Missing = nil
+		for _, idx := range indices {
+			if !hasPartial(report.Partials, idx) {
+				report.Missing = append(report.Missing, idx)
+			}
+		}
+		out = append(out, report)
+	}
+	return out
+}
+
+func (r *roundReports) observe(p PartialReport) {
+	label := strconv.Itoa(p.Index)
+	metrics.PartialsReceived.WithLabelValues(label).Inc()
+	metrics.PartialDelay.WithLabelValues(label).Set(float64(p.Delay.Milliseconds()))
+}
+
+func hasPartial(partials []PartialReport, idx int) bool {
+	for _, p := range partials {
+		if p.Index == idx {
+			return true
+		}
+	}
+	return false
+}
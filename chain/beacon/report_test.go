@@ -0,0 +1,57 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundReports(t *testing.T) {
+	genesis := time.Now().Unix()
+	period := 10 * time.Second
+	start := time.Unix(genesis, 0)
+	r := newRoundReports(func() chain.Schedule {
+		return chain.Schedule{Genesis: genesis, Period: period}
+	})
+
+	// round 1 starts at genesis time
+	r.received(1, 1, start.Add(100*time.Millisecond))
+	r.received(1, 2, start.Add(200*time.Millisecond))
+	r.received(1, 2, start.Add(300*time.Millisecond))
+	// partial in advance for the next round
+	r.received(2, 1, start.Add(period))
+	r.aggregated(1, start.Add(250*time.Millisecond))
+	r.late(1, 3, start.Add(time.Second))
+	// a late partial for an older round is ignored
+	r.late(0, 4, start.Add(time.Second))
+
+	reports := r.Last(0, []int{1, 2, 3, 4})
+	require.Len(t, reports, 1)
+	report := reports[0]
+	require.Equal(t, uint64(1), report.Round)
+	require.Equal(t, 250*time.Millisecond, report.Aggregation)
+	require.Equal(t, []PartialReport{
+		{Index: 1, Delay: 100 * time.Millisecond},
+		{Index: 2, Delay: 200 * time.Millisecond},
+		{Index: 3, Delay: time.Second, Late: true},
+	}, report.Partials)
+	require.Equal(t, []int{4}, report.Missing)
+
+	// the partial received in advance is kept for its round
+	r.aggregated(2, start.Add(period+time.Second))
+	reports = r.Last(1, []int{1, 2})
+	require.Len(t, reports, 1)
+	require.Equal(t, uint64(2), reports[0].Round)
+	require.Equal(t, []int{2}, reports[0].Missing)
+
+	// only the last rounds are kept
+	for round := uint64(3); round < MaxRoundReports+10; round++ {
+		r.aggregated(round, start.Add(time.Duration(round)*period))
+	}
+	reports = r.Last(0, nil)
+	require.Len(t, reports, MaxRoundReports)
+	require.Equal(t, uint64(10), reports[0].Round)
+	require.Equal(t, uint64(MaxRoundReports+9), reports[len(reports)-1].Round)
+}
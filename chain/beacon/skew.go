@@ -0,0 +1,85 @@
+package beacon
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// PeerSkew describes how far the clock of a node of the group deviates from
+// the local clock, as measured from the timestamps of its partials.
+type PeerSkew struct {
+	Index int
+	// Skew is the difference between the local time at which the last partial
+	// of the node was received and the time at which the node sent it. It
+	// includes the network latency.
+	Skew time.Duration
+	// Degraded is true when the skew of the last partials of the node all
+	// exceeded the maximum skew. The node is then not counted on to reach the
+	// threshold.
+	Degraded bool
+}
+
+// skewTracker keeps the clock skew of the nodes of the group.
+type skewTracker struct {
+	sync.Mutex
+	max   time.Duration
+	peers map[int]*peerSkew
+}
+
+type peerSkew struct {
+	skew time.Duration
+	// strikes is the number of consecutive partials outside of the window
+	strikes int
+}
+
+func newSkewTracker(max time.Duration) *skewTracker {
+	if max <= 0 {
+		max = DefaultMaxClockSkew
+	}
+	return &skewTracker{max: max, peers: make(map[int]*peerSkew)}
+}
+
+// observe records the skew of a partial sent by the node at the given time.
+func (s *skewTracker) observe(idx int, sent, received time.Time) {
+	skew := received.Sub(sent)
+	s.Lock()
+	defer s.Unlock()
+	p, ok := s.peers[idx]
+	if !ok {
+		p = new(peerSkew)
+		s.peers[idx] = p
+	}
+	p.skew = skew
+	if skew > s.max || skew < -s.max {
+		p.strikes++
+	} else {
+		p.strikes = 0
+	}
+}
+
+// countDegraded returns the number of degraded nodes.
+func (s *skewTracker) countDegraded() int {
+	s.Lock()
+	defer s.Unlock()
+	var n int
+	for _, p := range s.peers {
+		if p.strikes >= SkewStrikes {
+			n++
+		}
+	}
+	return n
+}
+
+// Skews returns the skew of all the nodes a partial was received from, sorted
+// by index.
+func (s *skewTracker) Skews() []PeerSkew {
+	s.Lock()
+	defer s.Unlock()
+	skews := make([]PeerSkew, 0, len(s.peers))
+	for idx, p := range s.peers {
+		skews = append(skews, PeerSkew{Index: idx, Skew: p.skew, Degraded: p.strikes >= SkewStrikes})
+	}
+	sort.Slice(skews, func(i, j int) bool { return skews[i].Index < skews[j].Index })
+	return skews
+}
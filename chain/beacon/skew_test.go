@@ -0,0 +1,30 @@
+package beacon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSkewTracker(t *testing.T) {
+	s := newSkewTracker(0)
+	require.Equal(t, DefaultMaxClockSkew, s.max)
+
+	now := time.Unix(1000, 0)
+	s.observe(2, now.Add(-100*time.Millisecond), now)
+	// a node whose clock is ahead is degraded after enough partials
+	for i := 0; i < SkewStrikes; i++ {
+		require.Equal(t, 0, s.countDegraded())
+		s.observe(1, now.Add(5*time.Second), now)
+	}
+	require.Equal(t, 1, s.countDegraded())
+	require.Equal(t, []PeerSkew{
+		{Index: 1, Skew: -5 * time.Second, Degraded: true},
+		{Index: 2, Skew: 100 * time.Millisecond},
+	}, s.Skews())
+
+	// a single partial in the window clears the node
+	s.observe(1, now, now)
+	require.Equal(t, 0, s.countDegraded())
+}
@@ -2,15 +2,16 @@ package beacon
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"runtime"
 	"sync"
 	"time"
 
 	"github.com/drand/drand/chain"
-	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/metrics"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 // CallbackStore is an interface that allows to register callbacks that gets
@@ -53,18 +54,61 @@ func (a *appendStore) Put(b *chain.Beacon) error {
 	return nil
 }
 
+// ErrStoreFull is returned when a beacon can not be saved because the
+// database reached its maximum allowed size.
+var ErrStoreFull = errors.New("beacon database reached its maximum size")
+
+// sizer is implemented by stores able to report the size they use on disk.
+type sizer interface {
+	Size() int64
+}
+
+// quotaStore refuses to save new beacons once the underlying store reached
+// its maximum size, and reports the current size of the store in the metrics.
+type quotaStore struct {
+	chain.Store
+	l   log.Logger
+	max int64
+}
+
+// newQuotaStore returns the store unwrapped when it can't report its size.
+// NewHandler refuses such a store when a maximum size is set.
+func newQuotaStore(s chain.Store, l log.Logger, max int64) chain.Store {
+	if _, ok := s.(sizer); !ok {
+		return s
+	}
+	return &quotaStore{
+		Store: s,
+		l:     l,
+		max:   max,
+	}
+}
+
+func (q *quotaStore) Put(b *chain.Beacon) error {
+	size := q.Store.(sizer).Size()
+	if q.max > 0 && size >= q.max {
+		q.l.Error("store", "quota exceeded", "size", size, "max", q.max, "round", b.Round)
+		return ErrStoreFull
+	}
+	if err := q.Store.Put(b); err != nil {
+		return err
+	}
+	metrics.StoreSize.Set(float64(q.Store.(sizer).Size()))
+	return nil
+}
+
 // discrepancyStore is used to log timing information about the rounds
 type discrepancyStore struct {
 	chain.Store
-	l     log.Logger
-	group *key.Group
+	l        log.Logger
+	schedule func() chain.Schedule
 }
 
-func newDiscrepancyStore(s chain.Store, l log.Logger, group *key.Group) chain.Store {
+func newDiscrepancyStore(s chain.Store, l log.Logger, schedule func() chain.Schedule) chain.Store {
 	return &discrepancyStore{
-		Store: s,
-		l:     l,
-		group: group,
+		Store:    s,
+		l:        l,
+		schedule: schedule,
 	}
 }
 
@@ -73,13 +117,104 @@ func (d *discrepancyStore) Put(b *chain.Beacon) error {
 		return err
 	}
 	actual := time.Now().UnixNano()
-	expected := chain.TimeOfRound(d.group.Period, d.group.GenesisTime, b.Round) * 1e9
+	expected := d.schedule().TimeOfRound(b.Round) * 1e9
 	discrepancy := float64(actual-expected) / float64(time.Millisecond)
 	metrics.BeaconDiscrepancyLatency.Set(float64(actual-expected) / float64(time.Millisecond))
 	d.l.Info("NEW_BEACON_STORED", b.String(), "time_discrepancy_ms", discrepancy)
 	return nil
 }
 
+// cacheStore keeps the last beacons read or stored in memory, in front of the
+// database: the latest rounds are requested orders of magnitude more than the
+// old ones through the public API.
+type cacheStore struct {
+	chain.Store
+	cache *lru.Cache
+	sync.Mutex
+	// last is the last beacon stored, nil until it is known
+	last *chain.Beacon
+}
+
+// newCacheStore returns a store caching up to size beacons, or the store
+// itself if the size is negative.
+func newCacheStore(s chain.Store, size int) chain.Store {
+	if size < 0 {
+		return s
+	}
+	if size == 0 {
+		size = DefaultCacheSize
+	}
+	cache, _ := lru.New(size)
+	return &cacheStore{
+		Store: s,
+		cache: cache,
+	}
+}
+
+func (c *cacheStore) Put(b *chain.Beacon) error {
+	if err := c.Store.Put(b); err != nil {
+		return err
+	}
+	c.cache.Add(b.Round, b)
+	c.Lock()
+	defer c.Unlock()
+	if c.last == nil || b.Round > c.last.Round {
+		c.last = b
+	}
+	return nil
+}
+
+func (c *cacheStore) Last() (*chain.Beacon, error) {
+	c.Lock()
+	last := c.last
+	c.Unlock()
+	if last != nil {
+		metrics.BeaconCacheHits.Inc()
+		return last, nil
+	}
+	metrics.BeaconCacheMisses.Inc()
+	last, err := c.Store.Last()
+	if err != nil {
+		return nil, err
+	}
+	c.Lock()
+	defer c.Unlock()
+	if c.last == nil || last.Round > c.last.Round {
+		c.last = last
+	}
+	return c.last, nil
+}
+
+func (c *cacheStore) Get(round uint64) (*chain.Beacon, error) {
+	if b, ok := c.cache.Get(round); ok {
+		metrics.BeaconCacheHits.Inc()
+		return b.(*chain.Beacon), nil
+	}
+	metrics.BeaconCacheMisses.Inc()
+	b, err := c.Store.Get(round)
+	if err != nil {
+		return nil, err
+	}
+	c.cache.Add(round, b)
+	return b, nil
+}
+
+// Del removes the beacon from the cache too. The last beacon is read from the
+// database again once it is deleted. The beacon is deleted from the database
+// first so it can't be cached again from there afterwards.
+func (c *cacheStore) Del(round uint64) error {
+	if err := c.Store.Del(round); err != nil {
+		return err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.cache.Remove(round)
+	if c.last != nil && c.last.Round >= round {
+		c.last = nil
+	}
+	return nil
+}
+
 // callbackStores keeps a list of functions to notify on new beacons
 type callbackStore struct {
 	chain.Store
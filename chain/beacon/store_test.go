@@ -0,0 +1,75 @@
+package beacon
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCacheStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bbstore, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, bbstore.Put(&chain.Beacon{Round: i, Signature: []byte{byte(i)}}))
+	}
+	require.Equal(t, bbstore, newCacheStore(bbstore, -1))
+	s := newCacheStore(bbstore, 2)
+
+	hits := testutil.ToFloat64(metrics.BeaconCacheHits)
+	misses := testutil.ToFloat64(metrics.BeaconCacheMisses)
+	last, err := s.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), last.Round)
+	_, err = s.Last()
+	require.NoError(t, err)
+	_, err = s.Get(2)
+	require.NoError(t, err)
+	b, err := s.Get(2)
+	require.NoError(t, err)
+	require.Equal(t, []byte{2}, b.Signature)
+	require.Equal(t, hits+2, testutil.ToFloat64(metrics.BeaconCacheHits))
+	require.Equal(t, misses+2, testutil.ToFloat64(metrics.BeaconCacheMisses))
+
+	// the beacons stored are cached and become the last one
+	require.NoError(t, s.Put(&chain.Beacon{Round: 6}))
+	last, err = s.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), last.Round)
+	_, err = s.Get(6)
+	require.NoError(t, err)
+	require.Equal(t, hits+4, testutil.ToFloat64(metrics.BeaconCacheHits))
+
+	// the deleted beacons are not served from the cache
+	require.NoError(t, s.Del(6))
+	_, err = s.Get(6)
+	require.Error(t, err)
+	last, err = s.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), last.Round)
+
+	// the cache is kept when the beacon can't be deleted from the database
+	failing := newCacheStore(&failingDelStore{bbstore}, 2)
+	require.NoError(t, failing.Put(&chain.Beacon{Round: 6}))
+	require.Error(t, failing.Del(6))
+	last, err = failing.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(6), last.Round)
+}
+
+type failingDelStore struct {
+	chain.Store
+}
+
+func (f *failingDelStore) Del(round uint64) error {
+	return errors.New("can't delete")
+}
@@ -1,6 +1,7 @@
 package beacon
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -12,6 +13,8 @@ import (
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
 	proto "github.com/drand/drand/protobuf/drand"
+	protobuf "github.com/golang/protobuf/proto"
+	clock "github.com/jonboulle/clockwork"
 )
 
 // Syncer allows to follow a chain from other nodes and replies to syncing
@@ -20,30 +23,47 @@ type Syncer interface {
 	// Follow is a blocking call that continuously fetches the beacon from the
 	// given nodes, verify the validity (chain etc) and then  stores it, until
 	// the context is canceled or the round reaches upTo.  To follow
-	// indefinitely, simply pass upTo = 0.
+	// indefinitely, simply pass upTo = 0. When the node is far behind, the
+	// missing rounds are first fetched from several nodes in parallel.
 	Follow(c context.Context, upTo uint64, to []net.Peer) error
 	// Syncing returns true if the syncer is currently being syncing
 	Syncing() bool
 	// SyncChain imeplements the server side of the syncing process
 	SyncChain(req *proto.SyncRequest, p proto.Protocol_SyncChainServer) error
+	// GetBeacon returns the stored beacon of the requested round
+	GetBeacon(c context.Context, req *proto.GetBeaconRequest) (*proto.BeaconPacket, error)
 }
 
 // syncer implements the Syncer interface
 type syncer struct {
 	l         log.Logger
 	store     CallbackStore
-	info      *chain.Info
+	info      func() *chain.Info
 	client    net.ProtocolClient
+	clock     clock.Clock
+	limits    SyncLimits
 	following bool
+	// serving is the number of peers currently syncing from this node
+	serving int
 	sync.Mutex
 }
 
-// NewSyncer returns a syncer implementation
-func NewSyncer(l log.Logger, s CallbackStore, info *chain.Info, client net.ProtocolClient) Syncer {
+// NewSyncer returns a syncer implementation fetching and serving the chain
+// within the given limits.
+func NewSyncer(l log.Logger, s CallbackStore, info *chain.Info, client net.ProtocolClient, c clock.Clock, limits SyncLimits) Syncer {
+	return newSyncer(l, s, func() *chain.Info { return info }, client, c, limits)
+}
+
+// newSyncer returns a syncer verifying beacons with the chain info returned by
+// info, which can change when the chain migrates its message format.
+func newSyncer(l log.Logger, s CallbackStore, info func() *chain.Info, client net.ProtocolClient, c clock.Clock,
+	limits SyncLimits) *syncer {
 	return &syncer{
 		store:  s,
 		info:   info,
 		client: client,
+		clock:  c,
+		limits: limits,
 		l:      l,
 	}
 }
@@ -70,6 +90,17 @@ func (s *syncer) Follow(c context.Context, upTo uint64, nodes []net.Peer) error
 
 	s.l.Debug("syncer", "starting", "up_to", upTo, "nodes", peersToString(nodes))
 
+	// fetch the bulk of the missing rounds from several nodes in parallel
+	if target := s.catchupTarget(upTo); len(nodes) > 1 && target > 0 {
+		last, err := s.catchup(c, target, nodes)
+		if err != nil {
+			s.l.Debug("syncer", "parallel_catchup", "err", err)
+		}
+		if upTo > 0 && last != nil && last.Round >= upTo {
+			return nil
+		}
+	}
+
 	// shuffle through the nodes
 	for _, n := range rand.Perm(len(nodes)) {
 		node := nodes[n]
@@ -80,60 +111,123 @@ func (s *syncer) Follow(c context.Context, upTo uint64, nodes []net.Peer) error
 	return errors.New("sync store tried to follow all nodes")
 }
 
+// catchupTarget returns the round up to which the chain is fetched from
+// several nodes in parallel, or zero if the node is less than a chunk behind.
+func (s *syncer) catchupTarget(upTo uint64) uint64 {
+	last, err := s.store.Last()
+	if err != nil {
+		return 0
+	}
+	target := upTo
+	if target == 0 {
+		// the current round may not be produced yet
+		target = s.info().Schedule().CurrentRound(s.clock.Now().Unix())
+		if target > 0 {
+			target--
+		}
+	}
+	if target <= last.Round+s.limits.chunkSize() {
+		return 0
+	}
+	return target
+}
+
 func (s *syncer) tryNode(global context.Context, upTo uint64, n net.Peer) bool {
-	cnode, cancel := context.WithCancel(global)
-	defer cancel()
 	last, err := s.store.Last()
 	if err != nil {
 		return false
 	}
+	// the rates are shared by the batches fetched from the node
+	t := newThrottle(s.clock, s.limits)
+	for {
+		var ok bool
+		last, ok = s.fetch(global, n, last, s.limits.batchEnd(last.Round+1, upTo), t)
+		if upTo > 0 && last.Round >= upTo {
+			s.l.Debug("syncer", "syncing finished to", "round", upTo)
+			return true
+		}
+		if !ok {
+			break
+		}
+	}
+	// see if this was a cancellation from the call itself
+	select {
+	case <-global.Done():
+		s.l.Debug("syncer", "follow canceled", "err?", global.Err())
+		if global.Err() == nil {
+			return true
+		}
+		return false
+	default:
+	}
+	return false
+}
+
+// fetch stores the beacons following last up to the round to, or without end
+// if to is zero, streamed from the given node. It returns the last beacon
+// stored and true if all the rounds were fetched.
+func (s *syncer) fetch(global context.Context, n net.Peer, last *chain.Beacon, to uint64, t *throttle) (*chain.Beacon, bool) {
+	cnode, cancel := context.WithCancel(global)
+	defer cancel()
+	hash := s.info().Hash()
 	beaconCh, err := s.client.SyncChain(cnode, n, &proto.SyncRequest{
 		FromRound: last.Round + 1,
+		ChainHash: hash,
+		UpTo:      to,
 	})
 	if err != nil {
 		s.l.Debug("syncer", "unable_to_sync", "with_peer", n.Address(), "err", err)
-		return false
+		return last, false
 	}
 
-	s.l.Debug("syncer", "start_follow", "with_peer", n.Address(), "from_round", last.Round+1)
+	s.l.Debug("syncer", "start_follow", "with_peer", n.Address(), "from_round", last.Round+1, "up_to", to)
 
 	for beaconPacket := range beaconCh {
 		s.l.Debug("syncer", "new_beacon_fetched", "with_peer", n.Address(), "from_round", last.Round+1, "got_round", beaconPacket.GetRound())
+		if ch := beaconPacket.GetChainHash(); len(ch) > 0 && !bytes.Equal(ch, hash) {
+			s.l.Debug("syncer", "beacon_from_another_chain", "with_peer", n.Address(), "round", beaconPacket.GetRound())
+			return last, false
+		}
 		beacon := protoToBeacon(beaconPacket)
 
 		// verify the signature validity
-		if err := chain.VerifyBeacon(s.info.PublicKey, beacon); err != nil {
+		if err := s.info().VerifyBeacon(beacon); err != nil {
 			s.l.Debug("syncer", "invalid_beacon", "with_peer", n.Address(), "round", beacon.Round, "err", err, fmt.Sprintf("%+v", beacon))
-			return false
+			return last, false
 		}
 
 		if err := s.store.Put(beacon); err != nil {
 			s.l.Debug("syncer", "unable to save", "with_peer", n.Address(), "err", err)
-			return false
+			return last, false
 		}
 		last = beacon
-		if last.Round == upTo {
-			s.l.Debug("syncer", "syncing finished to", "round", upTo)
-			return true
+		if last.Round == to {
+			return last, true
 		}
-	}
-	// see if this was a cancellation from the call itself
-	select {
-	case <-global.Done():
-		s.l.Debug("syncer", "follow canceled", "err?", global.Err())
-		if global.Err() == nil {
-			return true
+		if err := t.wait(cnode, protobuf.Size(beaconPacket)); err != nil {
+			return last, false
 		}
-		return false
-	default:
 	}
-	return false
+	return last, false
 }
 
 func (s *syncer) SyncChain(req *proto.SyncRequest, stream proto.Protocol_SyncChainServer) error {
 	fromRound := req.GetFromRound()
 	addr := net.RemoteAddress(stream.Context())
 	s.l.Debug("syncer", "sync_request", "from", addr, "from_round", fromRound)
+	hash := s.info().Hash()
+	if ch := req.GetChainHash(); len(ch) > 0 && !bytes.Equal(ch, hash) {
+		return errors.New("sync request for another chain")
+	}
+	upTo := req.GetUpTo()
+	if upTo > 0 && upTo < fromRound {
+		return fmt.Errorf("invalid sync range from round %d up to %d", fromRound, upTo)
+	}
+	if !s.startServing() {
+		s.l.Debug("syncer", "sync_refused", "from", addr, "serving", s.limits.MaxPeers)
+		return errors.New("too many peers syncing from this node")
+	}
+	defer s.stopServing()
 
 	last, err := s.store.Last()
 	if err != nil {
@@ -148,7 +242,10 @@ func (s *syncer) SyncChain(req *proto.SyncRequest, stream proto.Protocol_SyncCha
 		var err error
 		s.store.Cursor(func(c chain.Cursor) {
 			for bb := c.Seek(fromRound); bb != nil; bb = c.Next() {
-				if err = stream.Send(beaconToProto(bb)); err != nil {
+				if upTo > 0 && bb.Round > upTo {
+					return
+				}
+				if err = stream.Send(beaconToProto(bb, hash)); err != nil {
 					s.l.Debug("syncer", "streaming_send", "err", err)
 					return
 				}
@@ -157,14 +254,30 @@ func (s *syncer) SyncChain(req *proto.SyncRequest, stream proto.Protocol_SyncCha
 		if err != nil {
 			return err
 		}
+		if upTo > 0 && upTo <= last.Round {
+			return nil
+		}
 	}
 	var done = make(chan error, 1)
+	finish := func() {
+		select {
+		case done <- nil:
+		default:
+		}
+	}
 	// then register a callback to process new incoming beacons
 	s.store.AddCallback(addr, func(b *chain.Beacon) {
-		err := stream.Send(beaconToProto(b))
+		if upTo > 0 && b.Round > upTo {
+			return
+		}
+		err := stream.Send(beaconToProto(b, hash))
 		if err != nil {
 			s.l.Debug("syncer", "streaming_send", "err", err)
-			done <- nil
+			finish()
+			return
+		}
+		if b.Round == upTo {
+			finish()
 		}
 	})
 	defer s.store.RemoveCallback(addr)
@@ -178,6 +291,36 @@ func (s *syncer) SyncChain(req *proto.SyncRequest, stream proto.Protocol_SyncCha
 	}
 }
 
+// startServing returns false if the node already streams the chain to the
+// maximum number of peers, and counts a new one otherwise.
+func (s *syncer) GetBeacon(c context.Context, req *proto.GetBeaconRequest) (*proto.BeaconPacket, error) {
+	hash := s.info().Hash()
+	if ch := req.GetChainHash(); len(ch) > 0 && !bytes.Equal(ch, hash) {
+		return nil, errors.New("beacon request for another chain")
+	}
+	b, err := s.store.Get(req.GetRound())
+	if err != nil {
+		return nil, fmt.Errorf("no beacon stored for round %d: %w", req.GetRound(), err)
+	}
+	return beaconToProto(b, hash), nil
+}
+
+func (s *syncer) startServing() bool {
+	s.Lock()
+	defer s.Unlock()
+	if s.limits.MaxPeers > 0 && s.serving >= s.limits.MaxPeers {
+		return false
+	}
+	s.serving++
+	return true
+}
+
+func (s *syncer) stopServing() {
+	s.Lock()
+	defer s.Unlock()
+	s.serving--
+}
+
 func peersToString(peers []net.Peer) string {
 	var adds []string
 	for _, p := range peers {
@@ -0,0 +1,142 @@
+package beacon
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	"github.com/drand/kyber/share"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+// syncTestClient streams a chain to the syncer, with a corrupted signature
+// from the liars.
+type syncTestClient struct {
+	net.ProtocolClient
+	chain []*chain.Beacon
+	liars map[string]bool
+	sync.Mutex
+	// served is the number of beacons sent by each node
+	served map[string]int
+}
+
+func (c *syncTestClient) SyncChain(ctx context.Context, p net.Peer, in *drand.SyncRequest, opts ...net.CallOption) (chan *drand.BeaconPacket, error) {
+	ch := make(chan *drand.BeaconPacket)
+	go func() {
+		defer close(ch)
+		for r := in.GetFromRound(); r < uint64(len(c.chain)); r++ {
+			if in.GetUpTo() > 0 && r > in.GetUpTo() {
+				return
+			}
+			packet := beaconToProto(c.chain[r], nil)
+			if c.liars[p.Address()] && r > 0 {
+				packet.Signature = append([]byte{}, packet.Signature...)
+				packet.Signature[0] ^= 0xff
+			}
+			select {
+			case ch <- packet:
+			case <-ctx.Done():
+				return
+			}
+			c.Lock()
+			c.served[p.Address()]++
+			c.Unlock()
+		}
+	}()
+	return ch, nil
+}
+
+// testChain returns a chain of the given number of rounds after the genesis.
+func testChain(t *testing.T, rounds int) (*chain.Info, []*chain.Beacon) {
+	shares, commits := dkgShares(1, 1)
+	pubPoly := share.NewPubPoly(key.KeyGroup, nil, commits)
+	info := &chain.Info{
+		PublicKey:   commits[0],
+		Period:      time.Second,
+		GenesisTime: time.Now().Unix(),
+		GroupHash:   []byte("sync test chain"),
+	}
+	beacons := []*chain.Beacon{chain.GenesisBeacon(info)}
+	for i := 1; i <= rounds; i++ {
+		prev := beacons[i-1]
+		msg := info.Message(uint64(i), prev.Signature)
+		partial, err := key.Scheme.Sign(shares[0].PrivateShare(), msg)
+		require.NoError(t, err)
+		sig, err := key.Scheme.Recover(pubPoly, msg, [][]byte{partial}, 1, 1)
+		require.NoError(t, err)
+		beacons = append(beacons, &chain.Beacon{Round: uint64(i), PreviousSig: prev.Signature, Signature: sig})
+	}
+	return info, beacons
+}
+
+func TestSyncerParallelCatchup(t *testing.T) {
+	info, beacons := testChain(t, 50)
+	dir, err := ioutil.TempDir("", "sync-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bolt, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	defer bolt.Close()
+	require.NoError(t, bolt.Put(beacons[0]))
+	store := NewCallbackStore(newAppendStore(bolt))
+
+	ids, _ := test.BatchIdentities(4)
+	peers := make([]net.Peer, len(ids))
+	for i, id := range ids {
+		peers[i] = id.Public
+	}
+	client := &syncTestClient{
+		chain:  beacons,
+		liars:  map[string]bool{peers[0].Address(): true},
+		served: make(map[string]int),
+	}
+	limits := SyncLimits{BatchSize: 5, Parallel: 3}
+	s := newSyncer(log.DefaultLogger(), store, func() *chain.Info { return info }, client, clock.NewRealClock(), limits)
+
+	require.NoError(t, s.Follow(context.Background(), 50, peers))
+	for _, b := range beacons {
+		stored, err := store.Get(b.Round)
+		require.NoError(t, err)
+		require.True(t, b.Equal(stored))
+	}
+	// the rounds came from all the honest nodes
+	for _, p := range peers[1:] {
+		require.NotZero(t, client.served[p.Address()], "nothing fetched from %s", p.Address())
+	}
+}
+
+func TestSyncerGetBeacon(t *testing.T) {
+	info, beacons := testChain(t, 3)
+	dir, err := ioutil.TempDir("", "sync-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	bolt, err := boltdb.NewBoltStore(dir, nil)
+	require.NoError(t, err)
+	defer bolt.Close()
+	for _, b := range beacons {
+		require.NoError(t, bolt.Put(b))
+	}
+	store := NewCallbackStore(bolt)
+	s := newSyncer(log.DefaultLogger(), store, func() *chain.Info { return info }, nil, clock.NewRealClock(), SyncLimits{})
+
+	packet, err := s.GetBeacon(context.Background(), &drand.GetBeaconRequest{Round: 2, ChainHash: info.Hash()})
+	require.NoError(t, err)
+	require.True(t, beacons[2].Equal(protoToBeacon(packet)))
+	require.Equal(t, info.Hash(), packet.GetChainHash())
+
+	_, err = s.GetBeacon(context.Background(), &drand.GetBeaconRequest{Round: 4})
+	require.Error(t, err)
+	_, err = s.GetBeacon(context.Background(), &drand.GetBeaconRequest{Round: 2, ChainHash: []byte("another chain")})
+	require.Error(t, err)
+}
@@ -0,0 +1,122 @@
+package beacon
+
+import (
+	"context"
+	"math"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+)
+
+// SyncLimits bounds the load a node puts on its peers when catching up with
+// the chain, and the load the peers syncing from it put on the node. The zero
+// value means no limit.
+type SyncLimits struct {
+	// BatchSize is the number of rounds requested at once from a peer. The
+	// next batch is requested once the previous one is stored. It is also the
+	// number of rounds fetched in a burst above RoundRate. Zero requests all
+	// the rounds at once.
+	BatchSize uint64
+	// RoundRate is the maximum number of rounds fetched per second.
+	RoundRate float64
+	// ByteRate is the maximum number of bytes fetched per second.
+	ByteRate int64
+	// MaxPeers is the maximum number of peers this node streams the chain to
+	// at the same time. The other peers are refused and sync from another
+	// node.
+	MaxPeers int
+	// Parallel is the number of peers the chain is fetched from at the same
+	// time when catching up. It defaults to DefaultSyncParallel.
+	Parallel int
+}
+
+// chunkSize returns the number of rounds fetched from each peer when catching
+// up in parallel.
+func (l SyncLimits) chunkSize() uint64 {
+	if l.BatchSize > 0 {
+		return l.BatchSize
+	}
+	return SyncChunkSize
+}
+
+func (l SyncLimits) parallel() int {
+	if l.Parallel > 0 {
+		return l.Parallel
+	}
+	return DefaultSyncParallel
+}
+
+// batchEnd returns the last round of the batch starting at from, bounded by
+// upTo, or zero to fetch the rounds without end.
+func (l SyncLimits) batchEnd(from, upTo uint64) uint64 {
+	if l.BatchSize == 0 {
+		return upTo
+	}
+	end := from + l.BatchSize - 1
+	if upTo > 0 && end > upTo {
+		return upTo
+	}
+	return end
+}
+
+// throttle delays the beacons fetched during a sync to stay within the round
+// and byte rates of the sync limits.
+type throttle struct {
+	clock  clock.Clock
+	rounds bucket
+	bytes  bucket
+	last   time.Time
+}
+
+func newThrottle(c clock.Clock, l SyncLimits) *throttle {
+	burst := math.Max(float64(l.BatchSize), 1)
+	return &throttle{
+		clock:  c,
+		rounds: bucket{rate: l.RoundRate, burst: burst, tokens: burst},
+		bytes:  bucket{rate: float64(l.ByteRate), burst: float64(l.ByteRate), tokens: float64(l.ByteRate)},
+		last:   c.Now(),
+	}
+}
+
+// wait accounts for a fetched beacon of the given size and blocks until the
+// rates allow fetching the next one or the context is done.
+func (t *throttle) wait(ctx context.Context, size int) error {
+	now := t.clock.Now()
+	elapsed := now.Sub(t.last).Seconds()
+	t.last = now
+	delay := t.rounds.take(elapsed, 1)
+	if d := t.bytes.take(elapsed, float64(size)); d > delay {
+		delay = d
+	}
+	if delay <= 0 {
+		return nil
+	}
+	select {
+	case <-t.clock.After(delay):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// bucket is a token bucket filled at the given rate per second, up to burst
+// tokens. A zero rate means no limit.
+type bucket struct {
+	rate   float64
+	burst  float64
+	tokens float64
+}
+
+// take refills the bucket for the elapsed seconds and takes n tokens from it.
+// The tokens missing are taken on credit: it returns the time to wait for the
+// bucket to refill them.
+func (b *bucket) take(elapsed, n float64) time.Duration {
+	if b.rate <= 0 {
+		return 0
+	}
+	b.tokens = math.Min(b.burst, b.tokens+elapsed*b.rate) - n
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
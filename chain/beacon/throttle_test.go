@@ -0,0 +1,68 @@
+package beacon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncLimitsBatchEnd(t *testing.T) {
+	var l SyncLimits
+	require.Equal(t, uint64(0), l.batchEnd(10, 0))
+	require.Equal(t, uint64(20), l.batchEnd(10, 20))
+
+	l.BatchSize = 5
+	require.Equal(t, uint64(14), l.batchEnd(10, 0))
+	require.Equal(t, uint64(12), l.batchEnd(10, 12))
+}
+
+func TestThrottle(t *testing.T) {
+	c := clock.NewFakeClock()
+	th := newThrottle(c, SyncLimits{BatchSize: 2, RoundRate: 4, ByteRate: 1000})
+	ctx := context.Background()
+
+	// the batch size is fetched in a burst
+	require.NoError(t, th.wait(ctx, 100))
+	require.NoError(t, th.wait(ctx, 100))
+
+	// the next round waits for the round rate
+	done := make(chan error, 1)
+	go func() { done <- th.wait(ctx, 100) }()
+	c.BlockUntil(1)
+	select {
+	case <-done:
+		t.Fatal("round fetched above the rate")
+	default:
+	}
+	c.Advance(250 * time.Millisecond)
+	require.NoError(t, <-done)
+
+	// a large beacon waits for the byte rate
+	c.Advance(time.Second)
+	go func() { done <- th.wait(ctx, 1500) }()
+	c.BlockUntil(1)
+	c.Advance(400 * time.Millisecond)
+	select {
+	case <-done:
+		t.Fatal("bytes fetched above the rate")
+	default:
+	}
+	c.Advance(100 * time.Millisecond)
+	require.NoError(t, <-done)
+
+	// the wait stops with the context
+	ctx, cancel := context.WithCancel(ctx)
+	go func() { done <- th.wait(ctx, 5000) }()
+	c.BlockUntil(1)
+	cancel()
+	require.Equal(t, context.Canceled, <-done)
+
+	// no limit never waits
+	th = newThrottle(c, SyncLimits{})
+	for i := 0; i < 10; i++ {
+		require.NoError(t, th.wait(ctx, 1<<20))
+	}
+}
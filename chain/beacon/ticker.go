@@ -1,34 +1,64 @@
 package beacon
 
 import (
+	"sync"
 	"time"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/log"
 	clock "github.com/jonboulle/clockwork"
 )
 
 const tickerChanBacklog = 5
 
 type ticker struct {
-	clock   clock.Clock
-	period  time.Duration
-	genesis int64
-	newCh   chan channelInfo
-	stop    chan bool
+	clock clock.Clock
+	sync.Mutex
+	sched chain.Schedule
+	newCh chan channelInfo
+	stop  chan bool
+	// maxJump is the largest difference between the time slept measured by
+	// the wall clock and by the monotonic clock before the wall clock is
+	// considered to have jumped
+	maxJump time.Duration
+	// epoch is the time the ticker was created at, from which the monotonic
+	// time is measured
+	epoch time.Time
+	l     log.Logger
 }
 
-func newTicker(c clock.Clock, period time.Duration, genesis int64) *ticker {
+func newTicker(c clock.Clock, sched chain.Schedule, maxJump time.Duration, l log.Logger) *ticker {
+	if maxJump <= 0 {
+		maxJump = DefaultMaxClockJump
+	}
 	t := &ticker{
 		clock:   c,
-		period:  period,
-		genesis: genesis,
+		sched:   sched,
 		newCh:   make(chan channelInfo, tickerChanBacklog),
 		stop:    make(chan bool, 1),
+		maxJump: maxJump,
+		epoch:   c.Now(),
+		l:       l,
 	}
 	go t.Start()
 	return t
 }
 
+// Schedule returns the schedule the ticker follows
+func (t *ticker) Schedule() chain.Schedule {
+	t.Lock()
+	defer t.Unlock()
+	return t.sched
+}
+
+// SetSchedule changes the schedule of the ticker. The new schedule must give
+// the same times as the current one for the rounds already passed.
+func (t *ticker) SetSchedule(sched chain.Schedule) {
+	t.Lock()
+	defer t.Unlock()
+	t.sched = sched
+}
+
 func (t *ticker) Channel() chan roundInfo {
 	newCh := make(chan roundInfo, 1)
 	t.newCh <- channelInfo{
@@ -51,30 +81,42 @@ func (t *ticker) Stop() {
 }
 
 func (t *ticker) CurrentRound() uint64 {
-	return chain.CurrentRound(t.clock.Now().Unix(), t.period, t.genesis)
+	return t.Schedule().CurrentRound(t.clock.Now().Unix())
 }
 
-// Start will sleep until the next upcoming round and start sending out the
-// ticks asap
+// Start sleeps until the time of each round and sends out the tick. The time
+// of each round is computed from the genesis of the schedule, and the sleeps
+// are measured by the monotonic clock, so the ticks don't drift over time.
 func (t *ticker) Start() {
 	chanTime := make(chan time.Time, 1)
 	// whole reason of this function is to accept new incoming channels while
 	// still sleeping until the next time
 	go func() {
-		now := t.clock.Now().Unix()
-		_, ttime := chain.NextRound(now, t.period, t.genesis)
-		if ttime > now {
-			t.clock.Sleep(time.Duration(ttime-now) * time.Second)
-		}
-		// first tick happens at specified time
-		chanTime <- t.clock.Now()
-		ticker := t.clock.NewTicker(t.period)
-		defer ticker.Stop()
-		tickChan := ticker.Chan()
 		for {
+			now, start := t.clock.Now(), t.monotonic()
+			_, ttime := t.Schedule().NextRound(now.Unix())
+			target := time.Unix(ttime, 0)
+			if !t.sleep(target.Sub(now)) {
+				return
+			}
+			// a wake up later than expected, after a pause of the process,
+			// is measured by both clocks while a jump of the wall clock during
+			// the sleep is only measured by the wall clock: the wall times are
+			// compared without their monotonic reading
+			woke := t.clock.Now()
+			slept := t.monotonic() - start
+			if jump := woke.Round(0).Sub(now.Round(0)) - slept; jump > t.maxJump || jump < -t.maxJump {
+				t.l.Warn("ticker", "clock_jump", "jump", jump, "expected", ttime, "now", woke.Unix())
+			}
+			if woke.Round(0).Before(target) {
+				// early, or the clock jumped backward: sleep until the
+				// time of the next round
+				continue
+			}
+			// the round due at the wake up is always ticked, the rounds
+			// skipped by a jump forward are left to the catchup
 			select {
-			case nt := <-tickChan:
-				chanTime <- nt
+			case chanTime <- woke:
 			case <-t.stop:
 				return
 			}
@@ -84,9 +126,17 @@ func (t *ticker) Start() {
 	var sendTicks = false
 	var ttime int64
 	var tround uint64
+	// lastRound is the last round ticked, the rounds ticked again after the
+	// clock jumped backward are skipped
+	var lastRound uint64
 	for {
+		if sendTicks && tround <= lastRound {
+			t.l.Debug("ticker", "skip_round", "round", tround, "last_ticked", lastRound)
+			sendTicks = false
+		}
 		if sendTicks {
 			sendTicks = false
+			lastRound = tround
 			info := roundInfo{
 				round: tround,
 				time:  ttime,
@@ -104,7 +154,7 @@ func (t *ticker) Start() {
 		}
 		select {
 		case nt := <-chanTime:
-			tround = chain.CurrentRound(nt.Unix(), t.period, t.genesis)
+			tround = t.Schedule().CurrentRound(nt.Unix())
 			ttime = nt.Unix()
 			sendTicks = true
 		case newChan := <-t.newCh:
@@ -118,6 +168,26 @@ func (t *ticker) Start() {
 	}
 }
 
+// monotonic returns the time elapsed since the creation of the ticker,
+// measured by the monotonic clock.
+func (t *ticker) monotonic() time.Duration {
+	return t.clock.Since(t.epoch)
+}
+
+// sleep waits for the given duration, measured by the monotonic clock. It
+// returns false if the ticker is stopped before.
+func (t *ticker) sleep(d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+	select {
+	case <-t.clock.After(d):
+		return true
+	case <-t.stop:
+		return false
+	}
+}
+
 type roundInfo struct {
 	round uint64
 	time  int64
@@ -0,0 +1,159 @@
+package beacon
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+// jumpClock is a fake clock whose wall time can jump away from the time
+// measured by its sleeps and tickers, as the clock of a host whose time is
+// stepped.
+type jumpClock struct {
+	clock.FakeClock
+	sync.Mutex
+	offset time.Duration
+}
+
+func (j *jumpClock) Jump(d time.Duration) {
+	j.Lock()
+	defer j.Unlock()
+	j.offset += d
+}
+
+func (j *jumpClock) shift(t time.Time) time.Time {
+	j.Lock()
+	defer j.Unlock()
+	return t.Add(j.offset)
+}
+
+func (j *jumpClock) Now() time.Time {
+	return j.shift(j.FakeClock.Now())
+}
+
+func (j *jumpClock) NewTicker(d time.Duration) clock.Ticker {
+	inner := j.FakeClock.NewTicker(d)
+	jt := &jumpTicker{inner: inner, c: make(chan time.Time, 1), stop: make(chan bool)}
+	go func() {
+		for {
+			select {
+			case t := <-inner.Chan():
+				select {
+				case jt.c <- j.shift(t):
+				case <-jt.stop:
+					return
+				}
+			case <-jt.stop:
+				return
+			}
+		}
+	}()
+	return jt
+}
+
+type jumpTicker struct {
+	inner clock.Ticker
+	c     chan time.Time
+	stop  chan bool
+}
+
+func (jt *jumpTicker) Chan() <-chan time.Time { return jt.c }
+
+func (jt *jumpTicker) Stop() {
+	jt.inner.Stop()
+	close(jt.stop)
+}
+
+// nextTick advances the clock second by second until a round is ticked.
+func nextTick(t *testing.T, c clock.FakeClock, ticks chan roundInfo) roundInfo {
+	for i := 0; i < 100; i++ {
+		select {
+		case info := <-ticks:
+			return info
+		case <-time.After(20 * time.Millisecond):
+			c.Advance(time.Second)
+		}
+	}
+	t.Fatal("no round ticked")
+	return roundInfo{}
+}
+
+func TestTickerClockJump(t *testing.T) {
+	period := 10 * time.Second
+	fake := clock.NewFakeClockAt(time.Unix(1000, 0))
+	c := &jumpClock{FakeClock: fake}
+	group := &key.Group{Period: period, GenesisTime: 1005}
+	tk := newTicker(c, chain.NewSchedule(group), time.Second, log.DefaultLogger())
+	defer tk.Stop()
+	ticks := tk.ChannelAt(0)
+
+	require.Equal(t, uint64(1), nextTick(t, fake, ticks).round)
+	require.Equal(t, uint64(2), nextTick(t, fake, ticks).round)
+
+	// the clock jumps forward: the round due at the wake up is ticked and the
+	// rounds in between are left to the catchup
+	c.Jump(25 * time.Second)
+	info := nextTick(t, fake, ticks)
+	require.Equal(t, uint64(5), info.round)
+	require.Equal(t, c.Now().Unix(), info.time)
+
+	// the clock jumps backward: the rounds already ticked are not ticked again
+	c.Jump(-40 * time.Second)
+	info = nextTick(t, fake, ticks)
+	require.Equal(t, uint64(6), info.round)
+	require.Equal(t, chain.NewSchedule(group).TimeOfRound(6), c.Now().Unix())
+}
+
+func TestTickerLateWakeUp(t *testing.T) {
+	period := 10 * time.Second
+	fake := clock.NewFakeClockAt(time.Unix(1000, 0))
+	group := &key.Group{Period: period, GenesisTime: 1005}
+	tk := newTicker(fake, chain.NewSchedule(group), time.Second, log.DefaultLogger())
+	defer tk.Stop()
+	ticks := tk.ChannelAt(0)
+
+	require.Equal(t, uint64(1), nextTick(t, fake, ticks).round)
+	// the process is paused past the time of the next round: the wake up is
+	// late but the clock did not jump, so the round is still ticked
+	fake.BlockUntil(1)
+	fake.Advance(period + 3*time.Second)
+	select {
+	case info := <-ticks:
+		require.Equal(t, uint64(2), info.round)
+		require.Equal(t, int64(1018), info.time)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the late round was not ticked")
+	}
+}
+
+func TestTickerSchedule(t *testing.T) {
+	fake := clock.NewFakeClockAt(time.Unix(1000, 300*int64(time.Millisecond)))
+	group := &key.Group{Period: 3 * time.Second, GenesisTime: 1005}
+	tk := newTicker(fake, chain.NewSchedule(group), time.Second, log.DefaultLogger())
+	defer tk.Stop()
+	ticks := tk.ChannelAt(0)
+
+	// every round is ticked at its time, computed from the genesis
+	for round := uint64(1); round <= 5; round++ {
+		info := nextTick(t, fake, ticks)
+		require.Equal(t, round, info.round)
+		require.Equal(t, chain.NewSchedule(group).TimeOfRound(round), info.time)
+	}
+
+	// the period changes from round 8
+	changed := *group
+	changed.PeriodChanges = []key.PeriodChange{{Round: 8, Period: 5 * time.Second}}
+	sched := chain.NewSchedule(&changed)
+	tk.SetSchedule(sched)
+	for round := uint64(6); round <= 10; round++ {
+		info := nextTick(t, fake, ticks)
+		require.Equal(t, round, info.round)
+		require.Equal(t, sched.TimeOfRound(round), info.time)
+	}
+}
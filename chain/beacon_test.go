@@ -5,8 +5,23 @@ import (
 
 	"github.com/drand/drand/key"
 	"github.com/drand/kyber/util/random"
+	"github.com/stretchr/testify/require"
 )
 
+func TestContributorsBitmap(t *testing.T) {
+	bitmap := ContributorsBitmap([]int{9, 0, 3})
+	require.Equal(t, []byte{0x09, 0x02}, bitmap)
+	require.Equal(t, []int{0, 3, 9}, ContributorIndices(bitmap))
+	require.Nil(t, ContributorIndices(nil))
+
+	b := &Beacon{Round: 2, Signature: []byte("sig"), Contributors: bitmap}
+	buff, err := b.Marshal()
+	require.NoError(t, err)
+	b2 := new(Beacon)
+	require.NoError(t, b2.Unmarshal(buff))
+	require.Equal(t, bitmap, b2.Contributors)
+}
+
 func BenchmarkVerifyBeacon(b *testing.B) {
 	secret := key.KeyGroup.Scalar().Pick(random.New())
 	public := key.KeyGroup.Point().Mul(secret, nil)
@@ -1,29 +1,54 @@
 package boltdb
 
 import (
+	"encoding/json"
 	"errors"
+	"os"
 	"path"
 	"sync"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/store"
 	"github.com/drand/drand/log"
 	bolt "go.etcd.io/bbolt"
 )
 
+func init() {
+	store.Register(BackendName, func(folder string, opts interface{}) (chain.Store, error) {
+		boltOpts, ok := opts.(*bolt.Options)
+		if !ok && opts != nil {
+			return nil, errors.New("boltdb: invalid options")
+		}
+		return NewBoltStore(folder, boltOpts)
+	})
+}
+
 // boldStore implements the Store interface using the kv storage boltdb (native
 // golang implementation). Internally, Beacons are stored as JSON-encoded in the
 // db file.
 type boltStore struct {
 	sync.Mutex
-	db  *bolt.DB
-	len int
+	db   *bolt.DB
+	opts *bolt.Options
+	len  int
 }
 
 var beaconBucket = []byte("beacons")
 
+// epochBucket holds the JSON encoded epochs of the chain by round
+var epochBucket = []byte("epochs")
+
+// signedBucket holds the JSON encoded last round signed by the node under
+// signedKey
+var signedBucket = []byte("signed")
+var signedKey = []byte("last")
+
 // BoltFileName is the name of the file boltdb writes to
 const BoltFileName = "drand.db"
 
+// BackendName is the name the boltdb store is registered under
+const BackendName = store.DefaultBackend
+
 // NewBoltStore returns a Store implementation using the boltdb storage engine.
 func NewBoltStore(folder string, opts *bolt.Options) (chain.Store, error) {
 	dbPath := path.Join(folder, BoltFileName)
@@ -43,8 +68,9 @@ func NewBoltStore(folder string, opts *bolt.Options) (chain.Store, error) {
 	})
 
 	return &boltStore{
-		db:  db,
-		len: baseLen,
+		db:   db,
+		opts: opts,
+		len:  baseLen,
 	}, err
 }
 
@@ -61,6 +87,198 @@ func (b *boltStore) Len() int {
 	return length
 }
 
+// Size returns the size in bytes of the database.
+func (b *boltStore) Size() int64 {
+	var size int64
+	err := b.db.View(func(tx *bolt.Tx) error {
+		size = tx.Size()
+		return nil
+	})
+	if err != nil {
+		log.DefaultLogger().Warn("boltdb", "error getting size", "err", err)
+	}
+	return size
+}
+
+// compactBatch is the number of beacons copied per transaction when compacting
+// the database.
+const compactBatch = 10000
+
+// Compact implements the chain.Compacter interface. Deleting beacons leaves
+// free pages in the bolt file, which never shrinks: Compact copies the beacons
+// to a new file, in batches, and replaces the database with it.
+func (b *boltStore) Compact(progress func(done, total int)) error {
+	b.Lock()
+	defer b.Unlock()
+	srcPath := b.db.Path()
+	dstPath := srcPath + ".compact"
+	if err := os.RemoveAll(dstPath); err != nil {
+		return err
+	}
+	dst, err := bolt.Open(dstPath, 0660, b.opts)
+	if err != nil {
+		return err
+	}
+	total := b.Len()
+	var copied, n int
+	var next []byte
+	for {
+		n, next, err = copyBatch(b.db, dst, next)
+		if err != nil {
+			break
+		}
+		copied += n
+		if progress != nil {
+			progress(copied, total)
+		}
+		if next == nil {
+			break
+		}
+	}
+	if err == nil {
+		err = copyBucket(b.db, dst, epochBucket)
+	}
+	if err == nil {
+		err = copyBucket(b.db, dst, signedBucket)
+	}
+	if cerr := dst.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(dstPath)
+		return err
+	}
+	if err := b.db.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(dstPath, srcPath); err != nil {
+		return err
+	}
+	b.db, err = bolt.Open(srcPath, 0660, b.opts)
+	return err
+}
+
+// copyBatch copies up to compactBatch beacons from the src database to the dst
+// one, starting at the given key or at the first beacon if nil. It returns the
+// number of beacons copied and the key of the next beacon to copy, or nil once
+// all of them are copied.
+func copyBatch(src, dst *bolt.DB, from []byte) (int, []byte, error) {
+	var copied int
+	var next []byte
+	err := src.View(func(stx *bolt.Tx) error {
+		c := stx.Bucket(beaconBucket).Cursor()
+		k, v := c.First()
+		if from != nil {
+			k, v = c.Seek(from)
+		}
+		return dst.Update(func(dtx *bolt.Tx) error {
+			bucket, err := dtx.CreateBucketIfNotExists(beaconBucket)
+			if err != nil {
+				return err
+			}
+			// the rounds are appended in increasing order
+			bucket.FillPercent = 1
+			for ; k != nil && copied < compactBatch; copied++ {
+				if err := bucket.Put(k, v); err != nil {
+					return err
+				}
+				k, v = c.Next()
+			}
+			if k != nil {
+				next = append([]byte(nil), k...)
+			}
+			return nil
+		})
+	})
+	return copied, next, err
+}
+
+// copyBucket copies the bucket of the given name, if any, from the src
+// database to the dst one.
+func copyBucket(src, dst *bolt.DB, name []byte) error {
+	return src.View(func(stx *bolt.Tx) error {
+		from := stx.Bucket(name)
+		if from == nil {
+			return nil
+		}
+		return dst.Update(func(dtx *bolt.Tx) error {
+			bucket, err := dtx.CreateBucketIfNotExists(name)
+			if err != nil {
+				return err
+			}
+			return from.ForEach(bucket.Put)
+		})
+	})
+}
+
+// PutEpoch implements the chain.EpochStore interface.
+func (b *boltStore) PutEpoch(e *chain.Epoch) error {
+	buff, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(epochBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(chain.RoundToBytes(e.Round), buff)
+	})
+}
+
+// Epochs implements the chain.EpochStore interface.
+func (b *boltStore) Epochs() ([]*chain.Epoch, error) {
+	var epochs []*chain.Epoch
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(epochBucket)
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(_, v []byte) error {
+			e := new(chain.Epoch)
+			if err := json.Unmarshal(v, e); err != nil {
+				return err
+			}
+			epochs = append(epochs, e)
+			return nil
+		})
+	})
+	return epochs, err
+}
+
+// PutSigned implements the chain.SignedStore interface.
+func (b *boltStore) PutSigned(s *chain.SignedRound) error {
+	buff, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists(signedBucket)
+		if err != nil {
+			return err
+		}
+		return bucket.Put(signedKey, buff)
+	})
+}
+
+// LastSigned implements the chain.SignedStore interface.
+func (b *boltStore) LastSigned() (*chain.SignedRound, error) {
+	var signed *chain.SignedRound
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(signedBucket)
+		if bucket == nil {
+			return nil
+		}
+		v := bucket.Get(signedKey)
+		if v == nil {
+			return nil
+		}
+		signed = new(chain.SignedRound)
+		return json.Unmarshal(v, signed)
+	})
+	return signed, err
+}
+
 func (b *boltStore) Close() {
 	if err := b.db.Close(); err != nil {
 		log.DefaultLogger().Debug("boltdb", "close", "err", err)
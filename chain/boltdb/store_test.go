@@ -122,3 +122,116 @@ func TestStoreBolt(t *testing.T) {
 	require.Nil(t, unknown)
 	require.Equal(t, ErrNoBeaconSaved, err)
 }
+
+func TestStoreBoltStatsCompact(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+
+	n := compactBatch + 500
+	for i := 1; i <= n; i++ {
+		require.NoError(t, store.Put(&chain.Beacon{
+			Round:       uint64(i),
+			PreviousSig: make([]byte, 96),
+			Signature:   make([]byte, 96),
+		}))
+	}
+	for _, round := range []uint64{10, 11, 12, 500} {
+		require.NoError(t, store.Del(round))
+	}
+	stats := chain.ComputeStats(store, nil)
+	require.Equal(t, n-4, stats.Count)
+	require.Equal(t, uint64(1), stats.First)
+	require.Equal(t, uint64(n), stats.Last)
+	require.Equal(t, []chain.Gap{{From: 10, To: 12}, {From: 500, To: 500}}, stats.Gaps)
+	require.Equal(t, uint64(4), stats.Missing())
+	require.True(t, stats.Size > 0)
+
+	// delete most of the beacons so the database is mostly free pages
+	for i := 1000; i <= n; i++ {
+		require.NoError(t, store.Del(uint64(i)))
+	}
+	before := store.(*boltStore).Size()
+	var calls, done int
+	require.NoError(t, store.(chain.Compacter).Compact(func(d, total int) {
+		calls++
+		done = d
+		require.Equal(t, 995, total)
+	}))
+	require.Equal(t, 1, calls)
+	require.Equal(t, 995, done)
+	require.True(t, store.(*boltStore).Size() < before)
+
+	// the compacted store keeps the beacons and accepts new ones
+	stats = chain.ComputeStats(store, nil)
+	require.Equal(t, 995, stats.Count)
+	require.Equal(t, uint64(999), stats.Last)
+	require.NoError(t, store.Put(&chain.Beacon{Round: 1000, Signature: []byte("signature")}))
+	last, err := store.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), last.Round)
+}
+
+func TestStoreBoltEpochs(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	es := store.(chain.EpochStore)
+
+	epochs, err := es.Epochs()
+	require.NoError(t, err)
+	require.Empty(t, epochs)
+
+	e1 := &chain.Epoch{Round: 300, PreviousGroupHash: []byte("group1"), GroupHash: []byte("group2")}
+	e2 := &chain.Epoch{Round: 4000, PreviousGroupHash: []byte("group2"), GroupHash: []byte("group3")}
+	require.NoError(t, es.PutEpoch(e2))
+	require.NoError(t, es.PutEpoch(e1))
+	// saving an epoch again replaces it
+	require.NoError(t, es.PutEpoch(e1))
+	epochs, err = es.Epochs()
+	require.NoError(t, err)
+	require.Equal(t, []*chain.Epoch{e1, e2}, epochs)
+
+	// the epochs survive a compaction
+	require.NoError(t, store.Put(&chain.Beacon{Round: 1, Signature: []byte("signature")}))
+	require.NoError(t, store.(chain.Compacter).Compact(nil))
+	epochs, err = es.Epochs()
+	require.NoError(t, err)
+	require.Equal(t, []*chain.Epoch{e1, e2}, epochs)
+}
+
+func TestStoreBoltSigned(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	ss := store.(chain.SignedStore)
+
+	signed, err := ss.LastSigned()
+	require.NoError(t, err)
+	require.Nil(t, signed)
+
+	require.NoError(t, ss.PutSigned(&chain.SignedRound{Round: 10, Message: []byte("msg10")}))
+	last := &chain.SignedRound{Round: 11, Message: []byte("msg11")}
+	require.NoError(t, ss.PutSigned(last))
+	require.NoError(t, store.(chain.Compacter).Compact(nil))
+	signed, err = ss.LastSigned()
+	require.NoError(t, err)
+	require.Equal(t, last, signed)
+
+	// the last round signed survives a restart
+	store.Close()
+	store, err = NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	signed, err = store.(chain.SignedStore).LastSigned()
+	require.NoError(t, err)
+	require.Equal(t, last, signed)
+}
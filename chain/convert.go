@@ -18,24 +18,47 @@ func InfoFromProto(p *drand.ChainInfoPacket) (*Info, error) {
 		return nil, err
 	}
 
-	return &Info{
+	info := &Info{
 		PublicKey:   public,
 		GenesisTime: p.GenesisTime,
 		Period:      time.Duration(p.Period) * time.Second,
 		GroupHash:   p.GroupHash,
-	}, nil
+
+		MessageV1Round: p.MessageV1Round,
+		Digest:         p.Digest,
+	}
+	if _, err := key.DigestFunc(info.Digest); err != nil {
+		return nil, err
+	}
+	for _, c := range p.PeriodChanges {
+		info.PeriodChanges = append(info.PeriodChanges, key.PeriodChange{
+			Round:  c.GetRound(),
+			Period: time.Duration(c.GetPeriod()) * time.Second,
+		})
+	}
+	return info, nil
 }
 
 // ToProto returns the protobuf description of the chain info
 func (c *Info) ToProto() *drand.ChainInfoPacket {
 	buff, _ := c.PublicKey.MarshalBinary()
-	return &drand.ChainInfoPacket{
+	packet := &drand.ChainInfoPacket{
 		PublicKey:   buff,
 		GenesisTime: c.GenesisTime,
 		Period:      uint32(c.Period.Seconds()),
 		Hash:        c.Hash(),
 		GroupHash:   c.GroupHash,
+
+		MessageV1Round: c.MessageV1Round,
+		Digest:         c.Digest,
+	}
+	for _, pc := range c.PeriodChanges {
+		packet.PeriodChanges = append(packet.PeriodChanges, &drand.PeriodChange{
+			Round:  pc.Round,
+			Period: uint32(pc.Period.Seconds()),
+		})
 	}
+	return packet
 }
 
 // InfoFromJSON returns a Info from JSON description in the given reader
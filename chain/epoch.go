@@ -0,0 +1,47 @@
+package chain
+
+import (
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+)
+
+// Epoch records a resharing of the group producing a chain: the beacons from
+// Round on are signed by the group of hash GroupHash instead of the group of
+// hash PreviousGroupHash.
+type Epoch struct {
+	// Round is the first round signed by the new group
+	Round             uint64 `json:"round"`
+	PreviousGroupHash []byte `json:"previous_group_hash"`
+	GroupHash         []byte `json:"group_hash"`
+	// PublicKey is the public key verifying the beacons from Round on. It is
+	// only set if the resharing changed it, e.g. with a new signature scheme,
+	// since a resharing normally keeps the public key of the chain.
+	PublicKey []byte `json:"public_key,omitempty"`
+}
+
+// NewEpoch returns the epoch starting at the given round, when the group next
+// takes over from the group prev.
+func NewEpoch(round uint64, prev, next *key.Group) *Epoch {
+	e := &Epoch{
+		Round:             round,
+		PreviousGroupHash: prev.Hash(),
+		GroupHash:         next.Hash(),
+	}
+	if !prev.PublicKey.Key().Equal(next.PublicKey.Key()) {
+		buff, err := next.PublicKey.Key().MarshalBinary()
+		if err != nil {
+			log.DefaultLogger().Warn("epoch", "failed to marshal public key", "err", err)
+		}
+		e.PublicKey = buff
+	}
+	return e
+}
+
+// EpochStore is implemented by the stores keeping the epochs of their chain.
+type EpochStore interface {
+	// PutEpoch saves the epoch, replacing the epoch starting at the same
+	// round if any.
+	PutEpoch(e *Epoch) error
+	// Epochs returns the epochs saved, in increasing round order.
+	Epochs() ([]*Epoch, error)
+}
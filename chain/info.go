@@ -3,7 +3,10 @@ package chain
 import (
 	"bytes"
 	"crypto/sha256"
+	"encoding/base64"
 	"encoding/binary"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/drand/drand/key"
@@ -18,6 +21,15 @@ type Info struct {
 	Period      time.Duration `json:"period"`
 	GenesisTime int64         `json:"genesis_time"`
 	GroupHash   []byte        `json:"group_hash"`
+	// MessageV1Round is the first round whose beacon signs the domain
+	// separated message. Zero means the chain only uses the original format.
+	MessageV1Round uint64 `json:"message_v1_round,omitempty"`
+	// PeriodChanges are the changes of the period since the genesis. Period
+	// stays the period of the genesis.
+	PeriodChanges []key.PeriodChange `json:"period_changes,omitempty"`
+	// Digest names the digest deriving the randomness from the signatures,
+	// SHA-256 if empty.
+	Digest string `json:"digest,omitempty"`
 }
 
 // NewChainInfo makes a chain Info from a group
@@ -27,12 +39,20 @@ func NewChainInfo(g *key.Group) *Info {
 		PublicKey:   g.PublicKey.Key(),
 		GenesisTime: g.GenesisTime,
 		GroupHash:   g.GetGenesisSeed(),
+
+		MessageV1Round: g.MessageV1Round,
+		PeriodChanges:  g.PeriodChanges,
+		Digest:         g.Digest,
 	}
 }
 
 // Hash returns the canonical hash representing the chain information. A hash is
 // consistent throughout the entirety of a chain, regardless of the network
-// composition, the actual nodes, generating the randomness.
+// composition, the actual nodes, generating the randomness. The round where the
+// message format changes and the changes of period are not included since a
+// chain keeps its hash when migrating: they are checked by CheckUpdate instead.
+// The digest, chosen at the creation of the chain, is included unless it is the
+// default SHA-256.
 func (c *Info) Hash() []byte {
 	h := sha256.New()
 	_ = binary.Write(h, binary.BigEndian, uint32(c.Period.Seconds()))
@@ -43,6 +63,9 @@ func (c *Info) Hash() []byte {
 	}
 	_, _ = h.Write(buff)
 	_, _ = h.Write(c.GroupHash)
+	if c.Digest != "" {
+		_, _ = h.Write([]byte(c.Digest))
+	}
 	return h.Sum(nil)
 }
 
@@ -51,5 +74,82 @@ func (c *Info) Equal(c2 *Info) bool {
 	return c.GenesisTime == c2.GenesisTime &&
 		c.Period == c2.Period &&
 		c.PublicKey.Equal(c2.PublicKey) &&
-		bytes.Equal(c.GroupHash, c2.GroupHash)
+		bytes.Equal(c.GroupHash, c2.GroupHash) &&
+		c.MessageV1Round == c2.MessageV1Round &&
+		c.Digest == c2.Digest &&
+		samePeriodChanges(c.PeriodChanges, c2.PeriodChanges)
+}
+
+func samePeriodChanges(a, b []key.PeriodChange) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckUpdate returns an error unless the info is a valid update of prev, the
+// info of the same chain known before. A resharing can only set the round where
+// the message format changes, if it is not set yet, and append changes of
+// period: the clients and relays refreshing the info of a chain check it so
+// that they follow these changes from their transition round.
+func (c *Info) CheckUpdate(prev *Info) error {
+	if !bytes.Equal(c.Hash(), prev.Hash()) {
+		return fmt.Errorf("chain hash changed from %x to %x", prev.Hash(), c.Hash())
+	}
+	if prev.MessageV1Round != 0 && c.MessageV1Round != prev.MessageV1Round {
+		return fmt.Errorf("message format round changed from %d to %d", prev.MessageV1Round, c.MessageV1Round)
+	}
+	if len(c.PeriodChanges) < len(prev.PeriodChanges) ||
+		!samePeriodChanges(c.PeriodChanges[:len(prev.PeriodChanges)], prev.PeriodChanges) {
+		return errors.New("previous period changes not kept")
+	}
+	return nil
+}
+
+// Schedule returns the schedule of the rounds of the chain.
+func (c *Info) Schedule() Schedule {
+	return Schedule{Genesis: c.GenesisTime, Period: c.Period, Changes: c.PeriodChanges}
+}
+
+// FingerprintPrefix starts the fingerprints of the distributed public keys.
+const FingerprintPrefix = "SHA256:"
+
+// Fingerprint returns a short fingerprint of the distributed public key of the
+// chain, in the style of the SSH key fingerprints, for users to compare the key
+// they bootstrapped with the one published by the operators.
+func (c *Info) Fingerprint() string {
+	buff, _ := c.PublicKey.MarshalBinary()
+	h := sha256.Sum256(buff)
+	return FingerprintPrefix + base64.RawStdEncoding.EncodeToString(h[:])
+}
+
+// Message returns the message signed by the beacon of the given round, in the
+// format used by the chain at that round.
+func (c *Info) Message(round uint64, prevSig []byte) []byte {
+	if c.MessageV1Round != 0 && round >= c.MessageV1Round {
+		return MessageV1(c.Hash(), round, prevSig)
+	}
+	return Message(round, prevSig)
+}
+
+// Randomness derives the randomness of a round from its signature with the
+// digest of the chain.
+func (c *Info) Randomness(sig []byte) []byte {
+	digest, err := key.DigestFunc(c.Digest)
+	if err != nil {
+		// the chain infos with an unknown digest are refused when decoded
+		return nil
+	}
+	return digest(sig)
+}
+
+// VerifyBeacon returns an error if the given beacon does not verify under the
+// public key of the chain.
+func (c *Info) VerifyBeacon(b *Beacon) error {
+	return key.Scheme.VerifyRecovered(c.PublicKey, c.Message(b.Round, b.PreviousSig), b.Signature)
 }
@@ -2,6 +2,8 @@ package chain
 
 import (
 	"bytes"
+	"encoding/hex"
+	"strings"
 	"testing"
 
 	"github.com/drand/drand/key"
@@ -54,3 +56,111 @@ func TestChainInfo(t *testing.T) {
 	require.NotNil(t, c13)
 	require.Equal(t, c1, c13)
 }
+
+func TestChainInfoMessage(t *testing.T) {
+	_, g := test.BatchIdentities(5)
+	c := NewChainInfo(g)
+	prev := []byte("previous signature")
+	require.Equal(t, Message(10, prev), c.Message(10, prev))
+
+	migrated := NewChainInfo(g)
+	migrated.MessageV1Round = 10
+	// the chain keeps its hash when migrating
+	require.Equal(t, c.Hash(), migrated.Hash())
+	require.Equal(t, Message(9, prev), migrated.Message(9, prev))
+	tagged := migrated.Message(10, prev)
+	require.Equal(t, MessageV1(c.Hash(), 10, prev), tagged)
+	require.NotEqual(t, Message(10, prev), tagged)
+
+	var buff bytes.Buffer
+	require.NoError(t, migrated.ToJSON(&buff))
+	decoded, err := InfoFromJSON(&buff)
+	require.NoError(t, err)
+	require.Equal(t, migrated.MessageV1Round, decoded.MessageV1Round)
+}
+
+func TestChainInfoPeriodChanges(t *testing.T) {
+	_, g := test.BatchIdentities(5)
+	c := NewChainInfo(g)
+	changed := NewChainInfo(g)
+	changed.PeriodChanges = []key.PeriodChange{{Round: 10, Period: 2 * c.Period}}
+	// the chain keeps its hash when changing its period
+	require.Equal(t, c.Hash(), changed.Hash())
+	require.False(t, c.Equal(changed))
+
+	var buff bytes.Buffer
+	require.NoError(t, changed.ToJSON(&buff))
+	decoded, err := InfoFromJSON(&buff)
+	require.NoError(t, err)
+	require.True(t, changed.Equal(decoded))
+}
+
+func TestChainInfoCheckUpdate(t *testing.T) {
+	_, g := test.BatchIdentities(5)
+	c := NewChainInfo(g)
+	updated := NewChainInfo(g)
+	updated.MessageV1Round = 10
+	updated.PeriodChanges = []key.PeriodChange{{Round: 20, Period: 2 * c.Period}}
+	require.NoError(t, updated.CheckUpdate(c))
+	require.NoError(t, updated.CheckUpdate(updated))
+
+	// the migration round and the past period changes are fixed
+	moved := NewChainInfo(g)
+	moved.MessageV1Round = 11
+	moved.PeriodChanges = updated.PeriodChanges
+	require.Error(t, moved.CheckUpdate(updated))
+	require.Error(t, c.CheckUpdate(updated))
+
+	_, other := test.BatchIdentities(5)
+	require.Error(t, NewChainInfo(other).CheckUpdate(c))
+}
+
+func TestChainInfoFingerprint(t *testing.T) {
+	_, g1 := test.BatchIdentities(3)
+	_, g2 := test.BatchIdentities(3)
+	c1 := NewChainInfo(g1)
+	fp := c1.Fingerprint()
+	require.True(t, strings.HasPrefix(fp, FingerprintPrefix))
+	require.Equal(t, fp, NewChainInfo(g1).Fingerprint())
+	require.NotEqual(t, fp, NewChainInfo(g2).Fingerprint())
+}
+
+func TestChainInfoDigest(t *testing.T) {
+	_, g := test.BatchIdentities(3)
+	c := NewChainInfo(g)
+	sig := []byte("signature")
+	require.Equal(t, RandomnessFromSignature(sig), c.Randomness(sig))
+
+	g.Digest = key.DigestSHA3
+	tagged := NewChainInfo(g)
+	require.Equal(t, key.DigestSHA3, tagged.Digest)
+	require.NotEqual(t, c.Hash(), tagged.Hash())
+	require.NotEqual(t, c.Randomness(sig), tagged.Randomness(sig))
+
+	var buff bytes.Buffer
+	require.NoError(t, tagged.ToJSON(&buff))
+	decoded, err := InfoFromJSON(&buff)
+	require.NoError(t, err)
+	require.True(t, tagged.Equal(decoded))
+	require.Equal(t, tagged.Randomness(sig), decoded.Randomness(sig))
+
+	packet := tagged.ToProto()
+	packet.Digest = "md5"
+	_, err = InfoFromProto(packet)
+	require.Error(t, err)
+}
+
+// legacyInfo is the chain info served by the HTTP API of the drand mainnet.
+const legacyInfo = `{"public_key":"868f005eb8e6e4ca0a47c8a77ceaa5309a47978a7c71bc5cce96366b5d7a569937c529eeda66c7293784a9402801af31","period":30,"genesis_time":1595431050,"hash":"8990e7a9aaed2ffed73dbd7092123d6f289930540d7651336225dc172e51b2ce","groupHash":"176f93498eac9ca337150b46d21dd58673ea4e3581185f869672e59fa4cb390a"}`
+
+func TestChainInfoLegacyJSON(t *testing.T) {
+	info, err := InfoFromJSON(strings.NewReader(legacyInfo))
+	require.NoError(t, err)
+	require.Equal(t, "176f93498eac9ca337150b46d21dd58673ea4e3581185f869672e59fa4cb390a", hex.EncodeToString(info.GroupHash))
+	require.Equal(t, "8990e7a9aaed2ffed73dbd7092123d6f289930540d7651336225dc172e51b2ce", hex.EncodeToString(info.Hash()))
+
+	// the chain info is served with the same field names
+	var buff bytes.Buffer
+	require.NoError(t, info.ToJSON(&buff))
+	require.Contains(t, buff.String(), `"groupHash":"176f93`)
+}
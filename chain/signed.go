@@ -0,0 +1,42 @@
+package chain
+
+// SignedRound is the last round for which a node emitted a partial signature,
+// with the message it signed.
+type SignedRound struct {
+	Round   uint64 `json:"round"`
+	Message []byte `json:"message"`
+}
+
+// SignedStore is implemented by the stores keeping the last round signed by
+// the node, so that it never signs two different messages for the same round,
+// even after a restart.
+type SignedStore interface {
+	// PutSigned saves the round signed, replacing the previous one.
+	PutSigned(s *SignedRound) error
+	// LastSigned returns the last round saved, nil if none was.
+	LastSigned() (*SignedRound, error)
+}
+
+// RewindSigned makes the store forget the rounds signed from the given one,
+// after the beacons from that round were deleted, so that the node can sign
+// them again. The record is moved back to the round before, without a
+// message: that round is in the chain and won't be signed anymore. It does
+// nothing if the store doesn't keep the rounds signed.
+func RewindSigned(s Store, from uint64) error {
+	ss, ok := s.(SignedStore)
+	if !ok {
+		return nil
+	}
+	last, err := ss.LastSigned()
+	if err != nil {
+		return err
+	}
+	if last == nil || last.Round < from {
+		return nil
+	}
+	rewound := &SignedRound{}
+	if from > 0 {
+		rewound.Round = from - 1
+	}
+	return ss.PutSigned(rewound)
+}
@@ -0,0 +1,142 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/drand/drand/chain"
+)
+
+// Import stores the beacons of the snapshot in the bucket that follow the last
+// beacon of the store. The hash of each segment is checked against the
+// manifest and every beacon is verified against the chain info before being
+// stored, so an import stops at the first invalid beacon. progress, if not
+// nil, is called with the last round stored after each segment. Import returns
+// the number of beacons stored.
+func Import(ctx context.Context, bucket Bucket, s chain.Store, info *chain.Info, progress func(round uint64)) (int, error) {
+	m, err := LoadManifest(ctx, bucket)
+	if err != nil {
+		return 0, err
+	}
+	if m.ChainHash != hex.EncodeToString(info.Hash()) {
+		return 0, fmt.Errorf("snapshot: the snapshot is of the chain %s", m.ChainHash)
+	}
+	prev, err := s.Last()
+	if err != nil {
+		prev = chain.GenesisBeacon(info)
+		if err := s.Put(prev); err != nil {
+			return 0, err
+		}
+	}
+	var imported int
+	for _, seg := range m.Segments {
+		if seg.To <= prev.Round {
+			continue
+		}
+		buff, err := fetchSegment(ctx, bucket, seg)
+		if err != nil {
+			return imported, err
+		}
+		err = ReadSegment(bytes.NewReader(buff), func(b *chain.Beacon) error {
+			if b.Round <= prev.Round {
+				return nil
+			}
+			if b.Round != prev.Round+1 || !bytes.Equal(b.PreviousSig, prev.Signature) {
+				return fmt.Errorf("snapshot: round %d does not follow round %d", b.Round, prev.Round)
+			}
+			if err := info.VerifyBeacon(b); err != nil {
+				return fmt.Errorf("snapshot: invalid beacon %d: %w", b.Round, err)
+			}
+			if err := s.Put(b); err != nil {
+				return err
+			}
+			prev = b
+			imported++
+			return nil
+		})
+		if err != nil {
+			return imported, err
+		}
+		if progress != nil {
+			progress(prev.Round)
+		}
+	}
+	return imported, nil
+}
+
+// fetchSegment downloads the segment and checks its hash.
+func fetchSegment(ctx context.Context, bucket Bucket, seg Segment) ([]byte, error) {
+	r, err := bucket.Get(ctx, seg.Name)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: downloading segment %s: %w", seg.Name, err)
+	}
+	defer r.Close()
+	buff, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot: downloading segment %s: %w", seg.Name, err)
+	}
+	hash := sha256.Sum256(buff)
+	if hex.EncodeToString(hash[:]) != seg.SHA256 {
+		return nil, fmt.Errorf("snapshot: segment %s does not match the manifest", seg.Name)
+	}
+	return buff, nil
+}
+
+// OpenBucket returns the read only bucket at the given location, either the
+// HTTP URL the files of the snapshot are served under or a local folder.
+func OpenBucket(location string) (Bucket, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		return &httpBucket{base: strings.TrimSuffix(location, "/"), client: http.DefaultClient}, nil
+	}
+	if _, err := os.Stat(location); err != nil {
+		return nil, err
+	}
+	return &dirBucket{folder: location}, nil
+}
+
+// httpBucket reads the files of a snapshot served over HTTP, e.g. by a public
+// S3 bucket.
+type httpBucket struct {
+	base   string
+	client *http.Client
+}
+
+func (h *httpBucket) Put(context.Context, string, io.Reader) error {
+	return errors.New("snapshot: the http bucket is read only")
+}
+
+func (h *httpBucket) Delete(context.Context, string) error {
+	return errors.New("snapshot: the http bucket is read only")
+}
+
+func (h *httpBucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, h.base+"/"+name, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return resp.Body, nil
+	case http.StatusNotFound, http.StatusForbidden:
+		// S3 answers forbidden for the missing files of a bucket that can't
+		// be listed
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		resp.Body.Close()
+		return nil, fmt.Errorf("snapshot: fetching %s: %s", name, resp.Status)
+	}
+}
@@ -0,0 +1,102 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// DefaultS3Region is the region used when none is configured.
+const DefaultS3Region = "us-east-1"
+
+// S3Config configures a bucket of an S3 compatible storage.
+type S3Config struct {
+	Bucket string
+	// Prefix is prepended to the names of the files of the snapshot
+	Prefix string
+	Region string
+	// Endpoint is the URL of an S3 compatible storage, empty for AWS
+	Endpoint string
+	// AccessKey and SecretKey are the credentials of the bucket. The
+	// credentials are looked up in the environment and the AWS configuration
+	// files if they are empty.
+	AccessKey string
+	SecretKey string
+}
+
+type s3Bucket struct {
+	client   *s3.S3
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+// NewS3Bucket returns a bucket storing the files in an S3 compatible storage.
+func NewS3Bucket(c S3Config) (Bucket, error) {
+	if c.Bucket == "" {
+		return nil, errors.New("snapshot: no bucket name")
+	}
+	region := c.Region
+	if region == "" {
+		region = DefaultS3Region
+	}
+	conf := &aws.Config{Region: aws.String(region)}
+	if c.Endpoint != "" {
+		conf.Endpoint = aws.String(c.Endpoint)
+		// the S3 compatible storages rarely support the virtual host style
+		conf.S3ForcePathStyle = aws.Bool(true)
+	}
+	if c.AccessKey != "" {
+		conf.Credentials = credentials.NewStaticCredentials(c.AccessKey, c.SecretKey, "")
+	}
+	sess, err := session.NewSession(conf)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Bucket{
+		client:   s3.New(sess),
+		uploader: s3manager.NewUploader(sess),
+		bucket:   c.Bucket,
+		prefix:   c.Prefix,
+	}, nil
+}
+
+func (b *s3Bucket) Put(ctx context.Context, name string, r io.Reader) error {
+	_, err := b.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, name)),
+		Body:   r,
+	})
+	return err
+}
+
+func (b *s3Bucket) Delete(ctx context.Context, name string) error {
+	_, err := b.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, name)),
+	})
+	return err
+}
+
+func (b *s3Bucket) Get(ctx context.Context, name string) (io.ReadCloser, error) {
+	out, err := b.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(b.bucket),
+		Key:    aws.String(path.Join(b.prefix, name)),
+	})
+	var aerr awserr.Error
+	if errors.As(err, &aerr) && aerr.Code() == s3.ErrCodeNoSuchKey {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
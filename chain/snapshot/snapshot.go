@@ -0,0 +1,168 @@
+// Package snapshot archives the randomness chain of a node in a bucket, as
+// segments of consecutive rounds listed by a manifest, so the archive can be
+// extended incrementally and used to bootstrap new nodes and relays.
+//
+// A segment is a gzip compressed file holding one JSON encoded beacon per line,
+// in increasing round order. The manifest, stored under ManifestName, is the
+// JSON encoded Manifest of the snapshot.
+package snapshot
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/drand/drand/chain"
+)
+
+// ManifestName is the name of the manifest in the bucket.
+const ManifestName = "manifest.json"
+
+// MaxSegmentRounds is the maximum number of rounds of a segment.
+const MaxSegmentRounds = 100000
+
+// MinSegmentRounds is the number of rounds below which the last segment of a
+// snapshot is rewritten with the new rounds by the next upload, instead of
+// being followed by another small segment.
+const MinSegmentRounds = 1000
+
+// Manifest lists the segments of the snapshot of a chain.
+type Manifest struct {
+	// ChainHash is the hex encoded hash of the chain
+	ChainHash string `json:"chain_hash"`
+	// Segments are the segments of the snapshot, in increasing round order
+	Segments []Segment `json:"segments"`
+}
+
+// Segment is a file of the snapshot holding the beacons of consecutive rounds.
+type Segment struct {
+	Name string `json:"name"`
+	// From and To are the first and last rounds of the segment
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+	// SHA256 is the hex encoded hash of the segment file
+	SHA256 string `json:"sha256"`
+}
+
+// Last returns the last round of the snapshot, and false if the snapshot is
+// empty.
+func (m *Manifest) Last() (uint64, bool) {
+	if len(m.Segments) == 0 {
+		return 0, false
+	}
+	return m.Segments[len(m.Segments)-1].To, true
+}
+
+// ErrNotFound is returned by the buckets when a file does not exist.
+var ErrNotFound = errors.New("snapshot: file not found")
+
+// Bucket stores the files of a snapshot.
+type Bucket interface {
+	// Put writes the file of the given name, replacing it if it exists.
+	Put(ctx context.Context, name string, r io.Reader) error
+	// Get opens the file of the given name, it returns ErrNotFound if the
+	// file does not exist.
+	Get(ctx context.Context, name string) (io.ReadCloser, error)
+	// Delete removes the file of the given name, if it exists.
+	Delete(ctx context.Context, name string) error
+}
+
+// WriteSegment writes the beacons of the store from round from to round to,
+// both included, in the segment format. It stops at the first round missing
+// from the store, so the segment only holds consecutive rounds. It returns the
+// number of beacons written.
+func WriteSegment(w io.Writer, s chain.Store, from, to uint64) (int, error) {
+	zw := gzip.NewWriter(w)
+	var n int
+	var err error
+	s.Cursor(func(c chain.Cursor) {
+		for b := c.Seek(from); b != nil && b.Round <= to && b.Round == from+uint64(n); b = c.Next() {
+			var buff []byte
+			if buff, err = b.Marshal(); err != nil {
+				return
+			}
+			if _, err = zw.Write(append(buff, '\n')); err != nil {
+				return
+			}
+			n++
+		}
+	})
+	if err != nil {
+		return n, err
+	}
+	return n, zw.Close()
+}
+
+// ReadSegment calls fn with each beacon of the segment read from r, in order.
+// It stops at the first error returned by fn.
+func ReadSegment(r io.Reader, fn func(*chain.Beacon) error) error {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("snapshot: invalid segment: %w", err)
+	}
+	defer zr.Close()
+	scanner := bufio.NewScanner(zr)
+	for scanner.Scan() {
+		b := new(chain.Beacon)
+		if err := b.Unmarshal(scanner.Bytes()); err != nil {
+			return fmt.Errorf("snapshot: invalid beacon: %w", err)
+		}
+		if err := fn(b); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// dirBucket is a bucket keeping the files in a local folder.
+type dirBucket struct {
+	folder string
+}
+
+// NewDirBucket returns a bucket keeping the files in the given folder, which
+// is created if needed.
+func NewDirBucket(folder string) (Bucket, error) {
+	if err := os.MkdirAll(folder, 0750); err != nil {
+		return nil, err
+	}
+	return &dirBucket{folder: folder}, nil
+}
+
+func (d *dirBucket) Put(_ context.Context, name string, r io.Reader) error {
+	// write to a temporary file first so readers never see a partial file
+	tmp, err := ioutil.TempFile(d.folder, name+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(d.folder, name))
+}
+
+func (d *dirBucket) Delete(_ context.Context, name string) error {
+	err := os.Remove(filepath.Join(d.folder, name))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *dirBucket) Get(_ context.Context, name string) (io.ReadCloser, error) {
+	f, err := os.Open(filepath.Join(d.folder, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
@@ -0,0 +1,241 @@
+package snapshot
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/client/test/result/mock"
+	"github.com/drand/drand/log"
+	"github.com/stretchr/testify/require"
+)
+
+func putRounds(t *testing.T, s chain.Store, from, to uint64) {
+	for r := from; r <= to; r++ {
+		require.NoError(t, s.Put(&chain.Beacon{
+			Round:       r,
+			PreviousSig: []byte{byte(r - 1)},
+			Signature:   []byte{byte(r)},
+		}))
+	}
+}
+
+func TestUploader(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-snapshot-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	bucket, err := NewDirBucket(path.Join(tmp, "bucket"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	hash := []byte("chain hash")
+
+	_, err = LoadManifest(ctx, bucket)
+	require.Equal(t, ErrNotFound, err)
+
+	putRounds(t, store, 0, 10)
+	u := NewUploader(bucket, hash, log.DefaultLogger())
+	n, err := u.Upload(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, 11, n)
+	n, err = u.Upload(ctx, store)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// the next upload counts the new rounds only, the small segment is
+	// rewritten with them and its file deleted
+	m, err := LoadManifest(ctx, bucket)
+	require.NoError(t, err)
+	small := m.Segments[0].Name
+	putRounds(t, store, 11, 15)
+	n, err = NewUploader(bucket, hash, log.DefaultLogger()).Upload(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	_, err = bucket.Get(ctx, small)
+	require.Equal(t, ErrNotFound, err)
+
+	m, err = LoadManifest(ctx, bucket)
+	require.NoError(t, err)
+	require.Equal(t, hex.EncodeToString(hash), m.ChainHash)
+	require.Len(t, m.Segments, 1)
+	last, ok := m.Last()
+	require.True(t, ok)
+	require.Equal(t, uint64(15), last)
+	var round uint64
+	for _, seg := range m.Segments {
+		buff, err := ioutil.ReadFile(path.Join(tmp, "bucket", seg.Name))
+		require.NoError(t, err)
+		digest := sha256.Sum256(buff)
+		require.Equal(t, seg.SHA256, hex.EncodeToString(digest[:]))
+		r, err := bucket.Get(ctx, seg.Name)
+		require.NoError(t, err)
+		require.NoError(t, ReadSegment(r, func(b *chain.Beacon) error {
+			require.Equal(t, round, b.Round)
+			require.Equal(t, []byte{byte(round)}, b.Signature)
+			round++
+			return nil
+		}))
+		r.Close()
+		require.Equal(t, round-1, seg.To)
+	}
+	require.Equal(t, uint64(16), round)
+
+	// the bucket can't be shared with another chain
+	_, err = NewUploader(bucket, []byte("another chain"), log.DefaultLogger()).Upload(ctx, store)
+	require.Error(t, err)
+}
+
+func TestUploaderGap(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-snapshot-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	bucket, err := NewDirBucket(path.Join(tmp, "bucket"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	u := NewUploader(bucket, []byte("chain hash"), log.DefaultLogger())
+
+	// only the rounds before the missing one are uploaded
+	putRounds(t, store, 0, 5)
+	putRounds(t, store, 8, 10)
+	n, err := u.Upload(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, 6, n)
+	last, _ := u.manifest.Last()
+	require.Equal(t, uint64(5), last)
+	n, err = u.Upload(ctx, store)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// the upload resumes once the gap is filled
+	putRounds(t, store, 6, 7)
+	n, err = u.Upload(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	last, _ = u.manifest.Last()
+	require.Equal(t, uint64(10), last)
+}
+
+func TestUploaderFullSegment(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-snapshot-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	store, err := boltdb.NewBoltStore(tmp, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	bucket, err := NewDirBucket(path.Join(tmp, "bucket"))
+	require.NoError(t, err)
+	ctx := context.Background()
+	hash := []byte("chain hash")
+
+	// a segment of MinSegmentRounds rounds is not rewritten
+	full := Segment{Name: "full", From: 0, To: MinSegmentRounds - 1}
+	require.NoError(t, putManifest(ctx, bucket, &Manifest{ChainHash: hex.EncodeToString(hash), Segments: []Segment{full}}))
+	putRounds(t, store, MinSegmentRounds, MinSegmentRounds+4)
+	n, err := NewUploader(bucket, hash, log.DefaultLogger()).Upload(ctx, store)
+	require.NoError(t, err)
+	require.Equal(t, 5, n)
+	m, err := LoadManifest(ctx, bucket)
+	require.NoError(t, err)
+	require.Len(t, m.Segments, 2)
+	require.Equal(t, full, m.Segments[0])
+	require.Equal(t, uint64(MinSegmentRounds), m.Segments[1].From)
+}
+
+func TestImport(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-snapshot-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	info, results := mock.VerifiableResults(12)
+	for _, dir := range []string{"src", "dst", "tampered"} {
+		require.NoError(t, os.Mkdir(path.Join(tmp, dir), 0700))
+	}
+	src, err := boltdb.NewBoltStore(path.Join(tmp, "src"), nil)
+	require.NoError(t, err)
+	defer src.Close()
+	require.NoError(t, src.Put(chain.GenesisBeacon(info)))
+	for _, r := range results[:8] {
+		require.NoError(t, src.Put(&chain.Beacon{Round: r.Rnd, PreviousSig: r.PSig, Signature: r.Sig}))
+	}
+	ctx := context.Background()
+	bucket, err := NewDirBucket(path.Join(tmp, "bucket"))
+	require.NoError(t, err)
+	u := NewUploader(bucket, info.Hash(), log.DefaultLogger())
+	_, err = u.Upload(ctx, src)
+	require.NoError(t, err)
+	for _, r := range results[8:] {
+		require.NoError(t, src.Put(&chain.Beacon{Round: r.Rnd, PreviousSig: r.PSig, Signature: r.Sig}))
+	}
+	_, err = u.Upload(ctx, src)
+	require.NoError(t, err)
+
+	dst, err := boltdb.NewBoltStore(path.Join(tmp, "dst"), nil)
+	require.NoError(t, err)
+	defer dst.Close()
+	opened, err := OpenBucket(path.Join(tmp, "bucket"))
+	require.NoError(t, err)
+	var rounds []uint64
+	n, err := Import(ctx, opened, dst, info, func(round uint64) { rounds = append(rounds, round) })
+	require.NoError(t, err)
+	require.Equal(t, 12, n)
+	// the second upload rewrote the small first segment
+	require.Equal(t, []uint64{12}, rounds)
+	last, err := dst.Last()
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), last.Round)
+
+	// the import resumes after the last beacon stored
+	n, err = Import(ctx, opened, dst, info, nil)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	// the snapshot of another chain is refused
+	other, _ := mock.VerifiableResults(1)
+	_, err = Import(ctx, opened, dst, other, nil)
+	require.Error(t, err)
+
+	// a tampered segment is refused
+	m, err := LoadManifest(ctx, bucket)
+	require.NoError(t, err)
+	seg := path.Join(tmp, "bucket", m.Segments[0].Name)
+	require.NoError(t, ioutil.WriteFile(seg, []byte("tampered"), 0600))
+	tampered, err := boltdb.NewBoltStore(path.Join(tmp, "tampered"), nil)
+	require.NoError(t, err)
+	defer tampered.Close()
+	n, err = Import(ctx, opened, tampered, info, nil)
+	require.Error(t, err)
+	require.Zero(t, n)
+
+	_, err = OpenBucket(path.Join(tmp, "missing"))
+	require.Error(t, err)
+}
+
+func TestHTTPBucket(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-snapshot-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	require.NoError(t, ioutil.WriteFile(path.Join(tmp, ManifestName), []byte(`{"chain_hash":"abcd"}`), 0600))
+	srv := httptest.NewServer(http.FileServer(http.Dir(tmp)))
+	defer srv.Close()
+
+	bucket, err := OpenBucket(srv.URL + "/")
+	require.NoError(t, err)
+	m, err := LoadManifest(context.Background(), bucket)
+	require.NoError(t, err)
+	require.Equal(t, "abcd", m.ChainHash)
+	_, err = bucket.Get(context.Background(), "missing")
+	require.Equal(t, ErrNotFound, err)
+	require.Error(t, bucket.Put(context.Background(), ManifestName, nil))
+}
@@ -0,0 +1,144 @@
+package snapshot
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/log"
+)
+
+// Uploader extends the snapshot of a chain in a bucket with the rounds stored
+// since its last segment.
+type Uploader struct {
+	bucket    Bucket
+	chainHash string
+	l         log.Logger
+	// manifest is the manifest of the bucket, loaded by the first upload
+	manifest *Manifest
+}
+
+// NewUploader returns an uploader of the snapshot of the chain of the given
+// hash to the bucket.
+func NewUploader(bucket Bucket, chainHash []byte, l log.Logger) *Uploader {
+	return &Uploader{
+		bucket:    bucket,
+		chainHash: hex.EncodeToString(chainHash),
+		l:         l,
+	}
+}
+
+// Upload writes the rounds of the store following the last segment of the
+// snapshot in new segments of at most MaxSegmentRounds rounds, and the updated
+// manifest after each segment. Only the rounds following the snapshot without
+// a gap are uploaded: the upload stops at the first round missing from the
+// store, and resumes from it once it is stored. A last segment of less than
+// MinSegmentRounds rounds is rewritten with the new rounds, the new segment
+// replacing it in the manifest before its file is deleted. Upload returns the
+// number of new beacons uploaded.
+func (u *Uploader) Upload(ctx context.Context, s chain.Store) (int, error) {
+	if u.manifest == nil {
+		m, err := LoadManifest(ctx, u.bucket)
+		if err == ErrNotFound {
+			m = &Manifest{ChainHash: u.chainHash}
+		} else if err != nil {
+			return 0, err
+		}
+		if m.ChainHash != u.chainHash {
+			return 0, fmt.Errorf("snapshot: the bucket holds the chain %s", m.ChainHash)
+		}
+		u.manifest = m
+	}
+	last, err := s.Last()
+	if err != nil {
+		return 0, err
+	}
+	next, ok := u.manifest.Last()
+	if ok {
+		next++
+	}
+	var uploaded int
+	for next <= last.Round {
+		segments := u.manifest.Segments
+		from := next
+		var rolled *Segment
+		if k := len(segments); k > 0 && segments[k-1].To-segments[k-1].From+1 < MinSegmentRounds {
+			rolled = &segments[k-1]
+			segments = segments[:k-1]
+			from = rolled.From
+		}
+		to := from + MaxSegmentRounds - 1
+		if to > last.Round {
+			to = last.Round
+		}
+		var buff bytes.Buffer
+		n, err := WriteSegment(&buff, s, from, to)
+		if err != nil {
+			return uploaded, err
+		}
+		end := from + uint64(n)
+		if end <= next {
+			u.l.Info("snapshot", "upload stopped", "missing_round", next)
+			break
+		}
+		hash := sha256.Sum256(buff.Bytes())
+		seg := Segment{
+			Name:   fmt.Sprintf("%020d-%020d.jsonl.gz", from, end-1),
+			From:   from,
+			To:     end - 1,
+			SHA256: hex.EncodeToString(hash[:]),
+		}
+		if err := u.bucket.Put(ctx, seg.Name, &buff); err != nil {
+			return uploaded, fmt.Errorf("snapshot: uploading segment %s: %w", seg.Name, err)
+		}
+		m := *u.manifest
+		m.Segments = append(segments[:len(segments):len(segments)], seg)
+		if err := putManifest(ctx, u.bucket, &m); err != nil {
+			return uploaded, err
+		}
+		u.manifest = &m
+		if rolled != nil {
+			// the file is not listed anymore, it only wastes space
+			if err := u.bucket.Delete(ctx, rolled.Name); err != nil {
+				u.l.Error("snapshot", "deleting rolled segment", "segment", rolled.Name, "err", err)
+			}
+		}
+		uploaded += int(end - next)
+		u.l.Debug("snapshot", "uploaded", "segment", seg.Name, "beacons", end-next)
+		if seg.To < to {
+			u.l.Info("snapshot", "upload stopped", "missing_round", end)
+			break
+		}
+		next = end
+	}
+	return uploaded, nil
+}
+
+// LoadManifest reads the manifest of the snapshot in the bucket.
+func LoadManifest(ctx context.Context, bucket Bucket) (*Manifest, error) {
+	r, err := bucket.Get(ctx, ManifestName)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	m := new(Manifest)
+	if err := json.NewDecoder(r).Decode(m); err != nil {
+		return nil, fmt.Errorf("snapshot: invalid manifest: %w", err)
+	}
+	return m, nil
+}
+
+func putManifest(ctx context.Context, bucket Bucket, m *Manifest) error {
+	buff, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := bucket.Put(ctx, ManifestName, bytes.NewReader(buff)); err != nil {
+		return fmt.Errorf("snapshot: uploading manifest: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package chain
+
+// Stats are the statistics of the beacons kept by a store.
+type Stats struct {
+	// Count is the number of beacons stored
+	Count int
+	// First and Last are the first and last rounds stored
+	First, Last uint64
+	// Gaps are the ranges of rounds missing between First and Last
+	Gaps []Gap
+	// Size is the size in bytes used by the store, zero if the store can't
+	// report it
+	Size int64
+}
+
+// Gap is a range of consecutive rounds missing from a store, From and To
+// included.
+type Gap struct {
+	From, To uint64
+}
+
+// Missing returns the number of rounds missing between the first and last
+// rounds stored.
+func (s Stats) Missing() uint64 {
+	var n uint64
+	for _, g := range s.Gaps {
+		n += g.To - g.From + 1
+	}
+	return n
+}
+
+// StatsProgressStep is the number of beacons scanned between two calls of the
+// progress function of ComputeStats.
+const StatsProgressStep = 100000
+
+// ComputeStats scans the whole store to compute its statistics. The progress
+// function, if not nil, is called with the number of beacons scanned every
+// StatsProgressStep beacons.
+func ComputeStats(s Store, progress func(scanned int)) Stats {
+	var stats Stats
+	if sz, ok := s.(interface{ Size() int64 }); ok {
+		stats.Size = sz.Size()
+	}
+	s.Cursor(func(c Cursor) {
+		for b := c.First(); b != nil; b = c.Next() {
+			if stats.Count == 0 {
+				stats.First = b.Round
+			} else if b.Round > stats.Last+1 {
+				stats.Gaps = append(stats.Gaps, Gap{From: stats.Last + 1, To: b.Round - 1})
+			}
+			stats.Last = b.Round
+			stats.Count++
+			if progress != nil && stats.Count%StatsProgressStep == 0 {
+				progress(stats.Count)
+			}
+		}
+	})
+	return stats
+}
+
+// Compacter is implemented by the stores whose storage fragments as beacons
+// are deleted, to reclaim the space they used. Compact calls progress, if not
+// nil, with the number of beacons processed out of the total. The store must
+// not be used while it is compacted.
+type Compacter interface {
+	Compact(progress func(done, total int)) error
+}
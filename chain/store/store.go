@@ -0,0 +1,71 @@
+// Package store keeps the registry of the backends a drand node can store its
+// randomness chain in. Backends register themselves, usually from an init
+// function, and the daemon picks one by name:
+//
+//	store.Register("mykv", func(folder string, opts interface{}) (chain.Store, error) {
+//	    return newKVStore(folder)
+//	})
+//
+// The boltdb backend is registered under the name "bolt" and is the default.
+package store
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/drand/drand/chain"
+)
+
+// DefaultBackend is the name of the backend used when none is specified.
+const DefaultBackend = "bolt"
+
+// Factory creates a store keeping its data under the given folder. opts are
+// the backend specific options given to the node, which can be nil.
+type Factory func(folder string, opts interface{}) (chain.Store, error)
+
+var (
+	mu        sync.RWMutex
+	factories = make(map[string]Factory)
+)
+
+// Register makes a store backend available under the given name. It panics
+// if the factory is nil or if a backend is already registered with that name.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+	if f == nil {
+		panic("store: nil factory for backend " + name)
+	}
+	if _, exists := factories[name]; exists {
+		panic("store: backend " + name + " registered twice")
+	}
+	factories[name] = f
+}
+
+// New creates a store with the backend registered under the given name. An
+// empty name selects the DefaultBackend.
+func New(name, folder string, opts interface{}) (chain.Store, error) {
+	if name == "" {
+		name = DefaultBackend
+	}
+	mu.RLock()
+	f, exists := factories[name]
+	mu.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("store: unknown backend %q", name)
+	}
+	return f(folder, opts)
+}
+
+// Backends returns the sorted names of the registered backends.
+func Backends() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
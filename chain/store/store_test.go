@@ -0,0 +1,49 @@
+package store_test
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/drand/drand/chain"
+	_ "github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/store"
+	"github.com/stretchr/testify/require"
+)
+
+type memStore struct {
+	chain.Store
+	folder string
+}
+
+func TestRegister(t *testing.T) {
+	store.Register("mem", func(folder string, opts interface{}) (chain.Store, error) {
+		return &memStore{folder: folder}, nil
+	})
+	require.Panics(t, func() {
+		store.Register("mem", func(string, interface{}) (chain.Store, error) { return nil, nil })
+	})
+	require.Equal(t, []string{"bolt", "mem"}, store.Backends())
+
+	s, err := store.New("mem", "/tmp/chain", nil)
+	require.NoError(t, err)
+	require.Equal(t, "/tmp/chain", s.(*memStore).folder)
+
+	_, err = store.New("unknown", "/tmp/chain", nil)
+	require.Error(t, err)
+}
+
+func TestDefaultBackend(t *testing.T) {
+	dir, err := ioutil.TempDir("", "*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	s, err := store.New("", dir, nil)
+	require.NoError(t, err)
+	defer s.Close()
+	require.NoError(t, s.Put(&chain.Beacon{Round: 1}))
+	require.Equal(t, 1, s.Len())
+
+	_, err = store.New(store.DefaultBackend, dir, "not bolt options")
+	require.Error(t, err)
+}
@@ -3,6 +3,8 @@ package chain
 import (
 	"math"
 	"time"
+
+	"github.com/drand/drand/key"
 )
 
 // time.Unix will add `time.unixToInternal` to a unix timestamp in int64 space.
@@ -58,3 +60,85 @@ func NextRound(now int64, period time.Duration, genesis int64) (nextRound uint64
 	nextTime = genesis + int64(nextRound*uint64(period.Seconds()))
 	return nextRound + 1, nextTime
 }
+
+// Schedule gives the time of the rounds of a chain whose period may have been
+// changed by resharings. Without changes, it gives the same times as the
+// functions above.
+type Schedule struct {
+	Genesis int64
+	Period  time.Duration
+	Changes []key.PeriodChange
+}
+
+// NewSchedule returns the schedule of the rounds of the chain the group runs.
+func NewSchedule(g *key.Group) Schedule {
+	return Schedule{Genesis: g.GenesisTime, Period: g.Period, Changes: g.PeriodChanges}
+}
+
+// segment is a range of rounds happening every period from the given round.
+type segment struct {
+	round  uint64
+	time   int64
+	period time.Duration
+}
+
+func (s Schedule) segments() []segment {
+	segs := []segment{{round: 1, time: s.Genesis, period: s.Period}}
+	for _, c := range s.Changes {
+		last := segs[len(segs)-1]
+		if c.Round <= last.round {
+			continue
+		}
+		t := TimeOfRound(last.period, last.time, c.Round-last.round+1)
+		if t == TimeOfRoundErrorValue {
+			break
+		}
+		segs = append(segs, segment{round: c.Round, time: t, period: c.Period})
+	}
+	return segs
+}
+
+// segmentOfRound returns the segment the round belongs to
+func (s Schedule) segmentOfRound(round uint64) segment {
+	segs := s.segments()
+	i := len(segs) - 1
+	for i > 0 && segs[i].round > round {
+		i--
+	}
+	return segs[i]
+}
+
+// PeriodAt returns the time between the given round and the next one.
+func (s Schedule) PeriodAt(round uint64) time.Duration {
+	return s.segmentOfRound(round).period
+}
+
+// TimeOfRound returns the time the given round should happen.
+func (s Schedule) TimeOfRound(round uint64) int64 {
+	if round == 0 {
+		return s.Genesis
+	}
+	seg := s.segmentOfRound(round)
+	return TimeOfRound(seg.period, seg.time, round-seg.round+1)
+}
+
+// NextRound returns the next upcoming round and its UNIX time at `now`.
+func (s Schedule) NextRound(now int64) (uint64, int64) {
+	segs := s.segments()
+	i := len(segs) - 1
+	for i > 0 && segs[i].time > now {
+		i--
+	}
+	seg := segs[i]
+	next, nextTime := NextRound(now, seg.period, seg.time)
+	return next + seg.round - 1, nextTime
+}
+
+// CurrentRound returns the active round at `now`.
+func (s Schedule) CurrentRound(now int64) uint64 {
+	next, _ := s.NextRound(now)
+	if next <= 1 {
+		return next
+	}
+	return next - 1
+}
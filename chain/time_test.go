@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/drand/drand/key"
 	clock "github.com/jonboulle/clockwork"
 	"github.com/stretchr/testify/require"
 )
@@ -58,3 +59,35 @@ func TestChainNextRound(t *testing.T) {
 	time2 := TimeOfRound(period, genesis, 3)
 	require.Equal(t, expTime2, time2)
 }
+
+func TestScheduleChanges(t *testing.T) {
+	genesis := time.Date(2020, 01, 01, 0, 0, 0, 0, time.UTC).Unix()
+	period := 2 * time.Second
+
+	// without changes, the schedule gives the same times
+	s := Schedule{Genesis: genesis, Period: period}
+	for _, now := range []int64{genesis - 5, genesis, genesis + 1, genesis + 7} {
+		require.Equal(t, CurrentRound(now, period, genesis), s.CurrentRound(now))
+		next, nextTime := NextRound(now, period, genesis)
+		sNext, sNextTime := s.NextRound(now)
+		require.Equal(t, next, sNext)
+		require.Equal(t, nextTime, sNextTime)
+	}
+	require.Equal(t, TimeOfRound(period, genesis, 42), s.TimeOfRound(42))
+
+	// the period is 3s from round 5 on, which happens 4 periods after genesis
+	s.Changes = []key.PeriodChange{{Round: 5, Period: 3 * time.Second}}
+	require.Equal(t, genesis, s.TimeOfRound(1))
+	require.Equal(t, genesis+8, s.TimeOfRound(5))
+	require.Equal(t, genesis+11, s.TimeOfRound(6))
+	require.Equal(t, period, s.PeriodAt(4))
+	require.Equal(t, 3*time.Second, s.PeriodAt(5))
+	require.Equal(t, uint64(4), s.CurrentRound(genesis+7))
+	require.Equal(t, uint64(5), s.CurrentRound(genesis+10))
+	next, nextTime := s.NextRound(genesis + 8)
+	require.Equal(t, uint64(6), next)
+	require.Equal(t, genesis+11, nextTime)
+	next, nextTime = s.NextRound(genesis + 7)
+	require.Equal(t, uint64(5), next)
+	require.Equal(t, genesis+8, nextTime)
+}
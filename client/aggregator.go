@@ -165,3 +165,8 @@ func (c *watchAggregator) Close() error {
 	}
 	return err
 }
+
+// Range returns a page of the beacons of the range from the wrapped client.
+func (c *watchAggregator) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	return Range(ctx, c.Client, from, to, limit)
+}
@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/log"
+)
+
+// defaultBackfillRetry is the first delay after which a watch that ended is
+// re-opened, or a missed round that could not be fetched is requested again.
+const defaultBackfillRetry = time.Second * 5
+
+// maxBackfillRetry bounds the delay, doubled on each consecutive failure.
+const maxBackfillRetry = time.Minute
+
+// newBackfillClient wraps a client so that its `Watch` channel delivers the
+// results in order and without gaps: the watch is re-opened when it ends, and
+// the rounds missed in between, or dropped along the way, are fetched with
+// `Get` before the next result is delivered. The watches end when the client
+// is closed.
+func newBackfillClient(c Client, retry time.Duration) *backfillClient {
+	if retry <= 0 {
+		retry = defaultBackfillRetry
+	}
+	return &backfillClient{
+		Client: c,
+		retry:  retry,
+		log:    log.DefaultLogger(),
+		done:   make(chan struct{}),
+	}
+}
+
+type backfillClient struct {
+	Client
+	retry time.Duration
+	log   log.Logger
+
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// SetLog configures the client log output
+func (b *backfillClient) SetLog(l log.Logger) {
+	b.log = l
+}
+
+// String returns the name of this client.
+func (b *backfillClient) String() string {
+	return fmt.Sprintf("%s.(+backfill)", b.Client)
+}
+
+// Watch returns new randomness as it becomes available, without gaps, until
+// the context is done.
+func (b *backfillClient) Watch(ctx context.Context) <-chan Result {
+	out := make(chan Result, aggregatorWatchBuffer)
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		select {
+		case <-b.done:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	go func() {
+		defer close(out)
+		defer cancel()
+		var last uint64
+		delay := b.retry
+		for {
+			for r := range b.Client.Watch(ctx) {
+				if r.Round() <= last {
+					continue
+				}
+				for round := last + 1; last != 0 && round < r.Round(); round++ {
+					missed := b.fetch(ctx, round)
+					if missed == nil || !send(ctx, out, missed) {
+						return
+					}
+				}
+				if !send(ctx, out, r) {
+					return
+				}
+				last = r.Round()
+				delay = b.retry
+			}
+			if !wait(ctx, delay) {
+				return
+			}
+			delay = nextBackfillRetry(delay)
+			b.log.Info("backfill_client", "re-opening watch", "last_round", last)
+		}
+	}()
+	return out
+}
+
+// fetch gets the round, retrying until it succeeds. It returns nil if the
+// context is done before.
+func (b *backfillClient) fetch(ctx context.Context, round uint64) Result {
+	for delay := b.retry; ; delay = nextBackfillRetry(delay) {
+		r, err := b.Client.Get(ctx, round)
+		if err == nil && r.Round() == round {
+			b.log.Debug("backfill_client", "missed round fetched", "round", round)
+			return r
+		}
+		b.log.Warn("backfill_client", "unable to fetch missed round", "round", round, "err", err)
+		if !wait(ctx, delay) {
+			return nil
+		}
+	}
+}
+
+// nextBackfillRetry returns the delay following the given one, doubled up to
+// maxBackfillRetry.
+func nextBackfillRetry(delay time.Duration) time.Duration {
+	if delay >= maxBackfillRetry {
+		return delay
+	}
+	if delay *= 2; delay > maxBackfillRetry {
+		return maxBackfillRetry
+	}
+	return delay
+}
+
+func wait(ctx context.Context, delay time.Duration) bool {
+	t := time.NewTimer(delay)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func send(ctx context.Context, out chan<- Result, r Result) bool {
+	select {
+	case out <- r:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// Range returns a page of the beacons of the range from the wrapped client.
+func (b *backfillClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	return Range(ctx, b.Client, from, to, limit)
+}
+
+// Close ends the watches of the client and closes the wrapped client.
+func (b *backfillClient) Close() error {
+	b.closeOnce.Do(func() { close(b.done) })
+	return b.Client.Close()
+}
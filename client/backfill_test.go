@@ -0,0 +1,90 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/client/test/result/mock"
+)
+
+func TestBackfillWatch(t *testing.T) {
+	// the first watch misses round 3 and ends after round 4, the second one
+	// resumes at round 7
+	watches := [][]uint64{{1, 2, 4}, {4, 7}}
+	var l sync.Mutex
+	c := &MockClient{
+		StrictRounds: true,
+		WatchF: func(ctx context.Context) <-chan Result {
+			l.Lock()
+			defer l.Unlock()
+			ch := make(chan Result, 5)
+			if len(watches) > 0 {
+				for _, round := range watches[0] {
+					r := mock.NewMockResult(round)
+					ch <- &r
+				}
+				watches = watches[1:]
+				close(ch)
+			}
+			return ch
+		},
+	}
+	for round := uint64(1); round <= 7; round++ {
+		c.Results = append(c.Results, mock.NewMockResult(round))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	b := newBackfillClient(c, 10*time.Millisecond)
+	watched := b.Watch(ctx)
+	for expected := uint64(1); expected <= 7; expected++ {
+		select {
+		case r := <-watched:
+			if r.Round() != expected {
+				t.Fatalf("expected round %d, got %d", expected, r.Round())
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timeout waiting for round %d", expected)
+		}
+	}
+	cancel()
+	for range watched {
+	}
+}
+
+func TestBackfillClose(t *testing.T) {
+	c := &MockClient{
+		WatchF: func(ctx context.Context) <-chan Result {
+			ch := make(chan Result)
+			go func() {
+				<-ctx.Done()
+				close(ch)
+			}()
+			return ch
+		},
+	}
+	b := newBackfillClient(c, 10*time.Millisecond)
+	watched := b.Watch(context.Background())
+	if err := b.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case _, ok := <-watched:
+		if ok {
+			t.Fatal("unexpected result")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("the watch did not end with the client")
+	}
+}
+
+func TestBackfillRetryBackoff(t *testing.T) {
+	delay := time.Second * 5
+	for _, expected := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if delay = nextBackfillRetry(delay); delay != expected {
+			t.Fatalf("expected %s, got %s", expected, delay)
+		}
+	}
+}
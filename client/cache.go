@@ -89,6 +89,18 @@ func (c *cachingClient) String() string {
 	return fmt.Sprintf("%s.(+nil cache)", c.Client)
 }
 
+// Range returns a page of the beacons of the range, adding them to the cache.
+func (c *cachingClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	results, next, err := Range(ctx, c.Client, from, to, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, r := range results {
+		c.cache.Add(r.Round(), r)
+	}
+	return results, next, nil
+}
+
 // Get returns the randomness at `round` or an error.
 func (c *cachingClient) Get(ctx context.Context, round uint64) (res Result, err error) {
 	if val := c.cache.TryGet(round); val != nil {
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/drand/drand/chain"
@@ -43,12 +45,22 @@ func trySetLog(c Client, l log.Logger) {
 
 // makeClient creates a client from a configuration.
 func makeClient(cfg *clientConfig) (Client, error) {
-	if !cfg.insecure && cfg.chainHash == nil && cfg.chainInfo == nil {
+	if !cfg.insecure && cfg.chainHash == nil && cfg.chainInfo == nil && cfg.fingerprint == "" {
 		return nil, errors.New("no root of trust specified")
 	}
+	if cfg.fingerprint != "" && cfg.chainInfo != nil && cfg.chainInfo.Fingerprint() != cfg.fingerprint {
+		return nil, fmt.Errorf("chain info key fingerprint %s differs from the pinned %s", cfg.chainInfo.Fingerprint(), cfg.fingerprint)
+	}
 	if len(cfg.clients) == 0 && cfg.watcher == nil {
 		return nil, errors.New("no points of contact specified")
 	}
+	if cfg.light {
+		if cfg.fullVerify {
+			return nil, errors.New("light verification can not be combined with full chain verification")
+		}
+		// a light client only keeps the last verified beacon
+		cfg.cacheSize = 0
+	}
 
 	var err error
 
@@ -74,9 +86,13 @@ func makeClient(cfg *clientConfig) (Client, error) {
 
 	var c Client
 
+	var light *lightTrust
+	if cfg.light {
+		light = &lightTrust{last: cfg.previousResult}
+	}
 	verifiers := make([]Client, 0, len(cfg.clients))
 	for _, source := range cfg.clients {
-		nv := newVerifyingClient(source, cfg.previousResult, cfg.fullVerify)
+		nv := newVerifyingClient(source, cfg.previousResult, cfg.fullVerify, light, cfg.fingerprint)
 		verifiers = append(verifiers, nv)
 		if source == wc {
 			wc = nv
@@ -88,12 +104,19 @@ func makeClient(cfg *clientConfig) (Client, error) {
 		return nil, err
 	}
 
-	wa := newWatchAggregator(c, cfg.autoWatch, cfg.autoWatchRetry)
-	c = wa
+	c = newRetryingClient(c, cfg.chainInfo, cfg.retry)
 	trySetLog(c, cfg.log)
 
+	wa := newWatchAggregator(c, cfg.autoWatch, cfg.autoWatchRetry)
+	trySetLog(wa, cfg.log)
 	wa.Start()
 
+	c = wa
+	if cfg.backfill {
+		c = newBackfillClient(wa, cfg.backfillRetry)
+		trySetLog(c, cfg.log)
+	}
+
 	return attachMetrics(cfg, c)
 }
 
@@ -159,11 +182,17 @@ type clientConfig struct {
 	chainHash []byte
 	// Full chain information - serves as a root of trust.
 	chainInfo *chain.Info
+	// fingerprint of the distributed public key the results must verify
+	// under - serves as a root of trust.
+	fingerprint string
 	// A previously fetched result serving as a verification checkpoint if one exists.
 	previousResult Result
 	// chain signature verification back to the 1st round, or to a know result to ensure
 	// determinism in the event of a compromised chain.
 	fullVerify bool
+	// light verification only keeps the last verified result and never fetches
+	// other rounds to verify a result.
+	light bool
 	// insecure indicates the root of trust does not need to be present.
 	insecure bool
 	// cache size - how large of a cache to keep locally.
@@ -178,6 +207,13 @@ type clientConfig struct {
 	autoWatchRetry time.Duration
 	// prometheus is an interface to a Prometheus system
 	prometheus prometheus.Registerer
+	// backfill makes `Watch` deliver the results in order and without gaps.
+	backfill bool
+	// backfillRetry is the first delay after which watching is resumed when
+	// it ended, or a round missed while watching is requested again.
+	backfillRetry time.Duration
+	// retry is how the failed `Get` and `Info` calls are retried.
+	retry retryPolicy
 }
 
 func (c *clientConfig) tryPopulateInfo(clients ...Client) (err error) {
@@ -260,6 +296,20 @@ func WithChainInfo(chainInfo *chain.Info) Option {
 	}
 }
 
+// WithFingerprint pins the fingerprint of the distributed public key of the
+// chain, as returned by `chain.Info.Fingerprint`: the client refuses to verify
+// results against a chain info with another key. It serves as a root of trust
+// when the chain info is fetched over an untrusted channel.
+func WithFingerprint(fingerprint string) Option {
+	return func(cfg *clientConfig) error {
+		if !strings.HasPrefix(fingerprint, chain.FingerprintPrefix) {
+			return fmt.Errorf("invalid fingerprint %q: must start with %s", fingerprint, chain.FingerprintPrefix)
+		}
+		cfg.fingerprint = fingerprint
+		return nil
+	}
+}
+
 // WithVerifiedResult provides a checkpoint of randomness verified at a given round.
 // Used in combination with `VerifyFullChain`, this allows for catching up only on
 // previously not-yet-verified results.
@@ -286,6 +336,20 @@ func WithFullChainVerification() Option {
 	}
 }
 
+// WithLightVerification configures the client for constrained environments,
+// such as mobile or embedded consumers. The client keeps no cache and only
+// remembers the chain information and the latest verified result: each result
+// is verified on its own with the chain public key, and a result following
+// the latest verified one must also be derived from it. The latest result
+// returned can be persisted and given back with `WithVerifiedResult` on the
+// next start. It can't be combined with `WithFullChainVerification`.
+func WithLightVerification() Option {
+	return func(cfg *clientConfig) error {
+		cfg.light = true
+		return nil
+	}
+}
+
 // Watcher supplies the `Watch` portion of the drand client interface.
 type Watcher interface {
 	Watch(ctx context.Context) <-chan Result
@@ -323,6 +387,62 @@ func WithAutoWatchRetry(interval time.Duration) Option {
 	}
 }
 
+// WithWatchBackfill makes `Watch` deliver the results in order and without
+// gaps: the watch is resumed when the underlying watch ended, and the rounds
+// missed in between are fetched with `Get` before the next result.
+func WithWatchBackfill() Option {
+	return func(cfg *clientConfig) error {
+		cfg.backfill = true
+		return nil
+	}
+}
+
+// WithWatchRetry specifies the first delay after which a backfilled `Watch`
+// resumes watching when the underlying watch ended, or requests again a missed
+// round it could not fetch. The delay doubles on each consecutive failure, up
+// to a minute. Default 5 seconds.
+func WithWatchRetry(interval time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		cfg.backfillRetry = interval
+		return nil
+	}
+}
+
+// WithRetries specifies how many times a failed `Get` or `Info` call is
+// retried before its error is returned. Set to a negative value to return the
+// first error. Default 3.
+func WithRetries(retries int) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retry.retries = retries
+		return nil
+	}
+}
+
+// WithRetryBackoff specifies the time waited before retrying a failed call,
+// starting at min and doubling up to max. Default a tenth of the period of the
+// chain, doubling up to the period.
+func WithRetryBackoff(min, max time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		if min > max {
+			return fmt.Errorf("retry backoff min %s is greater than max %s", min, max)
+		}
+		cfg.retry.minBackoff = min
+		cfg.retry.maxBackoff = max
+		return nil
+	}
+}
+
+// WithCallTimeout bounds the time given to each attempt of a `Get` or `Info`
+// call, on top of the deadline of the context of the call. Set to a negative
+// value to only rely on the context. Default twice the period of the chain,
+// leaving the time to wait for the next round.
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(cfg *clientConfig) error {
+		cfg.retry.timeout = timeout
+		return nil
+	}
+}
+
 // WithPrometheus specifies a registry into which to report metrics
 func WithPrometheus(r prometheus.Registerer) Option {
 	return func(cfg *clientConfig) error {
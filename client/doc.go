@@ -27,6 +27,12 @@ Example:
 		fmt.Println(r.Round(), r.Randomness())
 	}
 
+To wait for a specific round, for example one committed to in advance,
+"WaitForRound" sleeps until the round is expected, then fetches it, retrying
+until it is published:
+
+	r, err := client.WaitForRound(ctx, c, round)
+
 The "From" option allows you to specify clients that work over particular
 transports. HTTP, gRPC and libp2p PubSub clients are provided in drand's
 subpackages https://pkg.go.dev/github.com/drand/drand/client/http,
@@ -35,7 +41,9 @@ https://pkg.go.dev/github.com/drand/drand/lp2p/clientlp2p/client
 respectively. Note that you are not restricted to just one client. You can use
 multiple clients of the same type or of different types. The base client will
 periodically "speed test" it's clients, failover, cache results and aggregate
-calls to "Watch" to reduce requests.
+calls to "Watch" to reduce requests. Results from "Watch" are always delivered
+in order and without gaps: rounds missed while reconnecting are fetched and
+delivered before the following ones.
 
 WARNING: When using the client you should use the "WithChainHash" or
 "WithChainInfo" option in order for your client to validate the randomness it
@@ -53,10 +61,27 @@ to be needed/customized:
 		both should be set for increased security if you have
 		persistent state and expect to be following the chain.
 
+	WithLightVerification()
+		keeps only the chain info and the latest verified result, for
+		mobile or embedded consumers. It can be combined with
+		WithVerifiedResult() but not with WithFullChainVerification().
+
 	WithAutoWatch()
 		will pre-load new results as they become available adding them
 		to the cache for speedy retreival when you need them.
 
+	WithWatchBackfill()
+	WithWatchRetry()
+		make "Watch" deliver the results without gaps, resuming the watch
+		when it ended and fetching the rounds it missed, with a backoff
+		starting from the given retry.
+
+	WithRetries()
+	WithRetryBackoff()
+	WithCallTimeout()
+		set how failed calls are retried, by default 3 times with a
+		backoff relative to the period of the chain.
+
 	WithPrometheus()
 		enables metrics reporting on speed and performance to a
 		provided prometheus registry.
@@ -12,13 +12,21 @@ import (
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/protobuf/drand"
 
+	"github.com/golang/protobuf/ptypes"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
 )
 
 const grpcDefaultTimeout = 5 * time.Second
 
+// maxRetryWait is the longest time Get waits before asking again for a round
+// the server reports as not produced yet.
+const maxRetryWait = 5 * time.Second
+
 type grpcClient struct {
 	address string
 	client  drand.PublicClient
@@ -57,6 +65,8 @@ func asRD(r *drand.PublicRandResponse) *client.RandomData {
 		Random:            r.Randomness,
 		Sig:               r.Signature,
 		PreviousSignature: r.PreviousSignature,
+		ChainHash:         r.ChainHash,
+		Contributors:      r.Contributors,
 	}
 }
 
@@ -68,6 +78,15 @@ func (g *grpcClient) String() string {
 // Get returns a the randomness at `round` or an error.
 func (g *grpcClient) Get(ctx context.Context, round uint64) (client.Result, error) {
 	curr, err := g.client.PublicRand(ctx, &drand.PublicRandRequest{Round: round})
+	if delay, ok := retryDelay(err); ok && delay <= maxRetryWait {
+		// the round is about to be produced
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+		curr, err = g.client.PublicRand(ctx, &drand.PublicRandRequest{Round: round})
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -78,6 +97,35 @@ func (g *grpcClient) Get(ctx context.Context, round uint64) (client.Result, erro
 	return asRD(curr), nil
 }
 
+// Range returns a page of the beacons of the given range of rounds, along with
+// the round of the next page.
+func (g *grpcClient) Range(ctx context.Context, from, to uint64, limit int) ([]client.Result, uint64, error) {
+	resp, err := g.client.PublicRandRange(ctx, &drand.PublicRandRangeRequest{From: from, To: to, Limit: uint32(limit)})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, 0, client.ErrRangeUnsupported
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	results := make([]client.Result, 0, len(resp.GetBeacons()))
+	for _, b := range resp.GetBeacons() {
+		results = append(results, asRD(b))
+	}
+	return results, resp.GetNext(), nil
+}
+
+// retryDelay returns the delay after which the server asked to retry the
+// request that failed with the given error.
+func retryDelay(err error) (time.Duration, bool) {
+	for _, d := range status.Convert(err).Details() {
+		if info, ok := d.(*errdetails.RetryInfo); ok {
+			delay, err := ptypes.Duration(info.GetRetryDelay())
+			return delay, err == nil
+		}
+	}
+	return 0, false
+}
+
 // Watch returns new randomness as it becomes available.
 func (g *grpcClient) Watch(ctx context.Context) <-chan client.Result {
 	stream, err := g.client.PublicRandStream(ctx, &drand.PublicRandRequest{Round: 0})
@@ -119,11 +167,15 @@ func (g *grpcClient) translate(stream drand.Public_PublicRandStreamClient, out c
 func (g *grpcClient) RoundAt(t time.Time) uint64 {
 	ctx, cancel := context.WithTimeout(context.Background(), grpcDefaultTimeout)
 	defer cancel()
-	info, err := g.client.ChainInfo(ctx, &drand.ChainInfoRequest{})
+	proto, err := g.client.ChainInfo(ctx, &drand.ChainInfoRequest{})
+	if err != nil {
+		return 0
+	}
+	info, err := chain.InfoFromProto(proto)
 	if err != nil {
 		return 0
 	}
-	return chain.CurrentRound(t.Unix(), time.Second*time.Duration(info.Period), info.GenesisTime)
+	return info.Schedule().CurrentRound(t.Unix())
 }
 
 // SetLog configures the client log output
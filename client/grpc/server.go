@@ -0,0 +1,127 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/protobuf/drand"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MaxStreamCatchup is the number of past rounds a stream sends before the new
+// ones. A stream starting further in the past is refused: the relay would
+// otherwise fetch the whole chain upstream for a single request, the past
+// rounds are fetched by pages with `PublicRandRange` instead.
+const MaxStreamCatchup = 1000
+
+// relayServer serves the public gRPC API of a chain from a client.
+type relayServer struct {
+	drand.UnimplementedPublicServer
+	client client.Client
+}
+
+// NewServer returns the public gRPC service of the chain the client follows,
+// to relay the chain of upstream nodes without exposing them. The streams are
+// served with `Watch` calls on the client: a client aggregating its watches,
+// as the ones of `client.New` do, serves all the streams from a single
+// subscription to the upstream nodes.
+func NewServer(c client.Client) drand.PublicServer {
+	return &relayServer{client: c}
+}
+
+// PublicRand returns the randomness of the requested round, the latest one if
+// the round is zero.
+func (s *relayServer) PublicRand(ctx context.Context, req *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
+	r, err := s.client.Get(ctx, req.GetRound())
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return asResponse(r), nil
+}
+
+// PublicRandStream sends the rounds from the requested one, if any, then the
+// new rounds as they are produced.
+func (s *relayServer) PublicRandStream(req *drand.PublicRandRequest, stream drand.Public_PublicRandStreamServer) error {
+	ctx := stream.Context()
+	next := req.GetRound()
+	if last := s.client.RoundAt(time.Now()); next != 0 && next+MaxStreamCatchup <= last {
+		return status.Errorf(codes.OutOfRange, "round %d is more than %d rounds before the current round %d, fetch it with PublicRandRange",
+			next, MaxStreamCatchup, last)
+	}
+	// watch first so that no round is missed while the past ones are sent
+	watch := s.client.Watch(ctx)
+	if next != 0 {
+		for last := s.client.RoundAt(time.Now()); next <= last; next++ {
+			r, err := s.client.Get(ctx, next)
+			if err != nil {
+				return status.Error(codes.Unavailable, err.Error())
+			}
+			if err := stream.Send(asResponse(r)); err != nil {
+				return err
+			}
+		}
+	}
+	for r := range watch {
+		if r.Round() < next {
+			continue
+		}
+		if err := stream.Send(asResponse(r)); err != nil {
+			return err
+		}
+		next = r.Round() + 1
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return status.Error(codes.Unavailable, "the upstream watch ended")
+}
+
+// PublicRandRange returns a page of the beacons of the requested range, if the
+// client fetches the beacons by range.
+func (s *relayServer) PublicRandRange(ctx context.Context, req *drand.PublicRandRangeRequest) (*drand.PublicRandRangeResponse, error) {
+	results, next, err := client.Range(ctx, s.client, req.GetFrom(), req.GetTo(), int(req.GetLimit()))
+	if errors.Is(err, client.ErrRangeUnsupported) {
+		return nil, status.Error(codes.Unimplemented, "the relay does not serve ranges")
+	}
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	resp := &drand.PublicRandRangeResponse{Next: next}
+	for _, r := range results {
+		resp.Beacons = append(resp.Beacons, asResponse(r))
+	}
+	return resp, nil
+}
+
+// ChainInfo returns the information of the chain the client follows.
+func (s *relayServer) ChainInfo(ctx context.Context, _ *drand.ChainInfoRequest) (*drand.ChainInfoPacket, error) {
+	info, err := s.client.Info(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return info.ToProto(), nil
+}
+
+// Home reports the relay is running.
+func (s *relayServer) Home(context.Context, *drand.HomeRequest) (*drand.HomeResponse, error) {
+	return &drand.HomeResponse{Status: "drand relay up and running"}, nil
+}
+
+// asResponse converts a result to its protobuf representation.
+func asResponse(r client.Result) *drand.PublicRandResponse {
+	resp := &drand.PublicRandResponse{
+		Round:      r.Round(),
+		Signature:  r.Signature(),
+		Randomness: r.Randomness(),
+	}
+	if rd, ok := r.(*client.RandomData); ok {
+		resp.PreviousSignature = rd.PreviousSignature
+		resp.ChainHash = rd.ChainHash
+		resp.Contributors = rd.Contributors
+	}
+	return resp
+}
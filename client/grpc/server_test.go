@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer(t *testing.T) {
+	l, upstream := mock.NewMockGRPCPublicServer("localhost:0", false)
+	go l.Start()
+	defer l.Stop(context.Background())
+
+	uc, err := New(l.Addr(), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	info, err := uc.Info(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the upstream mock only serves one stream at a time, the watches of the
+	// relay clients must share the aggregated watch of the wrapping client
+	c, err := client.Wrap([]client.Client{uc}, client.WithChainInfo(info), client.WithAutoWatch())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	drand.RegisterPublicServer(server, NewServer(c))
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	rc, err := New(lis.Addr().String(), "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rc.Close()
+	relayed, err := rc.Info(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !relayed.Equal(info) {
+		t.Fatal("unexpected chain info")
+	}
+	r, err := rc.Get(context.Background(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	w1 := rc.Watch(ctx)
+	w2 := rc.Watch(ctx)
+	// let the streams reach the relay before emitting
+	time.Sleep(200 * time.Millisecond)
+	upstream.(mock.MockService).EmitRand(false)
+	for _, w := range []<-chan client.Result{w1, w2} {
+		select {
+		case r2, ok := <-w:
+			if !ok {
+				t.Fatal("watch should work")
+			}
+			if r2.Round() <= r.Round() {
+				t.Fatalf("expected a round after %d, got %d", r.Round(), r2.Round())
+			}
+		case <-ctx.Done():
+			t.Fatal("timeout waiting for the relayed round")
+		}
+	}
+}
+
+// roundClient is a client at a fixed current round, failing the fetches.
+type roundClient struct {
+	client.Client
+	round uint64
+}
+
+func (c *roundClient) RoundAt(time.Time) uint64 { return c.round }
+
+func TestServerStreamCatchup(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := grpc.NewServer()
+	drand.RegisterPublicServer(server, NewServer(&roundClient{round: 5000}))
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	conn, err := grpc.Dial(lis.Addr().String(), grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	stream, err := drand.NewPublicClient(conn).PublicRandStream(context.Background(), &drand.PublicRandRequest{Round: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = stream.Recv()
+	if status.Code(err) != codes.OutOfRange {
+		t.Fatalf("expected the stream to be refused, got %v", err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	nhttp "net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/drand/drand/chain"
@@ -21,6 +22,14 @@ import (
 
 var errClientClosed = fmt.Errorf("client closed")
 
+// infoRefreshPeriod is how long the chain info is used before being fetched
+// again, so that the client follows the changes of period and of message
+// format decided by a resharing.
+var infoRefreshPeriod = 10 * time.Second
+
+// infoRefreshTimeout bounds the requests refreshing the chain info.
+const infoRefreshTimeout = 5 * time.Second
+
 // New creates a new client pointing to an HTTP endpoint
 func New(url string, chainHash []byte, transport nhttp.RoundTripper) (client.Client, error) {
 	if transport == nil {
@@ -40,6 +49,7 @@ func New(url string, chainHash []byte, transport nhttp.RoundTripper) (client.Cli
 		return nil, err
 	}
 	c.chainInfo = chainInfo
+	c.infoFetched = time.Now()
 
 	return c, nil
 }
@@ -54,11 +64,12 @@ func NewWithInfo(url string, info *chain.Info, transport nhttp.RoundTripper) (cl
 	}
 
 	c := &httpClient{
-		root:      url,
-		chainInfo: info,
-		client:    instrumentClient(url, transport),
-		l:         log.DefaultLogger(),
-		done:      make(chan struct{}),
+		root:        url,
+		chainInfo:   info,
+		infoFetched: time.Now(),
+		client:      instrumentClient(url, transport),
+		l:           log.DefaultLogger(),
+		done:        make(chan struct{}),
 	}
 	return c, nil
 }
@@ -130,11 +141,16 @@ func instrumentClient(url string, transport nhttp.RoundTripper) *nhttp.Client {
 
 // httpClient implements Client through http requests to a Drand relay.
 type httpClient struct {
-	root      string
-	client    *nhttp.Client
-	chainInfo *chain.Info
-	l         log.Logger
-	done      chan struct{}
+	root   string
+	client *nhttp.Client
+	l      log.Logger
+	done   chan struct{}
+
+	infoLk      sync.Mutex
+	chainInfo   *chain.Info
+	infoFetched time.Time
+	// refreshing is set while a request refreshes the chain info
+	refreshing bool
 }
 
 // SetLog configures the client log output
@@ -156,12 +172,20 @@ type httpInfoResponse struct {
 // it does not know the full group parameters for a drand group. The chain hash
 // is the hash of the chain info.
 func (h *httpClient) FetchChainInfo(chainHash []byte) (*chain.Info, error) {
-	if h.chainInfo != nil {
-		return h.chainInfo, nil
+	h.infoLk.Lock()
+	info := h.chainInfo
+	h.infoLk.Unlock()
+	if info != nil {
+		return info, nil
 	}
+	return h.fetchChainInfo(context.Background(), chainHash)
+}
 
+// fetchChainInfo requests the chain info, checking it has the given hash if
+// any.
+func (h *httpClient) fetchChainInfo(ctx context.Context, chainHash []byte) (*chain.Info, error) {
 	resC := make(chan httpInfoResponse, 1)
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
 	go func() {
@@ -272,6 +296,48 @@ func (h *httpClient) Get(ctx context.Context, round uint64) (client.Result, erro
 	}
 }
 
+// Range returns a page of the beacons of the given range of rounds, along with
+// the round of the next page, from the /public/range endpoint of the server.
+func (h *httpClient) Range(ctx context.Context, from, to uint64, limit int) ([]client.Result, uint64, error) {
+	select {
+	case <-h.done:
+		return nil, 0, errClientClosed
+	default:
+	}
+	url := fmt.Sprintf("%spublic/range?from=%d&to=%d&limit=%d", h.root, from, to, limit)
+	req, err := nhttp.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating request: %w", err)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("doing request: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case nhttp.StatusOK:
+	case nhttp.StatusNotFound:
+		return nil, 0, client.ErrRangeUnsupported
+	default:
+		return nil, 0, fmt.Errorf("unexpected status %d fetching the range", resp.StatusCode)
+	}
+	var page struct {
+		Beacons []*client.RandomData `json:"beacons"`
+		Next    uint64               `json:"next"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&page); err != nil {
+		return nil, 0, fmt.Errorf("decoding response: %w", err)
+	}
+	results := make([]client.Result, 0, len(page.Beacons))
+	for _, b := range page.Beacons {
+		if b == nil || len(b.Sig) == 0 {
+			return nil, 0, fmt.Errorf("insufficient response")
+		}
+		results = append(results, b)
+	}
+	return results, page.Next, nil
+}
+
 // Watch returns new randomness as it becomes available.
 func (h *httpClient) Watch(ctx context.Context) <-chan client.Result {
 	out := make(chan client.Result)
@@ -280,7 +346,8 @@ func (h *httpClient) Watch(ctx context.Context) <-chan client.Result {
 		defer cancel()
 		defer close(out)
 
-		in := client.PollingWatcher(ctx, h, h.chainInfo, h.l)
+		info, _ := h.Info(ctx)
+		in := client.PollingWatcher(ctx, h, info, h.l)
 		for {
 			select {
 			case res, ok := <-in:
@@ -296,15 +363,63 @@ func (h *httpClient) Watch(ctx context.Context) <-chan client.Result {
 	return out
 }
 
-// Info returns information about the chain.
+// Info returns information about the chain. It is fetched again with the
+// given context every infoRefreshPeriod, and only replaces the previous info if
+// it is a valid update of it. A single refresh runs at once: the calls made
+// meanwhile return the info known so far instead of waiting for it.
 func (h *httpClient) Info(ctx context.Context) (*chain.Info, error) {
-	return h.chainInfo, nil
+	info, refresh := h.startRefresh()
+	if !refresh {
+		return info, nil
+	}
+	return h.refreshInfo(ctx, info), nil
+}
+
+// startRefresh returns the known info and whether the caller must refresh it.
+// No other refresh starts until the caller's one ends.
+func (h *httpClient) startRefresh() (*chain.Info, bool) {
+	h.infoLk.Lock()
+	defer h.infoLk.Unlock()
+	if h.refreshing || time.Since(h.infoFetched) < infoRefreshPeriod {
+		return h.chainInfo, false
+	}
+	h.refreshing = true
+	return h.chainInfo, true
+}
+
+// refreshInfo fetches the info updating prev, without holding the lock during
+// the request, and returns the info known after the refresh.
+func (h *httpClient) refreshInfo(ctx context.Context, prev *chain.Info) *chain.Info {
+	ctx, cancel := context.WithTimeout(ctx, infoRefreshTimeout)
+	defer cancel()
+	info, err := h.fetchChainInfo(ctx, prev.Hash())
+	if err == nil {
+		err = info.CheckUpdate(prev)
+	}
+	h.infoLk.Lock()
+	defer h.infoLk.Unlock()
+	h.refreshing = false
+	// a failed refresh is retried after a period as well
+	h.infoFetched = time.Now()
+	if err != nil {
+		h.l.Warn("http_client", "failed to refresh chain info", "err", err)
+		return h.chainInfo
+	}
+	h.chainInfo = info
+	return info
 }
 
 // RoundAt will return the most recent round of randomness that will be available
-// at time for the current client.
+// at time for the current client. It does not wait for the chain info: when it
+// is due, the refresh runs in the background and the rounds computed after it
+// ends follow the update.
 func (h *httpClient) RoundAt(t time.Time) uint64 {
-	return chain.CurrentRound(t.Unix(), h.chainInfo.Period, h.chainInfo.GenesisTime)
+	info, refresh := h.startRefresh()
+	if refresh {
+		// the refresh is bounded by infoRefreshTimeout and ends with the client
+		go h.refreshInfo(context.Background(), info)
+	}
+	return info.Schedule().CurrentRound(t.Unix())
 }
 
 func (h *httpClient) Close() error {
@@ -2,13 +2,19 @@ package http
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/drand/drand/chain"
 	"github.com/drand/drand/client"
 	"github.com/drand/drand/client/test/http/mock"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/test"
 )
 
 func TestHTTPClient(t *testing.T) {
@@ -144,3 +150,146 @@ func TestHTTPClientClose(t *testing.T) {
 
 	wg.Wait() // wait for the watch to close
 }
+
+func TestHTTPInfoRefresh(t *testing.T) {
+	_, group := test.BatchIdentities(3)
+	info := chain.NewChainInfo(group)
+	var lk sync.Mutex
+	served := info
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lk.Lock()
+		defer lk.Unlock()
+		_ = served.ToJSON(w)
+	}))
+	defer server.Close()
+	serve := func(i *chain.Info) {
+		lk.Lock()
+		served = i
+		lk.Unlock()
+	}
+
+	c, err := New(server.URL, info.Hash(), http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	prev := infoRefreshPeriod
+	infoRefreshPeriod = 0
+	defer func() { infoRefreshPeriod = prev }()
+
+	// a resharing doubles the period from the round 10
+	now := time.Now()
+	changed := chain.NewChainInfo(group)
+	changed.PeriodChanges = []key.PeriodChange{{Round: 10, Period: 2 * info.Period}}
+	if info.Schedule().CurrentRound(now.Unix()) == changed.Schedule().CurrentRound(now.Unix()) {
+		t.Fatal("the period change should move the current round")
+	}
+	serve(changed)
+	if _, err := c.Info(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if got, expected := c.RoundAt(now), changed.Schedule().CurrentRound(now.Unix()); got != expected {
+		t.Fatalf("round after the period change: expected %d, got %d", expected, got)
+	}
+
+	// the period changes already known can't be dropped
+	serve(info)
+	got, err := c.Info(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.Equal(changed) {
+		t.Fatal("an invalid update of the chain info replaced the previous one")
+	}
+}
+
+func TestHTTPInfoRefreshNotBlocking(t *testing.T) {
+	_, group := test.BatchIdentities(3)
+	info := chain.NewChainInfo(group)
+	var blocked int32
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&blocked) == 1 {
+			<-release
+		}
+		_ = info.ToJSON(w)
+	}))
+	defer server.Close()
+	defer close(release)
+
+	c, err := New(server.URL, info.Hash(), http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Close()
+	prev := infoRefreshPeriod
+	infoRefreshPeriod = 0
+	defer func() { infoRefreshPeriod = prev }()
+
+	// a refresh waiting for the server does not block the other callers
+	atomic.StoreInt32(&blocked, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	refreshed := make(chan struct{})
+	go func() {
+		_, _ = c.Info(ctx)
+		close(refreshed)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	done := make(chan struct{})
+	go func() {
+		_, _ = c.Info(context.Background())
+		c.RoundAt(time.Now())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("the chain info was not returned during a refresh")
+	}
+
+	// the refresh ends with the context of its caller
+	cancel()
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("the refresh did not end with its context")
+	}
+}
+
+func TestHTTPRange(t *testing.T) {
+	ranges := true
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/public/range" || !ranges {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"beacons":[{"round":2,"signature":"02"},{"round":3,"signature":"03"}],"next":4}`))
+	}))
+	defer server.Close()
+
+	info := &chain.Info{Period: time.Second, GenesisTime: time.Now().Unix(), PublicKey: key.KeyGroup.Point().Base()}
+	httpClient, err := NewWithInfo(server.URL, info, http.DefaultTransport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer httpClient.Close()
+
+	results, next, err := client.Range(context.Background(), httpClient, 2, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "from=2&to=10&limit=2" {
+		t.Fatal("unexpected query", query)
+	}
+	if len(results) != 2 || results[0].Round() != 2 || results[1].Round() != 3 || next != 4 {
+		t.Fatal("unexpected page", results, next)
+	}
+
+	// a server that does not serve ranges
+	ranges = false
+	if _, _, err := client.Range(context.Background(), httpClient, 2, 10, 2); !errors.Is(err, client.ErrRangeUnsupported) {
+		t.Fatal("expected the range to be unsupported", err)
+	}
+}
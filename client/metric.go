@@ -35,7 +35,10 @@ func (c *watchLatencyMetricClient) startObserve(ctx context.Context) {
 			}
 			// compute the latency metric
 			actual := time.Now().UnixNano()
-			expected := chain.TimeOfRound(c.chainInfo.Period, c.chainInfo.GenesisTime, result.Round()) * 1e9
+			if info, err := c.Info(ctx); err == nil && info != nil {
+				c.chainInfo = info
+			}
+			expected := c.chainInfo.Schedule().TimeOfRound(result.Round()) * 1e9
 			// the labels of the gauge vec must already be set at the registerer level
 			metrics.ClientWatchLatency.Set(float64(actual-expected) / float64(time.Millisecond))
 		case <-ctx.Done():
@@ -49,3 +52,8 @@ func (c *watchLatencyMetricClient) Close() error {
 	c.cancel()
 	return err
 }
+
+// Range returns a page of the beacons of the range from the wrapped client.
+func (c *watchLatencyMetricClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	return Range(ctx, c.Client, from, to, limit)
+}
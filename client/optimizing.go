@@ -261,6 +261,25 @@ LOOP:
 	return res, err
 }
 
+// Range returns a page of the beacons of the range from the fastest client
+// fetching the beacons by range.
+func (oc *optimizingClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	err := ErrRangeUnsupported
+	for _, c := range oc.fastestClients() {
+		results, next, rerr := Range(ctx, c, from, to, limit)
+		if rerr == nil {
+			return results, next, nil
+		}
+		if ctx.Err() != nil {
+			return nil, 0, ctx.Err()
+		}
+		if !errors.Is(rerr, ErrRangeUnsupported) {
+			err = rerr
+		}
+	}
+	return nil, 0, err
+}
+
 // get calls Get on the passed client and returns a requestResult or nil if the context was canceled.
 func get(ctx context.Context, client Client, round uint64) *requestResult {
 	start := time.Now()
@@ -380,7 +399,7 @@ func (oc *optimizingClient) trackWatchResults(info *chain.Info, in chan watchRes
 	latest := uint64(0)
 	for r := range in {
 		round := r.Result.Round()
-		timeOfRound := time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, round), 0)
+		timeOfRound := time.Unix(info.Schedule().TimeOfRound(round), 0)
 		stat := requestStat{
 			client:    r.Client,
 			rtt:       time.Since(timeOfRound),
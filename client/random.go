@@ -7,6 +7,13 @@ type RandomData struct {
 	Random            []byte `json:"randomness,omitempty"`
 	Sig               []byte `json:"signature,omitempty"`
 	PreviousSignature []byte `json:"previous_signature,omitempty"`
+	// ChainHash is the hash of the chain the randomness belongs to, when given
+	// by the server.
+	ChainHash []byte `json:"chain_hash,omitempty"`
+	// Contributors is the bitmap of the indices of the nodes whose partial
+	// signature was aggregated, when given by the server. It is not covered
+	// by the signature.
+	Contributors []byte `json:"contributors,omitempty"`
 }
 
 // Round provides access to the round associatted with this random data.
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"errors"
+)
+
+// RangeClient is implemented by the clients able to return the beacons of a
+// range of rounds by pages. The next round is the one from which to request
+// the next page, zero if the page is the last one of the range.
+type RangeClient interface {
+	Range(ctx context.Context, from, to uint64, limit int) (results []Result, next uint64, err error)
+}
+
+// ErrRangeUnsupported is returned when a client can't fetch the beacons by
+// range.
+var ErrRangeUnsupported = errors.New("the client does not fetch the beacons by range")
+
+// Range returns a page of the beacons of the range of rounds from the client,
+// or ErrRangeUnsupported if it does not fetch the beacons by range. The clients
+// made by New forward the ranges to the clients they wrap, verifying them.
+func Range(ctx context.Context, c Client, from, to uint64, limit int) ([]Result, uint64, error) {
+	rc, ok := c.(RangeClient)
+	if !ok {
+		return nil, 0, ErrRangeUnsupported
+	}
+	return rc.Range(ctx, from, to, limit)
+}
@@ -0,0 +1,260 @@
+// Package redis provides a drand client sharing the randomness it fetches
+// through a Redis server. It is meant for HTTP relays deployed behind a load
+// balancer: all the relays share the same cache and, for each round, only one
+// of them fetches the beacon from the upstream drand nodes.
+package redis
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	goredis "github.com/go-redis/redis/v7"
+)
+
+// pollInterval is the time between two lookups in the shared cache while
+// another instance is fetching a round.
+const pollInterval = 50 * time.Millisecond
+
+// keyPrefix is prepended to all the keys written by drand
+const keyPrefix = "drand"
+
+// setLatest replaces the latest round of the hash at KEYS[1] with the round
+// ARGV[1] of data ARGV[2], unless it holds a later round already.
+var setLatest = goredis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], 'round') or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('HSET', KEYS[1], 'round', ARGV[1], 'data', ARGV[2])
+end
+return 0
+`)
+
+// NewClient wraps c so that the results it fetches are stored in the Redis
+// server behind rdb, and looked up there before reaching c.
+func NewClient(c client.Client, rdb *goredis.Client) client.Client {
+	return &redisClient{
+		Client: c,
+		rdb:    rdb,
+		log:    log.DefaultLogger(),
+	}
+}
+
+type redisClient struct {
+	client.Client
+	rdb *goredis.Client
+	log log.Logger
+
+	sync.Mutex
+	// info is the info of the chain, loaded by the first call. The results
+	// read from the cache are verified against it.
+	info *chain.Info
+	// prefix contains the chain hash so multiple chains can share a server
+	prefix string
+}
+
+// SetLog configures the client log output
+func (r *redisClient) SetLog(l log.Logger) {
+	r.log = l
+}
+
+// String returns the name of this client.
+func (r *redisClient) String() string {
+	return fmt.Sprintf("%s.(+redis cache)", r.Client)
+}
+
+// init loads the chain info on the first call and returns it with the prefix
+// of the keys of the chain.
+func (r *redisClient) init(ctx context.Context) (*chain.Info, string, error) {
+	r.Lock()
+	defer r.Unlock()
+	if r.info != nil {
+		return r.info, r.prefix, nil
+	}
+	info, err := r.Client.Info(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+	r.info = info
+	r.prefix = keyPrefix + ":" + hex.EncodeToString(info.Hash())
+	return r.info, r.prefix, nil
+}
+
+func roundKey(prefix string, round uint64) string {
+	return fmt.Sprintf("%s:%d", prefix, round)
+}
+
+func latestKey(prefix string) string {
+	return prefix + ":latest"
+}
+
+// Get returns the randomness at `round` from the shared cache if present.
+// Otherwise, only one of the clients sharing the cache fetches it while the
+// others wait for it to appear in the cache, up to one period. The latest
+// randomness is read from its own key, as long as it is the current round.
+// The results read from the cache are verified against the chain info.
+func (r *redisClient) Get(ctx context.Context, round uint64) (client.Result, error) {
+	info, prefix, err := r.init(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rdb := r.rdb.WithContext(ctx)
+	latest := round == 0
+	if latest {
+		round = r.Client.RoundAt(time.Now())
+		buff, err := rdb.HGet(latestKey(prefix), "data").Bytes()
+		if res := r.decode(info, buff, err); res != nil && res.Round() >= round {
+			return res, nil
+		}
+	}
+	if res := r.tryGet(rdb, info, prefix, round); res != nil {
+		return res, nil
+	}
+	owner, err := rdb.SetNX(roundKey(prefix, round)+":lock", 1, info.Period).Result()
+	if err != nil {
+		r.log.Warn("redis_client", "unable to lock round", "round", round, "err", err)
+		owner = true
+	}
+	if !owner {
+		if res := r.wait(ctx, rdb, info, prefix, round); res != nil {
+			return res, nil
+		}
+	}
+	if latest {
+		round = 0
+	}
+	res, err := r.Client.Get(ctx, round)
+	if err == nil && res != nil {
+		r.add(rdb, prefix, res)
+	}
+	return res, err
+}
+
+// Watch returns new randomness as it becomes available and shares it with
+// the other clients.
+func (r *redisClient) Watch(ctx context.Context) <-chan client.Result {
+	in := r.Client.Watch(ctx)
+	out := make(chan client.Result)
+	go func() {
+		defer close(out)
+		_, prefix, err := r.init(ctx)
+		if err != nil {
+			r.log.Warn("redis_client", "unable to load chain info", "err", err)
+		}
+		rdb := r.rdb.WithContext(ctx)
+		for res := range in {
+			if prefix != "" {
+				r.add(rdb, prefix, res)
+			}
+			out <- res
+		}
+	}()
+	return out
+}
+
+// wait polls the shared cache until the round is present or one period
+// elapsed.
+func (r *redisClient) wait(ctx context.Context, rdb *goredis.Client, info *chain.Info, prefix string, round uint64) *client.RandomData {
+	timeout := time.NewTimer(info.Period)
+	defer timeout.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if res := r.tryGet(rdb, info, prefix, round); res != nil {
+				return res
+			}
+		case <-timeout.C:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+func (r *redisClient) tryGet(rdb *goredis.Client, info *chain.Info, prefix string, round uint64) *client.RandomData {
+	buff, err := rdb.Get(roundKey(prefix, round)).Bytes()
+	res := r.decode(info, buff, err)
+	if res != nil && res.Round() != round {
+		r.log.Warn("redis_client", "cached round mismatch", "round", round, "cached", res.Round())
+		return nil
+	}
+	return res
+}
+
+// decode returns the result read from the cache if it verifies against the
+// chain info, nil otherwise.
+func (r *redisClient) decode(info *chain.Info, buff []byte, err error) *client.RandomData {
+	if err != nil {
+		if !errors.Is(err, goredis.Nil) {
+			r.log.Warn("redis_client", "unable to read cache", "err", err)
+		}
+		return nil
+	}
+	res := new(client.RandomData)
+	if err := json.Unmarshal(buff, res); err != nil {
+		r.log.Warn("redis_client", "invalid cached round", "err", err)
+		return nil
+	}
+	// the verification can't share the public key with the other calls
+	ipk := info.PublicKey.Clone()
+	if err := key.Scheme.VerifyRecovered(ipk, info.Message(res.Rnd, res.PreviousSignature), res.Sig); err != nil {
+		r.log.Warn("redis_client", "invalid cached round", "round", res.Rnd, "err", err)
+		return nil
+	}
+	res.Random = info.Randomness(res.Sig)
+	return res
+}
+
+// add stores the result in the cache, and as the latest result unless a
+// later one is stored. The results without their previous signature can't be
+// verified by the other clients so they are not stored.
+func (r *redisClient) add(rdb *goredis.Client, prefix string, res client.Result) {
+	rd, ok := res.(*client.RandomData)
+	if !ok {
+		rd = &client.RandomData{
+			Rnd:    res.Round(),
+			Random: res.Randomness(),
+			Sig:    res.Signature(),
+		}
+		if rp, ok := res.(interface{ PreviousSignature() []byte }); ok {
+			rd.PreviousSignature = rp.PreviousSignature()
+		}
+	}
+	if rd.PreviousSignature == nil {
+		return
+	}
+	buff, err := json.Marshal(rd)
+	if err != nil {
+		return
+	}
+	// beacons never change so they can be kept until evicted by the server
+	if err := rdb.Set(roundKey(prefix, rd.Rnd), buff, 0).Err(); err != nil {
+		r.log.Warn("redis_client", "unable to store round", "round", rd.Rnd, "err", err)
+	}
+	if err := setLatest.Run(rdb, []string{latestKey(prefix)}, rd.Rnd, buff).Err(); err != nil && !errors.Is(err, goredis.Nil) {
+		r.log.Warn("redis_client", "unable to store latest round", "round", rd.Rnd, "err", err)
+	}
+}
+
+// Range returns a page of the beacons of the range from the wrapped client.
+func (r *redisClient) Range(ctx context.Context, from, to uint64, limit int) ([]client.Result, uint64, error) {
+	return client.Range(ctx, r.Client, from, to, limit)
+}
+
+// Close closes the wrapped client and the connection to the Redis server.
+func (r *redisClient) Close() error {
+	err := r.Client.Close()
+	if rerr := r.rdb.Close(); err == nil {
+		err = rerr
+	}
+	return err
+}
@@ -0,0 +1,187 @@
+package redis_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/client/redis"
+	"github.com/drand/drand/client/test/result/mock"
+	goredis "github.com/go-redis/redis/v7"
+)
+
+// upstream is a client counting how many times the randomness is fetched.
+type upstream struct {
+	sync.Mutex
+	info    *chain.Info
+	results []mock.Result
+	gets    int
+	delay   time.Duration
+}
+
+func (u *upstream) Get(ctx context.Context, round uint64) (client.Result, error) {
+	u.Lock()
+	u.gets++
+	u.Unlock()
+	time.Sleep(u.delay)
+	if round == 0 {
+		round = u.RoundAt(time.Now())
+	}
+	r := u.results[round-1]
+	return &r, nil
+}
+
+func (u *upstream) Watch(ctx context.Context) <-chan client.Result {
+	ch := make(chan client.Result, 1)
+	r, _ := u.Get(ctx, 0)
+	ch <- r
+	close(ch)
+	return ch
+}
+
+func (u *upstream) Info(ctx context.Context) (*chain.Info, error) {
+	// each relay gets its own copy, as from a remote node
+	info := *u.info
+	info.PublicKey = u.info.PublicKey.Clone()
+	return &info, nil
+}
+
+func (u *upstream) RoundAt(t time.Time) uint64 {
+	return chain.CurrentRound(t.Unix(), u.info.Period, u.info.GenesisTime)
+}
+
+func (u *upstream) Close() error {
+	return nil
+}
+
+func (u *upstream) count() int {
+	u.Lock()
+	defer u.Unlock()
+	return u.gets
+}
+
+func newRelays(t *testing.T, n int) ([]client.Client, *upstream, *miniredis.Miniredis) {
+	t.Helper()
+	srv, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(srv.Close)
+	info, results := mock.VerifiableResults(5)
+	// the current round is the last one
+	info.Period = time.Minute
+	info.GenesisTime = time.Now().Unix() - 60*int64(len(results)-1) - 1
+	up := &upstream{
+		info:    info,
+		results: results,
+		delay:   100 * time.Millisecond,
+	}
+	relays := make([]client.Client, n)
+	for i := range relays {
+		relays[i] = redis.NewClient(up, goredis.NewClient(&goredis.Options{Addr: srv.Addr()}))
+	}
+	return relays, up, srv
+}
+
+func TestRedisSharedGet(t *testing.T) {
+	relays, up, _ := newRelays(t, 3)
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	results := make([]client.Result, len(relays))
+	for i, r := range relays {
+		wg.Add(1)
+		go func(i int, r client.Client) {
+			defer wg.Done()
+			res, err := r.Get(ctx, 0)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			results[i] = res
+		}(i, r)
+	}
+	wg.Wait()
+	if up.count() != 1 {
+		t.Fatalf("upstream fetched %d times instead of once", up.count())
+	}
+	for _, res := range results {
+		if res == nil || res.Round() != results[0].Round() || !bytes.Equal(res.Signature(), results[0].Signature()) {
+			t.Fatal("relays returned different results")
+		}
+	}
+
+	// a specific round is also only fetched once
+	if _, err := relays[0].Get(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	res, err := relays[1].Get(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Round() != 1 || up.count() != 2 {
+		t.Fatalf("round %d fetched with %d upstream calls", res.Round(), up.count())
+	}
+}
+
+func TestRedisWatchShares(t *testing.T) {
+	relays, up, _ := newRelays(t, 2)
+	ctx := context.Background()
+
+	var watched client.Result
+	for r := range relays[0].Watch(ctx) {
+		watched = r
+	}
+	if watched == nil {
+		t.Fatal("no result watched")
+	}
+	res, err := relays[1].Get(ctx, watched.Round())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Signature(), watched.Signature()) || up.count() != 1 {
+		t.Fatal("watched result not shared")
+	}
+}
+
+func TestRedisLatestAndVerified(t *testing.T) {
+	relays, up, srv := newRelays(t, 2)
+	ctx := context.Background()
+	prefix := "drand:" + hex.EncodeToString(up.info.Hash())
+
+	// the latest round is read from the cache without fetching it again
+	for range relays[0].Watch(ctx) {
+	}
+	res, err := relays[1].Get(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Round() != uint64(len(up.results)) || up.count() != 1 {
+		t.Fatalf("latest round %d fetched with %d upstream calls", res.Round(), up.count())
+	}
+
+	// a cached result not verifying against the chain info is ignored
+	forged := up.results[1]
+	forged.Sig = up.results[2].Sig
+	buff, err := json.Marshal(&client.RandomData{Rnd: forged.Rnd, Sig: forged.Sig, PreviousSignature: forged.PSig})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.Set(prefix+":2", string(buff)); err != nil {
+		t.Fatal(err)
+	}
+	res, err = relays[1].Get(ctx, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(res.Signature(), up.results[1].Sig) || up.count() != 2 {
+		t.Fatal("forged cached result returned")
+	}
+}
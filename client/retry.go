@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/log"
+)
+
+const (
+	// defaultRetries is the number of times a failed call is retried.
+	defaultRetries = 3
+	// defaultRetryPeriod stands for the period of the chain until the chain
+	// info is known.
+	defaultRetryPeriod = time.Second * 30
+	// minRetryBackoff is the lower bound of the default first backoff.
+	minRetryBackoff = time.Millisecond * 50
+)
+
+// retryPolicy is how the calls failing are retried. The zero values select
+// the defaults, relative to the period of the chain: the first backoff is a
+// tenth of the period, doubling up to the period, and each attempt is given
+// two periods so that a call waiting for the next round completes.
+type retryPolicy struct {
+	// retries is the number of times a failed call is retried, negative to
+	// never retry.
+	retries int
+	// minBackoff and maxBackoff bound the time waited before a retry.
+	minBackoff, maxBackoff time.Duration
+	// timeout bounds each attempt, negative for no timeout.
+	timeout time.Duration
+}
+
+// withPeriod returns the policy with the defaults resolved for a chain of the
+// given period.
+func (p retryPolicy) withPeriod(period time.Duration) retryPolicy {
+	if p.retries == 0 {
+		p.retries = defaultRetries
+	}
+	if p.minBackoff <= 0 {
+		p.minBackoff = period / 10
+		if p.minBackoff < minRetryBackoff {
+			p.minBackoff = minRetryBackoff
+		}
+	}
+	if p.maxBackoff <= 0 {
+		p.maxBackoff = period
+	}
+	if p.maxBackoff < p.minBackoff {
+		p.maxBackoff = p.minBackoff
+	}
+	if p.timeout == 0 {
+		p.timeout = 2 * period
+	}
+	return p
+}
+
+// newRetryingClient wraps a client so that its `Get` and `Info` calls are
+// retried with an exponential backoff when they fail, instead of returning
+// the first error. info, if known, gives the period of the chain the default
+// policy is relative to, otherwise it is fetched with the first `Get`.
+func newRetryingClient(c Client, info *chain.Info, policy retryPolicy) *retryingClient {
+	r := &retryingClient{
+		Client: c,
+		policy: policy,
+		log:    log.DefaultLogger(),
+	}
+	if info != nil {
+		r.period = info.Period
+	}
+	return r
+}
+
+type retryingClient struct {
+	Client
+	policy retryPolicy
+	log    log.Logger
+
+	sync.Mutex
+	// period is the period of the chain, zero until it is known
+	period time.Duration
+}
+
+// SetLog configures the client log output
+func (r *retryingClient) SetLog(l log.Logger) {
+	r.log = l
+}
+
+// String returns the name of this client.
+func (r *retryingClient) String() string {
+	return fmt.Sprintf("%s.(+retry)", r.Client)
+}
+
+// Get returns the randomness at `round`, retrying while it fails.
+func (r *retryingClient) Get(ctx context.Context, round uint64) (Result, error) {
+	var res Result
+	err := r.retry(ctx, r.resolve(ctx), "get", func(ctx context.Context) error {
+		var err error
+		res, err = r.Client.Get(ctx, round)
+		return err
+	})
+	return res, err
+}
+
+// Range returns a page of the beacons of the range, retrying while it fails.
+func (r *retryingClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	var results []Result
+	var next uint64
+	err := r.retry(ctx, r.resolve(ctx), "range", func(ctx context.Context) error {
+		var err error
+		results, next, err = Range(ctx, r.Client, from, to, limit)
+		return err
+	})
+	return results, next, err
+}
+
+// Info returns the parameters of the chain, retrying while it fails.
+func (r *retryingClient) Info(ctx context.Context) (*chain.Info, error) {
+	r.Lock()
+	period := r.period
+	r.Unlock()
+	if period == 0 {
+		period = defaultRetryPeriod
+	}
+	var info *chain.Info
+	err := r.retry(ctx, r.policy.withPeriod(period), "info", func(ctx context.Context) error {
+		var err error
+		info, err = r.Client.Info(ctx)
+		return err
+	})
+	if err == nil {
+		r.Lock()
+		r.period = info.Period
+		r.Unlock()
+	}
+	return info, err
+}
+
+// resolve returns the policy for the period of the chain, fetching the chain
+// info if the period is not known yet.
+func (r *retryingClient) resolve(ctx context.Context) retryPolicy {
+	r.Lock()
+	period := r.period
+	r.Unlock()
+	if period == 0 {
+		info, err := r.Client.Info(ctx)
+		if err != nil {
+			return r.policy.withPeriod(defaultRetryPeriod)
+		}
+		period = info.Period
+		r.Lock()
+		r.period = period
+		r.Unlock()
+	}
+	return r.policy.withPeriod(period)
+}
+
+func (r *retryingClient) retry(ctx context.Context, p retryPolicy, call string, fn func(context.Context) error) error {
+	backoff := p.minBackoff
+	for attempt := 0; ; attempt++ {
+		err := attemptWithTimeout(ctx, p.timeout, fn)
+		if err == nil || attempt >= p.retries || ctx.Err() != nil || errors.Is(err, ErrRangeUnsupported) {
+			return err
+		}
+		r.log.Debug("retrying_client", "call failed", "call", call, "attempt", attempt+1, "retry_in", backoff, "err", err)
+		t := time.NewTimer(backoff)
+		select {
+		case <-t.C:
+		case <-ctx.Done():
+			t.Stop()
+			return err
+		}
+		if backoff *= 2; backoff > p.maxBackoff {
+			backoff = p.maxBackoff
+		}
+	}
+}
+
+func attemptWithTimeout(ctx context.Context, timeout time.Duration, fn func(context.Context) error) error {
+	if timeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return fn(ctx)
+}
@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/client/test/result/mock"
+)
+
+// failingClient fails its first calls to `Get`.
+type failingClient struct {
+	*MockClient
+	sync.Mutex
+	failures int
+	calls    int
+}
+
+func (f *failingClient) Get(ctx context.Context, round uint64) (Result, error) {
+	f.Lock()
+	f.calls++
+	fail := f.calls <= f.failures
+	f.Unlock()
+	if fail {
+		return nil, errors.New("unavailable")
+	}
+	return f.MockClient.Get(ctx, round)
+}
+
+func TestRetryGet(t *testing.T) {
+	info := &chain.Info{Period: time.Second}
+	c := &failingClient{MockClient: &MockClient{Results: []mock.Result{mock.NewMockResult(1)}}, failures: 2}
+	r := newRetryingClient(c, info, retryPolicy{})
+	start := time.Now()
+	res, err := r.Get(context.Background(), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Round() != 1 || c.calls != 3 {
+		t.Fatalf("expected round 1 after 3 calls, got round %d after %d calls", res.Round(), c.calls)
+	}
+	// the default backoff is relative to the period: 100ms then 200ms
+	if elapsed := time.Since(start); elapsed < 300*time.Millisecond {
+		t.Fatalf("retried after %s, before the backoff", elapsed)
+	}
+
+	// the error of the last attempt is returned
+	c = &failingClient{MockClient: &MockClient{}, failures: 10}
+	r = newRetryingClient(c, info, retryPolicy{retries: 2, minBackoff: time.Millisecond, maxBackoff: time.Millisecond})
+	if _, err := r.Get(context.Background(), 1); err == nil {
+		t.Fatal("expected an error")
+	}
+	if c.calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", c.calls)
+	}
+
+	// a negative number of retries returns the first error
+	c = &failingClient{MockClient: &MockClient{}, failures: 10}
+	r = newRetryingClient(c, info, retryPolicy{retries: -1})
+	if _, err := r.Get(context.Background(), 1); err == nil || c.calls != 1 {
+		t.Fatalf("expected an error after 1 call, got %v after %d calls", err, c.calls)
+	}
+}
+
+func TestRetryCallTimeout(t *testing.T) {
+	c := &MockClient{Delay: time.Second}
+	for i := 0; i < 3; i++ {
+		c.Results = append(c.Results, mock.NewMockResult(1))
+	}
+	r := newRetryingClient(c, &chain.Info{Period: time.Second}, retryPolicy{
+		retries:    1,
+		minBackoff: time.Millisecond,
+		maxBackoff: time.Millisecond,
+		timeout:    50 * time.Millisecond,
+	})
+	start := time.Now()
+	if _, err := r.Get(context.Background(), 1); err == nil {
+		t.Fatal("expected the attempts to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("attempts not bounded by the call timeout: %s", elapsed)
+	}
+}
+
+func TestRetryPolicyDefaults(t *testing.T) {
+	p := retryPolicy{}.withPeriod(30 * time.Second)
+	if p.retries != defaultRetries || p.minBackoff != 3*time.Second || p.maxBackoff != 30*time.Second || p.timeout != time.Minute {
+		t.Fatalf("unexpected default policy %+v", p)
+	}
+	p = retryPolicy{timeout: -1}.withPeriod(100 * time.Millisecond)
+	if p.minBackoff != minRetryBackoff || p.maxBackoff != 100*time.Millisecond || p.timeout != -1 {
+		t.Fatalf("unexpected policy %+v", p)
+	}
+}
@@ -1,21 +1,25 @@
 package client
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"sync"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 )
 
 // newVerifyingClient wraps a client to perform `chain.Verify` on emitted results.
-func newVerifyingClient(c Client, previousResult Result, strict bool) Client {
+func newVerifyingClient(c Client, previousResult Result, strict bool, light *lightTrust, fingerprint string) Client {
 	return &verifyingClient{
 		Client:         c,
 		indirectClient: c,
 		pointOfTrust:   previousResult,
 		strict:         strict,
+		light:          light,
+		fingerprint:    fingerprint,
 	}
 }
 
@@ -30,6 +34,10 @@ type verifyingClient struct {
 	pointOfTrust Result
 	potLk        sync.Mutex
 	strict       bool
+	// light is only set when using light verification
+	light *lightTrust
+	// fingerprint is the pinned fingerprint of the public key, if any
+	fingerprint string
 
 	log log.Logger
 }
@@ -71,6 +79,11 @@ func (v *verifyingClient) Watch(ctx context.Context) <-chan Result {
 	go func() {
 		defer close(outCh)
 		for r := range inCh {
+			// the info is read again for each round so that a change of
+			// message format decided by a resharing is followed
+			if latest, err := v.indirectClient.Info(ctx); err == nil && latest != nil {
+				info = latest
+			}
 			if err := v.verify(ctx, info, asRandomData(r)); err != nil {
 				v.log.Warn("verifying_client", "skipping invalid watch round", "round", r.Round(), "err", err)
 				continue
@@ -81,6 +94,43 @@ func (v *verifyingClient) Watch(ctx context.Context) <-chan Result {
 	return outCh
 }
 
+// Range returns a page of the beacons of the range, each of them verified. The
+// consecutive beacons of the page are verified from the signature of the one
+// before them.
+func (v *verifyingClient) Range(ctx context.Context, from, to uint64, limit int) ([]Result, uint64, error) {
+	info, err := v.indirectClient.Info(ctx)
+	if err != nil {
+		return nil, 0, err
+	}
+	results, next, err := Range(ctx, v.Client, from, to, limit)
+	if err != nil {
+		return nil, 0, err
+	}
+	verified := make([]Result, 0, len(results))
+	var prev *RandomData
+	for _, r := range results {
+		rd := asRandomData(r)
+		if prev != nil && rd.Round() == prev.Round()+1 && v.light == nil {
+			switch {
+			case !bytes.Equal(rd.PreviousSignature, prev.Signature()):
+				err = fmt.Errorf("round %d of the range is not derived from the round before", rd.Round())
+			case len(rd.ChainHash) > 0 && !bytes.Equal(rd.ChainHash, info.Hash()):
+				err = fmt.Errorf("round %d is from another chain: %x", rd.Round(), rd.ChainHash)
+			default:
+				err = v.verifyFrom(info, rd, prev.Signature())
+			}
+		} else {
+			err = v.verify(ctx, info, rd)
+		}
+		if err != nil {
+			return nil, 0, err
+		}
+		verified = append(verified, rd)
+		prev = rd
+	}
+	return verified, next, nil
+}
+
 type resultWithPreviousSignature interface {
 	PreviousSignature() []byte
 }
@@ -145,7 +195,7 @@ func (v *verifyingClient) getTrustedPreviousSignature(ctx context.Context, round
 		b.Signature = next.Signature()
 
 		ipk := info.PublicKey.Clone()
-		if err := chain.VerifyBeacon(ipk, &b); err != nil {
+		if err := key.Scheme.VerifyRecovered(ipk, info.Message(b.Round, b.PreviousSig), b.Signature); err != nil {
 			v.log.Warn("verifying_client", "failed to verify value", "b", b, "err", err)
 			return []byte{}, fmt.Errorf("verifying beacon: %w", err)
 		}
@@ -163,7 +213,52 @@ func (v *verifyingClient) getTrustedPreviousSignature(ctx context.Context, round
 	return trustPrevSig, nil
 }
 
+// lightTrust is the latest verified result, shared by all the verifying
+// clients of a client using light verification.
+type lightTrust struct {
+	sync.Mutex
+	last Result
+}
+
+// verifyLight verifies a result using only the chain info and the latest
+// verified result, which it updates.
+func (v *verifyingClient) verifyLight(info *chain.Info, r *RandomData) error {
+	if r.PreviousSignature == nil {
+		return fmt.Errorf("light verification of round %d: missing previous signature", r.Round())
+	}
+	v.light.Lock()
+	defer v.light.Unlock()
+	last := v.light.last
+	if last != nil && r.Round() == last.Round()+1 && !bytes.Equal(r.PreviousSignature, last.Signature()) {
+		return fmt.Errorf("light verification of round %d: not derived from the last verified round", r.Round())
+	}
+	b := chain.Beacon{
+		PreviousSig: r.PreviousSignature,
+		Round:       r.Round(),
+		Signature:   r.Signature(),
+	}
+	ipk := info.PublicKey.Clone()
+	if err := key.Scheme.VerifyRecovered(ipk, info.Message(b.Round, b.PreviousSig), b.Signature); err != nil {
+		return fmt.Errorf("verification of %v failed: %w", b, err)
+	}
+	r.Random = info.Randomness(r.Sig)
+	if last == nil || r.Round() > last.Round() {
+		v.light.last = r
+	}
+	return nil
+}
+
 func (v *verifyingClient) verify(ctx context.Context, info *chain.Info, r *RandomData) (err error) {
+	if len(r.ChainHash) > 0 && !bytes.Equal(r.ChainHash, info.Hash()) {
+		return fmt.Errorf("round %d is from another chain: %x", r.Round(), r.ChainHash)
+	}
+	if v.fingerprint != "" && info.Fingerprint() != v.fingerprint {
+		return fmt.Errorf("refusing to verify round %d: key fingerprint %s differs from the pinned %s",
+			r.Round(), info.Fingerprint(), v.fingerprint)
+	}
+	if v.light != nil {
+		return v.verifyLight(info, r)
+	}
 	ps := r.PreviousSignature
 	if v.strict || r.PreviousSignature == nil {
 		ps, err = v.getTrustedPreviousSignature(ctx, r.Round())
@@ -171,7 +266,12 @@ func (v *verifyingClient) verify(ctx context.Context, info *chain.Info, r *Rando
 			return
 		}
 	}
+	return v.verifyFrom(info, r, ps)
+}
 
+// verifyFrom verifies the result as derived from the given previous signature,
+// already trusted.
+func (v *verifyingClient) verifyFrom(info *chain.Info, r *RandomData, ps []byte) error {
 	b := chain.Beacon{
 		PreviousSig: ps,
 		Round:       r.Round(),
@@ -179,11 +279,11 @@ func (v *verifyingClient) verify(ctx context.Context, info *chain.Info, r *Rando
 	}
 
 	ipk := info.PublicKey.Clone()
-	if err = chain.VerifyBeacon(ipk, &b); err != nil {
+	if err := key.Scheme.VerifyRecovered(ipk, info.Message(b.Round, b.PreviousSig), b.Signature); err != nil {
 		return fmt.Errorf("verification of %v failed: %w", b, err)
 	}
 
-	r.Random = chain.RandomnessFromSignature(r.Sig)
+	r.Random = info.Randomness(r.Sig)
 	return nil
 }
 
@@ -1,12 +1,16 @@
 package client_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/drand/drand/client"
 	"github.com/drand/drand/client/test/result/mock"
+	"github.com/drand/drand/key"
+	"golang.org/x/crypto/blake2b"
 )
 
 func mockClientWithVerifiableResults(n int) (client.Client, []mock.Result, error) {
@@ -52,3 +56,190 @@ func TestVerifyWithOldVerifiedResult(t *testing.T) {
 		t.Fatal("expected to get result.", results[4].Round(), res.Round(), fmt.Sprintf("%v", c))
 	}
 }
+
+func TestVerifyLight(t *testing.T) {
+	info, results := mock.VerifiableResults(5)
+	mc := client.MockClient{Results: results, StrictRounds: true}
+	c, err := client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info), &mc},
+		client.WithChainInfo(info),
+		client.WithVerifiedResult(&results[1]),
+		client.WithLightVerification(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// rounds are verified on their own, without loading the previous ones
+	res, err := c.Get(context.Background(), results[4].Round())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Round() != results[4].Round() {
+		t.Fatal("expected to get result.", results[4].Round(), res.Round())
+	}
+
+	// the round following the last verified one must be derived from it
+	forked := mock.Result{Rnd: results[2].Round(), Sig: []byte("not the signature of the chain")}
+	c2, err := client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info), &mc},
+		client.WithChainInfo(info),
+		client.WithVerifiedResult(&forked),
+		client.WithLightVerification(),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c2.Get(context.Background(), results[3].Round()); err == nil {
+		t.Fatal("expected round not derived from the verified result to be rejected")
+	}
+
+	_, err = client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info), &mc},
+		client.WithChainInfo(info),
+		client.WithLightVerification(),
+		client.WithFullChainVerification(),
+	)
+	if err == nil {
+		t.Fatal("expected light and full verification to be exclusive")
+	}
+}
+
+// chainHashClient stamps the results of the mock client with a chain hash.
+type chainHashClient struct {
+	client.MockClient
+	hash []byte
+}
+
+func (c *chainHashClient) Get(ctx context.Context, round uint64) (client.Result, error) {
+	r, err := c.MockClient.Get(ctx, round)
+	if err != nil {
+		return nil, err
+	}
+	m := r.(*mock.Result)
+	return &client.RandomData{Rnd: m.Rnd, Random: m.Rand, Sig: m.Sig, PreviousSignature: m.PSig, ChainHash: c.hash}, nil
+}
+
+func TestVerifyChainHash(t *testing.T) {
+	info, results := mock.VerifiableResults(3)
+	for _, hash := range [][]byte{info.Hash(), []byte("another chain")} {
+		mc := &chainHashClient{MockClient: client.MockClient{Results: results, StrictRounds: true}, hash: hash}
+		c, err := client.Wrap(
+			[]client.Client{client.MockClientWithInfo(info), mc},
+			client.WithChainInfo(info),
+			client.WithCacheSize(0),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Get(context.Background(), results[1].Round())
+		if valid := bytes.Equal(hash, info.Hash()); valid != (err == nil) {
+			t.Fatalf("chain hash %x accepted: %v (%v)", hash, err == nil, err)
+		}
+	}
+}
+
+func TestVerifyFingerprint(t *testing.T) {
+	info, results := mock.VerifiableResults(3)
+	for _, fingerprint := range []string{info.Fingerprint(), "SHA256:another key"} {
+		mc := &client.MockClient{Results: results, StrictRounds: true}
+		c, err := client.Wrap(
+			[]client.Client{client.MockClientWithInfo(info), mc},
+			client.WithFingerprint(fingerprint),
+			client.WithCacheSize(0),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = c.Get(context.Background(), results[1].Round())
+		if valid := fingerprint == info.Fingerprint(); valid != (err == nil) {
+			t.Fatalf("fingerprint %s accepted: %v (%v)", fingerprint, err == nil, err)
+		}
+	}
+
+	// the pinned fingerprint must match the chain info given
+	_, err := client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info)},
+		client.WithChainInfo(info),
+		client.WithFingerprint("SHA256:another key"),
+	)
+	if err == nil {
+		t.Fatal("expected an error for a chain info with another key")
+	}
+	if _, err := client.Wrap([]client.Client{client.MockClientWithInfo(info)}, client.WithFingerprint("another key")); err == nil {
+		t.Fatal("expected an error for an invalid fingerprint")
+	}
+}
+
+func TestVerifyDigest(t *testing.T) {
+	info, results := mock.VerifiableResults(3)
+	info.Digest = key.DigestBLAKE2b
+	mc := &client.MockClient{Results: results, StrictRounds: true}
+	c, err := client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info), mc},
+		client.WithChainInfo(info),
+		client.WithCacheSize(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := c.Get(context.Background(), results[1].Round())
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the randomness is derived with the digest of the chain
+	expected := blake2b.Sum256(res.Signature())
+	if !bytes.Equal(res.Randomness(), expected[:]) {
+		t.Fatalf("randomness %x is not the blake2b digest of the signature %x", res.Randomness(), expected)
+	}
+}
+
+// rangeMockClient serves the ranges of the results of the mock client.
+type rangeMockClient struct {
+	client.MockClient
+}
+
+func (c *rangeMockClient) Range(_ context.Context, from, to uint64, _ int) ([]client.Result, uint64, error) {
+	var out []client.Result
+	for i := range c.Results {
+		if r := c.Results[i].Round(); r >= from && r <= to {
+			out = append(out, &c.Results[i])
+		}
+	}
+	return out, 0, nil
+}
+
+func TestVerifyRange(t *testing.T) {
+	info, results := mock.VerifiableResults(5)
+	rc := &rangeMockClient{client.MockClient{Results: results, StrictRounds: true}}
+	c, err := client.Wrap(
+		[]client.Client{client.MockClientWithInfo(info), rc},
+		client.WithChainInfo(info),
+		client.WithVerifiedResult(&results[0]),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	page, next, err := client.Range(context.Background(), c, 2, 5, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 4 || next != 0 || page[0].Round() != 2 || page[3].Round() != 5 {
+		t.Fatal("unexpected page", len(page), next)
+	}
+
+	// a beacon of the page that does not verify fails the whole page
+	rc.Results[3].Sig = results[2].Sig
+	if _, _, err := client.Range(context.Background(), c, 2, 5, 0); err == nil {
+		t.Fatal("expected the invalid beacon of the range to be rejected")
+	}
+
+	// clients that do not serve ranges report it
+	mc := client.MockClient{Results: results}
+	c, err = client.Wrap([]client.Client{client.MockClientWithInfo(info), &mc}, client.WithChainInfo(info))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Range(context.Background(), c, 2, 5, 0); !errors.Is(err, client.ErrRangeUnsupported) {
+		t.Fatal("expected the range to be unsupported", err)
+	}
+}
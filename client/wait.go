@@ -0,0 +1,57 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// waitForRoundRetry is the time between two attempts at fetching a round that
+// is expected but not published yet.
+var waitForRoundRetry = time.Second
+
+// WaitForRound blocks until the given round is expected to be produced, then
+// fetches it from `c`, retrying until it is published or the context is done.
+// The result is verified by `c`, as it is by all the clients created with `New`
+// unless `Insecurely` is specified.
+func WaitForRound(ctx context.Context, c Client, round uint64) (Result, error) {
+	if round == 0 {
+		return nil, errors.New("wait for round: round must be positive")
+	}
+	info, err := c.Info(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("wait for round %d: %w", round, err)
+	}
+	at := time.Unix(info.Schedule().TimeOfRound(round), 0)
+	if err := sleepUntil(ctx, at); err != nil {
+		return nil, fmt.Errorf("wait for round %d: %w", round, err)
+	}
+	for {
+		r, err := c.Get(ctx, round)
+		if err == nil && r.Round() == round {
+			return r, nil
+		}
+		if err == nil {
+			err = fmt.Errorf("got round %d", r.Round())
+		}
+		if serr := sleepUntil(ctx, time.Now().Add(waitForRoundRetry)); serr != nil {
+			return nil, fmt.Errorf("wait for round %d: %v (last error: %w)", round, serr, err)
+		}
+	}
+}
+
+func sleepUntil(ctx context.Context, t time.Time) error {
+	d := time.Until(t)
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
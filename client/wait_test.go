@@ -0,0 +1,72 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/client/test/result/mock"
+)
+
+// lateClient publishes each round some time after it is expected.
+type lateClient struct {
+	MockInfoClient
+	delay time.Duration
+	gets  int32
+}
+
+func (l *lateClient) Get(ctx context.Context, round uint64) (Result, error) {
+	atomic.AddInt32(&l.gets, 1)
+	published := time.Unix(chain.TimeOfRound(l.i.Period, l.i.GenesisTime, round), 0).Add(l.delay)
+	if time.Now().Before(published) {
+		return nil, errors.New("round not published yet")
+	}
+	r := mock.NewMockResult(round)
+	return &r, nil
+}
+
+func TestWaitForRound(t *testing.T) {
+	waitForRoundRetry = 50 * time.Millisecond
+	defer func() { waitForRoundRetry = time.Second }()
+
+	info := &chain.Info{Period: time.Second, GenesisTime: time.Now().Unix()}
+	c := &lateClient{MockInfoClient: MockInfoClient{info}, delay: 200 * time.Millisecond}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	round := chain.CurrentRound(time.Now().Unix(), info.Period, info.GenesisTime) + 1
+	r, err := WaitForRound(ctx, c, round)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Round() != round {
+		t.Fatalf("expected round %d, got %d", round, r.Round())
+	}
+	if expected := time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, round), 0); time.Now().Before(expected) {
+		t.Fatal("round returned before its expected time")
+	}
+	if atomic.LoadInt32(&c.gets) < 2 {
+		t.Fatal("unpublished round not requested again")
+	}
+
+	// a past round is returned right away
+	start := time.Now()
+	if _, err := WaitForRound(ctx, c, 1); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("waited for a past round")
+	}
+
+	short, cancelShort := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancelShort()
+	if _, err := WaitForRound(short, c, round+10); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected a deadline error, got %v", err)
+	}
+	if _, err := WaitForRound(ctx, c, 0); err == nil {
+		t.Fatal("round 0 should be rejected")
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/log"
+)
+
+// entropyPool is where the randomness of each new round is mixed.
+type entropyPool interface {
+	// Add mixes the data in the pool, crediting it with `bits` of entropy.
+	Add(data []byte, bits int) error
+	Close() error
+}
+
+// devicePool mixes data into the kernel pool by writing to a random device,
+// crediting it with entropy when supported by the platform.
+type devicePool struct {
+	f *os.File
+}
+
+func openEntropyPool(path string) (entropyPool, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("opening entropy pool: %w", err)
+	}
+	return &devicePool{f: f}, nil
+}
+
+func (d *devicePool) Add(data []byte, bits int) error {
+	if bits == 0 {
+		// writing to the device mixes the data without crediting any entropy
+		_, err := d.f.Write(data)
+		return err
+	}
+	return addEntropy(d.f, data, bits)
+}
+
+func (d *devicePool) Close() error {
+	return d.f.Close()
+}
+
+// FeedEntropy mixes the randomness of each new verified round into the pool
+// until the watch ends.
+func FeedEntropy(ctx context.Context, inst client.Watcher, pool entropyPool, bits int) error {
+	l := log.DefaultLogger()
+	for r := range inst.Watch(ctx) {
+		if err := pool.Add(r.Randomness(), bits); err != nil {
+			return fmt.Errorf("feeding round %d: %w", r.Round(), err)
+		}
+		l.Debug("entropy", "fed", "round", r.Round(), "credited_bits", bits)
+	}
+	return nil
+}
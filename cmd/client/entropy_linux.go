@@ -0,0 +1,34 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// rndAddEntropy is the RNDADDENTROPY ioctl, _IOW('R', 0x03, int[2])
+const rndAddEntropy = 0x40085203
+
+// randPoolInfo mirrors struct rand_pool_info from linux/random.h, sized for
+// one round of randomness.
+type randPoolInfo struct {
+	entropyCount int32
+	bufSize      int32
+	buf          [32]byte
+}
+
+// addEntropy mixes the data into the kernel pool and credits it with the given
+// entropy, which requires CAP_SYS_ADMIN.
+func addEntropy(f *os.File, data []byte, bits int) error {
+	info := randPoolInfo{entropyCount: int32(bits)}
+	if len(data) > len(info.buf) || bits > 8*len(data) {
+		return errors.New("entropy: too much data or entropy credited")
+	}
+	info.bufSize = int32(copy(info.buf[:], data))
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), rndAddEntropy, uintptr(unsafe.Pointer(&info)))
+	if errno != 0 {
+		return errno
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux
+// +build !linux
+
+package main
+
+import (
+	"errors"
+	"os"
+)
+
+func addEntropy(_ *os.File, _ []byte, _ int) error {
+	return errors.New("entropy: crediting entropy is only supported on linux")
+}
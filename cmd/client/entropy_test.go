@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/client/test/result/mock"
+)
+
+type mockWatcher []mock.Result
+
+func (m mockWatcher) Watch(ctx context.Context) <-chan client.Result {
+	ch := make(chan client.Result, len(m))
+	for i := range m {
+		ch <- &m[i]
+	}
+	close(ch)
+	return ch
+}
+
+func TestFeedEntropy(t *testing.T) {
+	dir, err := ioutil.TempDir("", "entropy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	device := path.Join(dir, "random")
+	if err := ioutil.WriteFile(device, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	pool, err := openEntropyPool(device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher := mockWatcher{mock.NewMockResult(1), mock.NewMockResult(2)}
+	if err := FeedEntropy(context.Background(), watcher, pool, 0); err != nil {
+		t.Fatal(err)
+	}
+	pool.Close()
+
+	fed, err := ioutil.ReadFile(device)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := append(watcher[0].Randomness(), watcher[1].Randomness()...)
+	if !bytes.Equal(fed, expected) {
+		t.Fatal("randomness not fed to the pool")
+	}
+
+	if _, err := openEntropyPool(path.Join(dir, "missing")); err == nil {
+		t.Fatal("opening a missing device should fail")
+	}
+}
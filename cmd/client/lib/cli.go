@@ -61,6 +61,13 @@ var (
 		Usage: "Path to a drand group configuration (TOML encoded) or chain info (JSON encoded)," +
 			" can be used instead of `-hash` flag to verify the chain.",
 	}
+	// FingerprintFlag is the CLI flag for the pinned fingerprint of the
+	// distributed public key of the chain.
+	FingerprintFlag = &cli.StringFlag{
+		Name: "fingerprint",
+		Usage: "Fingerprint of the public key of the chain (SHA256:...), as shown by `drand show chain-info --fingerprint`." +
+			" Results are not verified against a chain info with another key.",
+	}
 	// InsecureFlag is the CLI flag to allow autodetection of the chain
 	// information.
 	InsecureFlag = &cli.BoolFlag{
@@ -87,6 +94,7 @@ var ClientFlags = []cli.Flag{
 	CertFlag,
 	HashFlag,
 	GroupConfFlag,
+	FingerprintFlag,
 	InsecureFlag,
 	RelayFlag,
 	PortFlag,
@@ -131,6 +139,13 @@ func Create(c *cli.Context, withInstrumentation bool, opts ...client.Option) (cl
 		}
 		opts = append(opts, client.WithChainHash(hash))
 	}
+	if c.IsSet(FingerprintFlag.Name) {
+		fingerprint := c.String(FingerprintFlag.Name)
+		if info != nil && info.Fingerprint() != fingerprint {
+			return nil, fmt.Errorf("incorrect key fingerprint %s != %s", fingerprint, info.Fingerprint())
+		}
+		opts = append(opts, client.WithFingerprint(fingerprint))
+	}
 	if c.Bool(InsecureFlag.Name) {
 		opts = append(opts, client.Insecurely())
 	}
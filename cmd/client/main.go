@@ -36,6 +36,18 @@ var roundFlag = &cli.IntFlag{
 	Usage: "request randomness for a specific round",
 }
 
+var feedEntropyFlag = &cli.StringFlag{
+	Name: "feed-entropy",
+	Usage: "mix the randomness of each new round into the host entropy pool through the given device," +
+		" e.g. /dev/random",
+}
+
+var entropyCreditFlag = &cli.IntFlag{
+	Name: "entropy-credit",
+	Usage: "bits of entropy to credit the pool with for each round fed (linux only, requires CAP_SYS_ADMIN)." +
+		" Beacons are public: only credit entropy if the output of the chain is not known to an attacker",
+}
+
 var verboseFlag = &cli.BoolFlag{
 	Name:  "verbose",
 	Usage: "print debug-level log messages",
@@ -71,7 +83,7 @@ func main() {
 	app.Usage = "CDN Drand client for loading randomness from an HTTP endpoint"
 	app.Flags = lib.ClientFlags
 	app.Flags = append(app.Flags,
-		watchFlag, roundFlag,
+		watchFlag, roundFlag, feedEntropyFlag, entropyCreditFlag,
 		clientMetricsAddressFlag, clientMetricsGatewayFlag, clientMetricsIDFlag,
 		clientMetricsPushIntervalFlag, verboseFlag)
 	app.Action = Client
@@ -117,6 +129,19 @@ func Client(c *cli.Context) error {
 		return err
 	}
 
+	if c.IsSet(feedEntropyFlag.Name) {
+		bits := c.Int(entropyCreditFlag.Name)
+		if bits < 0 || bits > 256 {
+			return fmt.Errorf("entropy credit must be between 0 and 256 bits")
+		}
+		pool, err := openEntropyPool(c.String(feedEntropyFlag.Name))
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+		return FeedEntropy(context.Background(), apiClient, pool, bits)
+	}
+
 	if c.IsSet(watchFlag.Name) {
 		return Watch(apiClient)
 	}
@@ -0,0 +1,135 @@
+package drand
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/drand/drand/backup"
+	"github.com/drand/drand/core"
+	"github.com/drand/drand/fs"
+	"github.com/drand/drand/key"
+	"github.com/urfave/cli/v2"
+)
+
+var passphraseFileFlag = &cli.StringFlag{
+	Name: "passphrase-file",
+	Usage: "File containing the passphrase used to encrypt or decrypt the backup archive." +
+		" The passphrase can also be given with the DRAND_BACKUP_PASSPHRASE environment variable.",
+}
+
+var overwriteFlag = &cli.BoolFlag{
+	Name:  "force",
+	Usage: "Overwrite the existing state of the node when restoring a backup.",
+}
+
+func loadPassphrase(c *cli.Context) ([]byte, error) {
+	pass := os.Getenv("DRAND_BACKUP_PASSPHRASE")
+	if c.IsSet(passphraseFileFlag.Name) {
+		buff, err := ioutil.ReadFile(c.String(passphraseFileFlag.Name))
+		if err != nil {
+			return nil, err
+		}
+		pass = strings.TrimRight(string(buff), "\r\n")
+	}
+	if pass == "" {
+		return nil, errors.New("no passphrase specified for the backup")
+	}
+	return []byte(pass), nil
+}
+
+func backupFolders(conf *core.Config, store key.Store) backup.Folders {
+	return backup.Folders{
+		Config:     conf.ConfigFolder(),
+		DB:         conf.DBFolder(),
+		ShareParts: key.SharePartFiles(store),
+	}
+}
+
+func backupCreateCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("missing archive file argument")
+	}
+	pass, err := loadPassphrase(c)
+	if err != nil {
+		return err
+	}
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	store, err := daemonStore(c, conf)
+	if err != nil {
+		return err
+	}
+	// the daemon must not modify the state while we archive it
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("drand daemon must be stopped before creating a backup: %w", err)
+	}
+	defer lock.Unlock()
+	return createBackup(conf, store, c.Args().First(), pass)
+}
+
+func createBackup(conf *core.Config, store key.Store, file string, pass []byte) error {
+	fd, err := fs.CreateSecureFile(file)
+	if err != nil {
+		return err
+	}
+	if err := backup.Create(fd, backupFolders(conf, store), pass); err != nil {
+		fd.Close()
+		os.Remove(file)
+		return err
+	}
+	if err := fd.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "drand: backup of %s saved to %s\n", conf.ConfigFolder(), file)
+	return nil
+}
+
+func backupRestoreCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("missing archive file argument")
+	}
+	pass, err := loadPassphrase(c)
+	if err != nil {
+		return err
+	}
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	store, err := daemonStore(c, conf)
+	if err != nil {
+		return err
+	}
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("drand daemon must be stopped before restoring a backup: %w", err)
+	}
+	defer lock.Unlock()
+	if _, err := store.LoadKeyPair(); err == nil && !c.Bool(overwriteFlag.Name) {
+		return fmt.Errorf("a key pair already exists in %s, use --%s to overwrite it", conf.ConfigFolder(), overwriteFlag.Name)
+	}
+	fd, err := os.Open(c.Args().First())
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	if err := backup.Restore(fd, backupFolders(conf, store), pass); err != nil {
+		return err
+	}
+	if key.SharePartFiles(store) != nil {
+		// a share restored in clear is split, and split parts are checked
+		if _, err := store.LoadGroup(); err == nil {
+			if _, err := store.LoadShare(); err != nil {
+				return fmt.Errorf("drand: can't load the restored share: %w", err)
+			}
+		}
+	}
+	fmt.Fprintf(output, "drand: backup %s restored to %s\n", c.Args().First(), conf.ConfigFolder())
+	return nil
+}
@@ -0,0 +1,133 @@
+package drand
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+	"github.com/urfave/cli/v2"
+)
+
+var benchNodesFlag = &cli.IntFlag{
+	Name:  "nodes",
+	Usage: "Size of the group to benchmark.",
+	Value: 10,
+}
+
+var benchThresholdFlag = &cli.IntFlag{
+	Name:  "threshold",
+	Usage: "Threshold of the group to benchmark, defaults to a majority of the nodes.",
+}
+
+var benchIterationsFlag = &cli.IntFlag{
+	Name:  "iterations",
+	Usage: "Number of times each operation is measured.",
+	Value: 10,
+}
+
+// benchCmd measures the cryptographic operations a node runs at every round
+// and prints their cost for a whole round, to help choosing the period.
+func benchCmd(c *cli.Context) error {
+	n := c.Int(benchNodesFlag.Name)
+	thr := c.Int(benchThresholdFlag.Name)
+	if !c.IsSet(benchThresholdFlag.Name) {
+		thr = key.DefaultThreshold(n)
+	}
+	iterations := c.Int(benchIterationsFlag.Name)
+	switch {
+	case n < 1:
+		return errors.New("bench: the group must have at least one node")
+	case thr < 1 || thr > n:
+		return fmt.Errorf("bench: invalid threshold %d for %d nodes", thr, n)
+	case iterations < 1:
+		return errors.New("bench: at least one iteration is needed")
+	}
+
+	secret := key.KeyGroup.Scalar().Pick(random.New())
+	priPoly := share.NewPriPoly(key.KeyGroup, thr, secret, random.New())
+	pubPoly := priPoly.Commit(key.KeyGroup.Point().Base())
+	shares := priPoly.Shares(n)
+	msg := []byte("drand benchmark message")
+
+	partials := make([][]byte, n)
+	sign := measure(iterations, func(i int) error {
+		_, err := key.Scheme.Sign(shares[i%n], msg)
+		return err
+	})
+	for _, s := range shares {
+		p, err := key.Scheme.Sign(s, msg)
+		if err != nil {
+			return err
+		}
+		partials[s.I] = p
+	}
+	verify := measure(iterations, func(i int) error {
+		return key.Scheme.VerifyPartial(pubPoly, msg, partials[i%n])
+	})
+	var sig []byte
+	aggregate := measure(iterations, func(int) error {
+		var err error
+		sig, err = key.Scheme.Recover(pubPoly, msg, partials[:thr], thr, n)
+		return err
+	})
+	if sig == nil {
+		return errors.New("bench: aggregation failed")
+	}
+	fullVerify := measure(iterations, func(int) error {
+		return key.Scheme.VerifyRecovered(pubPoly.Commit(), msg, sig)
+	})
+	for _, r := range []benchResult{sign, verify, aggregate, fullVerify} {
+		if r.err != nil {
+			return fmt.Errorf("bench: %s", r.err)
+		}
+	}
+
+	// per round, a node signs its partial, verifies the partials of the
+	// others, aggregates a threshold of them and verifies the beacon
+	perRound := []struct {
+		name  string
+		res   benchResult
+		times int
+	}{
+		{"partial sign", sign, 1},
+		{"partial verify", verify, n - 1},
+		{"aggregation", aggregate, 1},
+		{"full verify", fullVerify, 1},
+	}
+	fmt.Fprintf(output, "keys on %s, signatures on %s\n", key.KeyGroup, key.SigGroup)
+	fmt.Fprintf(output, "nodes: %d, threshold: %d, iterations: %d\n\n", n, thr, iterations)
+	w := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "operation\ttime\tper round\ttotal per round")
+	var total time.Duration
+	for _, op := range perRound {
+		roundTime := op.res.mean * time.Duration(op.times)
+		total += roundTime
+		fmt.Fprintf(w, "%s\t%s\tx%d\t%s\n", op.name, op.res.mean, op.times, roundTime)
+	}
+	fmt.Fprintf(w, "total\t\t\t%s\n", total)
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "\nThe period must leave room for the network latency on top of %s.\n", total)
+	return nil
+}
+
+type benchResult struct {
+	mean time.Duration
+	err  error
+}
+
+// measure returns the mean time taken by fn over the given iterations.
+func measure(iterations int, fn func(i int) error) benchResult {
+	start := time.Now()
+	for i := 0; i < iterations; i++ {
+		if err := fn(i); err != nil {
+			return benchResult{err: err}
+		}
+	}
+	return benchResult{mean: time.Since(start) / time.Duration(iterations)}
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
@@ -16,11 +17,16 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 
 	gonet "net"
 
 	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/beacon"
 	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/snapshot"
+	"github.com/drand/drand/chain/store"
 	"github.com/drand/drand/core"
 	"github.com/drand/drand/fs"
 	"github.com/drand/drand/key"
@@ -28,6 +34,7 @@ import (
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/encoding"
 )
 
 // default output of the drand operational commands
@@ -57,7 +64,7 @@ var folderFlag = &cli.StringFlag{
 
 var verboseFlag = &cli.BoolFlag{
 	Name:  "verbose",
-	Usage: "If set, verbosity is at the debug level",
+	Usage: "If set, verbosity is at the debug level.",
 }
 
 var tlsCertFlag = &cli.StringFlag{
@@ -79,6 +86,13 @@ var insecureFlag = &cli.BoolFlag{
 	Usage: "Disable TLS for all communications (not recommended).",
 }
 
+var networkFlag = &cli.StringFlag{
+	Name: "network",
+	Usage: "Name of the network the node runs for. The keys, share, group and database of each network are kept " +
+		"in their own subfolder of the configuration folder, so a host can run against multiple groups. The " +
+		"control commands reach the daemon started for that network.",
+}
+
 var controlFlag = &cli.StringFlag{
 	Name:  "control",
 	Usage: "Set the port you want to listen to for control port commands. If not specified, we will use the default port 8888.",
@@ -99,57 +113,368 @@ var pubListenFlag = &cli.StringFlag{
 	Usage: "Set the listening (binding) address of the public API. Useful if you have some kind of proxy.",
 }
 
+var fallbacksFlag = &cli.StringFlag{
+	Name: "fallback-addresses",
+	Usage: "<ADDRESS:PORT>,<...> of additional addresses where this node can be reached, " +
+		"tried in order by the other nodes when the main address is unreachable.",
+}
+
+var resolveFlag = &cli.BoolFlag{
+	Name: "resolve",
+	Usage: "Resolve the host of the address and warn if it is not reachable from other networks, " +
+		"e.g. a loopback or private address.",
+}
+
+var compressionFlag = &cli.StringFlag{
+	Name:  "grpc-compression",
+	Usage: "Compress the messages sent to other nodes with the given algorithm. Only \"gzip\" is supported. Disabled by default.",
+}
+
+var maxStoreSizeFlag = &cli.IntFlag{
+	Name:  "max-store-size",
+	Usage: "Maximum size in megabytes of the beacon database. The node refuses to store new beacons once it is reached. Unlimited by default.",
+}
+
+var fipsFlag = &cli.BoolFlag{
+	Name: "fips",
+	Usage: "Restrict the digests and TLS cipher suites to the ones approved by FIPS 140. On by default in the " +
+		"binaries built with the fips tag. The primitives used are shown by 'drand status'.",
+}
+
+var readOnlyFlag = &cli.BoolFlag{
+	Name: "read-only",
+	Usage: "Serve the public and sync APIs from the existing beacon database without taking part in the chain: " +
+		"the node never signs and refuses the DKG and resharing. The share is not needed, and neither is the " +
+		"key pair if --private-listen is given.",
+}
+
+var rotateInitiatorFlag = &cli.BoolFlag{
+	Name: "rotate-initiator",
+	Usage: "Only broadcast the partial signature at the round time when this node initiates the round, chosen from" +
+		" the previous signature. Otherwise wait for the partial of the initiator, up to a quarter of the period.",
+}
+
+var aggregationGraceFlag = &cli.DurationFlag{
+	Name: "aggregation-grace",
+	Usage: "Time to wait for the partials of all the nodes once a threshold of partials is received, bounded by" +
+		" half a period. By default, the beacon is aggregated as soon as the threshold is reached.",
+}
+
+var maxClockSkewFlag = &cli.DurationFlag{
+	Name: "max-clock-skew",
+	Usage: "Maximum difference between the time a node sends its partial signature and the time it is received." +
+		" The nodes chronically exceeding it are marked degraded and not counted on to reach the threshold.",
+	Value: beacon.DefaultMaxClockSkew,
+}
+
+var maxClockJumpFlag = &cli.DurationFlag{
+	Name: "max-clock-jump",
+	Usage: "Largest difference between the time slept by the beacon loop measured by the wall clock and by the monotonic clock." +
+		" Beyond it, the clock of the host is considered to have jumped and the jump is reported.",
+	Value: beacon.DefaultMaxClockJump,
+}
+
+var previousEpochFlag = &cli.IntFlag{
+	Name: "previous-epoch-rounds",
+	Usage: "Number of rounds after a resharing during which the share and group of the previous epoch are kept" +
+		" to sign and verify the late partial signatures of its last rounds.",
+	Value: beacon.DefaultPreviousEpochRounds,
+}
+
+var verifyPeersFlag = &cli.BoolFlag{
+	Name: "verify-peers",
+	Usage: "Only accept the partial signatures sent by the node that made them, authenticated by its TLS" +
+		" certificate which must be valid for its address in the group. Requires all the nodes to present their certificate.",
+}
+
+var beaconCacheFlag = &cli.IntFlag{
+	Name:  "beacon-cache-size",
+	Usage: "Number of beacons kept in memory in front of the database for the public API, 0 disables the cache.",
+	Value: beacon.DefaultCacheSize,
+}
+
+var partialWindowFlag = &cli.IntFlag{
+	Name: "partial-window",
+	Usage: "Number of rounds before the last stored beacon for which the partial signatures received are still" +
+		" processed. The older ones are dropped before their verification.",
+	Value: beacon.DefaultPartialWindow,
+}
+
+var syncBatchFlag = &cli.IntFlag{
+	Name:  "sync-batch",
+	Usage: "Number of rounds requested at once from a peer when catching up with the chain. All at once by default.",
+}
+
+var syncRateFlag = &cli.Float64Flag{
+	Name:  "sync-rate",
+	Usage: "Maximum number of rounds fetched per second when catching up with the chain. Unlimited by default.",
+}
+
+var syncBytesRateFlag = &cli.Int64Flag{
+	Name:  "sync-bytes-rate",
+	Usage: "Maximum number of bytes fetched per second when catching up with the chain. Unlimited by default.",
+}
+
+var syncMaxPeersFlag = &cli.IntFlag{
+	Name:  "sync-max-peers",
+	Usage: "Maximum number of peers syncing the chain from this node at the same time. Unlimited by default.",
+}
+
+var syncParallelFlag = &cli.IntFlag{
+	Name:  "sync-parallel",
+	Usage: "Number of peers the chain is fetched from in parallel when catching up with the chain.",
+	Value: beacon.DefaultSyncParallel,
+}
+
+var groupApprovalFlag = &cli.BoolFlag{
+	Name: "require-group-approval",
+	Usage: "Refuse to reshare towards a group not approved beforehand with 'drand util approve-group'. The leader " +
+		"proposes the group with 'drand util propose-group'.",
+}
+
+var minThresholdFlag = &cli.IntFlag{
+	Name: "min-threshold",
+	Usage: "Refuse to run a DKG or a resharing with a group whose threshold is lower. The threshold can never be" +
+		" lower than half of the nodes plus one.",
+}
+
+var storeBackendFlag = &cli.StringFlag{
+	Name:  "db-backend",
+	Usage: "Name of the backend storing the beacons, among the ones compiled in the binary.",
+	Value: store.DefaultBackend,
+}
+
+var corsOriginsFlag = &cli.StringSliceFlag{
+	Name: "cors-origins",
+	Usage: "<ORIGIN>,<...> of the origins allowed to call the public HTTP API from a browser, " +
+		"e.g. https://example.com. The flag can be repeated. All origins are allowed by default.",
+}
+
+var corsHeadersFlag = &cli.StringSliceFlag{
+	Name:  "cors-headers",
+	Usage: "<HEADER>,<...> of the headers browsers may send when calling the public HTTP API. The flag can be repeated.",
+}
+
+var httpTokensFlag = &cli.StringFlag{
+	Name:  "public-http-tokens",
+	Usage: "File containing the bearer tokens, one per line, required to call the public HTTP API.",
+}
+
+var httpClientCAFlag = &cli.StringFlag{
+	Name:  "public-http-client-ca",
+	Usage: "CA certificate (PEM format) signing the TLS client certificates allowed to call the public HTTP API.",
+}
+
+var grpcTokensFlag = &cli.StringFlag{
+	Name:  "public-grpc-tokens",
+	Usage: "File containing the bearer tokens, one per line, required to call the public gRPC API.",
+}
+
+var grpcClientCAFlag = &cli.StringFlag{
+	Name: "public-grpc-client-ca",
+	Usage: "CA certificate (PEM format) signing the TLS client certificates allowed to call the public gRPC API. " +
+		"The other members of the group can still reach the protocol API.",
+}
+
+var privateAllowFlag = &cli.StringFlag{
+	Name: "private-allow",
+	Usage: "<CIDR>,<...> of the networks allowed to connect to the private listener, e.g. the subnets of the other members. " +
+		"All networks are allowed by default.",
+}
+
+var privateDenyFlag = &cli.StringFlag{
+	Name:  "private-deny",
+	Usage: "<CIDR>,<...> of the networks not allowed to connect to the private listener.",
+}
+
+var publicAllowFlag = &cli.StringFlag{
+	Name:  "public-allow",
+	Usage: "<CIDR>,<...> of the networks allowed to connect to the public HTTP listener. All networks are allowed by default.",
+}
+
+var publicDenyFlag = &cli.StringFlag{
+	Name:  "public-deny",
+	Usage: "<CIDR>,<...> of the networks not allowed to connect to the public HTTP listener.",
+}
+
 var nodeFlag = &cli.StringFlag{
 	Name:  "nodes",
 	Usage: "Contact the nodes at the given list of whitespace-separated addresses which have to be present in group.toml.",
 }
 
+var lastRoundsFlag = &cli.IntFlag{
+	Name:  "last",
+	Usage: "Number of rounds to show, starting from the latest one. All the rounds kept by the daemon by default.",
+}
+
 var roundFlag = &cli.IntFlag{
 	Name: "round",
 	Usage: "Request the public randomness generated at round num. If the drand beacon does not have the requested value," +
 		" it returns an error. If not specified, the current randomness is returned.",
 }
 
+var watchFlag = &cli.BoolFlag{
+	Name:  "watch",
+	Usage: "Stream the verified randomness of each new round as it becomes available.",
+}
+
+var formatFlag = &cli.StringFlag{
+	Name: "format",
+	Usage: "Output format of the randomness: \"json\", or \"raw\" to only write the 32 bytes of randomness" +
+		" of each round, e.g. to pipe them to another program.",
+	Value: formatJSON,
+}
+
 var certsDirFlag = &cli.StringFlag{
 	Name:  "certs-dir",
-	Usage: "directory containing trusted certificates (PEM format). Useful for testing and self signed certificates",
+	Usage: "Directory containing trusted certificates (PEM format). Useful for testing and self signed certificates.",
+}
+
+var certsOverlapFlag = &cli.DurationFlag{
+	Name: "certs-overlap",
+	Usage: "Watch the certs-dir folder for rotated certificates while running. A certificate removed from the folder " +
+		"is still trusted for the given duration (e.g. 24h), so peers can switch to their new certificate at any time.",
+}
+
+var alertMissedFlag = &cli.IntFlag{
+	Name: "alert-missed-rounds",
+	Usage: "Raise an alert once the given number of consecutive rounds are missing from the chain. The alert is " +
+		"logged and counted in the metrics.",
+}
+
+var alertCommandFlag = &cli.StringFlag{
+	Name: "alert-command",
+	Usage: "Shell command run when an alert is raised. The alert is given in the DRAND_LAST_ROUND, " +
+		"DRAND_EXPECTED_ROUND and DRAND_MISSED_ROUNDS environment variables. Requires alert-missed-rounds.",
+}
+
+var alertWebhookFlag = &cli.StringFlag{
+	Name:  "alert-webhook",
+	Usage: "URL receiving the alert as a JSON POST request when an alert is raised. Requires alert-missed-rounds.",
+}
+
+var snapshotBucketFlag = &cli.StringFlag{
+	Name: "snapshot-bucket",
+	Usage: "Name of the S3 compatible bucket the new rounds of the chain are periodically uploaded to, as " +
+		"incremental snapshots from which new nodes and relays can bootstrap.",
+}
+
+var snapshotPrefixFlag = &cli.StringFlag{
+	Name:  "snapshot-prefix",
+	Usage: "Prefix of the names of the snapshot files in the bucket. Requires snapshot-bucket.",
+}
+
+var snapshotRegionFlag = &cli.StringFlag{
+	Name:  "snapshot-region",
+	Usage: "Region of the snapshot bucket. Requires snapshot-bucket.",
+	Value: snapshot.DefaultS3Region,
+}
+
+var snapshotEndpointFlag = &cli.StringFlag{
+	Name:  "snapshot-endpoint",
+	Usage: "URL of the S3 compatible storage of the snapshot bucket, if not AWS. Requires snapshot-bucket.",
+}
+
+var snapshotIntervalFlag = &cli.StringFlag{
+	Name:  "snapshot-interval",
+	Usage: "Interval at which the new rounds are uploaded to the snapshot bucket. Requires snapshot-bucket.",
+	Value: core.DefaultSnapshotInterval.String(),
+}
+
+var snapshotAccessKeyFlag = &cli.StringFlag{
+	Name: "snapshot-access-key",
+	Usage: "Access key of the snapshot bucket, with its secret read from snapshot-secret-key-file. The " +
+		"credentials are looked up in the AWS environment variables and configuration files if not given.",
+}
+
+var snapshotSecretKeyFileFlag = &cli.StringFlag{
+	Name:  "snapshot-secret-key-file",
+	Usage: "File holding the secret of the snapshot-access-key.",
+}
+
+var bootstrapFromFlag = &cli.StringFlag{
+	Name: "bootstrap-from",
+	Usage: "<URL|PATH> of a snapshot of the chain, as uploaded with snapshot-bucket, imported and verified when the " +
+		"node starts with no beacon stored, before syncing the rest of the chain from the other nodes.",
+}
+
+var sharePartsFlag = &cli.StringFlag{
+	Name: "share-parts",
+	Usage: "<FOLDER>,<...> of the locations, e.g. different disks, where to store the private share split with " +
+		"Shamir's secret sharing instead of keeping it in the config folder. An existing share is split at startup.",
+}
+
+var shareThresholdFlag = &cli.IntFlag{
+	Name:  "share-threshold",
+	Usage: "Number of share parts needed to recover the private share. Defaults to all of them.",
 }
 
 var outFlag = &cli.StringFlag{
 	Name:  "out",
-	Usage: "save the group file into a separate file instead of stdout",
+	Usage: "Save the group file into a separate file instead of stdout.",
 }
 
 var periodFlag = &cli.StringFlag{
 	Name:  "period",
-	Usage: "period to set when doing a setup",
+	Usage: "Period to set when doing a setup. When resharing, the period of the chain from the transition round, which all the nodes must give.",
 }
 
 var catchupPeriodFlag = &cli.StringFlag{
 	Name:  "catchup-period",
-	Usage: "Minimum period while in catchup. Set only by the leader of share / reshares",
+	Usage: "Minimum period while in catchup. Set only by the leader of share / reshares.",
 	Value: "0s",
 }
 
+var taggedMessagesFlag = &cli.BoolFlag{
+	Name: "tagged-messages",
+	Usage: "Sign the beacons over the domain separated message format, from the first round of a new network or " +
+		"from the transition round of a resharing. The format can't be changed back. Set only by the leader of share / reshares.",
+}
+
+var digestFlag = &cli.StringFlag{
+	Name: "digest",
+	Usage: fmt.Sprintf("Digest deriving the randomness of a new network from the signatures, one of %s, "+
+		"%s by default. It can't be changed by a resharing. Set only by the leader of share.",
+		strings.Join(key.DigestNames(), ", "), key.DigestSHA256),
+}
+
 var thresholdFlag = &cli.IntFlag{
 	Name:  "threshold",
-	Usage: "threshold to use for the DKG",
+	Usage: "Threshold to use for the DKG.",
 }
 
 var shareNodeFlag = &cli.IntFlag{
 	Name:  "nodes",
-	Usage: "number of nodes expected",
+	Usage: "Number of nodes expected.",
+}
+
+var transitionRoundFlag = &cli.IntFlag{
+	Name: "transition-round",
+	Usage: "Round at which the new group takes over in a resharing, agreed upon by the operators. " +
+		"The leader schedules the transition at that round and the other nodes refuse any other round. " +
+		"By default, the leader uses the first round after the resharing.",
+}
+
+var dryRunFlag = &cli.BoolFlag{
+	Name: "dry-run",
+	Usage: "Runs the resharing with the new group without using its outcome: the nodes keep their current " +
+		"share and group. All the participants must set this flag.",
 }
 
 var transitionFlag = &cli.BoolFlag{
 	Name: "transition",
 	Usage: "When set, this flag indicates the share operation is a resharing. " +
-		"The node will use the currently stored group as the basis for the resharing",
+		"The node will use the currently stored group as the basis for the resharing.",
+}
+
+var skipConfirmFlag = &cli.BoolFlag{
+	Name:  "force",
+	Usage: "Do not ask for confirmation. Useful for automation.",
 }
 
 var forceFlag = &cli.BoolFlag{
 	Name:  "force, f",
-	Usage: "When set, this flag forces the daemon to start a new reshare operation." + "By default, it does not allow to restart one",
+	Usage: "When set, this flag forces the daemon to start a new reshare operation. " + "By default, it does not allow to restart one.",
 }
 
 // secret flag is the "manual" security when the "leader"/coordinator creates the
@@ -165,18 +490,18 @@ var secretFlag = &cli.StringFlag{
 
 var connectFlag = &cli.StringFlag{
 	Name:  "connect",
-	Usage: "Address of the coordinator that will assemble the public keys and start the DKG",
+	Usage: "Address of the coordinator that will assemble the public keys and start the DKG.",
 }
 
 var leaderFlag = &cli.BoolFlag{
 	Name:  "leader",
-	Usage: "Specify if this node should act as the leader for setting up the group",
+	Usage: "Specify if this node should act as the leader for setting up the group.",
 }
 
 var beaconOffset = &cli.IntFlag{
 	Name: "beacon-delay",
 	Usage: "Leader uses this flag to specify the genesis time or transition time as a delay from when " +
-		" group is ready to run the share protocol",
+		" group is ready to run the share protocol.",
 }
 
 var oldGroupFlag = &cli.StringFlag{
@@ -187,19 +512,20 @@ var oldGroupFlag = &cli.StringFlag{
 }
 
 var skipValidationFlag = &cli.BoolFlag{
-	Name:  "skipValidation",
-	Usage: "skips bls verification of beacon rounds for faster catchup.",
+	Name:    "skip-validation",
+	Aliases: []string{"skipValidation"},
+	Usage:   "Skips bls verification of beacon rounds for faster catchup.",
 }
 
 var timeoutFlag = &cli.StringFlag{
 	Name:  "timeout",
-	Usage: fmt.Sprintf("Timeout to use during the DKG, in string format. Default is %s", core.DefaultDKGTimeout),
+	Usage: fmt.Sprintf("Timeout to use during the DKG, in string format. Default is %s.", core.DefaultDKGTimeout),
 }
 
 var pushFlag = &cli.BoolFlag{
 	Name: "push",
 	Usage: "Push mode forces the daemon to start making beacon requests to the other node, " +
-		"instead of waiting the other nodes contact it to catch-up on the round",
+		"instead of waiting the other nodes contact it to catch-up on the round.",
 }
 
 var sourceFlag = &cli.StringFlag{
@@ -209,13 +535,13 @@ var sourceFlag = &cli.StringFlag{
 
 var userEntropyOnlyFlag = &cli.BoolFlag{
 	Name: "user-source-only",
-	Usage: "user-source-only flag used with the source flag allows to only use the user's entropy to pick the dkg secret " +
+	Usage: "User-source-only flag used with the source flag allows to only use the user's entropy to pick the dkg secret " +
 		"(won't be mixed with crypto/rand). Should be used for reproducibility and debbuging purposes.",
 }
 
 var groupFlag = &cli.StringFlag{
 	Name:  "group",
-	Usage: "Test connections to nodes listed in the group",
+	Usage: "Test connections to nodes listed in the group.",
 }
 
 var enablePrivateRand = &cli.BoolFlag{
@@ -225,26 +551,29 @@ var enablePrivateRand = &cli.BoolFlag{
 
 var hashOnly = &cli.BoolFlag{
 	Name:  "hash",
-	Usage: "Only print the hash of the group file",
+	Usage: "Only print the hash of the group file.",
+}
+
+var fingerprintOnly = &cli.BoolFlag{
+	Name:  "fingerprint",
+	Usage: "Only print the fingerprint of the public key of the chain, to pin it in the clients.",
 }
 
 var hashInfoFlag = &cli.StringFlag{
-	Name:     "chain-hash",
-	Usage:    "The hash of the chain info",
-	Required: true,
+	Name:  "chain-hash",
+	Usage: "The hash of the chain info.",
 }
 
 // using a simple string flag because the StringSliceFlag is not intuitive
 // see https://github.com/urfave/cli/issues/62
 var syncNodeFlag = &cli.StringFlag{
-	Name:     "sync-nodes",
-	Usage:    "<ADDRESS:PORT>,<...> of (multiple) reachable drand daemon(s)",
-	Required: true,
+	Name:  "sync-nodes",
+	Usage: "<ADDRESS:PORT>,<...> of (multiple) reachable drand daemon(s).",
 }
 
 var upToFlag = &cli.IntFlag{
 	Name:  "up-to",
-	Usage: "Specify a round to which the drand daemon will stop following the chain",
+	Usage: "Specify a round to which the drand daemon will stop following the chain.",
 	Value: 0,
 }
 
@@ -252,9 +581,16 @@ var appCommands = []*cli.Command{
 	{
 		Name:  "start",
 		Usage: "Start the drand daemon.",
-		Flags: toArray(folderFlag, tlsCertFlag, tlsKeyFlag,
+		Flags: toArray(folderFlag, networkFlag, tlsCertFlag, tlsKeyFlag,
 			insecureFlag, controlFlag, privListenFlag, pubListenFlag, metricsFlag,
-			certsDirFlag, pushFlag, verboseFlag, enablePrivateRand, oldGroupFlag, skipValidationFlag),
+			certsDirFlag, pushFlag, verboseFlag, enablePrivateRand, oldGroupFlag, skipValidationFlag, compressionFlag,
+			maxStoreSizeFlag, storeBackendFlag, corsOriginsFlag, corsHeadersFlag,
+			httpTokensFlag, httpClientCAFlag, grpcTokensFlag, grpcClientCAFlag,
+			privateAllowFlag, privateDenyFlag, publicAllowFlag, publicDenyFlag, certsOverlapFlag,
+			sharePartsFlag, shareThresholdFlag, alertMissedFlag, alertCommandFlag, alertWebhookFlag,
+			snapshotBucketFlag, snapshotPrefixFlag, snapshotRegionFlag, snapshotEndpointFlag, snapshotIntervalFlag,
+			snapshotAccessKeyFlag, snapshotSecretKeyFileFlag, bootstrapFromFlag, rotateInitiatorFlag, aggregationGraceFlag, maxClockSkewFlag, maxClockJumpFlag, partialWindowFlag, previousEpochFlag, beaconCacheFlag, verifyPeersFlag, groupApprovalFlag, minThresholdFlag,
+			syncBatchFlag, syncRateFlag, syncBytesRateFlag, syncMaxPeersFlag, syncParallelFlag, fipsFlag, readOnlyFlag),
 		Action: func(c *cli.Context) error {
 			banner()
 			return startCmd(c)
@@ -262,38 +598,64 @@ var appCommands = []*cli.Command{
 	},
 	{
 		Name:  "stop",
-		Usage: "Stop the drand daemon.\n",
-		Flags: toArray(controlFlag),
+		Usage: "Stop the drand daemon.",
+		Flags: toArray(controlFlag, networkFlag),
 		Action: func(c *cli.Context) error {
 			banner()
 			return stopDaemon(c)
 		},
 	},
+	{
+		Name: "pause",
+		Usage: "Stop sending the partial signatures of this node, e.g. during a maintenance, while it keeps " +
+			"following the chain. The other nodes must still reach the threshold without it.",
+		Flags:  toArray(controlFlag, networkFlag),
+		Action: pauseCmd,
+	},
+	{
+		Name:   "resume",
+		Usage:  "Send the partial signatures of a paused node again, from the next round.",
+		Flags:  toArray(controlFlag, networkFlag),
+		Action: resumeCmd,
+	},
+	{
+		Name:   "status",
+		Usage: "Shows the disk usage of the beacon database, compared to the given maximum size if any, " +
+			"and the crypto configuration of the node.",
+		Flags:  toArray(folderFlag, networkFlag, controlFlag, storeBackendFlag, maxStoreSizeFlag, fipsFlag),
+		Action: chainStatusCmd,
+	},
 	{
 		Name:  "share",
 		Usage: "Launch a sharing protocol.",
-		Flags: toArray(insecureFlag, controlFlag, oldGroupFlag,
+		Flags: toArray(insecureFlag, controlFlag, networkFlag, oldGroupFlag,
 			timeoutFlag, sourceFlag, userEntropyOnlyFlag, secretFlag,
 			periodFlag, shareNodeFlag, thresholdFlag, connectFlag, outFlag,
-			leaderFlag, beaconOffset, transitionFlag, forceFlag, catchupPeriodFlag),
+			leaderFlag, beaconOffset, transitionFlag, forceFlag, catchupPeriodFlag, taggedMessagesFlag, digestFlag,
+			transitionRoundFlag, dryRunFlag),
 		Action: func(c *cli.Context) error {
 			banner()
 			return shareCmd(c)
 		},
 	},
 	{
-		Name:  "follow",
-		Usage: "follow and store a randomness chain",
-		Flags: toArray(folderFlag, controlFlag, hashInfoFlag, syncNodeFlag,
+		Name: "follow",
+		Usage: "Follow and store a randomness chain without participating in it. The local daemon syncs and " +
+			"verifies the chain from the given nodes, and keeps following it unless --up-to is set. " +
+			"It can be used to archive a chain or to sync a node before it joins the network in a resharing.",
+		ArgsUsage: "<chain-hash> <ADDRESS:PORT>,<...> can also be given with --chain-hash and --sync-nodes",
+		Flags: toArray(folderFlag, networkFlag, controlFlag, hashInfoFlag, syncNodeFlag,
 			tlsCertFlag, insecureFlag, upToFlag),
 		Action: followCmd,
 	},
 	{
-		Name: "generate-keypair",
+		Name:    "generate-keypair",
+		Aliases: []string{"keygen"},
 		Usage: "Generate the longterm keypair (drand.private, drand.public)" +
-			"for this node.\n",
+			"for this node. The public file records whether the node serves TLS, " +
+			"unless --tls-disable is given, and can be shared with the other nodes.",
 		ArgsUsage: "<address> is the address other nodes will be able to contact this node on (specified as 'private-listen' to the daemon)",
-		Flags:     toArray(folderFlag, insecureFlag),
+		Flags:     toArray(folderFlag, networkFlag, insecureFlag, fallbacksFlag, mnemonicFlag, resolveFlag),
 		Action: func(c *cli.Context) error {
 			banner()
 			return keygenCmd(c)
@@ -302,8 +664,8 @@ var appCommands = []*cli.Command{
 
 	{
 		Name: "get",
-		Usage: "get allows for public information retrieval from a remote " +
-			"drand node.\n",
+		Usage: "Get allows for public information retrieval from a remote " +
+			"drand node.",
 		Subcommands: []*cli.Command{
 			{
 				Name: "private",
@@ -313,7 +675,7 @@ var appCommands = []*cli.Command{
 					"key of the contacted node. This command attempts to connect " +
 					"to the drand beacon via TLS and falls back to " +
 					"plaintext communication if the contacted node has not " +
-					"activated TLS in which case it prints a warning.\n",
+					"activated TLS in which case it prints a warning.",
 				ArgsUsage: "<group.toml> provides the group informations of " +
 					"the nodes that we are trying to contact.",
 				Flags:  toArray(insecureFlag, tlsCertFlag, nodeFlag),
@@ -327,15 +689,17 @@ var appCommands = []*cli.Command{
 					"default. This command attempts to connect to the drand " +
 					"beacon via TLS and falls back to plaintext communication " +
 					"if the contacted node has not activated TLS in which case " +
-					"it prints a warning.\n",
-				Flags:  toArray(tlsCertFlag, insecureFlag, roundFlag, nodeFlag),
+					"it prints a warning.",
+				ArgsUsage: "<group.toml> provides the group informations of " +
+					"the nodes that we are trying to contact.",
+				Flags:  toArray(tlsCertFlag, insecureFlag, roundFlag, nodeFlag, watchFlag, formatFlag),
 				Action: getPublicRandomness,
 			},
 			{
 				Name:      "chain-info",
-				Usage:     "Get the binding chain information that this nodes participates to",
-				ArgsUsage: "`ADDRESS1` `ADDRESS2` ... provides the addresses of the node to try to contact to.",
-				Flags:     toArray(tlsCertFlag, insecureFlag, hashOnly),
+				Usage:     "Get the binding chain information that this nodes participates to.",
+				ArgsUsage: "<ADDRESS:PORT>... provides the addresses of the nodes to try to contact.",
+				Flags:     toArray(tlsCertFlag, insecureFlag, hashOnly, fingerprintOnly),
 				Action:    getChainInfo,
 			},
 		},
@@ -346,80 +710,308 @@ var appCommands = []*cli.Command{
 		Subcommands: []*cli.Command{
 			{
 				Name: "check",
-				Usage: "Check node at the given `ADDRESS` (you can put multiple ones)" +
-					" in the group for accessibility over the gRPC communication. If the node " +
-					" is not running behind TLS, you need to pass the tls-disable flag. You can " +
-					"also check a whole group's connectivity with the group flag.",
-				Flags:  toArray(groupFlag, certsDirFlag, insecureFlag, verboseFlag),
-				Action: checkConnection,
+				Usage: "Check the nodes at the given addresses for accessibility over the gRPC " +
+					"communication. If the nodes are not running behind TLS, you need to pass the tls-disable flag. " +
+					"You can also check a whole group's connectivity with the group flag.",
+				ArgsUsage: "<ADDRESS:PORT>... provides the addresses of the nodes to check.",
+				Flags:     toArray(groupFlag, certsDirFlag, insecureFlag, verboseFlag),
+				Action:    checkConnection,
 			},
 			{
 				Name:   "ping",
-				Usage:  "pings the daemon checking its state\n",
-				Flags:  toArray(controlFlag),
+				Usage:  "Pings the daemon checking its state.",
+				Flags:  toArray(controlFlag, networkFlag),
 				Action: pingpongCmd,
 			},
 			{
-				Name:   "reset",
-				Usage:  "Resets the local distributed information (share, group file and random beacons). It KEEPS the private/public key pair.",
-				Flags:  toArray(folderFlag, controlFlag),
+				Name: "reset",
+				Usage: "Resets the local distributed information (share, group file and random beacons). It KEEPS the private/public key pair. " +
+					"The previous state is saved in the backups folder. The daemon must be stopped.",
+				Flags:  toArray(folderFlag, networkFlag, controlFlag, skipConfirmFlag, sharePartsFlag, shareThresholdFlag),
 				Action: resetCmd,
 			},
+			{
+				Name:   "status",
+				Usage:  "Same as 'drand status'.",
+				Flags:  toArray(folderFlag, networkFlag, controlFlag, storeBackendFlag, maxStoreSizeFlag, fipsFlag),
+				Action: chainStatusCmd,
+			},
 			{
 				Name: "del-beacon",
-				Usage: "Delete all beacons from the given `ROUND` number until the head of the chain. " +
-					" You MUST restart the daemon after that command.",
-				Flags:  toArray(folderFlag),
-				Action: deleteBeaconCmd,
+				Usage: "Delete all beacons from the given round number until the head of the chain. " +
+					"You MUST restart the daemon after that command.",
+				ArgsUsage: "<ROUND> is the first round to delete.",
+				Flags:     toArray(folderFlag, networkFlag, storeBackendFlag),
+				Action:    deleteBeaconCmd,
+			},
+			{
+				Name: "bench",
+				Usage: "Measures the cryptographic operations run at every round (partial sign and verify, aggregation " +
+					"and verification of the beacon) for a group of the given size, to help choosing a safe period.",
+				Flags:  toArray(benchNodesFlag, benchThresholdFlag, benchIterationsFlag),
+				Action: benchCmd,
+			},
+			{
+				Name: "test-vectors",
+				Usage: "Prints, as JSON, the partial and recovered signatures of chained rounds by a group whose keys " +
+					"are derived from the seed, for each message format and digest, to check the compatibility of other " +
+					"implementations of the verification.",
+				Flags:  toArray(vectorsNodesFlag, vectorsThresholdFlag, vectorsRoundsFlag, vectorsSeedFlag),
+				Action: testVectorsCmd,
+			},
+			{
+				Name: "speedtest",
+				Usage: "Measures the latency of the public endpoint of each node of the group and verifies the " +
+					"beacons they return, then prints the nodes ranked from the best to the worst.",
+				ArgsUsage: "<group.toml>",
+				Flags:     toArray(tlsCertFlag, nodeFlag, speedtestRequestsFlag, speedtestTimeoutFlag),
+				Action:    speedtestCmd,
+			},
+			{
+				Name: "collect-keys",
+				Usage: "Collects the public identity files of the members of a new group, sent to the --listen address " +
+					"or fetched from the given URLs, and prints the draft group made of them.",
+				ArgsUsage: "<URL>... of the identity files to fetch, polled until they are available",
+				Flags: toArray(collectListenFlag, collectWaitFlag, collectExpectFlag, thresholdFlag, periodFlag,
+					catchupPeriodFlag, outFlag),
+				Action: collectKeysCmd,
+			},
+			{
+				Name: "propose-group",
+				Usage: "Sends the group file of a future resharing to all its nodes and the nodes of the current " +
+					"group, so their operators can approve it. Prints the hash of the proposed group.",
+				ArgsUsage: "<group.toml>",
+				Flags:     toArray(controlFlag, networkFlag),
+				Action:    proposeGroupCmd,
+			},
+			{
+				Name: "list-pending-groups",
+				Usage: "Lists the groups proposed to the daemon for a future resharing and whether they are approved. " +
+					"The proposals are kept in memory only and must be sent again after a restart of the daemon.",
+				Flags:  toArray(controlFlag, networkFlag),
+				Action: listPendingGroupsCmd,
+			},
+			{
+				Name: "approve-group",
+				Usage: "Approves the proposed group with the given hash. A daemon started with --require-group-approval " +
+					"only reshares towards approved groups.",
+				ArgsUsage: "<hash>",
+				Flags:     toArray(controlFlag, networkFlag),
+				Action:    approveGroupCmd,
+			},
+			{
+				Name: "schedule-maintenance",
+				Usage: "Declares a maintenance window of the daemon, reported in its status and metrics. With " +
+					"--announce, the window is signed and sent to the other nodes of the group, so their alerts " +
+					"can tell the planned downtime from a failure.",
+				Flags: toArray(controlFlag, networkFlag, maintenanceStartFlag, maintenanceDurationFlag,
+					maintenanceReasonFlag, maintenanceAnnounceFlag),
+				Action: scheduleMaintenanceCmd,
+			},
+			{
+				Name: "self-test",
+				Usage: "Verifies the key pair, the share against the distributed public key, signs and verifies a " +
+					"dummy message, and checks the beacon database and the clock. The daemon must be stopped.",
+				Flags:  toArray(folderFlag, networkFlag, storeBackendFlag),
+				Action: selfTestCmd,
 			},
 			{
 				Name:   "self-sign",
 				Usage:  "Signs the public identity of this node. Needed for backward compatibility with previous versions.",
-				Flags:  toArray(folderFlag),
+				Flags:  toArray(folderFlag, networkFlag),
 				Action: selfSign,
 			},
 		},
 	},
+	{
+		Name: "relay",
+		Usage: "Serve the public API of a chain from its upstream nodes, without any key material, to scale " +
+			"the public facing tier independently from the nodes.",
+		Subcommands: []*cli.Command{
+			{
+				Name: "http",
+				Usage: "Serves the public HTTP API, watching the upstream nodes over gRPC and caching the " +
+					"rounds they deliver.",
+				Flags: toArray(relayUpstreamFlag, hashInfoFlag, tlsCertFlag, insecureFlag, relayBindFlag,
+					relayCacheFlag, corsOriginsFlag, corsHeadersFlag),
+				Action: relayHTTPCmd,
+			},
+			{
+				Name: "grpc",
+				Usage: "Serves the public gRPC API, streaming the new rounds to all the clients from a single " +
+					"watch of the upstream nodes.",
+				Flags:  toArray(relayUpstreamFlag, hashInfoFlag, tlsCertFlag, insecureFlag, relayBindFlag, relayCacheFlag),
+				Action: relayGRPCCmd,
+			},
+		},
+	},
+	{
+		Name:  "backup",
+		Usage: "Create or restore an encrypted backup of the node's key pair, share, group file and beacon database. The daemon must be stopped.",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "create",
+				Usage:     "Saves the state of the node into an encrypted archive.",
+				ArgsUsage: "<file> is the path of the archive to create",
+				Flags:     toArray(folderFlag, networkFlag, passphraseFileFlag, sharePartsFlag, shareThresholdFlag),
+				Action:    backupCreateCmd,
+			},
+			{
+				Name:      "restore",
+				Usage:     "Verifies and restores the state of the node from an encrypted archive.",
+				ArgsUsage: "<file> is the path of the archive to restore",
+				Flags:     toArray(folderFlag, networkFlag, passphraseFileFlag, overwriteFlag, sharePartsFlag, shareThresholdFlag),
+				Action:    backupRestoreCmd,
+			},
+		},
+	},
+	{
+		Name:  "key",
+		Usage: "Export or import the keys of the node in standard encodings.",
+		Subcommands: []*cli.Command{
+			{
+				Name: "export",
+				Usage: "Writes the private or public identity key of the node, or the distributed public key" +
+					" of its group, in the given encoding.",
+				Flags:  toArray(folderFlag, networkFlag, keyTypeFlag, keyEncodingFlag, keyOutFlag),
+				Action: keyExportCmd,
+			},
+			{
+				Name: "import",
+				Usage: "Imports a private key as the identity of the node, which must be stopped. Public keys" +
+					" are verified and printed in the hexadecimal form used in the drand files.",
+				ArgsUsage: "<file> is the key to import. [address] is the address of the node, required unless" +
+					" replacing an existing key pair, whose address is then kept.",
+				Flags:  toArray(folderFlag, networkFlag, keyTypeFlag, keyEncodingFlag, insecureFlag, overwriteFlag),
+				Action: keyImportCmd,
+			},
+			{
+				Name: "restore",
+				Usage: "Restores the identity key pair of the node from the mnemonic given by " +
+					"`drand generate-keypair --mnemonic`, read from the standard input unless a file is given." +
+					" The share is not restored: the node must take part in a new DKG or resharing.",
+				ArgsUsage: "<address> is the address other nodes will be able to contact this node on",
+				Flags:     toArray(folderFlag, networkFlag, mnemonicFileFlag, insecureFlag, fallbacksFlag, overwriteFlag),
+				Action:    keyRestoreCmd,
+			},
+		},
+	},
+	{
+		Name:  "chain",
+		Usage: "Commands operating on the randomness chain stored locally by the drand daemon.",
+		Subcommands: []*cli.Command{
+			{
+				Name:   "info",
+				Usage:  "Shows the chain information this node is participating to.",
+				Flags:  toArray(controlFlag, networkFlag, hashOnly, fingerprintOnly),
+				Action: showChainInfo,
+			},
+			{
+				Name: "stats",
+				Usage: "Shows the number of beacons stored, the first and last rounds, the rounds missing in between " +
+					"and the size of the store. The daemon must be stopped.",
+				Flags:  toArray(folderFlag, networkFlag, storeBackendFlag),
+				Action: chainStatsCmd,
+			},
+			{
+				Name: "compact",
+				Usage: "Reclaims the space left by the deleted beacons in the backends that fragment, such as bolt " +
+					"which never shrinks its file. The daemon must be stopped.",
+				Flags:  toArray(folderFlag, networkFlag, storeBackendFlag),
+				Action: chainCompactCmd,
+			},
+			{
+				Name: "del-beacon",
+				Usage: "Delete all beacons from the given round number until the head of the chain. " +
+					"You MUST restart the daemon after that command.",
+				ArgsUsage: "<ROUND> is the first round to delete.",
+				Flags:     toArray(folderFlag, networkFlag, storeBackendFlag),
+				Action:    deleteBeaconCmd,
+			},
+		},
+	},
 	{
 		Name: "show",
-		Usage: "local information retrieval about the node's cryptographic " +
+		Usage: "Local information retrieval about the node's cryptographic " +
 			"material. Show prints the information about the collective " +
 			"public key (drand.cokey), the group details (group.toml), the " +
 			"long-term private key (drand.private), the long-term public key " +
 			"(drand.public), or the private key share (drand.share), " +
-			"respectively.\n",
-		Flags: toArray(folderFlag, controlFlag),
+			"respectively.",
+		Flags: toArray(folderFlag, networkFlag, controlFlag),
 		Subcommands: []*cli.Command{
 			{
 				Name:   "share",
-				Usage:  "shows the private share\n",
-				Flags:  toArray(controlFlag),
+				Usage:  "Shows the private share.",
+				Flags:  toArray(controlFlag, networkFlag),
 				Action: showShareCmd,
 			},
 			{
 				Name: "group",
-				Usage: "shows the current group.toml used. The group.toml " +
+				Usage: "Shows the current group.toml used. The group.toml " +
 					"may contain the distributed public key if the DKG has been " +
-					"ran already.\n",
-				Flags:  toArray(outFlag, controlFlag, hashOnly),
+					"ran already.",
+				Flags:  toArray(outFlag, controlFlag, networkFlag, hashOnly),
 				Action: showGroupCmd,
 			},
 			{
 				Name:   "chain-info",
-				Usage:  "shows the chain information this node is participating to",
-				Flags:  toArray(controlFlag, hashOnly),
+				Usage:  "Shows the chain information this node is participating to.",
+				Flags:  toArray(controlFlag, networkFlag, hashOnly, fingerprintOnly),
 				Action: showChainInfo,
 			},
+			{
+				Name: "dkg-transcript",
+				Usage: "Shows the transcript of the latest DKG this node took part in: all the signed packets " +
+					"exchanged and the qualified nodes, so it can be audited by a third party.",
+				Flags:  toArray(folderFlag, networkFlag),
+				Action: showTranscriptCmd,
+			},
+			{
+				Name: "rounds",
+				Usage: "Shows which nodes sent their partial signature for the last rounds aggregated by the " +
+					"daemon and how long it took. Delays are measured from the start of each round.",
+				Flags:  toArray(controlFlag, networkFlag, lastRoundsFlag),
+				Action: showRoundsCmd,
+			},
+			{
+				Name: "status",
+				Usage: "Shows the state of the node: fresh, running or resharing a DKG, waiting for the genesis, " +
+					"running the beacon, syncing the chain or stopped, and since when.",
+				Flags:  toArray(controlFlag, networkFlag),
+				Action: showStatusCmd,
+			},
+			{
+				Name: "peers",
+				Usage: "Shows the clock skew of the other nodes measured from their last partial signature, " +
+					"and whether they are degraded because they chronically exceed the maximum skew.",
+				Flags:  toArray(controlFlag, networkFlag),
+				Action: showPeersCmd,
+			},
+			{
+				Name: "maintenance",
+				Usage: "Shows the maintenance windows not over yet of the node and of the nodes of the group that " +
+					"announced theirs.",
+				Flags:  toArray(controlFlag, networkFlag),
+				Action: showMaintenanceCmd,
+			},
+			{
+				Name: "key-usage",
+				Usage: "Shows how many partial signatures the node produced with its current share and its " +
+					"identity key, and since when, to enforce key rotation policies.",
+				Flags:  toArray(controlFlag, networkFlag),
+				Action: showKeyUsageCmd,
+			},
 			{
 				Name:   "private",
-				Usage:  "shows the long-term private key of a node.\n",
-				Flags:  toArray(controlFlag),
+				Usage:  "Shows the long-term private key of a node.",
+				Flags:  toArray(controlFlag, networkFlag),
 				Action: showPrivateCmd,
 			},
 			{
 				Name:   "public",
-				Usage:  "shows the long-term public key of a node.\n",
-				Flags:  toArray(controlFlag),
+				Usage:  "Shows the long-term public key of a node.",
+				Flags:  toArray(controlFlag, networkFlag),
 				Action: showPublicCmd,
 			},
 		},
@@ -442,38 +1034,84 @@ func CLI() *cli.App {
 	app.Usage = "distributed randomness service"
 	// =====Commands=====
 	app.Commands = appCommands
-	app.Flags = toArray(verboseFlag, folderFlag)
+	app.Flags = toArray(verboseFlag, folderFlag, networkFlag)
 	app.Before = testWindows
 	return app
 }
 
 func resetCmd(c *cli.Context) error {
-	conf := contextToConfig(c)
-	fmt.Fprintf(output, "You are about to delete your local share, group file and generated random beacons. "+
-		"Are you sure you wish to perform this operation? [y/N]")
-	reader := bufio.NewReader(os.Stdin)
-	answer, err := reader.ReadString('\n')
+	conf, err := contextToConfig(c)
 	if err != nil {
-		return fmt.Errorf("error reading: %s", err)
+		return err
 	}
-	answer = strings.ToLower(strings.TrimSpace(answer))
-	if answer != "y" {
-		fmt.Fprintf(output, "drand: not reseting the state.")
-		return nil
+	store, err := daemonStore(c, conf)
+	if err != nil {
+		return err
 	}
-	store := key.NewFileStore(conf.ConfigFolder())
-	if err := store.Reset(); err != nil {
-		fmt.Fprintf(output, "drand: err reseting key store: %v\n", err)
-		os.Exit(1)
+	// resetting the state under a running daemon would corrupt it
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("drand daemon must be stopped before reseting its state: %w", err)
+	}
+	defer lock.Unlock()
+	if !c.Bool(skipConfirmFlag.Name) {
+		fmt.Fprintf(output, "You are about to delete your local share, group file and generated random beacons. "+
+			"Are you sure you wish to perform this operation? [y/N]")
+		reader := bufio.NewReader(os.Stdin)
+		answer, err := reader.ReadString('\n')
+		if err != nil {
+			return fmt.Errorf("error reading: %s", err)
+		}
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if answer != "y" {
+			fmt.Fprintf(output, "drand: not reseting the state.")
+			return nil
+		}
 	}
-	if err := os.RemoveAll(conf.DBFolder()); err != nil {
-		fmt.Fprintf(output, "drand: err reseting beacons database: %v\n", err)
-		os.Exit(1)
+	// the current state is moved aside instead of being deleted so a botched
+	// reset can always be reverted
+	backupFolder := path.Join(conf.ConfigFolder(), resetBackupFolder, fmt.Sprintf("reset-%d", time.Now().Unix()))
+	if fs.CreateSecureFolder(backupFolder) == "" {
+		return fmt.Errorf("drand: can't create backup folder %s", backupFolder)
 	}
-	fmt.Println("drand: database reset")
+	groupFolder := path.Join(conf.ConfigFolder(), key.GroupFolderName)
+	if err := moveIfExists(groupFolder, path.Join(backupFolder, key.GroupFolderName)); err != nil {
+		return fmt.Errorf("drand: err backing up group folder: %v", err)
+	}
+	if err := moveIfExists(conf.DBFolder(), path.Join(backupFolder, core.DefaultDBFolder)); err != nil {
+		return fmt.Errorf("drand: err backing up beacons database: %v", err)
+	}
+	for i, part := range key.SharePartFiles(store) {
+		dst := path.Join(backupFolder, sharePartsBackupFolder, strconv.Itoa(i))
+		if fs.CreateSecureFolder(dst) == "" {
+			return fmt.Errorf("drand: can't create backup folder %s", dst)
+		}
+		if err := moveIfExists(part, path.Join(dst, path.Base(part))); err != nil {
+			return fmt.Errorf("drand: err backing up share part: %v", err)
+		}
+	}
+	if err := store.Reset(); err != nil {
+		return fmt.Errorf("drand: err reseting key store: %v", err)
+	}
+	fmt.Fprintf(output, "drand: database reset, previous state saved in %s\n", backupFolder)
 	return nil
 }
 
+// resetBackupFolder is the folder, relative to the config folder, where the
+// reset command saves the previous state of the node.
+const resetBackupFolder = "backups"
+
+// sharePartsBackupFolder is the folder, relative to the reset backup folder,
+// where the parts of a split share are saved.
+const sharePartsBackupFolder = "share-parts"
+
+func moveIfExists(src, dst string) error {
+	if exists, _ := fs.Exists(src); !exists {
+		return nil
+	}
+	return os.Rename(src, dst)
+}
+
 func askPort() string {
 	for {
 		var port string
@@ -511,16 +1149,43 @@ func keygenCmd(c *cli.Context) error {
 		fmt.Println("Invalid port.")
 		addr = addr + ":" + askPort()
 	}
+	if err := validateAddress(addr); err != nil {
+		return err
+	}
+	warnUnroutable(addr, c.Bool(resolveFlag.Name))
 	var priv *key.Pair
-	if c.Bool(insecureFlag.Name) {
+	if c.Bool(mnemonicFlag.Name) {
+		mnemonic, err := key.NewMnemonic()
+		if err != nil {
+			return err
+		}
+		priv, err = key.NewKeyPairFromMnemonic(mnemonic, addr, !c.Bool(insecureFlag.Name))
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(output, "Write down the following words and keep them safe, they are the only way to "+
+			"restore the identity of this node with `drand key restore`:\n\n%s\n\n", mnemonic)
+	} else if c.Bool(insecureFlag.Name) {
 		fmt.Println("Generating private / public key pair without TLS.")
 		priv = key.NewKeyPair(addr)
 	} else {
 		fmt.Println("Generating private / public key pair with TLS indication")
 		priv = key.NewTLSKeyPair(addr)
 	}
+	if c.IsSet(fallbacksFlag.Name) {
+		for _, fallback := range strings.Split(c.String(fallbacksFlag.Name), ",") {
+			if _, _, err := gonet.SplitHostPort(fallback); err != nil {
+				return fmt.Errorf("invalid fallback address %q: %s", fallback, err)
+			}
+			priv.Public.Fallbacks = append(priv.Public.Fallbacks, fallback)
+		}
+		priv.SelfSign()
+	}
 
-	config := contextToConfig(c)
+	config, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
 	fileStore := key.NewFileStore(config.ConfigFolder())
 
 	if _, err := fileStore.LoadKeyPair(); err == nil {
@@ -536,6 +1201,7 @@ func keygenCmd(c *cli.Context) error {
 		return fmt.Errorf("err getting full path: %s", err)
 	}
 	fmt.Println("Generated keys at ", absPath)
+	fmt.Fprintf(output, "Address %s, TLS %v\n", priv.Public.Addr, priv.Public.TLS)
 	var buff bytes.Buffer
 	if err := toml.NewEncoder(&buff).Encode(priv.Public.TOML()); err != nil {
 		panic(err)
@@ -545,6 +1211,72 @@ func keygenCmd(c *cli.Context) error {
 	return nil
 }
 
+// hostnameRegexp matches the DNS names made of letters, digits and hyphens
+var hostnameRegexp = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// validateAddress checks the address is a valid host:port, with an IP or a
+// DNS name as host and a port in the range of TCP ports.
+func validateAddress(addr string) error {
+	host, port, err := gonet.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid address %q: %s", addr, err)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("invalid address %q: port must be between 1 and 65535", addr)
+	}
+	if gonet.ParseIP(host) == nil && (len(host) > 253 || !hostnameRegexp.MatchString(host)) {
+		return fmt.Errorf("invalid address %q: host is neither an IP nor a valid hostname", addr)
+	}
+	return nil
+}
+
+// warnUnroutable prints a warning if the host of the address is an IP the
+// other nodes can't reach from another network. Hostnames are only checked
+// if resolve is true, against all the IPs they resolve to.
+func warnUnroutable(addr string, resolve bool) {
+	host, _, _ := gonet.SplitHostPort(addr)
+	ips := []gonet.IP{gonet.ParseIP(host)}
+	if ips[0] == nil {
+		if !resolve {
+			return
+		}
+		var err error
+		if ips, err = gonet.LookupIP(host); err != nil {
+			fmt.Fprintf(output, "WARNING: can't resolve %s: %s\n", host, err)
+			return
+		}
+	}
+	for _, ip := range ips {
+		var kind string
+		switch {
+		case ip.IsLoopback():
+			kind = "a loopback"
+		case ip.IsUnspecified():
+			kind = "an unspecified"
+		case isPrivateIP(ip):
+			kind = "a private"
+		case ip.IsLinkLocalUnicast():
+			kind = "a link-local"
+		default:
+			continue
+		}
+		fmt.Fprintf(output, "WARNING: %s is %s address, only nodes of the same network can reach it\n", ip, kind)
+	}
+}
+
+// privateNets are the IPv4 ranges of RFC 1918 and the IPv6 unique local range
+var privateNets = []string{"10.0.0.0/8", "172.16.0.0/12", "192.168.0.0/16", "fc00::/7"}
+
+func isPrivateIP(ip gonet.IP) bool {
+	for _, cidr := range privateNets {
+		_, n, _ := gonet.ParseCIDR(cidr)
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func groupOut(c *cli.Context, group *key.Group) error {
 	if c.IsSet("out") {
 		groupPath := c.String("out")
@@ -602,7 +1334,10 @@ func checkConnection(c *cli.Context) error {
 	} else {
 		return fmt.Errorf("drand: check-group expects a list of identities or %s flag", groupFlag.Name)
 	}
-	conf := contextToConfig(c)
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
 
 	var isVerbose = c.IsSet(verboseFlag.Name)
 	var allGood = true
@@ -649,19 +1384,22 @@ func checkIdentityAddress(conf *core.Config, addr string, tls bool) error {
 // deleteBeaconCmd deletes all beacon in the database from the given round until
 // the head of the chain
 func deleteBeaconCmd(c *cli.Context) error {
-	conf := contextToConfig(c)
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
 	startRoundStr := c.Args().First()
 	sr, err := strconv.Atoi(startRoundStr)
 	if err != nil {
 		return fmt.Errorf("given round not valid: %d", sr)
 	}
 	startRound := uint64(sr)
-	store, err := boltdb.NewBoltStore(conf.DBFolder(), conf.BoltOptions())
+	db, err := store.New(conf.StoreBackend(), conf.DBFolder(), conf.StoreOptions())
 	if err != nil {
-		return fmt.Errorf("invalid bolt store creation: %s", err)
+		return fmt.Errorf("invalid store creation: %s", err)
 	}
-	defer store.Close()
-	lastBeacon, err := store.Last()
+	defer db.Close()
+	lastBeacon, err := db.Last()
 	if err != nil {
 		return fmt.Errorf("can't fetch last beacon: %s", err)
 	}
@@ -672,7 +1410,7 @@ func deleteBeaconCmd(c *cli.Context) error {
 		fmt.Println("Planning to delete ", lastBeacon.Round-startRound, " beacons")
 	}
 	for round := startRound; round <= lastBeacon.Round; round++ {
-		err := store.Del(round)
+		err := db.Del(round)
 		if err != nil {
 			return fmt.Errorf("error deleting round %d: %s", round, err)
 		}
@@ -680,9 +1418,158 @@ func deleteBeaconCmd(c *cli.Context) error {
 			fmt.Println("- Deleted beacon round ", round)
 		}
 	}
+	// the node must be able to sign the deleted rounds again
+	if err := chain.RewindSigned(db, startRound); err != nil {
+		return fmt.Errorf("can't rewind the last round signed: %s", err)
+	}
+	return nil
+}
+
+// maxGapsShown is the number of gaps printed by the chain stats command.
+const maxGapsShown = 20
+
+func chainStatsCmd(c *cli.Context) error {
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	db, err := store.New(conf.StoreBackend(), conf.DBFolder(), conf.StoreOptions())
+	if err != nil {
+		return fmt.Errorf("invalid store creation: %s", err)
+	}
+	defer db.Close()
+	stats := chain.ComputeStats(db, func(scanned int) {
+		fmt.Fprintf(output, "scanned %d beacons...\n", scanned)
+	})
+	fmt.Fprintf(output, "beacons: %d\n", stats.Count)
+	if stats.Count > 0 {
+		fmt.Fprintf(output, "rounds: %d to %d\n", stats.First, stats.Last)
+		fmt.Fprintf(output, "missing: %d rounds in %d gaps\n", stats.Missing(), len(stats.Gaps))
+	}
+	for i, g := range stats.Gaps {
+		if i == maxGapsShown {
+			fmt.Fprintf(output, "  ... %d more gaps\n", len(stats.Gaps)-maxGapsShown)
+			break
+		}
+		if g.From == g.To {
+			fmt.Fprintf(output, "  - round %d\n", g.From)
+		} else {
+			fmt.Fprintf(output, "  - rounds %d to %d\n", g.From, g.To)
+		}
+	}
+	if stats.Size > 0 {
+		fmt.Fprintf(output, "size: %d bytes\n", stats.Size)
+	}
+	return nil
+}
+
+func chainCompactCmd(c *cli.Context) error {
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	db, err := store.New(conf.StoreBackend(), conf.DBFolder(), conf.StoreOptions())
+	if err != nil {
+		return fmt.Errorf("invalid store creation: %s", err)
+	}
+	defer db.Close()
+	compacter, ok := db.(chain.Compacter)
+	if !ok {
+		return fmt.Errorf("the %s backend does not need to be compacted", conf.StoreBackend())
+	}
+	err = compacter.Compact(func(done, total int) {
+		fmt.Fprintf(output, "compacted %d/%d beacons\n", done, total)
+	})
+	if err != nil {
+		return fmt.Errorf("compacting the store: %s", err)
+	}
+	fmt.Fprintln(output, "store compacted")
 	return nil
 }
 
+func chainStatusCmd(c *cli.Context) error {
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	size, err := storeSize(conf)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "size: %d bytes\n", size)
+	if max := int64(c.Int(maxStoreSizeFlag.Name)) << 20; max > 0 {
+		fmt.Fprintf(output, "usage: %.1f%% of %d bytes\n", float64(size)*100/float64(max), max)
+	}
+	printCryptoConfig(statusCryptoConfig(c, conf))
+	return nil
+}
+
+// storeSize prints the location of the beacon database and returns its size
+// on disk, as reported by its backend.
+func storeSize(conf *core.Config) (int64, error) {
+	backend := conf.StoreBackend()
+	if backend == store.DefaultBackend {
+		// the file is not opened since the daemon holds a lock on it
+		dbPath := path.Join(conf.DBFolder(), boltdb.BoltFileName)
+		info, err := os.Stat(dbPath)
+		if err != nil {
+			return 0, fmt.Errorf("can't read beacon database: %s", err)
+		}
+		fmt.Fprintf(output, "database: %s\n", dbPath)
+		return info.Size(), nil
+	}
+	db, err := store.New(backend, conf.DBFolder(), conf.StoreOptions())
+	if err != nil {
+		return 0, fmt.Errorf("can't read beacon database: %s", err)
+	}
+	defer db.Close()
+	sizer, ok := db.(interface{ Size() int64 })
+	if !ok {
+		return 0, fmt.Errorf("store backend %q can't report its size", backend)
+	}
+	fmt.Fprintf(output, "database: %s (%s)\n", conf.DBFolder(), backend)
+	return sizer.Size(), nil
+}
+
+// statusCryptoConfig returns the crypto configuration of the running daemon.
+// When no daemon answers, it is derived from the group file and the FIPS
+// option given to the command.
+func statusCryptoConfig(c *cli.Context, conf *core.Config) *drand.CryptoConfig {
+	if client, err := controlClient(c); err == nil {
+		if resp, err := client.Status(); err == nil && resp.GetCrypto() != nil {
+			return resp.GetCrypto()
+		}
+	}
+	fmt.Fprintln(output, "daemon not running, crypto configuration of the local files:")
+	if c.Bool(fipsFlag.Name) && !key.FIPSMode() {
+		key.SetFIPSMode(true)
+		defer key.SetFIPSMode(false)
+	}
+	group, _ := key.NewFileStore(conf.ConfigFolder()).LoadGroup()
+	return core.CryptoConfig(group)
+}
+
+func isStoreBackend(name string) bool {
+	for _, b := range store.Backends() {
+		if b == name {
+			return true
+		}
+	}
+	return false
+}
+
+var networkName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// networkFolder returns the configuration folder of the network given on the
+// command line, under the configuration folder.
+func networkFolder(c *cli.Context) (string, error) {
+	network := c.String(networkFlag.Name)
+	if !networkName.MatchString(network) {
+		return "", fmt.Errorf("invalid network name %q: only letters, digits, '-' and '_' are allowed", network)
+	}
+	return core.NetworkFolder(c.String(folderFlag.Name), network), nil
+}
+
 func toArray(flags ...cli.Flag) []cli.Flag {
 	return flags
 }
@@ -699,7 +1586,7 @@ func getGroup(c *cli.Context) (*key.Group, error) {
 	return g, nil
 }
 
-func contextToConfig(c *cli.Context) *core.Config {
+func contextToConfig(c *cli.Context) (*core.Config, error) {
 	var opts []core.ConfigOption
 
 	if c.IsSet(verboseFlag.Name) {
@@ -719,7 +1606,13 @@ func contextToConfig(c *cli.Context) *core.Config {
 	if port != "" {
 		opts = append(opts, core.WithControlPort(port))
 	}
-	if c.IsSet(folderFlag.Name) {
+	if c.IsSet(networkFlag.Name) {
+		folder, err := networkFolder(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, core.WithConfigFolder(folder))
+	} else if c.IsSet(folderFlag.Name) {
 		opts = append(opts, core.WithConfigFolder(c.String(folderFlag.Name)))
 	}
 	opts = append(opts, core.WithVersion(fmt.Sprintf("drand/%s (%s)", version, gitCommit)))
@@ -727,7 +1620,7 @@ func contextToConfig(c *cli.Context) *core.Config {
 	if c.Bool("tls-disable") {
 		opts = append(opts, core.WithInsecure())
 		if c.IsSet("tls-cert") || c.IsSet("tls-key") {
-			panic("option 'tls-disable' used with 'tls-cert' or 'tls-key': combination is not valid")
+			return nil, errors.New("option 'tls-disable' used with 'tls-cert' or 'tls-key': combination is not valid")
 		}
 	} else {
 		certPath, keyPath := c.String("tls-cert"), c.String("tls-key")
@@ -736,15 +1629,290 @@ func contextToConfig(c *cli.Context) *core.Config {
 	if c.IsSet("certs-dir") {
 		paths, err := fs.Files(c.String("certs-dir"))
 		if err != nil {
-			panic(err)
+			return nil, err
 		}
 		opts = append(opts, core.WithTrustedCerts(paths...))
+		if c.IsSet(certsOverlapFlag.Name) {
+			opts = append(opts, core.WithCertsRotation(c.String("certs-dir"), c.Duration(certsOverlapFlag.Name)))
+		}
+	} else if c.IsSet(certsOverlapFlag.Name) {
+		return nil, errors.New("option 'certs-overlap' requires 'certs-dir'")
 	}
 	if c.Bool(enablePrivateRand.Name) {
 		opts = append(opts, core.WithPrivateRandomness())
 	}
-	conf := core.NewConfig(opts...)
-	return conf
+	if c.IsSet(compressionFlag.Name) {
+		name := c.String(compressionFlag.Name)
+		if encoding.GetCompressor(name) == nil {
+			return nil, fmt.Errorf("unknown grpc compression %q", name)
+		}
+		opts = append(opts, core.WithCompression(name))
+	}
+	if c.IsSet(storeBackendFlag.Name) {
+		name := c.String(storeBackendFlag.Name)
+		if !isStoreBackend(name) {
+			return nil, fmt.Errorf("unknown db backend %q, available: %s", name, strings.Join(store.Backends(), ", "))
+		}
+		opts = append(opts, core.WithStoreBackend(name, nil))
+	}
+	if c.IsSet(maxStoreSizeFlag.Name) {
+		opts = append(opts, core.WithMaxStoreSize(int64(c.Int(maxStoreSizeFlag.Name))<<20))
+	}
+	if c.Bool(rotateInitiatorFlag.Name) {
+		opts = append(opts, core.WithInitiatorRotation())
+	}
+	if c.IsSet(aggregationGraceFlag.Name) {
+		grace := c.Duration(aggregationGraceFlag.Name)
+		if grace < 0 {
+			return nil, errors.New("option 'aggregation-grace' must not be negative")
+		}
+		opts = append(opts, core.WithAggregationGrace(grace))
+	}
+	if c.IsSet(maxClockSkewFlag.Name) {
+		skew := c.Duration(maxClockSkewFlag.Name)
+		if skew <= 0 {
+			return nil, errors.New("option 'max-clock-skew' must be positive")
+		}
+		opts = append(opts, core.WithMaxClockSkew(skew))
+	}
+	if c.IsSet(maxClockJumpFlag.Name) {
+		jump := c.Duration(maxClockJumpFlag.Name)
+		if jump <= 0 {
+			return nil, errors.New("option 'max-clock-jump' must be positive")
+		}
+		opts = append(opts, core.WithMaxClockJump(jump))
+	}
+	if c.IsSet(partialWindowFlag.Name) {
+		window := c.Int(partialWindowFlag.Name)
+		if window <= 0 {
+			return nil, errors.New("option 'partial-window' must be positive")
+		}
+		opts = append(opts, core.WithPartialWindow(uint64(window)))
+	}
+	if c.IsSet(previousEpochFlag.Name) {
+		rounds := c.Int(previousEpochFlag.Name)
+		if rounds <= 0 {
+			return nil, errors.New("option 'previous-epoch-rounds' must be positive")
+		}
+		opts = append(opts, core.WithPreviousEpochRounds(uint64(rounds)))
+	}
+	if c.IsSet(beaconCacheFlag.Name) {
+		size := c.Int(beaconCacheFlag.Name)
+		switch {
+		case size < 0:
+			return nil, errors.New("option 'beacon-cache-size' can't be negative")
+		case size == 0:
+			opts = append(opts, core.WithBeaconCacheSize(-1))
+		default:
+			opts = append(opts, core.WithBeaconCacheSize(size))
+		}
+	}
+	if c.IsSet(minThresholdFlag.Name) {
+		thr := c.Int(minThresholdFlag.Name)
+		if thr <= 1 {
+			return nil, errors.New("option 'min-threshold' must be greater than 1")
+		}
+		opts = append(opts, core.WithMinThreshold(thr))
+	}
+	if c.Bool(verifyPeersFlag.Name) {
+		if c.Bool(insecureFlag.Name) {
+			return nil, errors.New("option 'verify-peers' requires TLS")
+		}
+		opts = append(opts, core.WithPeerVerification())
+	}
+	if c.Bool(groupApprovalFlag.Name) {
+		opts = append(opts, core.WithGroupApproval())
+	}
+	limits, set, err := contextToSyncLimits(c)
+	if err != nil {
+		return nil, err
+	}
+	if set {
+		opts = append(opts, core.WithSyncLimits(limits))
+	}
+	if c.IsSet(corsOriginsFlag.Name) || c.IsSet(corsHeadersFlag.Name) {
+		opts = append(opts, core.WithCORS(c.StringSlice(corsOriginsFlag.Name), c.StringSlice(corsHeadersFlag.Name)))
+	}
+	if auth, err := contextToAuth(c, httpTokensFlag, httpClientCAFlag); err != nil {
+		return nil, err
+	} else if auth != nil {
+		opts = append(opts, core.WithPublicHTTPAuth(auth))
+	}
+	if auth, err := contextToAuth(c, grpcTokensFlag, grpcClientCAFlag); err != nil {
+		return nil, err
+	} else if auth != nil {
+		opts = append(opts, core.WithPublicGRPCAuth(auth))
+	}
+	if f, err := contextToIPFilter(c, privateAllowFlag, privateDenyFlag); err != nil {
+		return nil, err
+	} else if f != nil {
+		opts = append(opts, core.WithPrivateIPFilter(f))
+	}
+	if f, err := contextToIPFilter(c, publicAllowFlag, publicDenyFlag); err != nil {
+		return nil, err
+	} else if f != nil {
+		opts = append(opts, core.WithPublicIPFilter(f))
+	}
+	if c.IsSet(alertMissedFlag.Name) {
+		missed := c.Int(alertMissedFlag.Name)
+		if missed <= 0 {
+			return nil, errors.New("option 'alert-missed-rounds' must be positive")
+		}
+		opts = append(opts, core.WithHaltAlert(uint64(missed), c.String(alertCommandFlag.Name), c.String(alertWebhookFlag.Name)))
+	} else if c.IsSet(alertCommandFlag.Name) || c.IsSet(alertWebhookFlag.Name) {
+		return nil, errors.New("options 'alert-command' and 'alert-webhook' require 'alert-missed-rounds'")
+	}
+	if c.IsSet(bootstrapFromFlag.Name) {
+		bucket, err := snapshot.OpenBucket(c.String(bootstrapFromFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("invalid option 'bootstrap-from': %s", err)
+		}
+		opts = append(opts, core.WithBootstrap(bucket))
+	}
+	if c.IsSet(snapshotBucketFlag.Name) {
+		opt, err := contextToSnapshots(c)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	} else {
+		for _, f := range []*cli.StringFlag{snapshotPrefixFlag, snapshotRegionFlag, snapshotEndpointFlag,
+			snapshotIntervalFlag, snapshotAccessKeyFlag, snapshotSecretKeyFileFlag} {
+			if c.IsSet(f.Name) {
+				return nil, fmt.Errorf("option '%s' requires 'snapshot-bucket'", f.Name)
+			}
+		}
+	}
+	return core.NewConfig(opts...), nil
+}
+
+// contextToSnapshots returns the option uploading the snapshots of the chain to
+// the bucket set with the flags.
+func contextToSnapshots(c *cli.Context) (core.ConfigOption, error) {
+	interval, err := time.ParseDuration(c.String(snapshotIntervalFlag.Name))
+	if err != nil || interval <= 0 {
+		return nil, errors.New("option 'snapshot-interval' must be a positive duration")
+	}
+	conf := snapshot.S3Config{
+		Bucket:    c.String(snapshotBucketFlag.Name),
+		Prefix:    c.String(snapshotPrefixFlag.Name),
+		Region:    c.String(snapshotRegionFlag.Name),
+		Endpoint:  c.String(snapshotEndpointFlag.Name),
+		AccessKey: c.String(snapshotAccessKeyFlag.Name),
+	}
+	if conf.AccessKey != "" {
+		secret, err := ioutil.ReadFile(c.String(snapshotSecretKeyFileFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("option 'snapshot-access-key' requires a readable 'snapshot-secret-key-file': %s", err)
+		}
+		conf.SecretKey = strings.TrimSpace(string(secret))
+	}
+	bucket, err := snapshot.NewS3Bucket(conf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid snapshot bucket: %s", err)
+	}
+	return core.WithSnapshots(bucket, interval), nil
+}
+
+// contextToSyncLimits returns the sync limits set with the flags, and false if
+// none is set.
+func contextToSyncLimits(c *cli.Context) (beacon.SyncLimits, bool, error) {
+	var limits beacon.SyncLimits
+	var set bool
+	if c.IsSet(syncBatchFlag.Name) {
+		batch := c.Int(syncBatchFlag.Name)
+		if batch <= 0 {
+			return limits, false, errors.New("option 'sync-batch' must be positive")
+		}
+		limits.BatchSize = uint64(batch)
+		set = true
+	}
+	if c.IsSet(syncRateFlag.Name) {
+		rate := c.Float64(syncRateFlag.Name)
+		if rate <= 0 {
+			return limits, false, errors.New("option 'sync-rate' must be positive")
+		}
+		limits.RoundRate = rate
+		set = true
+	}
+	if c.IsSet(syncBytesRateFlag.Name) {
+		rate := c.Int64(syncBytesRateFlag.Name)
+		if rate <= 0 {
+			return limits, false, errors.New("option 'sync-bytes-rate' must be positive")
+		}
+		limits.ByteRate = rate
+		set = true
+	}
+	if c.IsSet(syncMaxPeersFlag.Name) {
+		peers := c.Int(syncMaxPeersFlag.Name)
+		if peers <= 0 {
+			return limits, false, errors.New("option 'sync-max-peers' must be positive")
+		}
+		limits.MaxPeers = peers
+		set = true
+	}
+	if c.IsSet(syncParallelFlag.Name) {
+		parallel := c.Int(syncParallelFlag.Name)
+		if parallel <= 0 {
+			return limits, false, errors.New("option 'sync-parallel' must be positive")
+		}
+		limits.Parallel = parallel
+		set = true
+	}
+	return limits, set, nil
+}
+
+// contextToIPFilter returns the filter configured by the given flags, or nil
+// if none of them is set.
+func contextToIPFilter(c *cli.Context, allowFlag, denyFlag *cli.StringFlag) (*net.IPFilter, error) {
+	if !c.IsSet(allowFlag.Name) && !c.IsSet(denyFlag.Name) {
+		return nil, nil
+	}
+	f, err := net.NewIPFilter(splitList(c.String(allowFlag.Name)), splitList(c.String(denyFlag.Name)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid option '%s' or '%s': %w", allowFlag.Name, denyFlag.Name, err)
+	}
+	return f, nil
+}
+
+// contextToAuth returns the authentication configured by the given flags, or
+// nil if none of them is set.
+func contextToAuth(c *cli.Context, tokensFlag, caFlag *cli.StringFlag) (*net.Auth, error) {
+	if !c.IsSet(tokensFlag.Name) && !c.IsSet(caFlag.Name) {
+		return nil, nil
+	}
+	var tokens []string
+	if c.IsSet(tokensFlag.Name) {
+		buff, err := ioutil.ReadFile(c.String(tokensFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("option '%s' requires a readable file: %w", tokensFlag.Name, err)
+		}
+		for _, line := range strings.Split(string(buff), "\n") {
+			if t := strings.TrimSpace(line); t != "" {
+				tokens = append(tokens, t)
+			}
+		}
+	}
+	var cas []string
+	if c.IsSet(caFlag.Name) {
+		cas = append(cas, c.String(caFlag.Name))
+	}
+	auth, err := net.NewAuth(tokens, cas...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid option '%s' or '%s': %w", tokensFlag.Name, caFlag.Name, err)
+	}
+	return auth, nil
+}
+
+// splitList splits a comma separated list, ignoring empty elements
+func splitList(list string) []string {
+	var out []string
+	for _, e := range strings.Split(list, ",") {
+		if e = strings.TrimSpace(e); e != "" {
+			out = append(out, e)
+		}
+	}
+	return out
 }
 
 func getNodes(c *cli.Context) ([]*key.Node, error) {
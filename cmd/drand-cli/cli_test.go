@@ -4,35 +4,164 @@ import (
 	"bytes"
 	"context"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	gnet "net"
+	nhttp "net/http"
+	"net/http/httptest"
 	"os"
 	"path"
+	"path/filepath"
+	"regexp"
+	"runtime"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
+	"unicode"
 
 	json "github.com/nikkolasg/hexjson"
 
 	"github.com/BurntSushi/toml"
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/store"
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/client/grpc"
+	"github.com/drand/drand/client/test/result/mock"
 	"github.com/drand/drand/core"
 	"github.com/drand/drand/fs"
 	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
 	"github.com/drand/drand/test"
+	testmock "github.com/drand/drand/test/mock"
 	"github.com/drand/kyber"
 	"github.com/drand/kyber/share"
 	"github.com/drand/kyber/util/random"
 	"github.com/kabukky/httpscerts"
+	"github.com/urfave/cli/v2"
 
 	"github.com/stretchr/testify/require"
 )
 
 const expectedShareOutput = "0000000000000000000000000000000000000000000000000000000000000001"
 
+// TestCLIConventions checks the help and the flags of all the commands are
+// written the same way.
+func TestCLIConventions(t *testing.T) {
+	flagName := regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	var check func(prefix string, cmds []*cli.Command)
+	check = func(prefix string, cmds []*cli.Command) {
+		for _, cmd := range cmds {
+			name := prefix + " " + cmd.Name
+			require.NotEmpty(t, cmd.Usage, name)
+			require.True(t, unicode.IsUpper(rune(cmd.Usage[0])), "%s: usage must start with a capital letter", name)
+			require.True(t, strings.HasSuffix(cmd.Usage, "."), "%s: usage must be a sentence without trailing newline", name)
+			for _, f := range cmd.Flags {
+				require.Regexp(t, flagName, f.Names()[0], "%s: flag names are kebab-case", name)
+				usage := f.(cli.DocGenerationFlag).GetUsage()
+				require.True(t, strings.HasSuffix(usage, "."), "%s: usage of --%s must be a sentence", name, f.Names()[0])
+			}
+			check(name, cmd.Subcommands)
+		}
+	}
+	check("drand", CLI().Commands)
+
+	// a failing command is reported to the caller, which exits with an error
+	require.Error(t, CLI().Run([]string{"drand", "util", "del-beacon", "--folder", t.TempDir()}))
+	require.Error(t, CLI().Run([]string{"drand", "unknown-command"}))
+}
+
+func TestStartInvalidOptions(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-options")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	// the invalid options are reported as errors of the command
+	for _, opts := range [][]string{
+		{"--grpc-compression", "unknown"},
+		{"--max-clock-skew", "0s"},
+		{"--sync-batch", "0"},
+		{"--snapshot-prefix", "chain"},
+		{"--public-http-tokens", path.Join(tmp, "missing")},
+		{"--private-allow", "not-a-network"},
+	} {
+		args := append([]string{"drand", "start", "--tls-disable", "--folder", tmp}, opts...)
+		require.Error(t, CLI().Run(args), "%v", opts)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-status")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	conf := core.NewConfig(core.WithConfigFolder(tmp))
+	fs.CreateSecureFolder(conf.DBFolder())
+	store, err := boltdb.NewBoltStore(conf.DBFolder(), conf.BoltOptions())
+	require.NoError(t, err)
+	require.NoError(t, store.Put(&chain.Beacon{Round: 1, Signature: []byte("Hello")}))
+	store.Close()
+
+	var buff bytes.Buffer
+	output = &buff
+	defer func() { output = os.Stdout }()
+	// no daemon runs, the crypto configuration is the one of the local files
+	ctrl := test.FreePort()
+	// the status is a top level command, still available under util
+	for _, args := range [][]string{{"drand", "status"}, {"drand", "util", "status"}} {
+		buff.Reset()
+		require.NoError(t, CLI().Run(append(args, "--folder", tmp, "--control", ctrl, "--max-store-size", "1")))
+		require.Contains(t, buff.String(), "size: ")
+		require.Contains(t, buff.String(), "usage: ")
+		require.Contains(t, buff.String(), "crypto (FIPS mode false):")
+	}
+
+	buff.Reset()
+	require.NoError(t, CLI().Run([]string{"drand", "status", "--folder", tmp, "--control", ctrl, "--fips"}))
+	require.Contains(t, buff.String(), "crypto (FIPS mode true):")
+	require.False(t, key.FIPSMode())
+}
+
+func init() {
+	// backends other than the default one, able or not to report their size
+	store.Register("sized-test", func(folder string, opts interface{}) (chain.Store, error) {
+		return boltdb.NewBoltStore(folder, nil)
+	})
+	store.Register("unsized-test", func(folder string, opts interface{}) (chain.Store, error) {
+		s, err := boltdb.NewBoltStore(folder, nil)
+		if err != nil {
+			return nil, err
+		}
+		return struct{ chain.Store }{s}, nil
+	})
+}
+
+func TestStatusBackends(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-status")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	fs.CreateSecureFolder(core.NewConfig(core.WithConfigFolder(tmp)).DBFolder())
+
+	var buff bytes.Buffer
+	output = &buff
+	defer func() { output = os.Stdout }()
+	ctrl := test.FreePort()
+	status := []string{"drand", "status", "--folder", tmp, "--control", ctrl, "--db-backend"}
+	require.NoError(t, CLI().Run(append(status, "sized-test")))
+	require.Contains(t, buff.String(), "(sized-test)")
+	require.Error(t, CLI().Run(append(status, "unsized-test")))
+
+	// the daemon refuses a maximum size it can't enforce
+	start := []string{"drand", "start", "--tls-disable", "--folder", tmp, "--control", ctrl,
+		"--db-backend", "unsized-test", "--max-store-size", "1"}
+	err = CLI().Run(start)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "can't report its size")
+}
+
 func TestDeleteBeacon(t *testing.T) {
 	tmp := path.Join(os.TempDir(), "drand")
 	defer os.RemoveAll(tmp)
@@ -58,6 +187,8 @@ func TestDeleteBeacon(t *testing.T) {
 		Round:     4,
 		Signature: []byte("hello"),
 	})
+	// the node signed up to round 4
+	require.NoError(t, store.(chain.SignedStore).PutSigned(&chain.SignedRound{Round: 4, Message: []byte("msg")}))
 	// try to fetch round 3 and 4
 	b, err := store.Get(3)
 	require.NoError(t, err)
@@ -81,6 +212,43 @@ func TestDeleteBeacon(t *testing.T) {
 	b, err = store.Get(4)
 	require.Error(t, err)
 	require.Nil(t, b)
+	// and rewind the last round signed so that the node signs them again
+	signed, err := store.(chain.SignedStore).LastSigned()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), signed.Round)
+	require.Nil(t, signed.Message)
+	store.Close()
+
+	// the command is also available in the chain group
+	args = []string{"drand", "chain", "del-beacon", "--folder", tmp, "2"}
+	require.NoError(t, CLI().Run(args))
+	store, err = boltdb.NewBoltStore(conf.DBFolder(), conf.BoltOptions())
+	require.NoError(t, err)
+	defer store.Close()
+	_, err = store.Get(2)
+	require.Error(t, err)
+	b, err = store.Get(1)
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), b.Round)
+}
+
+func TestChainStatsCompact(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-stats-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	conf := core.NewConfig(core.WithConfigFolder(tmp))
+	fs.CreateSecureFolder(conf.DBFolder())
+	store, err := boltdb.NewBoltStore(conf.DBFolder(), conf.BoltOptions())
+	require.NoError(t, err)
+	for _, round := range []uint64{1, 2, 5, 6, 7, 9} {
+		require.NoError(t, store.Put(&chain.Beacon{Round: round, Signature: []byte("signature")}))
+	}
+	store.Close()
+
+	stats := []string{"drand", "chain", "stats", "--folder", tmp}
+	testCommand(t, stats, "beacons: 6\nrounds: 1 to 9\nmissing: 3 rounds in 2 gaps\n  - rounds 3 to 4\n  - round 8")
+	testCommand(t, []string{"drand", "chain", "compact", "--folder", tmp}, "compacted 6/6 beacons\nstore compacted")
+	testCommand(t, stats, "beacons: 6\nrounds: 1 to 9")
 }
 
 func TestKeySelfSign(t *testing.T) {
@@ -129,6 +297,83 @@ func TestKeyGen(t *testing.T) {
 	require.Nil(t, priv)
 }
 
+func TestKeyGenAddress(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drand-keygen")
+	defer os.RemoveAll(tmp)
+	for _, addr := range []string{"127.0.0.1:0", "127.0.0.1:70000", "-bad-.host:8080", "[::1:8080"} {
+		args := []string{"drand", "keygen", "--folder", tmp, addr}
+		require.Error(t, CLI().Run(args), addr)
+	}
+
+	args := []string{"drand", "keygen", "--tls-disable", "--folder", tmp, "drand.example.org:4444"}
+	require.NoError(t, CLI().Run(args))
+	config := core.NewConfig(core.WithConfigFolder(tmp))
+	fileStore := key.NewFileStore(config.ConfigFolder())
+	priv, err := fileStore.LoadKeyPair()
+	require.NoError(t, err)
+	require.Equal(t, "drand.example.org:4444", priv.Public.Addr)
+	require.False(t, priv.Public.TLS)
+
+	keyFolder := path.Join(config.ConfigFolder(), key.KeyFolderName)
+	private, err := os.Stat(path.Join(keyFolder, "drand_id.private"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0600), private.Mode().Perm())
+	public, err := os.Stat(path.Join(keyFolder, "drand_id.public"))
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(0644), public.Mode().Perm())
+}
+
+func TestUtilCollectKeys(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drand-collect")
+	os.MkdirAll(tmp, 0740)
+	defer os.RemoveAll(tmp)
+	n := 3
+	identities := make(map[string][]byte, n)
+	urls := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		pair := key.NewTLSKeyPair(fmt.Sprintf("drand-%d.example.org:4444", i))
+		var buff bytes.Buffer
+		require.NoError(t, toml.NewEncoder(&buff).Encode(pair.Public.TOML()))
+		name := fmt.Sprintf("/%d.public", i)
+		identities[name] = buff.Bytes()
+		urls = append(urls, name)
+	}
+	// the identities are only published after a first failed attempt
+	var served = make(map[string]bool)
+	var lock sync.Mutex
+	srv := httptest.NewServer(nhttp.HandlerFunc(func(w nhttp.ResponseWriter, r *nhttp.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		if !served[r.URL.Path] {
+			served[r.URL.Path] = true
+			nhttp.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(identities[r.URL.Path])
+	}))
+	defer srv.Close()
+	for i := range urls {
+		urls[i] = srv.URL + urls[i]
+	}
+
+	groupPath := path.Join(tmp, "group.toml")
+	args := append([]string{"drand", "util", "collect-keys", "--wait", "10s", "--period", "30s", "--out", groupPath}, urls...)
+	require.NoError(t, CLI().Run(args))
+	group := new(key.Group)
+	require.NoError(t, key.Load(groupPath, group))
+	require.Len(t, group.Nodes, n)
+	require.Equal(t, key.DefaultThreshold(n), group.Threshold)
+	require.Equal(t, 30*time.Second, group.Period)
+
+	// a group can't be assembled if an identity is missing
+	args = append([]string{"drand", "util", "collect-keys", "--wait", "1s", "--out", groupPath}, urls[:2]...)
+	args = append(args, srv.URL+"/missing.public")
+	err := CLI().Run(args)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "collected 2 of the 3 identities expected")
+	require.Contains(t, err.Error(), srv.URL+"/missing.public")
+}
+
 // tests valid commands and then invalid commands
 func TestStartAndStop(t *testing.T) {
 	tmpPath := path.Join(os.TempDir(), "drand")
@@ -163,6 +408,45 @@ func TestStartAndStop(t *testing.T) {
 	}
 }
 
+func TestStartSignal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("signals can not be sent to the process on windows")
+	}
+	tmp, err := ioutil.TempDir("", "drand-signal-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	generate := []string{"drand", "generate-keypair", "--tls-disable", "--folder", tmp, "127.0.0.1:" + test.FreePort()}
+	require.NoError(t, CLI().Run(generate))
+
+	start := []string{"drand", "start", "--tls-disable", "--folder", tmp, "--control", test.FreePort()}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- CLI().Run(start)
+	}()
+	// the daemon handles the signals once it wrote its control port
+	portFile := path.Join(tmp, core.ControlPortFileName)
+	require.Eventually(t, func() bool {
+		exists, _ := fs.Exists(portFile)
+		return exists
+	}, 5*time.Second, 10*time.Millisecond)
+
+	self, err := os.FindProcess(os.Getpid())
+	require.NoError(t, err)
+	require.NoError(t, self.Signal(syscall.SIGTERM))
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(shutdownTimeout + time.Second):
+		t.Fatal("drand daemon did not stop on SIGTERM")
+	}
+	// the daemon released the folder
+	exists, _ := fs.Exists(portFile)
+	require.False(t, exists)
+	lock, err := fs.LockFolder(tmp)
+	require.NoError(t, err)
+	lock.Unlock()
+}
+
 func TestUtilCheck(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "drand-cli-*")
 	require.NoError(t, err)
@@ -175,21 +459,33 @@ func TestUtilCheck(t *testing.T) {
 
 	listenPort := test.FreePort()
 	listenAddr := "127.0.0.1:" + listenPort
-	listen := []string{"drand", "start", "--tls-disable", "--private-listen", listenAddr, "--folder", tmp}
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-	go CLI().RunContext(ctx, listen)
+	ctrlPort := test.FreePort()
+	listen := []string{"drand", "start", "--tls-disable", "--private-listen", listenAddr, "--folder", tmp, "--control", ctrlPort}
+	startCh := make(chan bool)
+	go func() {
+		CLI().Run(listen)
+		startCh <- true
+	}()
 	// XXX can we maybe try to bind continuously to not having to wait
 	time.Sleep(200 * time.Millisecond)
 
+	// a second daemon can not run on the same folder
+	second := []string{"drand", "start", "--tls-disable", "--folder", tmp, "--control", test.FreePort()}
+	require.Error(t, CLI().Run(second))
+
 	// run the check tool it should fail because key and address are not
 	// consistent
 	check := []string{"drand", "util", "check", "--tls-disable", listenAddr}
 	require.Error(t, CLI().Run(check))
 
-	// cancel the daemon and make it listen on the right address
-	cancel()
-	ctx, cancel = context.WithCancel(context.Background())
+	// stop the daemon and make it listen on the right address
+	CLI().Run([]string{"drand", "stop", "--control", ctrlPort})
+	select {
+	case <-startCh:
+	case <-time.After(1 * time.Second):
+		t.Fatal("drand daemon did not stop")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 	listen = []string{"drand", "start", "--tls-disable", "--folder", tmp, "--control", test.FreePort()}
 	go CLI().RunContext(ctx, listen)
@@ -199,6 +495,20 @@ func TestUtilCheck(t *testing.T) {
 	require.NoError(t, CLI().Run(check))
 }
 
+func TestFollowArgs(t *testing.T) {
+	ctrlPort := test.FreePort()
+	for _, args := range [][]string{
+		{"drand", "follow", "--control", ctrlPort},
+		{"drand", "follow", "--control", ctrlPort, "nothex", "127.0.0.1:8080"},
+		{"drand", "follow", "--control", ctrlPort, "--chain-hash", "abcd"},
+		{"drand", "follow", "--control", ctrlPort, "abcd", ","},
+	} {
+		err := CLI().Run(args)
+		require.Error(t, err)
+		require.NotContains(t, err.Error(), "error asking to follow chain", args)
+	}
+}
+
 func TestStartWithoutGroup(t *testing.T) {
 	tmpPath := path.Join(os.TempDir(), "drand")
 	os.Mkdir(tmpPath, 0740)
@@ -258,7 +568,6 @@ func TestStartWithoutGroup(t *testing.T) {
 	group.GenesisTime = time.Now().Unix() - 10
 	group.PublicKey = distKey
 	group.Nodes[0] = &key.Node{Identity: priv.Public, Index: 0}
-	group.Nodes[1] = &key.Node{Identity: priv.Public, Index: 1}
 	groupPath := path.Join(tmpPath, "drand_group.toml")
 	require.NoError(t, key.Save(groupPath, group, false))
 	// save it also to somewhere drand will find it
@@ -329,8 +638,19 @@ func testStartedDrandFunctional(t *testing.T, ctrlPort, rootPath, address string
 	expectedOutput = fmt.Sprintf("%x", chain.NewChainInfo(group).Hash())
 	testCommand(t, showChainInfo, expectedOutput)
 
-	// reset state
-	resetCmd := []string{"drand", "util", "reset", "--folder", rootPath}
+	showChainInfo = []string{"drand", "show", "chain-info", "--fingerprint", "--control", ctrlPort}
+	testCommand(t, showChainInfo, chain.NewChainInfo(group).Fingerprint())
+
+	chainInfoHash := []string{"drand", "chain", "info", "--hash", "--control", ctrlPort}
+	testCommand(t, chainInfoHash, expectedOutput)
+
+	// reset state, which is refused while the daemon is running
+	resetCmd := []string{"drand", "util", "reset", "--force", "--folder", rootPath}
+	require.Error(t, CLI().Run(resetCmd))
+	CLI().Run([]string{"drand", "stop", "--control", ctrlPort})
+	time.Sleep(500 * time.Millisecond)
+
+	resetCmd = []string{"drand", "util", "reset", "--folder", rootPath}
 	r, w, err := os.Pipe()
 	require.NoError(t, err)
 	_, err = w.Write([]byte("y\n"))
@@ -341,6 +661,13 @@ func testStartedDrandFunctional(t *testing.T, ctrlPort, rootPath, address string
 	require.Error(t, err)
 	_, err = fileStore.LoadGroup()
 	require.Error(t, err)
+	// the previous state has been saved
+	backups, err := ioutil.ReadDir(path.Join(rootPath, resetBackupFolder))
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	saved := key.NewFileStore(path.Join(rootPath, resetBackupFolder, backups[0].Name()))
+	_, err = saved.LoadShare()
+	require.NoError(t, err)
 }
 
 func TestClientTLS(t *testing.T) {
@@ -472,3 +799,510 @@ func testCommand(t *testing.T, args []string, exp string) {
 	fmt.Println("CONTAINS: ", strings.Contains(strings.Trim(buff.String(), "\n"), exp))
 	require.True(t, strings.Contains(strings.Trim(buff.String(), "\n"), exp))
 }
+
+func TestBackupRestore(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-backup-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "src")
+	dst := path.Join(tmp, "dst")
+	passFile := path.Join(tmp, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passFile, []byte("a long enough passphrase\n"), 0600))
+	archive := path.Join(tmp, "backup.enc")
+
+	generate := []string{"drand", "generate-keypair", "--tls-disable", "--folder", src, "127.0.0.1:8081"}
+	require.NoError(t, CLI().Run(generate))
+	create := []string{"drand", "backup", "create", "--folder", src, "--passphrase-file", passFile, archive}
+	require.NoError(t, CLI().Run(create))
+
+	restore := []string{"drand", "backup", "restore", "--folder", dst, "--passphrase-file", passFile, archive}
+	require.NoError(t, CLI().Run(restore))
+	pair, err := key.NewFileStore(dst).LoadKeyPair()
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:8081", pair.Public.Address())
+
+	// existing state is not overwritten unless forced
+	require.Error(t, CLI().Run(restore))
+	forced := append(restore[:3:3], "--force", "--folder", dst, "--passphrase-file", passFile, archive)
+	require.NoError(t, CLI().Run(forced))
+}
+
+func TestSharePartsBackupReset(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-parts-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "src")
+	passFile := path.Join(tmp, "passphrase")
+	require.NoError(t, ioutil.WriteFile(passFile, []byte("a long enough passphrase\n"), 0600))
+	archive := path.Join(tmp, "backup.enc")
+	parts := path.Join(tmp, "a") + "," + path.Join(tmp, "b")
+
+	generate := []string{"drand", "generate-keypair", "--tls-disable", "--folder", src, "127.0.0.1:8081"}
+	require.NoError(t, CLI().Run(generate))
+	store, err := key.NewSplitShareFileStore(src, 2, []string{path.Join(tmp, "a"), path.Join(tmp, "b")})
+	require.NoError(t, err)
+	priPoly := share.NewPriPoly(key.KeyGroup, 2, nil, random.New())
+	_, commits := priPoly.Commit(key.KeyGroup.Point().Base()).Info()
+	require.NoError(t, store.SaveShare(&key.Share{Commits: commits, Share: priPoly.Shares(3)[0]}))
+	files := key.SharePartFiles(store)
+
+	// the parts are archived and restored to the given locations
+	create := []string{"drand", "backup", "create", "--folder", src, "--share-parts", parts, "--passphrase-file", passFile, archive}
+	require.NoError(t, CLI().Run(create))
+	dst := path.Join(tmp, "dst")
+	dstParts := path.Join(tmp, "c") + "," + path.Join(tmp, "d")
+	restore := []string{"drand", "backup", "restore", "--folder", dst, "--passphrase-file", passFile, archive}
+	require.Error(t, CLI().Run(restore))
+	restore = append(restore[:3:3], "--folder", dst, "--share-parts", dstParts, "--passphrase-file", passFile, archive)
+	require.NoError(t, CLI().Run(restore))
+	for _, l := range splitList(dstParts) {
+		_, err := os.Stat(path.Join(l, path.Base(files[0])))
+		require.NoError(t, err)
+	}
+
+	// the parts are moved aside on reset
+	reset := []string{"drand", "util", "reset", "--folder", src, "--share-parts", parts, "--force"}
+	require.NoError(t, CLI().Run(reset))
+	for i, f := range files {
+		_, err := os.Stat(f)
+		require.True(t, os.IsNotExist(err))
+		saved, err := filepath.Glob(path.Join(src, resetBackupFolder, "reset-*", sharePartsBackupFolder, strconv.Itoa(i), path.Base(f)))
+		require.NoError(t, err)
+		require.Len(t, saved, 1)
+	}
+}
+
+func TestUtilBench(t *testing.T) {
+	bench := []string{"drand", "util", "bench", "--nodes", "3", "--iterations", "1"}
+	testCommand(t, bench, "partial verify")
+	require.Error(t, CLI().Run([]string{"drand", "util", "bench", "--nodes", "3", "--threshold", "4"}))
+}
+
+func TestUtilTestVectors(t *testing.T) {
+	vectors, err := newTestVectors("seed", 4, 3, 2)
+	require.NoError(t, err)
+	again, err := newTestVectors("seed", 4, 3, 2)
+	require.NoError(t, err)
+	require.Equal(t, vectors, again)
+	require.Len(t, vectors.Commitments, 3)
+	require.Len(t, vectors.Chains, 2*len(key.DigestNames()))
+
+	// the vectors verify as beacons of their chain
+	buff, err := hex.DecodeString(vectors.PublicKey)
+	require.NoError(t, err)
+	public := key.KeyGroup.Point()
+	require.NoError(t, public.UnmarshalBinary(buff))
+	genesis, err := hex.DecodeString(vectors.GenesisSeed)
+	require.NoError(t, err)
+	formats := make(map[string]bool)
+	for _, vc := range vectors.Chains {
+		formats[vc.Format+"/"+vc.Digest] = true
+		require.Len(t, vc.Rounds, 2)
+		require.Len(t, vc.Rounds[0].Partials, 4)
+		require.Equal(t, vc.Rounds[0].Signature, vc.Rounds[1].PreviousSignature)
+		info := &chain.Info{
+			PublicKey:      public,
+			Period:         time.Duration(vc.Period) * time.Second,
+			GenesisTime:    vc.GenesisTime,
+			GroupHash:      genesis,
+			MessageV1Round: vc.MessageV1Round,
+			Digest:         vc.Digest,
+		}
+		require.Equal(t, vc.ChainHash, hex.EncodeToString(info.Hash()))
+		for _, r := range vc.Rounds {
+			prev, err := hex.DecodeString(r.PreviousSignature)
+			require.NoError(t, err)
+			sig, err := hex.DecodeString(r.Signature)
+			require.NoError(t, err)
+			require.Equal(t, r.Message, hex.EncodeToString(info.Message(r.Round, prev)))
+			require.Equal(t, r.Randomness, hex.EncodeToString(info.Randomness(sig)))
+			require.NoError(t, info.VerifyBeacon(&chain.Beacon{Round: r.Round, PreviousSig: prev, Signature: sig}))
+		}
+	}
+	require.Len(t, formats, len(vectors.Chains))
+
+	other, err := newTestVectors("another seed", 4, 3, 2)
+	require.NoError(t, err)
+	require.NotEqual(t, vectors.PublicKey, other.PublicKey)
+	testCommand(t, []string{"drand", "util", "test-vectors", "--nodes", "2", "--rounds", "1"}, "public_share")
+	require.Error(t, CLI().Run([]string{"drand", "util", "test-vectors", "--nodes", "3", "--threshold", "4"}))
+}
+
+func TestPrintRandomness(t *testing.T) {
+	var buff bytes.Buffer
+	output = &buff
+	defer func() { output = os.Stdout }()
+
+	r := mock.NewMockResult(3)
+	require.NoError(t, printRandomness(formatRaw, &r))
+	require.Equal(t, r.Randomness(), buff.Bytes())
+
+	buff.Reset()
+	require.NoError(t, printRandomness(formatJSON, &r))
+	require.Contains(t, buff.String(), hex.EncodeToString(r.Randomness()))
+
+	tmp, err := ioutil.TempDir("", "drand-format-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	_, group := test.BatchIdentities(3)
+	group.PublicKey = &key.DistPublic{Coefficients: []kyber.Point{key.KeyGroup.Point().Pick(random.New())}}
+	groupPath := path.Join(tmp, "group.toml")
+	require.NoError(t, key.Save(groupPath, group, false))
+	get := []string{"drand", "get", "public", "--format", "base64", groupPath}
+	require.Error(t, CLI().Run(get))
+}
+
+// watchClient only watches the given results.
+type watchClient struct {
+	info    *chain.Info
+	results []mock.Result
+}
+
+func (w *watchClient) Get(ctx context.Context, round uint64) (client.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (w *watchClient) Watch(ctx context.Context) <-chan client.Result {
+	ch := make(chan client.Result, len(w.results))
+	for i := range w.results {
+		ch <- &w.results[i]
+	}
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch
+}
+
+func (w *watchClient) Info(ctx context.Context) (*chain.Info, error) { return w.info, nil }
+
+func (w *watchClient) RoundAt(t time.Time) uint64 {
+	return chain.CurrentRound(t.Unix(), w.info.Period, w.info.GenesisTime)
+}
+
+func (w *watchClient) Close() error { return nil }
+
+func (w *watchClient) String() string { return "watchClient" }
+
+func TestWatchRawOutput(t *testing.T) {
+	info, results := mock.VerifiableResults(3)
+	r, w, err := os.Pipe()
+	require.NoError(t, err)
+	// the default logger of the clients writes to stdout
+	stdout := os.Stdout
+	os.Stdout, output = w, w
+	log.SetDefaultLogger(log.LoggerTo(w), log.LogInfo)
+	defer func() {
+		os.Stdout, output = stdout, stdout
+		log.SetDefaultLogger(log.LoggerTo(stdout), log.DefaultLevel)
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		clients := []client.Client{&watchClient{info, results}, &watchClient{info, results}}
+		done <- watchRandomness(ctx, clients, info, formatRaw)
+	}()
+	expected := make([]byte, 0, len(results)*32)
+	for i := range results {
+		expected = append(expected, results[i].Randomness()...)
+	}
+	got := make([]byte, len(expected))
+	_, err = io.ReadFull(r, got)
+	require.NoError(t, err)
+	cancel()
+	require.NoError(t, <-done)
+	require.NoError(t, w.Close())
+	rest, err := ioutil.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, expected, append(got, rest...))
+}
+
+func TestKeyExportImport(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-key-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "src")
+	dst := path.Join(tmp, "dst")
+
+	generate := []string{"drand", "generate-keypair", "--tls-disable", "--folder", src, "127.0.0.1:8081"}
+	require.NoError(t, CLI().Run(generate))
+	pair, err := key.NewFileStore(src).LoadKeyPair()
+	require.NoError(t, err)
+
+	for _, encoding := range []string{key.HexEncoding, key.Base64Encoding, key.PEMEncoding} {
+		exported := path.Join(tmp, "private."+encoding)
+		export := []string{"drand", "key", "export", "--folder", src, "--type", "private", "--encoding", encoding, "--out", exported}
+		require.NoError(t, CLI().Run(export))
+
+		imp := []string{"drand", "key", "import", "--folder", dst, "--type", "private", "--encoding", encoding, "--tls-disable", exported, "127.0.0.1:8082"}
+		if encoding != key.HexEncoding {
+			// the key pair imported first is only replaced when forced
+			require.Error(t, CLI().Run(imp))
+			imp = append(imp[:3:3], append([]string{"--force"}, imp[3:]...)...)
+		}
+		require.NoError(t, CLI().Run(imp))
+		imported, err := key.NewFileStore(dst).LoadKeyPair()
+		require.NoError(t, err)
+		require.True(t, imported.Key.Equal(pair.Key))
+		require.True(t, imported.Public.Key.Equal(pair.Public.Key))
+		require.Equal(t, "127.0.0.1:8082", imported.Public.Addr)
+		require.NoError(t, imported.Public.ValidSignature())
+	}
+
+	exported := path.Join(tmp, "public.pem")
+	export := []string{"drand", "key", "export", "--folder", src, "--encoding", "pem", "--out", exported}
+	require.NoError(t, CLI().Run(export))
+	imp := []string{"drand", "key", "import", "--encoding", "pem", exported}
+	testCommand(t, imp, key.PointToString(pair.Public.Key))
+	// the PEM block type must match the type of key
+	require.Error(t, CLI().Run([]string{"drand", "key", "import", "--type", "dist", "--encoding", "pem", exported}))
+}
+
+func TestKeyRestoreMnemonic(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-mnemonic-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	src := path.Join(tmp, "src")
+	dst := path.Join(tmp, "dst")
+
+	var buff bytes.Buffer
+	output = &buff
+	defer func() { output = os.Stdout }()
+	generate := []string{"drand", "generate-keypair", "--mnemonic", "--folder", src, "127.0.0.1:8081"}
+	require.NoError(t, CLI().Run(generate))
+	parts := strings.Split(buff.String(), "\n\n")
+	require.True(t, len(parts) > 2)
+	mnemonic := parts[1]
+	require.Len(t, strings.Fields(mnemonic), 24)
+	pair, err := key.NewFileStore(src).LoadKeyPair()
+	require.NoError(t, err)
+
+	mnemonicFile := path.Join(tmp, "mnemonic")
+	require.NoError(t, ioutil.WriteFile(mnemonicFile, []byte(mnemonic+"\n"), 0600))
+	restore := []string{"drand", "key", "restore", "--folder", dst, "--mnemonic-file", mnemonicFile, "127.0.0.1:8081"}
+	require.NoError(t, CLI().Run(restore))
+	restored, err := key.NewFileStore(dst).LoadKeyPair()
+	require.NoError(t, err)
+	require.True(t, restored.Key.Equal(pair.Key))
+	require.True(t, restored.Public.Equal(pair.Public))
+	// the restored key pair is not overwritten unless forced
+	require.Error(t, CLI().Run(restore))
+}
+
+func TestNetworkFolders(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-networks-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	for i, network := range []string{"mainnet", "testnet"} {
+		generate := []string{"drand", "generate-keypair", "--tls-disable", "--folder", tmp, "--network", network,
+			fmt.Sprintf("127.0.0.1:%d", 8081+i)}
+		require.NoError(t, CLI().Run(generate))
+	}
+	mainnet, err := key.NewFileStore(core.NetworkFolder(tmp, "mainnet")).LoadKeyPair()
+	require.NoError(t, err)
+	testnet, err := key.NewFileStore(core.NetworkFolder(tmp, "testnet")).LoadKeyPair()
+	require.NoError(t, err)
+	require.False(t, mainnet.Public.Key.Equal(testnet.Public.Key))
+	_, err = key.NewFileStore(tmp).LoadKeyPair()
+	require.Error(t, err)
+
+	// the control commands of a network reach the daemon started for it
+	ctrlPort := test.FreePort()
+	start := []string{"drand", "start", "--tls-disable", "--folder", tmp, "--network", "testnet", "--control", ctrlPort}
+	go CLI().Run(start)
+	portFile := path.Join(core.NetworkFolder(tmp, "testnet"), core.ControlPortFileName)
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(portFile); err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.NoError(t, CLI().Run([]string{"drand", "--folder", tmp, "util", "ping", "--network", "testnet"}))
+	_ = CLI().Run([]string{"drand", "--folder", tmp, "stop", "--network", "testnet"})
+	for i := 0; i < 50; i++ {
+		if _, err := os.Stat(portFile); os.IsNotExist(err) {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	_, err = os.Stat(portFile)
+	require.True(t, os.IsNotExist(err))
+
+	for _, network := range []string{"", "a/b", "../mainnet"} {
+		require.Error(t, CLI().Run([]string{"drand", "--folder", tmp, "util", "ping", "--network", network}), network)
+		require.Error(t, CLI().Run([]string{"drand", "--folder", tmp, "status", "--network", network}), network)
+	}
+}
+
+func TestUtilSelfTest(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-selftest-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	pairs, group := test.BatchIdentities(3)
+	priPoly := share.NewPriPoly(key.KeyGroup, 2, nil, random.New())
+	pubPoly := priPoly.Commit(key.KeyGroup.Point().Base())
+	_, commits := pubPoly.Info()
+	group.PublicKey = &key.DistPublic{Coefficients: commits}
+	group.GenesisTime = time.Now().Unix() - 10
+	node := group.Find(pairs[0].Public)
+	shares := priPoly.Shares(3)
+
+	fileStore := key.NewFileStore(tmp)
+	require.NoError(t, fileStore.SaveKeyPair(pairs[0]))
+	require.NoError(t, fileStore.SaveGroup(group))
+	require.NoError(t, fileStore.SaveShare(&key.Share{Share: shares[node.Index], Commits: commits}))
+
+	selfTest := []string{"drand", "util", "self-test", "--folder", tmp}
+	testCommand(t, selfTest, "self-test passed")
+
+	// the share of another node doesn't match the index of this one
+	other := (node.Index + 1) % 3
+	require.NoError(t, fileStore.SaveShare(&key.Share{Share: shares[other], Commits: commits}))
+	require.Error(t, CLI().Run(selfTest))
+}
+
+func TestUtilSpeedtest(t *testing.T) {
+	listener, server := testmock.NewMockGRPCPublicServer("127.0.0.1:0", false)
+	go listener.Start()
+	defer listener.Stop(context.Background())
+	packet, err := server.ChainInfo(context.Background(), nil)
+	require.NoError(t, err)
+	info, err := chain.InfoFromProto(packet)
+	require.NoError(t, err)
+
+	// the other nodes do not answer
+	downAddr := func() string {
+		closed, err := gnet.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer closed.Close()
+		return closed.Addr().String()
+	}
+	down, down2 := downAddr(), downAddr()
+
+	tmp, err := ioutil.TempDir("", "drand-speedtest-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	_, group := test.BatchIdentities(3)
+	group.Threshold = 2
+	group.Nodes[0].Addr, group.Nodes[0].TLS = down, false
+	group.Nodes[1].Addr, group.Nodes[1].TLS = listener.Addr(), false
+	group.Nodes[2].Addr, group.Nodes[2].TLS = down2, false
+	group.GenesisTime = info.GenesisTime
+	group.Period = info.Period
+	group.PublicKey = &key.DistPublic{Coefficients: []kyber.Point{info.PublicKey, info.PublicKey}}
+	groupPath := path.Join(tmp, "group.toml")
+	require.NoError(t, key.Save(groupPath, group, false))
+
+	var buff bytes.Buffer
+	output = &buff
+	defer func() { output = os.Stdout }()
+	speedtest := []string{"drand", "util", "speedtest", "--requests", "2", "--timeout", "1s", groupPath}
+	require.NoError(t, CLI().Run(speedtest))
+	lines := strings.Split(strings.TrimSpace(buff.String()), "\n")
+	require.Len(t, lines, 4)
+	require.Contains(t, lines[1], listener.Addr())
+	require.Contains(t, lines[1], "0/2")
+	require.Contains(t, lines[2]+lines[3], down)
+	require.Contains(t, lines[2]+lines[3], down2)
+	require.Contains(t, lines[2], "2/2")
+	require.Contains(t, lines[3], "2/2")
+
+	// no node answers
+	group.Nodes[1].Addr = downAddr()
+	require.NoError(t, key.Save(groupPath, group, false))
+	require.Error(t, CLI().Run(speedtest))
+}
+
+func TestRelayHTTP(t *testing.T) {
+	listener, server := testmock.NewMockGRPCPublicServer("127.0.0.1:0", false)
+	go listener.Start()
+	defer listener.Stop(context.Background())
+	packet, err := server.ChainInfo(context.Background(), nil)
+	require.NoError(t, err)
+	info, err := chain.InfoFromProto(packet)
+	require.NoError(t, err)
+
+	require.Error(t, CLI().Run([]string{"drand", "relay", "http", "--upstream", listener.Addr(), "--tls-disable"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bind := "127.0.0.1:" + test.FreePort()
+	relay := []string{"drand", "relay", "http", "--upstream", listener.Addr(), "--tls-disable",
+		"--chain-hash", hex.EncodeToString(info.Hash()), "--bind", bind}
+	done := make(chan error, 1)
+	go func() { done <- CLI().RunContext(ctx, relay) }()
+
+	var resp *nhttp.Response
+	for i := 0; i < 20; i++ {
+		resp, err = nhttp.Get("http://" + bind + "/info")
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	relayed, err := chain.InfoFromJSON(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	require.Equal(t, info.Hash(), relayed.Hash())
+
+	resp, err = nhttp.Get("http://" + bind + "/public/latest")
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, nhttp.StatusOK, resp.StatusCode)
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay not stopped with its context")
+	}
+}
+
+func TestRelayGRPC(t *testing.T) {
+	listener, server := testmock.NewMockGRPCPublicServer("127.0.0.1:0", false)
+	go listener.Start()
+	defer listener.Stop(context.Background())
+	packet, err := server.ChainInfo(context.Background(), nil)
+	require.NoError(t, err)
+	info, err := chain.InfoFromProto(packet)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bind := "127.0.0.1:" + test.FreePort()
+	relay := []string{"drand", "relay", "grpc", "--upstream", listener.Addr(), "--tls-disable",
+		"--chain-hash", hex.EncodeToString(info.Hash()), "--bind", bind}
+	done := make(chan error, 1)
+	go func() { done <- CLI().RunContext(ctx, relay) }()
+
+	rc, err := grpc.New(bind, "", true)
+	require.NoError(t, err)
+	defer rc.Close()
+	var r client.Result
+	for i := 0; i < 20; i++ {
+		r, err = rc.Get(context.Background(), 0)
+		if err == nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.NoError(t, err)
+	require.NoError(t, info.VerifyBeacon(&chain.Beacon{
+		Round:       r.Round(),
+		Signature:   r.Signature(),
+		PreviousSig: r.(*client.RandomData).PreviousSignature,
+	}))
+
+	cancel()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("relay not stopped with its context")
+	}
+}
@@ -0,0 +1,256 @@
+package drand
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	gonet "net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/core"
+	"github.com/drand/drand/key"
+	"github.com/urfave/cli/v2"
+)
+
+var collectListenFlag = &cli.StringFlag{
+	Name: "listen",
+	Usage: "<ADDRESS:PORT> to listen on for the members to send their public identity file, " +
+		"e.g. with `curl --data-binary @drand_id.public http://<ADDRESS:PORT>`.",
+}
+
+var collectWaitFlag = &cli.DurationFlag{
+	Name:  "wait",
+	Usage: "How long to listen and poll the URLs for the identities before assembling the group.",
+	Value: 5 * time.Minute,
+}
+
+var collectExpectFlag = &cli.IntFlag{
+	Name:  "expect",
+	Usage: "Number of identities expected, the group is assembled as soon as they are all collected.",
+}
+
+// collectPollInterval is the time between two attempts to fetch the
+// identity of a URL that could not be fetched.
+const collectPollInterval = 2 * time.Second
+
+// maxIdentitySize is the largest public identity file accepted.
+const maxIdentitySize = 64 * 1024
+
+// keyCollector gathers the public identities of the future members of a
+// group, refusing the ones that conflict with an identity already collected.
+type keyCollector struct {
+	sync.Mutex
+	ids    map[string]*key.Identity
+	expect int
+	// missing are the URLs whose identity could not be collected
+	missing []string
+	// done is closed once the expected number of identities is collected
+	done chan struct{}
+}
+
+func newKeyCollector(expect int) *keyCollector {
+	return &keyCollector{
+		ids:    make(map[string]*key.Identity),
+		expect: expect,
+		done:   make(chan struct{}),
+	}
+}
+
+// add parses and verifies the public identity file and collects it. Sending
+// the same identity again is not an error.
+func (k *keyCollector) add(buff []byte) (*key.Identity, error) {
+	ptoml := new(key.PublicTOML)
+	if _, err := toml.Decode(string(buff), ptoml); err != nil {
+		return nil, fmt.Errorf("invalid identity file: %s", err)
+	}
+	id := new(key.Identity)
+	if err := id.FromTOML(ptoml); err != nil {
+		return nil, fmt.Errorf("invalid identity file: %s", err)
+	}
+	if err := validateAddress(id.Addr); err != nil {
+		return nil, err
+	}
+	if err := id.ValidSignature(); err != nil {
+		return nil, fmt.Errorf("invalid self signature of %s, sign it with `drand util self-sign`", id.Addr)
+	}
+
+	k.Lock()
+	defer k.Unlock()
+	if prev, ok := k.ids[id.Addr]; ok {
+		if !prev.Key.Equal(id.Key) {
+			return nil, fmt.Errorf("another key was already collected for %s", id.Addr)
+		}
+		return id, nil
+	}
+	for _, prev := range k.ids {
+		if prev.Key.Equal(id.Key) {
+			return nil, fmt.Errorf("the key of %s was already collected for %s", id.Addr, prev.Addr)
+		}
+	}
+	k.ids[id.Addr] = id
+	fmt.Fprintf(output, "collected %s (%d identities)\n", id.Addr, len(k.ids))
+	if len(k.ids) == k.expect {
+		close(k.done)
+	}
+	return id, nil
+}
+
+func (k *keyCollector) identities() []*key.Identity {
+	k.Lock()
+	defer k.Unlock()
+	ids := make([]*key.Identity, 0, len(k.ids))
+	for _, id := range k.ids {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+// missingError returns the error listing the identities not collected out of
+// the expected ones.
+func (k *keyCollector) missingError(expect int) error {
+	k.Lock()
+	defer k.Unlock()
+	missing := append([]string{}, k.missing...)
+	sort.Strings(missing)
+	// the members sending their identity to the listening address are unknown
+	if unknown := expect - len(k.ids) - len(missing); unknown > 0 {
+		missing = append(missing, fmt.Sprintf("%d identities not sent to the listening address", unknown))
+	}
+	return fmt.Errorf("collected %d of the %d identities expected, missing: %s", len(k.ids), expect,
+		strings.Join(missing, ", "))
+}
+
+// ServeHTTP collects the identity file sent as the body of a POST request.
+func (k *keyCollector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "send the public identity file with a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+	buff, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxIdentitySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	id, err := k.add(buff)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	fmt.Fprintf(w, "collected %s\n", id.Addr)
+}
+
+// poll fetches the identity file at the URL until it is collected or the
+// context is done.
+func (k *keyCollector) poll(ctx context.Context, url string) {
+	client := &http.Client{Timeout: collectPollInterval}
+	for {
+		err := k.fetch(ctx, client, url)
+		if err == nil {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			fmt.Fprintf(output, "could not collect the identity at %s: %s\n", url, err)
+			k.Lock()
+			k.missing = append(k.missing, url)
+			k.Unlock()
+			return
+		case <-time.After(collectPollInterval):
+		}
+	}
+}
+
+func (k *keyCollector) fetch(ctx context.Context, client *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %s", resp.Status)
+	}
+	buff, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxIdentitySize))
+	if err != nil {
+		return err
+	}
+	_, err = k.add(buff)
+	return err
+}
+
+// collectKeysCmd gathers the public identity files of the members, sent to
+// the listening address or fetched from the URLs given as arguments, and
+// prints a draft group made of them.
+func collectKeysCmd(c *cli.Context) error {
+	urls := c.Args().Slice()
+	if len(urls) == 0 && !c.IsSet(collectListenFlag.Name) {
+		return errors.New("collect-keys needs the URLs of the identity files or an address to --listen on")
+	}
+	expect := c.Int(collectExpectFlag.Name)
+	if expect == 0 && !c.IsSet(collectListenFlag.Name) {
+		expect = len(urls)
+	}
+	period := core.DefaultBeaconPeriod
+	if c.IsSet(periodFlag.Name) {
+		var err error
+		if period, err = time.ParseDuration(c.String(periodFlag.Name)); err != nil {
+			return fmt.Errorf("invalid period: %s", err)
+		}
+	}
+	catchupPeriod, err := time.ParseDuration(c.String(catchupPeriodFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid catchup period: %s", err)
+	}
+
+	collector := newKeyCollector(expect)
+	ctx, cancel := context.WithTimeout(context.Background(), c.Duration(collectWaitFlag.Name))
+	defer cancel()
+	if c.IsSet(collectListenFlag.Name) {
+		listener, err := gonet.Listen("tcp", c.String(collectListenFlag.Name))
+		if err != nil {
+			return fmt.Errorf("can't listen for the identities: %s", err)
+		}
+		server := &http.Server{Handler: collector}
+		go func() { _ = server.Serve(listener) }()
+		defer server.Close()
+		fmt.Fprintf(output, "waiting for the identities on %s for %s\n", listener.Addr(), c.Duration(collectWaitFlag.Name))
+	}
+	var wg sync.WaitGroup
+	for _, url := range urls {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			collector.poll(ctx, url)
+		}(url)
+	}
+	select {
+	case <-collector.done:
+	case <-ctx.Done():
+	}
+	cancel()
+	wg.Wait()
+
+	ids := collector.identities()
+	if len(ids) < expect {
+		return collector.missingError(expect)
+	}
+	threshold := key.DefaultThreshold(len(ids))
+	if c.IsSet(thresholdFlag.Name) {
+		threshold = c.Int(thresholdFlag.Name)
+	}
+	group := key.NewGroup(ids, threshold, 0, period, catchupPeriod)
+	if err := group.Validate(); err != nil {
+		return fmt.Errorf("can't assemble a group from the %d identities collected: %s", len(ids), err)
+	}
+	return groupOut(c, group)
+}
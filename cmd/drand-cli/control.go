@@ -1,10 +1,14 @@
 package drand
 
 import (
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -80,7 +84,10 @@ func getShareArgs(c *cli.Context) (*shareArgs, error) {
 		return nil, fmt.Errorf("error getting entropy source: %w", err)
 	}
 
-	args.conf = contextToConfig(c)
+	args.conf, err = contextToConfig(c)
+	if err != nil {
+		return nil, err
+	}
 
 	return args, nil
 }
@@ -110,7 +117,7 @@ func shareCmd(c *cli.Context) error {
 	}
 
 	fmt.Fprintln(output, "Participating to the setup of the DKG")
-	groupP, shareErr := ctrlClient.InitDKG(connectPeer, args.entropy, args.secret)
+	groupP, shareErr := ctrlClient.InitDKG(connectPeer, args.entropy, args.secret, printDKGProgress)
 
 	if shareErr != nil {
 		return fmt.Errorf("error setting up the network: %v", shareErr)
@@ -122,6 +129,24 @@ func shareCmd(c *cli.Context) error {
 	return groupOut(c, group)
 }
 
+// printDKGProgress prints the progress of the DKG run by the node.
+func printDKGProgress(p *control.DKGProgress) {
+	switch p.GetEvent() {
+	case control.DKGEvent_DKG_SETUP:
+		fmt.Fprintln(output, "Waiting for the group of the DKG")
+	case control.DKGEvent_DKG_STARTED:
+		fmt.Fprintf(output, "DKG started with %d nodes\n", p.GetNodes())
+	case control.DKGEvent_DKG_DEAL:
+		fmt.Fprintf(output, "Deals of node %d (%d/%d)\n", p.GetFrom(), p.GetDeals(), p.GetNodes())
+	case control.DKGEvent_DKG_RESPONSE:
+		fmt.Fprintf(output, "Responses of node %d (%d/%d)\n", p.GetFrom(), p.GetResponses(), p.GetNodes())
+	case control.DKGEvent_DKG_JUSTIFICATION:
+		fmt.Fprintf(output, "Justifications of node %d (%d)\n", p.GetFrom(), p.GetJustifications())
+	case control.DKGEvent_DKG_DONE:
+		fmt.Fprintf(output, "DKG done, %d qualified nodes: %v\n", len(p.GetQualified()), p.GetQualified())
+	}
+}
+
 func leadShareCmd(c *cli.Context) error {
 	if !c.IsSet(thresholdFlag.Name) || !c.IsSet(shareNodeFlag.Name) {
 		return fmt.Errorf("leader needs to specify --nodes and --threshold for sharing")
@@ -165,7 +190,7 @@ func leadShareCmd(c *cli.Context) error {
 		"file will not be written out to the specified output. To get the "+
 		"group file once the setup phase is done, you can run the `drand show "+
 		"group` command")
-	groupP, shareErr := ctrlClient.InitDKGLeader(nodes, args.threshold, period, catchupPeriod, args.timeout, args.entropy, args.secret, offset)
+	groupP, shareErr := ctrlClient.InitDKGLeader(nodes, args.threshold, period, catchupPeriod, args.timeout, args.entropy, args.secret, offset, c.Bool(taggedMessagesFlag.Name), c.String(digestFlag.Name), printDKGProgress)
 
 	if shareErr != nil {
 		return fmt.Errorf("error setting up the network: %v", shareErr)
@@ -211,16 +236,17 @@ func reshareCmd(c *cli.Context) error {
 		oldPath = c.String(oldGroupFlag.Name)
 	}
 
+	period, err := getResharePeriod(c)
+	if err != nil {
+		return err
+	}
 	fmt.Fprintln(output, "Participating to the resharing")
-	groupP, shareErr := ctrlClient.InitReshare(connectPeer, args.secret, oldPath, args.force)
+	groupP, shareErr := ctrlClient.InitReshare(connectPeer, args.secret, oldPath, args.force,
+		uint64(c.Int(transitionRoundFlag.Name)), c.Bool(dryRunFlag.Name), period, printDKGProgress)
 	if shareErr != nil {
 		return fmt.Errorf("error setting up the network: %v", shareErr)
 	}
-	group, err := key.GroupFromProto(groupP)
-	if err != nil {
-		return fmt.Errorf("error interpreting the group from protobuf: %v", err)
-	}
-	return groupOut(c, group)
+	return reshareOut(c, groupP)
 }
 
 func leadReshareCmd(c *cli.Context) error {
@@ -264,16 +290,49 @@ func leadReshareCmd(c *cli.Context) error {
 			return fmt.Errorf("catchup period given is invalid: %v", err)
 		}
 	}
+	period, err := getResharePeriod(c)
+	if err != nil {
+		return err
+	}
 	fmt.Fprintln(output, "Initiating the resharing as a leader")
-	groupP, shareErr := ctrlClient.InitReshareLeader(nodes, args.threshold, args.timeout, catchupPeriod, args.secret, oldPath, offset)
+	groupP, shareErr := ctrlClient.InitReshareLeader(nodes, args.threshold, args.timeout, catchupPeriod, args.secret, oldPath, offset,
+		c.Bool(taggedMessagesFlag.Name), uint64(c.Int(transitionRoundFlag.Name)), c.Bool(dryRunFlag.Name), period, printDKGProgress)
 
 	if shareErr != nil {
 		return fmt.Errorf("error setting up the network: %v", shareErr)
 	}
+	return reshareOut(c, groupP)
+}
+
+// getResharePeriod returns the period the resharing changes the chain to, or
+// zero to keep the current one.
+func getResharePeriod(c *cli.Context) (time.Duration, error) {
+	if !c.IsSet(periodFlag.Name) {
+		return 0, nil
+	}
+	period, err := time.ParseDuration(c.String(periodFlag.Name))
+	if err != nil {
+		return 0, fmt.Errorf("period given is invalid: %v", err)
+	}
+	if period < time.Second || period%time.Second != 0 {
+		return 0, fmt.Errorf("period must be a positive number of seconds: %s", period)
+	}
+	return period, nil
+}
+
+// reshareOut prints the group resulting from a resharing. The group of a dry
+// run is not used by the node so it is only summarized.
+func reshareOut(c *cli.Context, groupP *control.GroupPacket) error {
 	group, err := key.GroupFromProto(groupP)
 	if err != nil {
 		return fmt.Errorf("error interpreting the group from protobuf: %v", err)
 	}
+	if c.Bool(dryRunFlag.Name) {
+		fmt.Fprintf(output, "Dry run of the resharing succeeded: %d nodes qualified with a threshold of %d.\n",
+			group.Len(), group.Threshold)
+		fmt.Fprintln(output, "The node keeps its current share and group.")
+		return nil
+	}
 	return groupOut(c, group)
 }
 
@@ -293,7 +352,11 @@ func pingpongCmd(c *cli.Context) error {
 	if err := client.Ping(); err != nil {
 		return fmt.Errorf("drand: can't ping the daemon ... %s", err)
 	}
-	fmt.Fprintf(output, "drand daemon is alive on port %s", controlPort(c))
+	port, err := controlPort(c)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(output, "drand daemon is alive on port %s", port)
 	return nil
 }
 
@@ -329,6 +392,263 @@ func showChainInfo(c *cli.Context) error {
 	return printChainInfo(c, ci)
 }
 
+func showTranscriptCmd(c *cli.Context) error {
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	t, err := core.LoadTranscript(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("could not load the dkg transcript: %s", err)
+	}
+	return printJSON(t)
+}
+
+func showRoundsCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.RoundReports(c.Int(lastRoundsFlag.Name))
+	if err != nil {
+		return fmt.Errorf("could not request round reports: %s", err)
+	}
+	for _, r := range resp.GetReports() {
+		partials := make([]string, len(r.GetPartials()))
+		for i, p := range r.GetPartials() {
+			partials[i] = fmt.Sprintf("%d (%dms)", p.GetIndex(), p.GetDelayMs())
+			if p.GetLate() {
+				partials[i] = fmt.Sprintf("%d (%dms, late)", p.GetIndex(), p.GetDelayMs())
+			}
+		}
+		fmt.Fprintf(output, "round %d: aggregated after %dms, partials: %s", r.GetRound(), r.GetAggregationMs(),
+			strings.Join(partials, ", "))
+		if len(r.GetMissing()) > 0 {
+			fmt.Fprintf(output, ", missing: %v", r.GetMissing())
+		}
+		fmt.Fprintln(output)
+	}
+	return nil
+}
+
+func showPeersCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.PeerStatus()
+	if err != nil {
+		return fmt.Errorf("could not request peer status: %s", err)
+	}
+	for _, p := range resp.GetPeers() {
+		status := "ok"
+		if p.GetDegraded() {
+			status = "degraded"
+		}
+		if n := p.GetEquivocations(); n > 0 {
+			status = fmt.Sprintf("%s, equivocated in %d rounds", status, n)
+		}
+		if p.GetInMaintenance() {
+			status = fmt.Sprintf("%s, in maintenance", status)
+		}
+		fmt.Fprintf(output, "node %d (%s): skew %dms, %s\n", p.GetIndex(), p.GetAddress(), p.GetSkewMs(), status)
+	}
+	if !resp.GetThresholdReachable() {
+		fmt.Fprintln(output, "threshold unreachable: too many nodes are degraded")
+	}
+	return nil
+}
+
+func showStatusCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Status()
+	if err != nil {
+		return fmt.Errorf("could not request the status: %s", err)
+	}
+	state := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(resp.GetState().String(), "STATE_"), "_", " "))
+	fmt.Fprintf(output, "%s since %s\n", state, time.Unix(resp.GetSince(), 0).UTC().Format(time.RFC3339))
+	if w := resp.GetMaintenance(); w != nil {
+		fmt.Fprintf(output, "in maintenance %s\n", formatMaintenance(w))
+	}
+	if crypto := resp.GetCrypto(); crypto != nil {
+		printCryptoConfig(crypto)
+	}
+	return nil
+}
+
+func printCryptoConfig(crypto *control.CryptoConfig) {
+	fmt.Fprintf(output, "crypto (FIPS mode %v):\n", crypto.GetFips())
+	for _, p := range crypto.GetPrimitives() {
+		approved := "not FIPS approved"
+		if p.GetApproved() {
+			approved = "FIPS approved"
+		}
+		fmt.Fprintf(output, "  %s: %s (%s)\n", p.GetUsage(), p.GetName(), approved)
+	}
+}
+
+var maintenanceStartFlag = &cli.StringFlag{
+	Name:  "start",
+	Usage: "Start of the maintenance window, RFC3339 formatted, e.g. 2021-01-02T15:04:05Z. Starts now by default.",
+}
+
+var maintenanceDurationFlag = &cli.DurationFlag{
+	Name:     "duration",
+	Usage:    "Duration of the maintenance window, e.g. 2h.",
+	Required: true,
+}
+
+var maintenanceReasonFlag = &cli.StringFlag{
+	Name:  "reason",
+	Usage: "Reason of the maintenance, reported to the operators.",
+}
+
+var maintenanceAnnounceFlag = &cli.BoolFlag{
+	Name:  "announce",
+	Usage: "Sign and send the maintenance window to the other nodes of the group.",
+}
+
+func scheduleMaintenanceCmd(c *cli.Context) error {
+	start := time.Now()
+	if c.IsSet(maintenanceStartFlag.Name) {
+		var err error
+		if start, err = time.Parse(time.RFC3339, c.String(maintenanceStartFlag.Name)); err != nil {
+			return fmt.Errorf("invalid start of the maintenance window: %s", err)
+		}
+	}
+	end := start.Add(c.Duration(maintenanceDurationFlag.Name))
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ScheduleMaintenance(start.Unix(), end.Unix(), c.String(maintenanceReasonFlag.Name),
+		c.Bool(maintenanceAnnounceFlag.Name))
+	if err != nil {
+		return fmt.Errorf("could not schedule the maintenance: %s", err)
+	}
+	fmt.Fprintf(output, "maintenance scheduled from %s to %s\n", start.UTC().Format(time.RFC3339),
+		end.UTC().Format(time.RFC3339))
+	if len(resp.GetFailed()) > 0 {
+		fmt.Fprintf(output, "could not reach: %s\n", strings.Join(resp.GetFailed(), ", "))
+	}
+	return nil
+}
+
+func showMaintenanceCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListMaintenance()
+	if err != nil {
+		return fmt.Errorf("could not list the maintenance windows: %s", err)
+	}
+	for _, w := range resp.GetWindows() {
+		fmt.Fprintf(output, "%s: %s\n", w.GetAddress(), formatMaintenance(w))
+	}
+	return nil
+}
+
+func showKeyUsageCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.KeyUsage()
+	if err != nil {
+		return fmt.Errorf("could not request the key usage: %s", err)
+	}
+	for _, u := range []struct {
+		name  string
+		count *control.KeyUsageCount
+	}{{"share", resp.GetShare()}, {"identity key", resp.GetIdentity()}} {
+		if u.count.GetSignatures() == 0 {
+			fmt.Fprintf(output, "%s: no partial signature produced\n", u.name)
+			continue
+		}
+		fmt.Fprintf(output, "%s %s: %d partial signatures since %s, last round %d\n", u.name, u.count.GetFingerprint(),
+			u.count.GetSignatures(), time.Unix(u.count.GetSince(), 0).UTC().Format(time.RFC3339), u.count.GetLastRound())
+	}
+	return nil
+}
+
+func formatMaintenance(w *control.MaintenanceWindow) string {
+	s := fmt.Sprintf("from %s to %s", time.Unix(w.GetStart(), 0).UTC().Format(time.RFC3339),
+		time.Unix(w.GetEnd(), 0).UTC().Format(time.RFC3339))
+	if w.GetReason() != "" {
+		s += fmt.Sprintf(" (%s)", w.GetReason())
+	}
+	return s
+}
+
+func proposeGroupCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("propose-group takes the path of the proposed group file")
+	}
+	path, err := filepath.Abs(c.Args().First())
+	if err != nil {
+		return err
+	}
+	if err := key.Load(path, new(key.Group)); err != nil {
+		return fmt.Errorf("could not load the proposed group: %s", err)
+	}
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ProposeGroup(path)
+	if err != nil {
+		return fmt.Errorf("could not propose the group: %s", err)
+	}
+	fmt.Fprintf(output, "proposed group %x\n", resp.GetHash())
+	if len(resp.GetFailed()) > 0 {
+		fmt.Fprintf(output, "could not reach: %s\n", strings.Join(resp.GetFailed(), ", "))
+	}
+	return nil
+}
+
+func listPendingGroupsCmd(c *cli.Context) error {
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	resp, err := client.ListPendingGroups()
+	if err != nil {
+		return fmt.Errorf("could not list the proposed groups: %s", err)
+	}
+	for _, g := range resp.GetGroups() {
+		status := "pending"
+		if g.GetApproved() {
+			status = "approved"
+		}
+		fmt.Fprintf(output, "%x (%s) from %s: threshold %d/%d, period %ds, nodes: %s\n", g.GetHash(), status,
+			g.GetFrom(), g.GetThreshold(), len(g.GetNodes()), g.GetPeriod(), strings.Join(g.GetNodes(), ", "))
+	}
+	return nil
+}
+
+func approveGroupCmd(c *cli.Context) error {
+	if c.NArg() != 1 {
+		return errors.New("approve-group takes the hash of the proposed group")
+	}
+	hash, err := hex.DecodeString(c.Args().First())
+	if err != nil {
+		return fmt.Errorf("invalid group hash: %s", err)
+	}
+	client, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	if err := client.ApproveGroup(hash); err != nil {
+		return fmt.Errorf("could not approve the group: %s", err)
+	}
+	fmt.Fprintf(output, "approved group %x\n", hash)
+	return nil
+}
+
 func showPrivateCmd(c *cli.Context) error {
 	client, err := controlClient(c)
 	if err != nil {
@@ -365,16 +685,30 @@ func showShareCmd(c *cli.Context) error {
 	return printJSON(resp)
 }
 
-func controlPort(c *cli.Context) string {
+func controlPort(c *cli.Context) (string, error) {
 	port := c.String(controlFlag.Name)
+	if port == "" && c.IsSet(networkFlag.Name) {
+		folder, err := networkFolder(c)
+		if err != nil {
+			return "", err
+		}
+		// the daemon of the network writes its control port at startup
+		buff, err := ioutil.ReadFile(path.Join(folder, core.ControlPortFileName))
+		if err == nil {
+			port = strings.TrimSpace(string(buff))
+		}
+	}
 	if port == "" {
 		port = core.DefaultControlPort
 	}
-	return port
+	return port, nil
 }
 
 func controlClient(c *cli.Context) (*net.ControlClient, error) {
-	port := controlPort(c)
+	port, err := controlPort(c)
+	if err != nil {
+		return nil, err
+	}
 	client, err := net.NewControlClient(port)
 	if err != nil {
 		return nil, fmt.Errorf("can't instantiate control client: %s", err)
@@ -407,7 +741,10 @@ func entropyInfoFromReader(c *cli.Context) (*control.EntropyInfo, error) {
 	return nil, nil
 }
 func selfSign(c *cli.Context) error {
-	conf := contextToConfig(c)
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
 	fs := key.NewFileStore(conf.ConfigFolder())
 	pair, err := fs.LoadKeyPair()
 	if err != nil {
@@ -428,15 +765,38 @@ func selfSign(c *cli.Context) error {
 
 const refreshRate = 1000 * time.Millisecond
 
+// followArgs returns the hash of the chain to follow and the nodes to follow
+// it from, given either as arguments or with flags.
+func followArgs(c *cli.Context) (hash string, addrs []string, err error) {
+	switch {
+	case c.NArg() == 2:
+		hash, addrs = c.Args().Get(0), splitList(c.Args().Get(1))
+	case c.NArg() == 0 && c.IsSet(hashInfoFlag.Name) && c.IsSet(syncNodeFlag.Name):
+		hash, addrs = c.String(hashInfoFlag.Name), splitList(c.String(syncNodeFlag.Name))
+	default:
+		return "", nil, errors.New("follow needs the chain hash and the nodes to sync from")
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", nil, fmt.Errorf("invalid chain hash %q: %s", hash, err)
+	}
+	if len(addrs) == 0 {
+		return "", nil, errors.New("follow needs at least one node to sync from")
+	}
+	return hash, addrs, nil
+}
+
 func followCmd(c *cli.Context) error {
 	ctrlClient, err := controlClient(c)
 	if err != nil {
 		return fmt.Errorf("unable to create control client: %s", err)
 	}
-	addrs := strings.Split(c.String(syncNodeFlag.Name), ",")
+	hash, addrs, err := followArgs(c)
+	if err != nil {
+		return err
+	}
 	channel, errCh, err := ctrlClient.StartFollowChain(
 		c.Context,
-		c.String(hashInfoFlag.Name),
+		hash,
 		addrs,
 		!c.Bool(insecureFlag.Name),
 		uint64(c.Int(upToFlag.Name)))
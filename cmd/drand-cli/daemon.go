@@ -1,34 +1,117 @@
 package drand
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/signal"
+	"path"
+	"syscall"
+	"time"
 
+	"github.com/drand/drand/chain/store"
 	"github.com/drand/drand/core"
+	"github.com/drand/drand/fs"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/metrics"
 	"github.com/drand/drand/metrics/pprof"
 	"github.com/urfave/cli/v2"
 )
 
+// shutdownTimeout is the maximum time given to the daemon to close its
+// connections when receiving a termination signal.
+const shutdownTimeout = 5 * time.Second
+
+// daemonStore returns the key store of the daemon, splitting the private share
+// across multiple locations if requested.
+func daemonStore(c *cli.Context, conf *core.Config) (key.Store, error) {
+	if !c.IsSet(sharePartsFlag.Name) {
+		if c.IsSet(shareThresholdFlag.Name) {
+			return nil, fmt.Errorf("--%s requires --%s", shareThresholdFlag.Name, sharePartsFlag.Name)
+		}
+		return key.NewFileStore(conf.ConfigFolder()), nil
+	}
+	locations := splitList(c.String(sharePartsFlag.Name))
+	threshold := len(locations)
+	if c.IsSet(shareThresholdFlag.Name) {
+		threshold = c.Int(shareThresholdFlag.Name)
+	}
+	return key.NewSplitShareFileStore(conf.ConfigFolder(), threshold, locations)
+}
+
+// checkStoreQuota makes sure the beacon database can report its size when a
+// maximum size is given, otherwise the maximum could not be enforced.
+func checkStoreQuota(c *cli.Context, conf *core.Config) error {
+	if c.Int(maxStoreSizeFlag.Name) <= 0 {
+		return nil
+	}
+	fs.CreateSecureFolder(conf.DBFolder())
+	db, err := store.New(conf.StoreBackend(), conf.DBFolder(), conf.StoreOptions())
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	if _, ok := db.(interface{ Size() int64 }); !ok {
+		return fmt.Errorf("store backend %q can't report its size: --%s is not supported",
+			conf.StoreBackend(), maxStoreSizeFlag.Name)
+	}
+	return nil
+}
+
 func startCmd(c *cli.Context) error {
-	conf := contextToConfig(c)
-	fs := key.NewFileStore(conf.ConfigFolder())
+	if c.Bool(fipsFlag.Name) {
+		key.SetFIPSMode(true)
+	}
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	store, err := daemonStore(c, conf)
+	if err != nil {
+		return err
+	}
+	// make sure no other daemon is running on the same folder
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("can't start drand daemon: %w", err)
+	}
+	defer lock.Unlock()
+	if err := checkStoreQuota(c, conf); err != nil {
+		return fmt.Errorf("can't start drand daemon: %w", err)
+	}
+	// stop gracefully on SIGINT / SIGTERM so the beacon database is properly
+	// closed and connections are terminated. The signals received while the
+	// daemon starts are handled once it runs.
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	portFile := path.Join(conf.ConfigFolder(), core.ControlPortFileName)
+	if err := ioutil.WriteFile(portFile, []byte(conf.ControlPort()), 0600); err != nil {
+		return fmt.Errorf("can't write the control port: %w", err)
+	}
+	defer os.Remove(portFile)
 	var drand *core.Drand
 	// determine if we already ran a DKG or not
-	_, errG := fs.LoadGroup()
-	_, errS := fs.LoadShare()
+	_, errG := store.LoadGroup()
+	_, errS := store.LoadShare()
 	// XXX place that logic inside core/ directly with only one method
 	freshRun := errG != nil || errS != nil
-	var err error
-	if freshRun {
+	if c.Bool(readOnlyFlag.Name) {
+		fmt.Println("drand: will serve the beacons of its database in read-only mode")
+		drand, err = core.LoadReadOnlyDrand(store, conf)
+		if err != nil {
+			return fmt.Errorf("can't load read-only drand instance %s", err)
+		}
+	} else if freshRun {
 		fmt.Println("drand: will run as fresh install -> expect to run DKG.")
-		drand, err = core.NewDrand(fs, conf)
+		drand, err = core.NewDrand(store, conf)
 		if err != nil {
 			return fmt.Errorf("can't instantiate drand instance %s", err)
 		}
 	} else {
 		fmt.Println("drand: will already start running randomness beacon")
-		drand, err = core.LoadDrand(fs, conf)
+		drand, err = core.LoadDrand(store, conf)
 		if err != nil {
 			return fmt.Errorf("can't load drand instance %s", err)
 		}
@@ -42,8 +125,15 @@ func startCmd(c *cli.Context) error {
 	if c.IsSet(metricsFlag.Name) {
 		_ = metrics.Start(c.String(metricsFlag.Name), pprof.WithProfile(), drand.PeerMetrics)
 	}
-	<-drand.WaitExit()
-
+	select {
+	case <-drand.WaitExit():
+	case sig := <-sigCh:
+		fmt.Printf("drand: received %s signal, shutting down\n", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		drand.Stop(ctx)
+		<-drand.WaitExit()
+	}
 	return nil
 }
 
@@ -58,3 +148,27 @@ func stopDaemon(c *cli.Context) error {
 	fmt.Println("drand daemon stopped correctly. Bye.")
 	return nil
 }
+
+func pauseCmd(c *cli.Context) error {
+	ctrlClient, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	if err := ctrlClient.Pause(); err != nil {
+		return fmt.Errorf("error pausing drand daemon: %w", err)
+	}
+	fmt.Fprintln(output, "drand daemon paused, it does not send its partial signatures anymore")
+	return nil
+}
+
+func resumeCmd(c *cli.Context) error {
+	ctrlClient, err := controlClient(c)
+	if err != nil {
+		return err
+	}
+	if err := ctrlClient.Resume(); err != nil {
+		return fmt.Errorf("error resuming drand daemon: %w", err)
+	}
+	fmt.Fprintln(output, "drand daemon resumed, it sends its partial signatures from the next round")
+	return nil
+}
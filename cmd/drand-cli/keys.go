@@ -0,0 +1,242 @@
+package drand
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	gonet "net"
+	"os"
+	"strings"
+
+	"github.com/drand/drand/fs"
+	"github.com/drand/drand/key"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	privateKeyType    = "private"
+	publicKeyType     = "public"
+	distPublicKeyType = "dist"
+)
+
+var keyTypeFlag = &cli.StringFlag{
+	Name: "type",
+	Usage: "Key to export or import: \"private\" or \"public\" for the identity key pair of the node," +
+		" \"dist\" for the distributed public key of the group.",
+	Value: publicKeyType,
+}
+
+var keyEncodingFlag = &cli.StringFlag{
+	Name:  "encoding",
+	Usage: "Encoding of the key: \"hex\", \"base64\" or \"pem\".",
+	Value: key.HexEncoding,
+}
+
+var keyOutFlag = &cli.StringFlag{
+	Name:  "out",
+	Usage: "Write the key into the given file, only readable by its owner, instead of stdout.",
+}
+
+var mnemonicFlag = &cli.BoolFlag{
+	Name: "mnemonic",
+	Usage: "Derive the key pair from a freshly generated mnemonic, printed once, from which it can be" +
+		" restored with `drand key restore`.",
+}
+
+var mnemonicFileFlag = &cli.StringFlag{
+	Name:  "mnemonic-file",
+	Usage: "File containing the mnemonic to restore the key pair from.",
+}
+
+func keyPEMType(keyType string) (string, error) {
+	switch keyType {
+	case privateKeyType:
+		return key.PrivatePEMType, nil
+	case publicKeyType:
+		return key.PublicPEMType, nil
+	case distPublicKeyType:
+		return key.DistPublicPEMType, nil
+	default:
+		return "", fmt.Errorf("unknown key type %q", keyType)
+	}
+}
+
+func keyExportCmd(c *cli.Context) error {
+	keyType := c.String(keyTypeFlag.Name)
+	pemType, err := keyPEMType(keyType)
+	if err != nil {
+		return err
+	}
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	store := key.NewFileStore(conf.ConfigFolder())
+	var buff []byte
+	switch keyType {
+	case distPublicKeyType:
+		group, err := store.LoadGroup()
+		if err != nil {
+			return fmt.Errorf("could not load group: %w", err)
+		}
+		if group.PublicKey == nil {
+			return errors.New("the group does not contain the distributed public key")
+		}
+		buff, err = group.PublicKey.Key().MarshalBinary()
+		if err != nil {
+			return err
+		}
+	default:
+		pair, err := store.LoadKeyPair()
+		if err != nil {
+			return fmt.Errorf("could not load key pair: %w", err)
+		}
+		if keyType == privateKeyType {
+			buff, err = pair.Key.MarshalBinary()
+		} else {
+			buff, err = pair.Public.Key.MarshalBinary()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	encoded, err := key.EncodeKey(c.String(keyEncodingFlag.Name), pemType, buff)
+	if err != nil {
+		return err
+	}
+	if !c.IsSet(keyOutFlag.Name) {
+		_, err = output.Write(encoded)
+		return err
+	}
+	fd, err := fs.CreateSecureFile(c.String(keyOutFlag.Name))
+	if err != nil {
+		return err
+	}
+	if _, err := fd.Write(encoded); err != nil {
+		fd.Close()
+		return err
+	}
+	return fd.Close()
+}
+
+// keyImportCmd imports a private key as the identity of the node. Public keys
+// are validated and printed in the hexadecimal form used in the drand files.
+func keyImportCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("missing key file argument")
+	}
+	keyType := c.String(keyTypeFlag.Name)
+	pemType, err := keyPEMType(keyType)
+	if err != nil {
+		return err
+	}
+	data, err := ioutil.ReadFile(c.Args().First())
+	if err != nil {
+		return err
+	}
+	buff, err := key.DecodeKey(c.String(keyEncodingFlag.Name), pemType, data)
+	if err != nil {
+		return fmt.Errorf("could not decode key: %w", err)
+	}
+	if keyType != privateKeyType {
+		point := key.KeyGroup.Point()
+		if err := point.UnmarshalBinary(buff); err != nil {
+			return fmt.Errorf("invalid public key: %w", err)
+		}
+		fmt.Fprintln(output, key.PointToString(point))
+		return nil
+	}
+
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	fs.CreateSecureFolder(conf.ConfigFolder())
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("drand daemon must be stopped before importing a key: %w", err)
+	}
+	defer lock.Unlock()
+	store := key.NewFileStore(conf.ConfigFolder())
+	address, tls := c.Args().Get(1), !c.Bool(insecureFlag.Name)
+	var fallbacks []string
+	if existing, err := store.LoadKeyPair(); err == nil {
+		if !c.Bool(overwriteFlag.Name) {
+			return fmt.Errorf("key pair already present in %s, use --%s to replace it", conf.ConfigFolder(), overwriteFlag.Name)
+		}
+		if address == "" {
+			address, tls, fallbacks = existing.Public.Addr, existing.Public.TLS, existing.Public.Fallbacks
+		}
+	}
+	if address == "" {
+		return errors.New("missing drand address in argument")
+	}
+	pair, err := key.NewKeyPairFromKey(buff, address, tls)
+	if err != nil {
+		return err
+	}
+	if fallbacks != nil {
+		pair.Public.Fallbacks = fallbacks
+		pair.SelfSign()
+	}
+	if err := store.SaveKeyPair(pair); err != nil {
+		return fmt.Errorf("could not save key: %w", err)
+	}
+	fmt.Fprintf(output, "Imported key pair for %s, public key %s\n", pair.Public.Addr, key.PointToString(pair.Public.Key))
+	return nil
+}
+
+func keyRestoreCmd(c *cli.Context) error {
+	if !c.Args().Present() {
+		return errors.New("missing drand address in argument")
+	}
+	var mnemonic string
+	if c.IsSet(mnemonicFileFlag.Name) {
+		buff, err := ioutil.ReadFile(c.String(mnemonicFileFlag.Name))
+		if err != nil {
+			return err
+		}
+		mnemonic = string(buff)
+	} else {
+		fmt.Fprintf(output, "Enter the mnemonic: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("error reading mnemonic: %w", err)
+		}
+		mnemonic = line
+	}
+	pair, err := key.NewKeyPairFromMnemonic(mnemonic, c.Args().First(), !c.Bool(insecureFlag.Name))
+	if err != nil {
+		return fmt.Errorf("invalid mnemonic: %w", err)
+	}
+	if c.IsSet(fallbacksFlag.Name) {
+		for _, fallback := range strings.Split(c.String(fallbacksFlag.Name), ",") {
+			if _, _, err := gonet.SplitHostPort(fallback); err != nil {
+				return fmt.Errorf("invalid fallback address %q: %s", fallback, err)
+			}
+			pair.Public.Fallbacks = append(pair.Public.Fallbacks, fallback)
+		}
+		pair.SelfSign()
+	}
+
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	fs.CreateSecureFolder(conf.ConfigFolder())
+	lock, err := fs.LockFolder(conf.ConfigFolder())
+	if err != nil {
+		return fmt.Errorf("drand daemon must be stopped before restoring a key: %w", err)
+	}
+	defer lock.Unlock()
+	store := key.NewFileStore(conf.ConfigFolder())
+	if _, err := store.LoadKeyPair(); err == nil && !c.Bool(overwriteFlag.Name) {
+		return fmt.Errorf("key pair already present in %s, use --%s to replace it", conf.ConfigFolder(), overwriteFlag.Name)
+	}
+	if err := store.SaveKeyPair(pair); err != nil {
+		return fmt.Errorf("could not save key: %w", err)
+	}
+	fmt.Fprintf(output, "Restored key pair for %s, public key %s\n", pair.Public.Addr, key.PointToString(pair.Public.Key))
+	return nil
+}
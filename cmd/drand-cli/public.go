@@ -1,6 +1,7 @@
 package drand
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -11,6 +12,8 @@ import (
 	"github.com/drand/drand/client"
 	"github.com/drand/drand/client/grpc"
 	"github.com/drand/drand/core"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
 	"github.com/urfave/cli/v2"
 )
@@ -71,6 +74,13 @@ func getPublicRandomness(c *cli.Context) error {
 	if group.PublicKey == nil {
 		return errors.New("drand: group file must contain the distributed public key")
 	}
+	format := c.String(formatFlag.Name)
+	if format != formatJSON && format != formatRaw {
+		return fmt.Errorf("drand: unknown output format %q", format)
+	}
+	if c.Bool(watchFlag.Name) {
+		return watchPublicRandomness(c, ids, group, certPath, format)
+	}
 
 	var resp client.Result
 	var foundCorrect bool
@@ -96,7 +106,57 @@ func getPublicRandomness(c *cli.Context) error {
 		return errors.New("drand: could not verify randomness")
 	}
 
-	return printJSON(resp)
+	return printRandomness(format, resp)
+}
+
+// watchPublicRandomness prints the randomness of each new round, verified
+// against the group, fetching it from any of the given nodes.
+func watchPublicRandomness(c *cli.Context, ids []*key.Node, group *key.Group, certPath, format string) error {
+	var clients []client.Client
+	for _, id := range ids {
+		grpcClient, err := grpc.New(id.Addr, certPath, !id.TLS)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "drand: could not connect to %s: %s", id.Addr, err)
+			continue
+		}
+		clients = append(clients, grpcClient)
+	}
+	if len(clients) == 0 {
+		return errors.New("drand: could not connect to any node")
+	}
+	return watchRandomness(c.Context, clients, chain.NewChainInfo(group), format)
+}
+
+// watchRandomness prints the randomness of each new round watched through
+// the given clients.
+func watchRandomness(ctx context.Context, clients []client.Client, info *chain.Info, format string) error {
+	// stdout only carries the randomness, e.g. raw bytes piped to another
+	// program, so the client logs to stderr
+	logger := log.NewLogger(log.LoggerTo(os.Stderr), log.LogInfo)
+	watcher, err := client.Wrap(clients, client.WithChainInfo(info), client.WithLogger(logger))
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+	for r := range watcher.Watch(ctx) {
+		if err := printRandomness(format, r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	formatJSON = "json"
+	formatRaw  = "raw"
+)
+
+func printRandomness(format string, r client.Result) error {
+	if format == formatRaw {
+		_, err := output.Write(r.Randomness())
+		return err
+	}
+	return printJSON(r)
 }
 
 func getChainInfo(c *cli.Context) error {
@@ -133,5 +193,9 @@ func printChainInfo(c *cli.Context, ci *chain.Info) error {
 		fmt.Fprintf(output, "%s\n", hex.EncodeToString(ci.Hash()))
 		return nil
 	}
+	if c.Bool(fingerprintOnly.Name) {
+		fmt.Fprintf(output, "%s\n", ci.Fingerprint())
+		return nil
+	}
 	return printJSON(ci.ToProto())
 }
@@ -0,0 +1,128 @@
+package drand
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	gonet "net"
+	"net/http"
+
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/client/grpc"
+	dhttp "github.com/drand/drand/http"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/urfave/cli/v2"
+	ggrpc "google.golang.org/grpc"
+)
+
+var relayUpstreamFlag = &cli.StringFlag{
+	Name:  "upstream",
+	Usage: "<ADDRESS>,<...> of the public gRPC endpoints of the drand nodes to relay.",
+}
+
+var relayBindFlag = &cli.StringFlag{
+	Name:  "bind",
+	Usage: "Host:port to bind the listener of the relay.",
+	Value: "localhost:0",
+}
+
+var relayCacheFlag = &cli.IntFlag{
+	Name:  "cache-size",
+	Usage: "Number of rounds kept in memory to answer the requests.",
+	Value: 32,
+}
+
+// relayClient returns the client watching the upstream nodes, over the TLS
+// certificate given if any, and caching the rounds they deliver.
+func relayClient(c *cli.Context) (client.Client, error) {
+	addrs := splitList(c.String(relayUpstreamFlag.Name))
+	if len(addrs) == 0 {
+		return nil, errors.New("relay: at least one upstream node is needed")
+	}
+	if !c.IsSet(hashInfoFlag.Name) {
+		return nil, fmt.Errorf("relay: the %s of the relayed chain is needed", hashInfoFlag.Name)
+	}
+	hash, err := hex.DecodeString(c.String(hashInfoFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("relay: invalid chain hash: %s", err)
+	}
+	if c.Int(relayCacheFlag.Name) < 1 {
+		return nil, errors.New("relay: the cache must keep at least one round")
+	}
+	clients := make([]client.Client, 0, len(addrs))
+	for _, addr := range addrs {
+		if _, _, err := gonet.SplitHostPort(addr); err != nil {
+			return nil, fmt.Errorf("relay: invalid upstream address %s: %s", addr, err)
+		}
+		gc, err := grpc.New(addr, c.String(tlsCertFlag.Name), c.Bool(insecureFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("relay: upstream %s: %s", addr, err)
+		}
+		clients = append(clients, gc)
+	}
+	// the watch keeps the cache filled with the new rounds as they are
+	// produced, so most requests are answered without reaching the nodes
+	return client.Wrap(clients,
+		client.WithChainHash(hash),
+		client.WithCacheSize(c.Int(relayCacheFlag.Name)),
+		client.WithAutoWatch(),
+		client.WithLogger(log.DefaultLogger().With("binary", "relay")))
+}
+
+// relayHTTPCmd serves the public HTTP API of the chain of the upstream nodes,
+// without any key material, until the command is interrupted.
+func relayHTTPCmd(c *cli.Context) error {
+	cl, err := relayClient(c)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	cors := dhttp.WithCORS(c.StringSlice(corsOriginsFlag.Name), c.StringSlice(corsHeadersFlag.Name))
+	handler, err := dhttp.New(c.Context, cl, fmt.Sprintf("drand/%s (%s)", version, gitCommit), log.DefaultLogger().With("binary", "relay"), cors)
+	if err != nil {
+		return fmt.Errorf("relay: failed to create the http handler: %s", err)
+	}
+	listener, err := gonet.Listen("tcp", c.String(relayBindFlag.Name))
+	if err != nil {
+		return err
+	}
+	server := &http.Server{Handler: handler}
+	go func() {
+		<-c.Context.Done()
+		server.Close()
+	}()
+	fmt.Fprintf(output, "Listening at %s\n", listener.Addr())
+	if err := server.Serve(listener); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// relayGRPCCmd serves the public gRPC API of the chain of the upstream nodes
+// until the command is interrupted. All the streams of the clients are served
+// from a single subscription to the upstream nodes.
+func relayGRPCCmd(c *cli.Context) error {
+	cl, err := relayClient(c)
+	if err != nil {
+		return err
+	}
+	defer cl.Close()
+
+	listener, err := gonet.Listen("tcp", c.String(relayBindFlag.Name))
+	if err != nil {
+		return err
+	}
+	server := ggrpc.NewServer()
+	drand.RegisterPublicServer(server, grpc.NewServer(cl))
+	go func() {
+		<-c.Context.Done()
+		server.Stop()
+	}()
+	fmt.Fprintf(output, "Listening at %s\n", listener.Addr())
+	if err := server.Serve(listener); err != nil && err != ggrpc.ErrServerStopped {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package drand
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/store"
+	"github.com/drand/drand/core"
+	"github.com/drand/drand/key"
+	"github.com/urfave/cli/v2"
+	bolt "go.etcd.io/bbolt"
+)
+
+// selfTestMessage is the dummy message signed with the share of the node
+var selfTestMessage = []byte("drand self-test message")
+
+// storeOpenTimeout bounds the time waiting for the lock of the database, held
+// by a running daemon.
+const storeOpenTimeout = 2 * time.Second
+
+// selfTest holds the material loaded by the checks of the self-test.
+type selfTest struct {
+	conf  *core.Config
+	pair  *key.Pair
+	share *key.Share
+	group *key.Group
+	last  *chain.Beacon
+}
+
+type selfCheck struct {
+	name string
+	// needs is the name of the checks that must pass for this one to be run
+	needs []string
+	run   func(s *selfTest) (string, error)
+}
+
+var selfChecks = []selfCheck{
+	{name: "key pair", run: (*selfTest).checkKeyPair},
+	{name: "group", needs: []string{"key pair"}, run: (*selfTest).checkGroup},
+	{name: "share", needs: []string{"group"}, run: (*selfTest).checkShare},
+	{name: "partial signature", needs: []string{"share"}, run: (*selfTest).checkSignature},
+	{name: "beacon store", run: (*selfTest).checkStore},
+	{name: "clock", needs: []string{"group"}, run: (*selfTest).checkClock},
+}
+
+// selfTestCmd verifies the key material and the state of a stopped node and
+// prints a report, before the node rejoins the network.
+func selfTestCmd(c *cli.Context) error {
+	conf, err := contextToConfig(c)
+	if err != nil {
+		return err
+	}
+	s := &selfTest{conf: conf}
+	passed := make(map[string]bool)
+	var failed int
+	for _, check := range selfChecks {
+		var missing string
+		for _, n := range check.needs {
+			if !passed[n] {
+				missing = n
+				break
+			}
+		}
+		if missing != "" {
+			fmt.Fprintf(output, "[SKIP] %s: needs %s\n", check.name, missing)
+			failed++
+			continue
+		}
+		msg, err := check.run(s)
+		if err != nil {
+			fmt.Fprintf(output, "[FAIL] %s: %s\n", check.name, err)
+			failed++
+			continue
+		}
+		passed[check.name] = true
+		fmt.Fprintf(output, "[PASS] %s: %s\n", check.name, msg)
+	}
+	if failed > 0 {
+		return fmt.Errorf("self-test: %d of %d checks did not pass", failed, len(selfChecks))
+	}
+	fmt.Fprintln(output, "self-test passed")
+	return nil
+}
+
+func (s *selfTest) checkKeyPair() (string, error) {
+	fs := key.NewFileStore(s.conf.ConfigFolder())
+	pair, err := fs.LoadKeyPair()
+	if err != nil {
+		return "", fmt.Errorf("could not load key pair: %s", err)
+	}
+	if !key.KeyGroup.Point().Mul(pair.Key, nil).Equal(pair.Public.Key) {
+		return "", errors.New("the public key does not match the private key")
+	}
+	if err := pair.Public.ValidSignature(); err != nil {
+		return "", fmt.Errorf("invalid self signature, run `drand util self-sign`: %s", err)
+	}
+	s.pair = pair
+	return pair.Public.Address(), nil
+}
+
+func (s *selfTest) checkGroup() (string, error) {
+	fs := key.NewFileStore(s.conf.ConfigFolder())
+	group, err := fs.LoadGroup()
+	if err != nil {
+		return "", fmt.Errorf("could not load group: %s", err)
+	}
+	if group.PublicKey == nil {
+		return "", errors.New("the group does not contain the distributed public key")
+	}
+	if group.Find(s.pair.Public) == nil {
+		return "", errors.New("the node is not part of the group")
+	}
+	s.group = group
+	return fmt.Sprintf("%d nodes, threshold %d, period %s", group.Len(), group.Threshold, group.Period), nil
+}
+
+func (s *selfTest) checkShare() (string, error) {
+	fs := key.NewFileStore(s.conf.ConfigFolder())
+	share, err := fs.LoadShare()
+	if err != nil {
+		return "", fmt.Errorf("could not load share: %s", err)
+	}
+	if !share.Public().Equal(s.group.PublicKey) {
+		return "", errors.New("the commitments of the share differ from the distributed public key of the group")
+	}
+	node := s.group.Find(s.pair.Public)
+	if int(node.Index) != share.Share.I {
+		return "", fmt.Errorf("share index %d differs from the index %d of the node in the group", share.Share.I, node.Index)
+	}
+	expected := s.group.PublicKey.PubPoly().Eval(share.Share.I).V
+	if !key.KeyGroup.Point().Mul(share.Share.V, nil).Equal(expected) {
+		return "", errors.New("the share does not match the commitments of the distributed public key")
+	}
+	s.share = share
+	return fmt.Sprintf("index %d", share.Share.I), nil
+}
+
+func (s *selfTest) checkSignature() (string, error) {
+	sig, err := key.Scheme.Sign(s.share.PrivateShare(), selfTestMessage)
+	if err != nil {
+		return "", fmt.Errorf("could not sign: %s", err)
+	}
+	if err := key.Scheme.VerifyPartial(s.group.PublicKey.PubPoly(), selfTestMessage, sig); err != nil {
+		return "", fmt.Errorf("could not verify the partial signature: %s", err)
+	}
+	return "signed and verified", nil
+}
+
+func (s *selfTest) checkStore() (string, error) {
+	if _, err := os.Stat(s.conf.DBFolder()); os.IsNotExist(err) {
+		return "no database yet", nil
+	}
+	opts := s.conf.StoreOptions()
+	if s.conf.StoreBackend() == boltdb.BackendName {
+		// don't wait forever on the lock of a running daemon
+		boltOpts := &bolt.Options{Timeout: storeOpenTimeout}
+		if o, ok := opts.(*bolt.Options); ok && o != nil {
+			*boltOpts = *o
+			boltOpts.Timeout = storeOpenTimeout
+		}
+		opts = boltOpts
+	}
+	db, err := store.New(s.conf.StoreBackend(), s.conf.DBFolder(), opts)
+	if err != nil {
+		return "", fmt.Errorf("could not open the database, is the daemon stopped? %s", err)
+	}
+	defer db.Close()
+	if db.Len() == 0 {
+		return "empty", nil
+	}
+	last, err := db.Last()
+	if err != nil {
+		return "", fmt.Errorf("could not read the last beacon: %s", err)
+	}
+	if _, err := db.Get(last.Round); err != nil {
+		return "", fmt.Errorf("could not read round %d: %s", last.Round, err)
+	}
+	s.last = last
+	return fmt.Sprintf("%d beacons, last round %d", db.Len(), last.Round), nil
+}
+
+// checkClock verifies that the local clock is not behind the chain: the last
+// stored beacon must not be ahead of the current round.
+func (s *selfTest) checkClock() (string, error) {
+	now := time.Now().Unix()
+	sched := chain.NewSchedule(s.group)
+	current := sched.CurrentRound(now)
+	if s.last != nil && s.last.Round > current+1 {
+		expected := sched.TimeOfRound(s.last.Round)
+		return "", fmt.Errorf("stored round %d is ahead of the current round %d, the clock is behind by at least %s",
+			s.last.Round, current, time.Duration(expected-now)*time.Second)
+	}
+	if now < s.group.GenesisTime {
+		return fmt.Sprintf("genesis in %s", time.Duration(s.group.GenesisTime-now)*time.Second), nil
+	}
+	return fmt.Sprintf("current round %d", current), nil
+}
@@ -0,0 +1,177 @@
+package drand
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/client"
+	"github.com/drand/drand/client/grpc"
+	"github.com/urfave/cli/v2"
+)
+
+var speedtestRequestsFlag = &cli.IntFlag{
+	Name:  "requests",
+	Usage: "Number of requests sent to each node.",
+	Value: 5,
+}
+
+var speedtestTimeoutFlag = &cli.DurationFlag{
+	Name:  "timeout",
+	Usage: "Maximum time given to each request.",
+	Value: 5 * time.Second,
+}
+
+// speedtestResult is the outcome of the requests sent to a node.
+type speedtestResult struct {
+	addr string
+	// latencies are the times taken by the requests that succeeded
+	latencies []time.Duration
+	// failed is the number of requests that failed or returned a beacon that
+	// does not verify
+	failed int
+	// behind is the largest number of rounds the node lagged behind the
+	// current round
+	behind uint64
+	// err is the last error encountered
+	err error
+}
+
+// median returns the median latency of the requests that succeeded.
+func (s *speedtestResult) median() time.Duration {
+	if len(s.latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, s.latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[len(sorted)/2]
+}
+
+// rankSpeedtest sorts the results from the best node to the worst: the nodes
+// that failed the least requests first, then the ones lagging the least, then
+// the fastest.
+func rankSpeedtest(results []*speedtestResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if a.failed != b.failed {
+			return a.failed < b.failed
+		}
+		if a.behind != b.behind {
+			return a.behind < b.behind
+		}
+		return a.median() < b.median()
+	})
+}
+
+// speedtestCmd measures the latency of the public endpoint of each node of
+// the group and checks the beacons returned, then prints the nodes ranked
+// from the best to the worst, to help choosing the nodes to configure in the
+// clients.
+func speedtestCmd(c *cli.Context) error {
+	ids, err := getNodes(c)
+	if err != nil {
+		return err
+	}
+	group, err := getGroup(c)
+	if err != nil {
+		return err
+	}
+	if group.PublicKey == nil {
+		return errors.New("speedtest: the group file must contain the distributed public key")
+	}
+	requests := c.Int(speedtestRequestsFlag.Name)
+	if requests < 1 {
+		return errors.New("speedtest: at least one request is needed")
+	}
+	timeout := c.Duration(speedtestTimeoutFlag.Name)
+	certPath := c.String(tlsCertFlag.Name)
+	info := chain.NewChainInfo(group)
+
+	results := make([]*speedtestResult, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		results[i] = &speedtestResult{addr: id.Addr}
+		cl, err := grpc.New(id.Addr, certPath, !id.TLS)
+		if err != nil {
+			results[i].failed = requests
+			results[i].err = err
+			continue
+		}
+		wg.Add(1)
+		go func(res *speedtestResult, cl client.Client) {
+			defer wg.Done()
+			defer cl.Close()
+			speedtest(c.Context, cl, info, requests, timeout, res)
+		}(results[i], cl)
+	}
+	wg.Wait()
+	rankSpeedtest(results)
+
+	w := tabwriter.NewWriter(output, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "rank\tnode\tmedian\tfailed\tbehind\terror")
+	for i, r := range results {
+		median := "-"
+		if len(r.latencies) > 0 {
+			median = r.median().Round(time.Microsecond).String()
+		}
+		errMsg := ""
+		if r.err != nil {
+			errMsg = r.err.Error()
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%d/%d\t%d\t%s\n", i+1, r.addr, median, r.failed, requests, r.behind, errMsg)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if results[0].failed == requests {
+		return errors.New("speedtest: no node answered correctly")
+	}
+	return nil
+}
+
+// speedtest sends the requests for the latest round to the node and verifies
+// the beacons it returns.
+func speedtest(ctx context.Context, cl client.Client, info *chain.Info, requests int, timeout time.Duration, res *speedtestResult) {
+	for i := 0; i < requests; i++ {
+		rctx, cancel := context.WithTimeout(ctx, timeout)
+		start := time.Now()
+		r, err := cl.Get(rctx, 0)
+		elapsed := time.Since(start)
+		cancel()
+		if err == nil {
+			err = verifyResult(info, r)
+		}
+		if err != nil {
+			res.failed++
+			res.err = err
+			continue
+		}
+		res.latencies = append(res.latencies, elapsed)
+		current := chain.CurrentRound(time.Now().Unix(), info.Period, info.GenesisTime)
+		// the round may have just changed when the request was answered
+		if current > r.Round()+1 && current-r.Round()-1 > res.behind {
+			res.behind = current - r.Round() - 1
+		}
+	}
+}
+
+// verifyResult checks the signature and the randomness of the result.
+func verifyResult(info *chain.Info, r client.Result) error {
+	b := &chain.Beacon{Round: r.Round(), Signature: r.Signature()}
+	if rd, ok := r.(*client.RandomData); ok {
+		b.PreviousSig = rd.PreviousSignature
+	}
+	if err := info.VerifyBeacon(b); err != nil {
+		return fmt.Errorf("invalid beacon for round %d: %s", r.Round(), err)
+	}
+	if !bytes.Equal(info.Randomness(r.Signature()), r.Randomness()) {
+		return fmt.Errorf("invalid randomness for round %d", r.Round())
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package drand
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/xof/blake2xb"
+	"github.com/urfave/cli/v2"
+)
+
+var vectorsNodesFlag = &cli.IntFlag{
+	Name:  "nodes",
+	Usage: "Size of the group signing the test vectors.",
+	Value: 3,
+}
+
+var vectorsThresholdFlag = &cli.IntFlag{
+	Name:  "threshold",
+	Usage: "Threshold of the group signing the test vectors, defaults to a majority of the nodes.",
+}
+
+var vectorsRoundsFlag = &cli.IntFlag{
+	Name:  "rounds",
+	Usage: "Number of chained rounds to generate.",
+	Value: 3,
+}
+
+var vectorsSeedFlag = &cli.StringFlag{
+	Name:  "seed",
+	Usage: "Seed from which the keys of the group are derived, the same seed gives the same vectors.",
+	Value: "drand test vectors",
+}
+
+// vectorsPeriod and vectorsGenesisTime are the parameters of the chains of
+// the test vectors, they are part of the chain hash signed by the messages of
+// the V1 format.
+const (
+	vectorsPeriod      = 30 * time.Second
+	vectorsGenesisTime = 1600000000
+)
+
+// testVectors are the keys of a group and the beacons it signs, derived from a
+// seed, for each format of the messages and each digest of the randomness.
+type testVectors struct {
+	Scheme    string `json:"scheme"`
+	KeyGroup  string `json:"key_group"`
+	SigGroup  string `json:"sig_group"`
+	Seed      string `json:"seed"`
+	Nodes     int    `json:"nodes"`
+	Threshold int    `json:"threshold"`
+	// PublicKey is the distributed public key verifying the beacons
+	PublicKey string `json:"public_key"`
+	// Commitments are the coefficients of the public polynomial
+	Commitments []string `json:"commitments"`
+	// GenesisSeed is the previous signature of the first round
+	GenesisSeed string        `json:"genesis_seed"`
+	Chains      []vectorChain `json:"chains"`
+}
+
+// vectorChain are the rounds signed by the group for a chain using the given
+// message format and digest.
+type vectorChain struct {
+	// Format is "legacy" for H(previous_signature || round as 8 bytes big
+	// endian) or "v1" for the domain separated message of chain.MessageV1
+	Format string `json:"format"`
+	// Digest derives the randomness from the signature
+	Digest      string `json:"digest"`
+	Period      int64  `json:"period"`
+	GenesisTime int64  `json:"genesis_time"`
+	// MessageV1Round is the first round signing the v1 format, zero for the
+	// legacy format
+	MessageV1Round uint64        `json:"message_v1_round"`
+	ChainHash      string        `json:"chain_hash"`
+	Rounds         []vectorRound `json:"rounds"`
+}
+
+type vectorRound struct {
+	Round             uint64 `json:"round"`
+	PreviousSignature string `json:"previous_signature"`
+	// Message is the message of the round in the format of the chain
+	Message  string          `json:"message"`
+	Partials []vectorPartial `json:"partials"`
+	// Signature is recovered from the partials of the first threshold nodes
+	Signature  string `json:"signature"`
+	Randomness string `json:"randomness"`
+}
+
+type vectorPartial struct {
+	Index int `json:"index"`
+	// PublicShare verifies the partial signature of the node
+	PublicShare string `json:"public_share"`
+	// Signature is prefixed by the index of the node on two bytes
+	Signature string `json:"signature"`
+}
+
+// testVectorsCmd prints, as JSON, the partial and recovered signatures of
+// chained rounds by a group whose keys are derived from the seed, for each
+// message format and digest of the randomness, for the implementers of
+// verifiers to check their compatibility with drand.
+func testVectorsCmd(c *cli.Context) error {
+	n := c.Int(vectorsNodesFlag.Name)
+	thr := c.Int(vectorsThresholdFlag.Name)
+	if !c.IsSet(vectorsThresholdFlag.Name) {
+		thr = key.DefaultThreshold(n)
+	}
+	rounds := c.Int(vectorsRoundsFlag.Name)
+	switch {
+	case n < 1:
+		return errors.New("test-vectors: the group must have at least one node")
+	case thr < 1 || thr > n:
+		return fmt.Errorf("test-vectors: invalid threshold %d for %d nodes", thr, n)
+	case rounds < 1:
+		return errors.New("test-vectors: at least one round is needed")
+	}
+	vectors, err := newTestVectors(c.String(vectorsSeedFlag.Name), n, thr, rounds)
+	if err != nil {
+		return fmt.Errorf("test-vectors: %s", err)
+	}
+	buff, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(output, string(buff))
+	return nil
+}
+
+// newTestVectors derives the keys of a group of n nodes from the seed and
+// signs the given number of chained rounds, for a chain of each message format
+// and digest.
+func newTestVectors(seed string, n, thr, rounds int) (*testVectors, error) {
+	stream := blake2xb.New([]byte(seed))
+	secret := key.KeyGroup.Scalar().Pick(stream)
+	priPoly := share.NewPriPoly(key.KeyGroup, thr, secret, stream)
+	pubPoly := priPoly.Commit(key.KeyGroup.Point().Base())
+	shares := priPoly.Shares(n)
+
+	v := &testVectors{
+		Scheme:    key.SchemeName,
+		KeyGroup:  key.KeyGroup.String(),
+		SigGroup:  key.SigGroup.String(),
+		Seed:      seed,
+		Nodes:     n,
+		Threshold: thr,
+	}
+	publicKey, err := pubPoly.Commit().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	v.PublicKey = hex.EncodeToString(publicKey)
+	_, commits := pubPoly.Info()
+	for _, c := range commits {
+		buff, err := c.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		v.Commitments = append(v.Commitments, hex.EncodeToString(buff))
+	}
+	genesis := sha256.Sum256([]byte(seed))
+	v.GenesisSeed = hex.EncodeToString(genesis[:])
+
+	for _, format := range []string{"legacy", "v1"} {
+		for _, digest := range key.DigestNames() {
+			if _, err := key.DigestFunc(digest); err != nil {
+				// not available in FIPS mode
+				continue
+			}
+			info := &chain.Info{
+				PublicKey:   pubPoly.Commit(),
+				Period:      vectorsPeriod,
+				GenesisTime: vectorsGenesisTime,
+				GroupHash:   genesis[:],
+				Digest:      digest,
+			}
+			if format == "v1" {
+				info.MessageV1Round = 1
+			}
+			vc, err := signVectorChain(info, format, pubPoly, shares, thr, rounds)
+			if err != nil {
+				return nil, err
+			}
+			v.Chains = append(v.Chains, vc)
+		}
+	}
+	return v, nil
+}
+
+// signVectorChain signs the given number of chained rounds of the chain with
+// the shares of the group.
+func signVectorChain(info *chain.Info, format string, pubPoly *share.PubPoly, shares []*share.PriShare,
+	thr, rounds int) (vectorChain, error) {
+	n := len(shares)
+	vc := vectorChain{
+		Format:         format,
+		Digest:         info.Digest,
+		Period:         int64(info.Period.Seconds()),
+		GenesisTime:    info.GenesisTime,
+		MessageV1Round: info.MessageV1Round,
+		ChainHash:      hex.EncodeToString(info.Hash()),
+	}
+	prevSig := info.GroupHash
+	for round := uint64(1); round <= uint64(rounds); round++ {
+		msg := info.Message(round, prevSig)
+		r := vectorRound{
+			Round:             round,
+			PreviousSignature: hex.EncodeToString(prevSig),
+			Message:           hex.EncodeToString(msg),
+		}
+		partials := make([][]byte, 0, n)
+		for _, s := range shares {
+			partial, err := key.Scheme.Sign(s, msg)
+			if err != nil {
+				return vc, err
+			}
+			partials = append(partials, partial)
+			pubShare, err := pubPoly.Eval(s.I).V.MarshalBinary()
+			if err != nil {
+				return vc, err
+			}
+			r.Partials = append(r.Partials, vectorPartial{
+				Index:       s.I,
+				PublicShare: hex.EncodeToString(pubShare),
+				Signature:   hex.EncodeToString(partial),
+			})
+		}
+		sig, err := key.Scheme.Recover(pubPoly, msg, partials[:thr], thr, n)
+		if err != nil {
+			return vc, err
+		}
+		if err := key.Scheme.VerifyRecovered(pubPoly.Commit(), msg, sig); err != nil {
+			return vc, err
+		}
+		r.Signature = hex.EncodeToString(sig)
+		r.Randomness = hex.EncodeToString(info.Randomness(sig))
+		vc.Rounds = append(vc.Rounds, r)
+		prevSig = sig
+	}
+	return vc, nil
+}
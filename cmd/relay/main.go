@@ -7,12 +7,14 @@ import (
 	"net/http/httptest"
 	"os"
 
+	"github.com/drand/drand/client/redis"
 	"github.com/drand/drand/cmd/client/lib"
 	dhttp "github.com/drand/drand/http"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/metrics"
 	"github.com/drand/drand/metrics/pprof"
 
+	goredis "github.com/go-redis/redis/v7"
 	"github.com/gorilla/handlers"
 	grpc_prometheus "github.com/grpc-ecosystem/go-grpc-prometheus"
 	"github.com/urfave/cli/v2"
@@ -42,6 +44,22 @@ var metricsFlag = &cli.StringFlag{
 	Usage: "local host:port to bind a metrics servlet (optional)",
 }
 
+var redisFlag = &cli.StringFlag{
+	Name: "redis",
+	Usage: "redis://host:port/db URL of a Redis server used as a cache shared between relays (optional)." +
+		" Only one of the relays sharing the cache fetches each round from the drand nodes.",
+}
+
+var corsOriginsFlag = &cli.StringSliceFlag{
+	Name:  "cors-origins",
+	Usage: "origins allowed to call the API from a browser, comma separated or repeated (default: all origins)",
+}
+
+var corsHeadersFlag = &cli.StringSliceFlag{
+	Name:  "cors-headers",
+	Usage: "headers browsers may send when calling the API, comma separated or repeated",
+}
+
 // Relay a GRPC connection to an HTTP server.
 func Relay(c *cli.Context) error {
 	if c.IsSet(metricsFlag.Name) {
@@ -58,7 +76,16 @@ func Relay(c *cli.Context) error {
 		return err
 	}
 
-	handler, err := dhttp.New(c.Context, client, fmt.Sprintf("drand/%s (%s)", version, gitCommit), log.DefaultLogger().With("binary", "relay"))
+	if c.IsSet(redisFlag.Name) {
+		opts, err := goredis.ParseURL(c.String(redisFlag.Name))
+		if err != nil {
+			return fmt.Errorf("invalid redis URL: %w", err)
+		}
+		client = redis.NewClient(client, goredis.NewClient(opts))
+	}
+
+	cors := dhttp.WithCORS(c.StringSlice(corsOriginsFlag.Name), c.StringSlice(corsHeadersFlag.Name))
+	handler, err := dhttp.New(c.Context, client, fmt.Sprintf("drand/%s (%s)", version, gitCommit), log.DefaultLogger().With("binary", "relay"), cors)
 	if err != nil {
 		return fmt.Errorf("failed to create rest handler: %w", err)
 	}
@@ -100,7 +127,7 @@ func main() {
 		Name:    "relay",
 		Version: version,
 		Usage:   "Relay a Drand group to a public HTTP Rest API",
-		Flags:   append(lib.ClientFlags, listenFlag, accessLogFlag, metricsFlag),
+		Flags:   append(lib.ClientFlags, listenFlag, accessLogFlag, metricsFlag, redisFlag, corsOriginsFlag, corsHeadersFlag),
 		Action:  Relay,
 	}
 	cli.VersionPrinter = func(c *cli.Context) {
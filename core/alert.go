@@ -0,0 +1,196 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/metrics"
+)
+
+// HaltAlert is sent to the alert webhook, as JSON, when the chain stopped
+// producing beacons for the configured number of rounds.
+type HaltAlert struct {
+	// Address of the node raising the alert
+	Address string `json:"address"`
+	// LastRound is the last round stored by the node
+	LastRound uint64 `json:"last_round"`
+	// ExpectedRound is the latest round that should have been produced
+	ExpectedRound uint64 `json:"expected_round"`
+	// Missed is the number of consecutive rounds missing
+	Missed uint64 `json:"missed"`
+	// Time at which the alert was raised, in unix seconds
+	Time int64 `json:"time"`
+	// Unreachable lists the nodes that did not receive the last partial
+	// signature of the node
+	Unreachable []string `json:"unreachable,omitempty"`
+	// Maintenance lists the nodes in a declared maintenance window, whose
+	// downtime is planned
+	Maintenance []string `json:"maintenance,omitempty"`
+}
+
+// missedRounds returns the number of rounds that should have been produced
+// after the last one stored. The current round is not counted since its
+// beacon may still be in the making.
+func missedRounds(now int64, group *key.Group, last uint64) (expected, missed uint64) {
+	if now < group.GenesisTime {
+		return 0, 0
+	}
+	current := chain.NewSchedule(group).CurrentRound(now)
+	expected = current - 1
+	if expected <= last {
+		return expected, 0
+	}
+	return expected, expected - last
+}
+
+// watchHalts checks at every period that the chain keeps growing and raises
+// an alert once the configured number of consecutive rounds are missing. A
+// new alert is only raised once the chain made progress again. It returns a
+// function stopping the watch.
+func (d *Drand) watchHalts() func() {
+	done := make(chan struct{})
+	go func() {
+		alerted := false
+		for {
+			period := time.Duration(atomic.LoadInt64(&d.period))
+			if period == 0 {
+				period = haltCheckPeriod
+			}
+			select {
+			case <-d.opts.clock.After(period):
+			case <-done:
+				return
+			}
+			alert, ok := d.checkHalt()
+			if !ok {
+				continue
+			}
+			metrics.MissedRounds.Set(float64(alert.Missed))
+			switch {
+			case alert.Missed >= d.opts.alertThreshold && !alerted:
+				alerted = true
+				d.raiseAlert(alert)
+			case alert.Missed == 0 && alerted:
+				alerted = false
+				d.log.Info("halt_alert", "recovered", "last_round", alert.LastRound)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// checkHalt returns the state of the chain if the node runs the beacon.
+func (d *Drand) checkHalt() (*HaltAlert, bool) {
+	d.state.Lock()
+	b, group := d.beacon, d.group
+	d.state.Unlock()
+	if b == nil || group == nil {
+		return nil, false
+	}
+	last, err := b.Store().Last()
+	if err != nil {
+		d.log.Error("halt_alert", "can't load last beacon", "err", err)
+		return nil, false
+	}
+	now := d.opts.clock.Now().Unix()
+	expected, missed := missedRounds(now, group, last.Round)
+	var unreachable []string
+	_, results := b.LastBroadcast()
+	for addr, res := range results {
+		if res.Err != nil {
+			unreachable = append(unreachable, addr)
+		}
+	}
+	sort.Strings(unreachable)
+	var maintenance []string
+	for addr := range d.maintenance.inMaintenance(now) {
+		maintenance = append(maintenance, addr)
+	}
+	sort.Strings(maintenance)
+	return &HaltAlert{
+		Address:       d.priv.Public.Address(),
+		LastRound:     last.Round,
+		ExpectedRound: expected,
+		Missed:        missed,
+		Time:          now,
+		Unreachable:   unreachable,
+		Maintenance:   maintenance,
+	}, true
+}
+
+// raiseAlert logs the alert, bumps the alert metric and runs the configured
+// command and webhook, if any.
+func (d *Drand) raiseAlert(alert *HaltAlert) {
+	d.log.Error("halt_alert", "chain halted", "last_round", alert.LastRound, "expected_round", alert.ExpectedRound,
+		"missed", alert.Missed, "unreachable", strings.Join(alert.Unreachable, ","),
+		"maintenance", strings.Join(alert.Maintenance, ","))
+	metrics.HaltAlerts.Inc()
+	if cmd := d.opts.alertCommand; cmd != "" {
+		go func() {
+			if err := runAlertCommand(cmd, alert); err != nil {
+				d.log.Error("halt_alert", "command failed", "err", err)
+			}
+		}()
+	}
+	if url := d.opts.alertWebhook; url != "" {
+		go func() {
+			if err := postAlert(url, alert); err != nil {
+				d.log.Error("halt_alert", "webhook failed", "err", err)
+			}
+		}()
+	}
+}
+
+// runAlertCommand runs the command with the shell. The alert is given in the
+// DRAND_* environment variables.
+func runAlertCommand(command string, alert *HaltAlert) error {
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		fmt.Sprintf("DRAND_ADDRESS=%s", alert.Address),
+		fmt.Sprintf("DRAND_LAST_ROUND=%d", alert.LastRound),
+		fmt.Sprintf("DRAND_EXPECTED_ROUND=%d", alert.ExpectedRound),
+		fmt.Sprintf("DRAND_MISSED_ROUNDS=%d", alert.Missed),
+		fmt.Sprintf("DRAND_MAINTENANCE=%s", strings.Join(alert.Maintenance, ",")),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s: %s", err, bytes.TrimSpace(out))
+	}
+	return nil
+}
+
+// postAlert sends the alert as JSON to the given URL.
+func postAlert(url string, alert *HaltAlert) error {
+	buff, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), alertTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(buff))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMissedRounds(t *testing.T) {
+	group := &key.Group{GenesisTime: 100, Period: 10 * time.Second}
+	for _, tc := range []struct {
+		now      int64
+		last     uint64
+		expected uint64
+		missed   uint64
+	}{
+		{now: 50, last: 0, expected: 0, missed: 0},
+		// round 1 is in the making
+		{now: 105, last: 0, expected: 0, missed: 0},
+		{now: 115, last: 1, expected: 1, missed: 0},
+		{now: 115, last: 0, expected: 1, missed: 1},
+		{now: 155, last: 2, expected: 5, missed: 3},
+		{now: 155, last: 6, expected: 5, missed: 0},
+	} {
+		expected, missed := missedRounds(tc.now, group, tc.last)
+		require.Equal(t, tc.expected, expected, "now %d last %d", tc.now, tc.last)
+		require.Equal(t, tc.missed, missed, "now %d last %d", tc.now, tc.last)
+	}
+}
+
+func TestAlertHooks(t *testing.T) {
+	alert := &HaltAlert{Address: "127.0.0.1:8080", LastRound: 10, ExpectedRound: 15, Missed: 5, Time: 42,
+		Unreachable: []string{"127.0.0.2:8080"}}
+
+	received := make(chan HaltAlert, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var a HaltAlert
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&a))
+		received <- a
+	}))
+	defer srv.Close()
+	require.NoError(t, postAlert(srv.URL, alert))
+	require.Equal(t, *alert, <-received)
+
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failing.Close()
+	require.Error(t, postAlert(failing.URL, alert))
+
+	dir, err := ioutil.TempDir("", "drand-alert")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	out := filepath.Join(dir, "alert")
+	require.NoError(t, runAlertCommand("echo $DRAND_MISSED_ROUNDS $DRAND_LAST_ROUND > "+out, alert))
+	buff, err := ioutil.ReadFile(out)
+	require.NoError(t, err)
+	require.Equal(t, "5 10\n", string(buff))
+	require.Error(t, runAlertCommand("exit 1", alert))
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/kyber/share/dkg"
+	"google.golang.org/protobuf/proto"
 )
 
 // broadcast implements a very simple broadcasting mechanism: for each new packet
@@ -46,6 +47,12 @@ type broadcast struct {
 	respCh chan dkg.ResponseBundle
 	justCh chan dkg.JustificationBundle
 	verif  verifier
+	// transcript records all the packets given to the DKG
+	transcript *transcript
+	// chunks reassembles the packets too large to be sent at once
+	chunks *chunkAssembler
+	// progress is notified of the packets given to the DKG, if not nil
+	progress *dkgProgress
 }
 
 type packet = dkg.Packet
@@ -56,19 +63,25 @@ var _ dkg.Board = (*broadcast)(nil)
 // Packet, namely that the signature is correct.
 type verifier func(packet) error
 
-func newBroadcast(l log.Logger, c net.ProtocolClient, own string, to []*key.Node, v verifier) *broadcast {
+// newBroadcast returns a broadcast sending the packets to the given nodes until
+// ctx is done.
+func newBroadcast(ctx context.Context, l log.Logger, c net.ProtocolClient, own string, to []*key.Node, v verifier) *broadcast {
 	return &broadcast{
 		l:          l,
-		dispatcher: newDispatcher(l, c, to, own),
+		dispatcher: newDispatcher(ctx, l, c, to, own),
 		dealCh:     make(chan dkg.DealBundle, len(to)),
 		respCh:     make(chan dkg.ResponseBundle, len(to)),
 		justCh:     make(chan dkg.JustificationBundle, len(to)),
 		hashes:     new(arraySet),
 		verif:      v,
+		transcript: new(transcript),
+		// each node sends at most a deal, a response and a justification
+		chunks: newChunkAssembler(to, 3*len(to), DefaultDKGTimeout),
 	}
 }
 
 func (b *broadcast) PushDeals(bundle *dkg.DealBundle) {
+	b.record(bundle)
 	b.dealCh <- *bundle
 	b.Lock()
 	defer b.Unlock()
@@ -78,6 +91,7 @@ func (b *broadcast) PushDeals(bundle *dkg.DealBundle) {
 }
 
 func (b *broadcast) PushResponses(bundle *dkg.ResponseBundle) {
+	b.record(bundle)
 	b.respCh <- *bundle
 	b.Lock()
 	defer b.Unlock()
@@ -87,6 +101,7 @@ func (b *broadcast) PushResponses(bundle *dkg.ResponseBundle) {
 }
 
 func (b *broadcast) PushJustifications(bundle *dkg.JustificationBundle) {
+	b.record(bundle)
 	b.justCh <- *bundle
 	b.Lock()
 	defer b.Unlock()
@@ -124,6 +139,7 @@ func (b *broadcast) BroadcastDKG(c context.Context, p *drand.DKGPacket) (*drand.
 }
 
 func (b *broadcast) passToApplication(p packet) {
+	b.record(p)
 	switch pp := p.(type) {
 	case *dkg.DealBundle:
 		b.dealCh <- *pp
@@ -136,6 +152,15 @@ func (b *broadcast) passToApplication(p packet) {
 	}
 }
 
+// record keeps the packet given to the DKG in the transcript and reports it to
+// the progress.
+func (b *broadcast) record(p packet) {
+	b.transcript.record(p)
+	if b.progress != nil {
+		b.progress.packet(p)
+	}
+}
+
 // sendout converts the packet to protobuf and pass the packet to the dispatcher
 // so it is broadcasted out out to all nodes. sendout requires the broadcast
 // lock.
@@ -210,13 +235,13 @@ type dispatcher struct {
 	senders []*sender
 }
 
-func newDispatcher(l log.Logger, client net.ProtocolClient, to []*key.Node, us string) *dispatcher {
+func newDispatcher(ctx context.Context, l log.Logger, client net.ProtocolClient, to []*key.Node, us string) *dispatcher {
 	var senders = make([]*sender, 0, len(to)-1)
 	for _, node := range to {
 		if node.Address() == us {
 			continue
 		}
-		sender := newSender(l, client, node)
+		sender := newSender(ctx, l, client, node)
 		go sender.run()
 		senders = append(senders, sender)
 	}
@@ -244,14 +269,18 @@ func (d *dispatcher) stop() {
 const senderQueueSize = 10
 
 type sender struct {
+	// ctx is done when the packets don't need to be sent anymore. Stopping
+	// the sender does not cancel it so the packets queued still get out.
+	ctx    context.Context
 	l      log.Logger
 	client net.ProtocolClient
 	to     net.Peer
 	newCh  chan broadcastPacket
 }
 
-func newSender(l log.Logger, client net.ProtocolClient, to net.Peer) *sender {
+func newSender(ctx context.Context, l log.Logger, client net.ProtocolClient, to net.Peer) *sender {
 	return &sender{
+		ctx:    ctx,
 		l:      l,
 		client: client,
 		to:     to,
@@ -269,7 +298,11 @@ func (s *sender) sendPacket(p broadcastPacket) {
 
 func (s *sender) run() {
 	for newPacket := range s.newCh {
-		err := s.client.BroadcastDKG(context.Background(), s.to, newPacket)
+		if s.ctx.Err() != nil {
+			// drain the queue without sending
+			continue
+		}
+		err := s.send(newPacket)
 		if err != nil {
 			s.l.Debug("broadcast", "sending out", "error to", s.to.Address(), "err:", err)
 		} else {
@@ -278,6 +311,23 @@ func (s *sender) run() {
 	}
 }
 
+// send sends the packet at once if it is small enough, in chunks otherwise.
+func (s *sender) send(p broadcastPacket) error {
+	if proto.Size(p) <= dkgChunkSize {
+		return s.client.BroadcastDKG(s.ctx, s.to, p)
+	}
+	chunks, err := splitDKGPacket(p, dkgChunkSize)
+	if err != nil {
+		return err
+	}
+	for _, c := range chunks {
+		if err := s.client.BroadcastDKGChunk(s.ctx, s.to, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *sender) stop() {
 	close(s.newCh)
 }
@@ -0,0 +1,196 @@
+package core
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	gnet "net"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+	"google.golang.org/protobuf/proto"
+)
+
+// splitDKGPacket serializes the packet and splits it in chunks of at most size
+// bytes.
+func splitDKGPacket(p *drand.DKGPacket, size int) ([]*drand.DKGChunk, error) {
+	buff, err := proto.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	if len(buff) > maxDKGPacketSize {
+		return nil, fmt.Errorf("dkg packet too large: %d bytes", len(buff))
+	}
+	id := sha256.Sum256(buff)
+	total := (len(buff) + size - 1) / size
+	chunks := make([]*drand.DKGChunk, 0, total)
+	for i := 0; i < total; i++ {
+		end := (i + 1) * size
+		if end > len(buff) {
+			end = len(buff)
+		}
+		chunks = append(chunks, &drand.DKGChunk{
+			Id:    id[:],
+			Index: uint32(i),
+			Total: uint32(total),
+			Data:  buff[i*size : end],
+		})
+	}
+	return chunks, nil
+}
+
+// chunkAssembler reassembles the DKG packets sent in chunks. It only accepts
+// chunks from the hosts of the DKG participants, bounds the bytes buffered per
+// sender and in total, and drops the packets not completed within ttl.
+type chunkAssembler struct {
+	sync.Mutex
+	max     int
+	ttl     time.Duration
+	now     func() time.Time
+	allowed map[string]bool
+	pending map[string]*pendingPacket
+	// buffered is the number of bytes buffered per sender
+	buffered map[string]int
+	total    int
+}
+
+type pendingPacket struct {
+	chunks   [][]byte
+	from     []string
+	received int
+	started  time.Time
+}
+
+// newChunkAssembler returns an assembler accepting the chunks of at most max
+// packets at once from the given nodes.
+func newChunkAssembler(nodes []*key.Node, max int, ttl time.Duration) *chunkAssembler {
+	return &chunkAssembler{
+		max:      max,
+		ttl:      ttl,
+		now:      time.Now,
+		allowed:  participantHosts(nodes),
+		pending:  make(map[string]*pendingPacket),
+		buffered: make(map[string]int),
+	}
+}
+
+// participantHosts returns the hosts, resolved to their IP addresses, the
+// given nodes can connect from.
+func participantHosts(nodes []*key.Node) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, n := range nodes {
+		for _, addr := range append([]string{n.Address()}, n.FallbackAddresses()...) {
+			host, _, err := gnet.SplitHostPort(addr)
+			if err != nil {
+				continue
+			}
+			hosts[host] = true
+			if gnet.ParseIP(host) != nil {
+				continue
+			}
+			ips, err := gnet.LookupHost(host)
+			if err != nil {
+				continue
+			}
+			for _, ip := range ips {
+				hosts[ip] = true
+			}
+		}
+	}
+	return hosts
+}
+
+// add stores the chunk received from the given address and returns the packet
+// once all its chunks are received, nil otherwise.
+func (a *chunkAssembler) add(from string, c *drand.DKGChunk) (*drand.DKGPacket, error) {
+	total := int(c.GetTotal())
+	switch {
+	case len(c.GetId()) != sha256.Size:
+		return nil, errors.New("invalid chunk id")
+	case total == 0 || int(c.GetIndex()) >= total:
+		return nil, fmt.Errorf("invalid chunk index %d of %d", c.GetIndex(), total)
+	case len(c.GetData()) > dkgChunkSize || total > maxDKGPacketSize/dkgChunkSize+1:
+		return nil, errors.New("dkg packet too large")
+	}
+	sender, _, err := gnet.SplitHostPort(from)
+	if err != nil {
+		sender = from
+	}
+	a.Lock()
+	defer a.Unlock()
+	if !a.allowed[sender] {
+		return nil, fmt.Errorf("chunk from %s: not a dkg participant", from)
+	}
+	a.expire()
+	id := string(c.GetId())
+	p, ok := a.pending[id]
+	if !ok {
+		if len(a.pending) >= a.max {
+			return nil, errors.New("too many dkg packets being reassembled")
+		}
+		p = &pendingPacket{
+			chunks:  make([][]byte, total),
+			from:    make([]string, total),
+			started: a.now(),
+		}
+		a.pending[id] = p
+	}
+	if len(p.chunks) != total {
+		return nil, errors.New("inconsistent number of chunks")
+	}
+	if p.chunks[c.GetIndex()] == nil {
+		size := len(c.GetData())
+		switch {
+		case a.buffered[sender]+size > maxDKGPacketSize:
+			return nil, fmt.Errorf("too many dkg chunks buffered from %s", sender)
+		case a.total+size > maxDKGBufferedSize:
+			return nil, errors.New("too many dkg chunks buffered")
+		}
+		p.chunks[c.GetIndex()] = c.GetData()
+		p.from[c.GetIndex()] = sender
+		p.received++
+		a.buffered[sender] += size
+		a.total += size
+	}
+	if p.received < total {
+		return nil, nil
+	}
+	a.remove(id)
+	buff := bytes.Join(p.chunks, nil)
+	if hash := sha256.Sum256(buff); !bytes.Equal(hash[:], c.GetId()) {
+		return nil, errors.New("invalid chunks: hash mismatch")
+	}
+	packet := new(drand.DKGPacket)
+	if err := proto.Unmarshal(buff, packet); err != nil {
+		return nil, fmt.Errorf("invalid chunked packet: %w", err)
+	}
+	return packet, nil
+}
+
+// expire drops the packets not completed within the ttl.
+func (a *chunkAssembler) expire() {
+	for id, p := range a.pending {
+		if a.now().Sub(p.started) > a.ttl {
+			a.remove(id)
+		}
+	}
+}
+
+// remove drops the packet and releases the bytes buffered for it.
+func (a *chunkAssembler) remove(id string) {
+	p := a.pending[id]
+	delete(a.pending, id)
+	for i, data := range p.chunks {
+		if data == nil {
+			continue
+		}
+		a.buffered[p.from[i]] -= len(data)
+		if a.buffered[p.from[i]] == 0 {
+			delete(a.buffered, p.from[i])
+		}
+		a.total -= len(data)
+	}
+}
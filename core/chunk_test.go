@@ -0,0 +1,158 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	"github.com/drand/kyber/share/dkg"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestDKGChunks(t *testing.T) {
+	dealProto, err := dkgPacketToProto(fakeDeal())
+	require.NoError(t, err)
+	packet := &drand.DKGPacket{Dkg: dealProto}
+	chunks, err := splitDKGPacket(packet, 16)
+	require.NoError(t, err)
+	require.True(t, len(chunks) > 2)
+
+	_, group := test.BatchIdentities(3)
+	from := group.Nodes[1].Address()
+	// chunks can arrive in any order, and more than once
+	a := newChunkAssembler(group.Nodes, 1, time.Minute)
+	for i := len(chunks) - 1; i > 0; i-- {
+		p, err := a.add(from, chunks[i])
+		require.NoError(t, err)
+		require.Nil(t, p)
+	}
+	p, err := a.add(from, chunks[1])
+	require.NoError(t, err)
+	require.Nil(t, p)
+	p, err = a.add(from, chunks[0])
+	require.NoError(t, err)
+	require.True(t, proto.Equal(packet, p))
+	require.Len(t, a.pending, 0)
+	require.Len(t, a.buffered, 0)
+	require.Equal(t, 0, a.total)
+
+	// tampered chunks are detected once the packet is complete
+	tampered := proto.Clone(chunks[0]).(*drand.DKGChunk)
+	tampered.Data = []byte("tampered")
+	for _, c := range append([]*drand.DKGChunk{tampered}, chunks[1:]...) {
+		p, err = a.add(from, c)
+	}
+	require.Error(t, err)
+	require.Nil(t, p)
+
+	// the number of packets being reassembled is bounded
+	_, err = a.add(from, chunks[0])
+	require.NoError(t, err)
+	other := proto.Clone(chunks[0]).(*drand.DKGChunk)
+	other.Id = make([]byte, len(other.Id))
+	_, err = a.add(from, other)
+	require.Error(t, err)
+
+	invalid := proto.Clone(chunks[0]).(*drand.DKGChunk)
+	invalid.Index = invalid.Total
+	_, err = a.add(from, invalid)
+	require.Error(t, err)
+
+	// only the participants can send chunks
+	_, err = a.add("192.0.2.1:4444", chunks[1])
+	require.Error(t, err)
+}
+
+func TestDKGChunksLimits(t *testing.T) {
+	nodes := make([]*key.Node, 6)
+	for i := range nodes {
+		nodes[i] = &key.Node{Index: uint32(i), Identity: key.NewKeyPair(fmt.Sprintf("127.0.0.%d:4444", i+1)).Public}
+	}
+	now := time.Now()
+	a := newChunkAssembler(nodes, 100, time.Minute)
+	a.now = func() time.Time { return now }
+	// the chunks share their data, only the accounting grows
+	data := make([]byte, dkgChunkSize)
+	total := maxDKGPacketSize/dkgChunkSize + 1
+	chunk := func(packet, index int) *drand.DKGChunk {
+		id := sha256.Sum256([]byte{byte(packet)})
+		return &drand.DKGChunk{Id: id[:], Index: uint32(index), Total: uint32(total), Data: data}
+	}
+
+	// a sender can't buffer more than a packet
+	from := nodes[0].Address()
+	var err error
+	for i := 0; i < total && err == nil; i++ {
+		_, err = a.add(from, chunk(0, i))
+	}
+	require.Error(t, err)
+	require.LessOrEqual(t, a.buffered["127.0.0.1"], maxDKGPacketSize)
+
+	// nor can all the senders together
+	for s := 1; s < len(nodes) && err == nil; s++ {
+		for i := 0; i < total-1 && err == nil; i++ {
+			_, err = a.add(nodes[s].Address(), chunk(s, i))
+		}
+	}
+	require.Error(t, err)
+	require.LessOrEqual(t, a.total, maxDKGBufferedSize)
+
+	// the incomplete packets expire after the phase timeout
+	now = now.Add(2 * time.Minute)
+	_, err = a.add(from, chunk(10, 0))
+	require.NoError(t, err)
+	require.Len(t, a.pending, 1)
+	require.Equal(t, dkgChunkSize, a.total)
+}
+
+func TestDKGChunksSize(t *testing.T) {
+	deal := fakeDeal()
+	deal.Deals[0].EncryptedShare = make([]byte, 5<<20)
+	dealProto, err := dkgPacketToProto(deal)
+	require.NoError(t, err)
+	chunks, err := splitDKGPacket(&drand.DKGPacket{Dkg: dealProto}, dkgChunkSize)
+	require.NoError(t, err)
+	require.True(t, len(chunks) > 5)
+	for _, c := range chunks {
+		require.LessOrEqual(t, proto.Size(c), net.MaxMessageSize)
+	}
+}
+
+func TestBroadcastChunked(t *testing.T) {
+	n := 3
+	drands, group, dir, _ := BatchNewDrand(n, true)
+	defer os.RemoveAll(dir)
+	defer CloseAllDrands(drands)
+
+	broads := make([]*broadcast, 0, n)
+	for _, d := range drands {
+		b := newBroadcast(context.Background(), d.log, d.privGateway.ProtocolClient, d.priv.Public.Address(), group.Nodes, func(dkg.Packet) error { return nil })
+		d.dkgInfo = &dkgInfo{
+			board:   b,
+			started: true,
+		}
+		broads = append(broads, b)
+	}
+	// the deal is several times larger than the messages nodes accept
+	deal := fakeDeal()
+	deal.Deals[0].EncryptedShare = make([]byte, 3*net.MaxMessageSize)
+	dealProto, err := dkgPacketToProto(deal)
+	require.NoError(t, err)
+	_, err = broads[0].BroadcastDKG(context.Background(), &drand.DKGPacket{Dkg: dealProto})
+	require.NoError(t, err)
+	for i, b := range broads {
+		select {
+		case <-b.dealCh:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("node %d did not receive the deal", i)
+		}
+	}
+}
@@ -5,6 +5,10 @@ import (
 	"time"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/beacon"
+	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/snapshot"
+	"github.com/drand/drand/chain/store"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
@@ -28,6 +32,8 @@ type Config struct {
 	callOpts          []grpc.CallOption
 	dkgTimeout        time.Duration
 	boltOpts          *bolt.Options
+	storeBackend      string
+	storeOpts         interface{}
 	beaconCbs         []func(*chain.Beacon)
 	dkgCallback       func(*key.Share)
 	insecure          bool
@@ -37,6 +43,34 @@ type Config struct {
 	logger            log.Logger
 	clock             clock.Clock
 	enablePrivate     bool
+	compression       string
+	maxStoreSize      int64
+	rotateInitiator   bool
+	aggregationGrace  time.Duration
+	maxClockSkew      time.Duration
+	maxClockJump      time.Duration
+	partialWindow     uint64
+	verifyPeers       bool
+	previousEpoch     uint64
+	cacheSize         int
+	readOnly          bool
+	groupApproval     bool
+	minThreshold      int
+	syncLimits        beacon.SyncLimits
+	corsOrigins       []string
+	corsHeaders       []string
+	httpAuth          *net.Auth
+	grpcAuth          *net.Auth
+	privateFilter     *net.IPFilter
+	publicFilter      *net.IPFilter
+	certsFolder       string
+	certsOverlap      time.Duration
+	alertThreshold    uint64
+	alertCommand      string
+	alertWebhook      string
+	snapshotBucket    snapshot.Bucket
+	snapshotInterval  time.Duration
+	bootstrapBucket   snapshot.Bucket
 }
 
 // NewConfig returns the config to pass to drand with the default options set
@@ -148,6 +182,32 @@ func WithBoltOptions(opts *bolt.Options) ConfigOption {
 	}
 }
 
+// WithStoreBackend makes drand store the beacons with the backend registered
+// under the given name in the chain/store package. The options are passed to
+// the backend as is.
+func WithStoreBackend(name string, opts interface{}) ConfigOption {
+	return func(d *Config) {
+		d.storeBackend = name
+		d.storeOpts = opts
+	}
+}
+
+// StoreBackend returns the name of the backend storing the beacons.
+func (d *Config) StoreBackend() string {
+	if d.storeBackend == "" {
+		return store.DefaultBackend
+	}
+	return d.storeBackend
+}
+
+// StoreOptions returns the options given to the backend storing the beacons.
+func (d *Config) StoreOptions() interface{} {
+	if d.storeOpts == nil && d.StoreBackend() == boltdb.BackendName {
+		return d.boltOpts
+	}
+	return d.storeOpts
+}
+
 // BoltOptions returns the options given to the bolt db
 func (d *Config) BoltOptions() *bolt.Options {
 	return d.boltOpts
@@ -220,6 +280,48 @@ func WithTrustedCerts(certPaths ...string) ConfigOption {
 	}
 }
 
+// WithCertsRotation makes drand synchronize the trusted certificates with the
+// content of the given folder while running. A certificate removed from the
+// folder is still trusted during the overlap window, so peers can rotate their
+// certificate without a coordinated restart of the group.
+func WithCertsRotation(folder string, overlap time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.certsFolder = folder
+		d.certsOverlap = overlap
+	}
+}
+
+// WithHaltAlert makes drand raise an alert once the given number of
+// consecutive rounds are missing from the chain. The alert is logged and
+// counted in the metrics. If given, the command is run with the shell and
+// the webhook receives the alert as JSON.
+func WithHaltAlert(missed uint64, command, webhook string) ConfigOption {
+	return func(d *Config) {
+		d.alertThreshold = missed
+		d.alertCommand = command
+		d.alertWebhook = webhook
+	}
+}
+
+// WithSnapshots makes drand upload the new rounds of its chain to the bucket
+// at the given interval, as a snapshot extended incrementally. A zero interval
+// means DefaultSnapshotInterval.
+func WithSnapshots(bucket snapshot.Bucket, interval time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.snapshotBucket = bucket
+		d.snapshotInterval = interval
+	}
+}
+
+// WithBootstrap makes drand import the snapshot of the chain in the bucket
+// when it starts with no beacon stored, before syncing the rest of the chain
+// from the other nodes.
+func WithBootstrap(bucket snapshot.Bucket) ConfigOption {
+	return func(d *Config) {
+		d.bootstrapBucket = bucket
+	}
+}
+
 // WithPublicListenAddress specifies the address the drand instance should bind to. It
 // is useful if you want to advertise a public proxy address and the drand
 // instance runs behind your network.
@@ -261,6 +363,184 @@ func WithPrivateRandomness() ConfigOption {
 	}
 }
 
+// WithCompression compresses the messages sent to other nodes with the given
+// gRPC compressor, e.g. "gzip". Nodes always accept compressed messages
+// regardless of this option.
+func WithCompression(name string) ConfigOption {
+	return func(d *Config) {
+		d.compression = name
+	}
+}
+
+// dialOptions returns the gRPC options used when contacting other nodes.
+func (d *Config) dialOptions() []grpc.DialOption {
+	opts := append([]grpc.DialOption{}, d.grpcOpts...)
+	if d.compression != "" {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(d.compression)))
+	}
+	return opts
+}
+
+// WithMaxStoreSize sets the maximum size in bytes of the beacon database. Once
+// reached, the node stops saving new beacons and logs an error until more
+// space is allowed. Zero means no limit.
+func WithMaxStoreSize(size int64) ConfigOption {
+	return func(d *Config) {
+		d.maxStoreSize = size
+	}
+}
+
+// WithInitiatorRotation makes the node only broadcast its partial at the round
+// time when it is the initiator of the round, which changes every round based
+// on the previous signature. Otherwise, the node answers the partial of the
+// initiator, or broadcasts its own after a quarter of the period if none came.
+// This spreads the initiation of the rounds across the group.
+func WithInitiatorRotation() ConfigOption {
+	return func(d *Config) {
+		d.rotateInitiator = true
+	}
+}
+
+// WithAggregationGrace makes the node wait up to the given time for the
+// partials of all the nodes once it has a threshold of partials for a round,
+// so the beacon is aggregated from every available partial. The wait is
+// bounded by half a period. By default, the beacon is aggregated as soon as the
+// threshold is reached.
+func WithAggregationGrace(grace time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.aggregationGrace = grace
+	}
+}
+
+// WithMaxClockSkew sets the maximum difference between the time at which a
+// node sends a partial and the time at which it is received. The nodes
+// chronically exceeding it are degraded and not counted on to reach the
+// threshold. It defaults to beacon.DefaultMaxClockSkew.
+func WithMaxClockSkew(skew time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.maxClockSkew = skew
+	}
+}
+
+// WithMaxClockJump sets the largest difference between the time slept by the
+// beacon loop measured by the wall clock and by the monotonic clock. Beyond
+// it, the clock of the host is considered to have jumped and the jump is
+// reported. It defaults to beacon.DefaultMaxClockJump.
+func WithMaxClockJump(jump time.Duration) ConfigOption {
+	return func(d *Config) {
+		d.maxClockJump = jump
+	}
+}
+
+// WithPartialWindow sets the number of rounds before the last stored beacon
+// for which the partials received are still processed. The older partials are
+// dropped before their verification. It defaults to
+// beacon.DefaultPartialWindow.
+func WithPartialWindow(rounds uint64) ConfigOption {
+	return func(d *Config) {
+		d.partialWindow = rounds
+	}
+}
+
+// WithMinThreshold sets the minimum threshold of the groups the node runs a
+// DKG or a resharing with. Lower values than key.MinimumT of the group size
+// have no effect.
+func WithMinThreshold(threshold int) ConfigOption {
+	return func(d *Config) {
+		d.minThreshold = threshold
+	}
+}
+
+// WithPreviousEpochRounds sets the number of rounds after a resharing during
+// which the node keeps the share and group of the previous epoch to sign and
+// verify the partials of its last rounds. It defaults to
+// beacon.DefaultPreviousEpochRounds.
+func WithPreviousEpochRounds(rounds uint64) ConfigOption {
+	return func(d *Config) {
+		d.previousEpoch = rounds
+	}
+}
+
+// WithBeaconCacheSize sets the number of beacons kept in memory in front of
+// the database for the public API. It defaults to beacon.DefaultCacheSize, a
+// negative size disables the cache.
+func WithBeaconCacheSize(size int) ConfigOption {
+	return func(d *Config) {
+		d.cacheSize = size
+	}
+}
+
+// WithPeerVerification makes the node only accept the partials sent by the node
+// that signed them, as authenticated by its TLS certificate, which must be
+// valid for the address of the node in the group. The other nodes must then
+// present their certificate. It requires TLS.
+func WithPeerVerification() ConfigOption {
+	return func(d *Config) {
+		d.verifyPeers = true
+	}
+}
+
+// WithSyncLimits sets the batch size and the rates at which the node fetches
+// the chain when catching up or following it, and the maximum number of peers
+// syncing from the node at the same time. The zero value means no limit.
+func WithSyncLimits(limits beacon.SyncLimits) ConfigOption {
+	return func(d *Config) {
+		d.syncLimits = limits
+	}
+}
+
+// WithGroupApproval makes the node refuse to reshare towards a group its
+// operator did not approve beforehand, see the ProposeGroup and ApproveGroup
+// control calls.
+func WithGroupApproval() ConfigOption {
+	return func(d *Config) {
+		d.groupApproval = true
+	}
+}
+
+// WithCORS sets the origins allowed to call the public HTTP API from a
+// browser, and the additional headers they may send. By default, all origins
+// are allowed.
+func WithCORS(origins, headers []string) ConfigOption {
+	return func(d *Config) {
+		d.corsOrigins = origins
+		d.corsHeaders = headers
+	}
+}
+
+// WithPublicHTTPAuth restricts the public HTTP API to the clients
+// authenticated by the given Auth.
+func WithPublicHTTPAuth(a *net.Auth) ConfigOption {
+	return func(d *Config) {
+		d.httpAuth = a
+	}
+}
+
+// WithPublicGRPCAuth restricts the public gRPC API to the clients
+// authenticated by the given Auth. The protocol API used by the other members
+// of the group is not affected.
+func WithPublicGRPCAuth(a *net.Auth) ConfigOption {
+	return func(d *Config) {
+		d.grpcAuth = a
+	}
+}
+
+// WithPrivateIPFilter restricts the addresses allowed to connect to the
+// private listener, serving the protocol and the public gRPC APIs.
+func WithPrivateIPFilter(f *net.IPFilter) ConfigOption {
+	return func(d *Config) {
+		d.privateFilter = f
+	}
+}
+
+// WithPublicIPFilter restricts the addresses allowed to connect to the public
+// HTTP listener.
+func WithPublicIPFilter(f *net.IPFilter) ConfigOption {
+	return func(d *Config) {
+		d.publicFilter = f
+	}
+}
+
 // WithVersion sets a version for drand, a visible string to other peers.
 func WithVersion(version string) ConfigOption {
 	return func(d *Config) {
@@ -2,10 +2,12 @@ package core
 
 import (
 	"crypto/sha256"
+	"os"
 	"path"
 	"time"
 
 	"github.com/drand/drand/fs"
+	"github.com/drand/drand/net"
 )
 
 // DefaultConfigFolderName is the name of the folder containing all key materials
@@ -13,11 +15,39 @@ import (
 // directory.
 const DefaultConfigFolderName = ".drand"
 
+// XDGConfigFolderName is the name of the configuration folder when it is
+// located under $XDG_CONFIG_HOME.
+const XDGConfigFolderName = "drand"
+
 // DefaultConfigFolder returns the default path of the configuration folder.
+// An existing $HOME/.drand folder always takes precedence so existing
+// deployments keep working. Otherwise, if $XDG_CONFIG_HOME is set, the folder
+// is $XDG_CONFIG_HOME/drand.
 func DefaultConfigFolder() string {
-	return path.Join(fs.HomeFolder(), DefaultConfigFolderName)
+	legacy := path.Join(fs.HomeFolder(), DefaultConfigFolderName)
+	if exists, _ := fs.Exists(legacy); exists {
+		return legacy
+	}
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return path.Join(xdg, XDGConfigFolderName)
+	}
+	return legacy
 }
 
+// NetworksFolderName is the name of the folder, relative to the configuration
+// folder, containing one configuration folder per network.
+const NetworksFolderName = "networks"
+
+// NetworkFolder returns the configuration folder of the given network under
+// the base configuration folder.
+func NetworkFolder(base, network string) string {
+	return path.Join(base, NetworksFolderName, network)
+}
+
+// ControlPortFileName is the name of the file in which the daemon writes its
+// control port, so the commands run for a network reach the right daemon.
+const ControlPortFileName = "control.port"
+
 // DefaultDBFolder is the name of the folder in which the db file is saved. By
 // default it is relative to the DefaultConfigFolder path.
 const DefaultDBFolder = "db"
@@ -54,5 +84,57 @@ const DefaultGenesisOffset = 1 * time.Second
 // has to keep the same period.
 var DefaultResharingOffset = 30 * time.Second
 
+// ProtocolVersion is the version of the protocol between the nodes. The nodes
+// of a DKG must all run the same version.
+const ProtocolVersion uint32 = 1
+
+// DefaultSnapshotInterval is the default interval at which the new rounds of
+// the chain are uploaded to the snapshot bucket.
+const DefaultSnapshotInterval = 1 * time.Hour
+
 // PrivateRandLength is the length of expected private randomness buffers
 const PrivateRandLength = 32
+
+// certsSyncPeriod is the interval at which the trusted certificates are
+// synchronized with their folder when certificate rotation is enabled.
+var certsSyncPeriod = 1 * time.Minute
+
+// haltCheckPeriod is the interval at which the node checks the chain keeps
+// growing when it does not know the period of the chain yet.
+var haltCheckPeriod = 10 * time.Second
+
+// alertTimeout is the maximum time the alert command or webhook can take.
+var alertTimeout = 30 * time.Second
+
+// watchdogRounds is the number of rounds the beacon loop can miss before the
+// watchdog restarts it.
+const watchdogRounds = 3
+
+// dkgChunkHeadroom is the room left in a request for the envelope of a chunk:
+// its id, index and total, and the protobuf and gRPC framing.
+const dkgChunkHeadroom = 4 << 10
+
+// dkgChunkSize is the maximum size of a DKG packet sent in one request. Larger
+// packets, e.g. deals of groups of hundreds of nodes, are sent in chunks that
+// stay below the message size nodes accept.
+const dkgChunkSize = net.MaxMessageSize - dkgChunkHeadroom
+
+// maxDKGPacketSize is the maximum size of a DKG packet a node reassembles from
+// chunks. It is also the maximum number of bytes buffered for a single sender.
+const maxDKGPacketSize = 64 << 20
+
+// maxDKGBufferedSize is the maximum number of bytes of incomplete packets a
+// node buffers from all the senders.
+const maxDKGBufferedSize = 4 * maxDKGPacketSize
+
+// evidenceTimeout is the maximum time sending the evidence of an equivocation
+// to a node can take.
+var evidenceTimeout = 10 * time.Second
+
+// DefaultRangeLimit is the number of beacons of a page of a range request that
+// does not specify it.
+const DefaultRangeLimit = 100
+
+// MaxRangeLimit is the maximum number of beacons of a page of a range request,
+// so that bulk downloads of the chain are spread over many requests.
+const MaxRangeLimit = 1000
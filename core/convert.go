@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/beacon"
 	"github.com/drand/drand/key"
 	pdkg "github.com/drand/drand/protobuf/crypto/dkg"
 	"github.com/drand/drand/protobuf/drand"
@@ -12,15 +13,39 @@ import (
 	"github.com/drand/kyber/share/dkg"
 )
 
-func beaconToProto(b *chain.Beacon) *drand.PublicRandResponse {
+// beaconToProto returns the public reply of the beacon, whose randomness is
+// derived from the signature with the digest of the chain.
+func beaconToProto(b *chain.Beacon, info *chain.Info, chainHash []byte) *drand.PublicRandResponse {
 	return &drand.PublicRandResponse{
 		Round:             b.Round,
 		Signature:         b.Signature,
 		PreviousSignature: b.PreviousSig,
-		Randomness:        b.Randomness(),
+		Randomness:        info.Randomness(b.Signature),
+		ChainHash:         chainHash,
+		Contributors:      b.Contributors,
 	}
 }
 
+func roundReportToProto(r beacon.RoundReport) *drand.RoundReport {
+	report := &drand.RoundReport{
+		Round:         r.Round,
+		Partials:      make([]*drand.PartialReport, len(r.Partials)),
+		Missing:       make([]uint32, len(r.Missing)),
+		AggregationMs: r.Aggregation.Milliseconds(),
+	}
+	for i, p := range r.Partials {
+		report.Partials[i] = &drand.PartialReport{
+			Index:   uint32(p.Index),
+			DelayMs: p.Delay.Milliseconds(),
+			Late:    p.Late,
+		}
+	}
+	for i, idx := range r.Missing {
+		report.Missing[i] = uint32(idx)
+	}
+	return report
+}
+
 func protoToDKGPacket(d *pdkg.Packet) (dkg.Packet, error) {
 	switch packet := d.GetBundle().(type) {
 	case *pdkg.Packet_Deal:
@@ -51,7 +76,11 @@ func protoToDeal(d *pdkg.DealBundle) (*dkg.DealBundle, error) {
 	bundle := new(dkg.DealBundle)
 	bundle.DealerIndex = d.DealerIndex
 	publics := make([]kyber.Point, 0, len(d.Commits))
+	pointLen := key.KeyGroup.PointLen()
 	for _, c := range d.Commits {
+		if len(c) != pointLen {
+			return nil, fmt.Errorf("invalid public coeff length: %d instead of %d", len(c), pointLen)
+		}
 		coeff := key.KeyGroup.Point()
 		if err := coeff.UnmarshalBinary(c); err != nil {
 			return nil, fmt.Errorf("invalid public coeff:%s", err)
@@ -93,7 +122,11 @@ func protoToJustif(j *pdkg.JustificationBundle) (*dkg.JustificationBundle, error
 	just := new(dkg.JustificationBundle)
 	just.DealerIndex = j.DealerIndex
 	just.Justifications = make([]dkg.Justification, len(j.Justifications))
+	scalarLen := key.KeyGroup.ScalarLen()
 	for i, j := range j.Justifications {
+		if len(j.Share) != scalarLen {
+			return nil, fmt.Errorf("invalid share length: %d instead of %d", len(j.Share), scalarLen)
+		}
 		share := key.KeyGroup.Scalar()
 		if err := share.UnmarshalBinary(j.Share); err != nil {
 			return nil, fmt.Errorf("invalid share: %s", err)
@@ -28,3 +28,17 @@ func TestConvertJustification(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, j, bundle)
 }
+
+func TestConvertInvalidLength(t *testing.T) {
+	j := &pdkg.JustificationBundle{
+		Justifications: []*pdkg.Justification{{ShareIndex: 1, Share: []byte{1, 2, 3}}},
+	}
+	_, err := protoToJustif(j)
+	require.Error(t, err)
+
+	d := &pdkg.DealBundle{
+		Commits: [][]byte{make([]byte, key.KeyGroup.PointLen()+1)},
+	}
+	_, err = protoToDeal(d)
+	require.Error(t, err)
+}
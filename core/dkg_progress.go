@@ -0,0 +1,132 @@
+package core
+
+import (
+	"sync"
+
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/kyber/share/dkg"
+)
+
+// dkgProgress dispatches the progress of the DKG run by the node to the
+// control clients watching it.
+type dkgProgress struct {
+	sync.Mutex
+	watchers map[int]*dkgWatcher
+	next     int
+	// counters of the current protocol
+	nodes          uint32
+	deals          uint32
+	responses      uint32
+	justifications uint32
+}
+
+func newDKGProgress() *dkgProgress {
+	return &dkgProgress{watchers: make(map[int]*dkgWatcher)}
+}
+
+// dkgWatcher queues the progress events for a control client. The events are
+// never dropped for a slow client: a protocol emits a few events per node of
+// the group, so the queue is bounded by the size of the group.
+type dkgWatcher struct {
+	sync.Mutex
+	events []*drand.DKGProgress
+	// wake is signaled when events are queued
+	wake chan struct{}
+}
+
+func (w *dkgWatcher) push(ev *drand.DKGProgress) {
+	w.Lock()
+	w.events = append(w.events, ev)
+	w.Unlock()
+	select {
+	case w.wake <- struct{}{}:
+	default:
+	}
+}
+
+// pop returns the events queued since the last call.
+func (w *dkgWatcher) pop() []*drand.DKGProgress {
+	w.Lock()
+	defer w.Unlock()
+	events := w.events
+	w.events = nil
+	return events
+}
+
+// watch returns a watcher receiving the progress events until unwatch is
+// called with the returned id.
+func (p *dkgProgress) watch() (int, *dkgWatcher) {
+	p.Lock()
+	defer p.Unlock()
+	id := p.next
+	p.next++
+	w := &dkgWatcher{wake: make(chan struct{}, 1)}
+	p.watchers[id] = w
+	return id, w
+}
+
+func (p *dkgProgress) unwatch(id int) {
+	p.Lock()
+	defer p.Unlock()
+	delete(p.watchers, id)
+}
+
+// start resets the counters for a protocol run between the given number of
+// nodes.
+func (p *dkgProgress) start(nodes int) {
+	p.Lock()
+	defer p.Unlock()
+	p.nodes = uint32(nodes)
+	p.deals, p.responses, p.justifications = 0, 0, 0
+	p.emit(drand.DKGEvent_DKG_STARTED, 0)
+}
+
+// packet reports a bundle sent or received by the node.
+func (p *dkgProgress) packet(pk dkg.Packet) {
+	p.Lock()
+	defer p.Unlock()
+	switch bundle := pk.(type) {
+	case *dkg.DealBundle:
+		p.deals++
+		p.emit(drand.DKGEvent_DKG_DEAL, bundle.DealerIndex)
+	case *dkg.ResponseBundle:
+		p.responses++
+		p.emit(drand.DKGEvent_DKG_RESPONSE, bundle.ShareIndex)
+	case *dkg.JustificationBundle:
+		p.justifications++
+		p.emit(drand.DKGEvent_DKG_JUSTIFICATION, bundle.DealerIndex)
+	}
+}
+
+// ready reports a node ready to run the protocol.
+func (p *dkgProgress) ready(from uint32) {
+	p.Lock()
+	defer p.Unlock()
+	p.emit(drand.DKGEvent_DKG_READY, from)
+}
+
+// current returns an event of the given kind with the counters of the current
+// protocol.
+func (p *dkgProgress) current(event drand.DKGEvent) *drand.DKGProgress {
+	p.Lock()
+	defer p.Unlock()
+	return p.event(event, 0)
+}
+
+func (p *dkgProgress) event(event drand.DKGEvent, from uint32) *drand.DKGProgress {
+	return &drand.DKGProgress{
+		Event:          event,
+		From:           from,
+		Nodes:          p.nodes,
+		Deals:          p.deals,
+		Responses:      p.responses,
+		Justifications: p.justifications,
+	}
+}
+
+// emit sends an event to the watchers. It requires the lock.
+func (p *dkgProgress) emit(event drand.DKGEvent, from uint32) {
+	for _, w := range p.watchers {
+		w.push(p.event(event, from))
+	}
+}
@@ -0,0 +1,172 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+	clock "github.com/jonboulle/clockwork"
+)
+
+// errIncompatible is returned to a node whose versions prevent it from running
+// the DKG with the leader.
+var errIncompatible = errors.New("incompatible node")
+
+// dkgReadiness collects the nodes ready to run the DKG over the group pushed by
+// the leader, so the leader only starts the protocol once the nodes listen to
+// its packets.
+type dkgReadiness struct {
+	sync.Mutex
+	hash               []byte
+	outgoing, incoming []*key.Node
+	// thresholds of nodes from the old and new group that must be ready
+	oldThr, newThr int
+	ready          map[string]bool
+	// absent holds the nodes the group could not be pushed to, they are not
+	// waited for
+	absent map[string]bool
+	// incompatible holds the reason each incompatible node can't run the DKG
+	incompatible map[string]string
+	done         chan struct{}
+	closed       bool
+}
+
+// newDKGReadiness returns the readiness of the nodes leaving and joining the
+// group during the DKG, where self, the leader, is ready.
+func newDKGReadiness(self string, outgoing, incoming []*key.Node, oldThr int, group *key.Group) *dkgReadiness {
+	r := &dkgReadiness{
+		hash:         group.Hash(),
+		outgoing:     outgoing,
+		incoming:     incoming,
+		oldThr:       oldThr,
+		newThr:       group.Threshold,
+		ready:        make(map[string]bool),
+		absent:       make(map[string]bool),
+		incompatible: make(map[string]string),
+		done:         make(chan struct{}),
+	}
+	r.ready[self] = true
+	r.check()
+	return r
+}
+
+// signal verifies the packet of a node ready for the DKG and returns the node.
+// It returns an errIncompatible error if the versions of the node prevent it
+// from running the DKG.
+func (r *dkgReadiness) signal(p *drand.DKGReadyPacket) (*key.Node, error) {
+	if !bytes.Equal(p.GetGroupHash(), r.hash) {
+		return nil, errors.New("ready for another group")
+	}
+	var node *key.Node
+	for _, n := range nodeUnion(r.outgoing, r.incoming) {
+		if n.Address() == p.GetAddress() {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return nil, fmt.Errorf("%s is not part of the dkg", p.GetAddress())
+	}
+	if err := key.DKGAuthScheme.Verify(node.Key, r.hash, p.GetSignature()); err != nil {
+		return nil, fmt.Errorf("invalid signature from %s: %s", p.GetAddress(), err)
+	}
+	r.Lock()
+	defer r.Unlock()
+	if reason := incompatibility(p); reason != "" {
+		version := p.GetVersion()
+		if version == "" {
+			version = "unknown version"
+		}
+		r.incompatible[node.Address()] = fmt.Sprintf("%s (%s): %s", node.Address(), version, reason)
+		r.check()
+		return node, fmt.Errorf("%w: %s", errIncompatible, reason)
+	}
+	r.ready[node.Address()] = true
+	r.check()
+	return node, nil
+}
+
+// incompatibility returns why the node announcing its versions in the packet
+// can't run a DKG with this node, or an empty string if it can.
+func incompatibility(p *drand.DKGReadyPacket) string {
+	if p.GetProtocolVersion() != ProtocolVersion {
+		return fmt.Sprintf("protocol version %d instead of %d", p.GetProtocolVersion(), ProtocolVersion)
+	}
+	for _, s := range p.GetSchemes() {
+		if s == key.SchemeName {
+			return ""
+		}
+	}
+	return fmt.Sprintf("scheme %s not supported", key.SchemeName)
+}
+
+// missing marks a node that did not receive the group.
+func (r *dkgReadiness) missing(addr string) {
+	r.Lock()
+	defer r.Unlock()
+	r.absent[addr] = true
+	r.check()
+}
+
+// check closes the done channel once every node is either ready, absent or
+// incompatible. It requires the lock.
+func (r *dkgReadiness) check() {
+	if r.closed {
+		return
+	}
+	for _, n := range nodeUnion(r.outgoing, r.incoming) {
+		addr := n.Address()
+		if !r.ready[addr] && !r.absent[addr] && r.incompatible[addr] == "" {
+			return
+		}
+	}
+	r.closed = true
+	close(r.done)
+}
+
+// count returns the number of ready nodes among the given ones.
+func (r *dkgReadiness) count(nodes []*key.Node) int {
+	var n int
+	for _, node := range nodes {
+		if r.ready[node.Address()] {
+			n++
+		}
+	}
+	return n
+}
+
+// wait blocks until every node is ready, absent or incompatible, or until the
+// timeout. It returns an error listing the incompatible nodes if any, or if
+// less than a threshold of the old or new nodes are ready.
+func (r *dkgReadiness) wait(ctx context.Context, c clock.Clock, timeout time.Duration) error {
+	select {
+	case <-r.done:
+	case <-c.After(timeout):
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	r.Lock()
+	defer r.Unlock()
+	if len(r.incompatible) > 0 {
+		reasons := make([]string, 0, len(r.incompatible))
+		for _, reason := range r.incompatible {
+			reasons = append(reasons, reason)
+		}
+		sort.Strings(reasons)
+		return fmt.Errorf("incompatible nodes: %s", strings.Join(reasons, "; "))
+	}
+	if n := r.count(r.incoming); n < r.newThr {
+		return fmt.Errorf("only %d of %d nodes of the new group are ready for the dkg, threshold %d", n, len(r.incoming), r.newThr)
+	}
+	if n := r.count(r.outgoing); len(r.outgoing) > 0 && n < r.oldThr {
+		return fmt.Errorf("only %d of %d nodes of the old group are ready for the dkg, threshold %d", n, len(r.outgoing), r.oldThr)
+	}
+	return nil
+}
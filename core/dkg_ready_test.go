@@ -0,0 +1,88 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	clock "github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDKGReadiness(t *testing.T) {
+	pairs, group := test.BatchIdentities(4)
+	r := newDKGReadiness(pairs[0].Public.Address(), nil, group.Nodes, 0, group)
+	ready := func(p *key.Pair, hash []byte) *drand.DKGReadyPacket {
+		sig, err := key.DKGAuthScheme.Sign(p.Key, hash)
+		require.NoError(t, err)
+		return &drand.DKGReadyPacket{
+			Address:         p.Public.Address(),
+			GroupHash:       hash,
+			Signature:       sig,
+			Version:         "drand/test",
+			ProtocolVersion: ProtocolVersion,
+			Schemes:         []string{key.SchemeName},
+		}
+	}
+
+	_, err := r.signal(ready(pairs[1], []byte("another group")))
+	require.Error(t, err)
+	forged := ready(pairs[2], group.Hash())
+	forged.Address = pairs[1].Public.Address()
+	_, err = r.signal(forged)
+	require.Error(t, err)
+	node, err := r.signal(ready(pairs[1], group.Hash()))
+	require.NoError(t, err)
+	require.Equal(t, pairs[1].Public.Address(), node.Address())
+
+	// two nodes are ready, below the threshold of three
+	c := clock.NewFakeClock()
+	errCh := make(chan error, 1)
+	go func() { errCh <- r.wait(context.Background(), c, time.Second) }()
+	c.BlockUntil(1)
+	c.Advance(time.Second)
+	require.Error(t, <-errCh)
+
+	// the wait ends once every node is ready or absent
+	r.missing(pairs[2].Public.Address())
+	_, err = r.signal(ready(pairs[3], group.Hash()))
+	require.NoError(t, err)
+	require.NoError(t, r.wait(context.Background(), clock.NewFakeClock(), time.Second))
+}
+
+func TestDKGReadinessIncompatible(t *testing.T) {
+	pairs, group := test.BatchIdentities(3)
+	r := newDKGReadiness(pairs[0].Public.Address(), nil, group.Nodes, 0, group)
+	sign := func(p *key.Pair) []byte {
+		sig, err := key.DKGAuthScheme.Sign(p.Key, group.Hash())
+		require.NoError(t, err)
+		return sig
+	}
+	_, err := r.signal(&drand.DKGReadyPacket{
+		Address:         pairs[1].Public.Address(),
+		GroupHash:       group.Hash(),
+		Signature:       sign(pairs[1]),
+		Version:         "drand/future",
+		ProtocolVersion: ProtocolVersion + 1,
+		Schemes:         []string{key.SchemeName},
+	})
+	require.True(t, errors.Is(err, errIncompatible))
+	_, err = r.signal(&drand.DKGReadyPacket{
+		Address:         pairs[2].Public.Address(),
+		GroupHash:       group.Hash(),
+		Signature:       sign(pairs[2]),
+		ProtocolVersion: ProtocolVersion,
+		Schemes:         []string{"another-scheme"},
+	})
+	require.True(t, errors.Is(err, errIncompatible))
+
+	// every node answered, the error lists the incompatible ones
+	err = r.wait(context.Background(), clock.NewFakeClock(), time.Second)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), pairs[1].Public.Address()+" (drand/future): protocol version")
+	require.Contains(t, err.Error(), pairs[2].Public.Address()+" (unknown version): scheme "+key.SchemeName)
+}
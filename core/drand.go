@@ -6,23 +6,33 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/chain/beacon"
-	"github.com/drand/drand/chain/boltdb"
+	"github.com/drand/drand/chain/store"
 	"github.com/drand/drand/fs"
 	"github.com/drand/drand/http"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/kyber/share/dkg"
+
+	"google.golang.org/grpc/encoding"
 )
 
 // Drand is the main logic of the program. It reads the keys / group file, it
 // can start the DKG, read/write shars to files and can initiate/respond to TBlS
 // signature requests.
 type Drand struct {
+	// period of the current group, in nanoseconds, once its changes of period
+	// took effect. It is read atomically by the incoming request interceptors
+	// so it must not depend on the state lock. Kept first in the struct for
+	// 64-bit alignment.
+	period int64
+
 	opts *Config
 	priv *key.Pair
 	// current group this drand node is using
@@ -36,8 +46,14 @@ type Drand struct {
 
 	beacon *beacon.Handler
 	// dkg private share. can be nil if dkg not finished yet.
-	share   *key.Share
-	dkgDone bool
+	share *key.Share
+	// status is the state of the node in its lifecycle, entered at the unix
+	// time statusSince
+	status      drand.NodeState
+	statusSince int64
+	// paused is true when the operator paused the participation of the node,
+	// kept when the beacon restarts
+	paused bool
 	// manager is created and destroyed during a setup phase
 	manager  *setupManager
 	receiver *setupReceiver
@@ -45,6 +61,9 @@ type Drand struct {
 	// dkgInfo contains all the information related to an upcoming or in
 	// progress dkg protocol. It is nil for the rest of the time.
 	dkgInfo *dkgInfo
+	// readiness collects the nodes ready for the dkg the leader is about to
+	// start. It is nil for the rest of the time.
+	readiness *dkgReadiness
 	// general logger
 	log log.Logger
 
@@ -56,6 +75,36 @@ type Drand struct {
 	// but not participating. Drand calls the cancel func when the node
 	// participates to a resharing.
 	syncerCancel context.CancelFunc
+
+	// groups proposed for a future resharing
+	proposals *groupProposals
+
+	// evidence of the nodes caught signing two different messages
+	evidence *evidenceLog
+
+	// maintenance windows of the node and of the nodes that announced theirs
+	maintenance *maintenanceWindows
+
+	// partial signatures produced with the share and the identity key
+	keyUsage *keyUsage
+
+	// progress of the DKG for the control clients
+	dkgProgress *dkgProgress
+
+	// stopCertsWatch stops the synchronization of the trusted certificates
+	stopCertsWatch func()
+	// stopHaltWatch stops checking the chain keeps growing
+	stopHaltWatch func()
+	// stopMaintenanceWatch stops updating the maintenance metric
+	stopMaintenanceWatch func()
+	// stopWatchdog stops the watchdog of the beacon loop
+	stopWatchdog func()
+	// stopSnapshots stops the uploads of the snapshots of the chain
+	stopSnapshots func()
+	// ctx is cancelled when drand stops so the operations in flight, e.g. a
+	// DKG, don't outlive it
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 // NewDrand returns an drand struct. It assumes the private key pair
@@ -75,7 +124,15 @@ func initDrand(s key.Store, c *Config) (*Drand, error) {
 	if !c.insecure && (c.certPath == "" || c.keyPath == "") {
 		return nil, errors.New("config: need to set WithInsecure if no certificate and private key path given")
 	}
+	if c.compression != "" && encoding.GetCompressor(c.compression) == nil {
+		return nil, fmt.Errorf("config: unknown grpc compression %q", c.compression)
+	}
 	priv, err := s.LoadKeyPair()
+	if err != nil && c.readOnly {
+		// a read-only node never signs: it only needs an identity to listen
+		// on its private address
+		priv, err = readOnlyKeyPair(c)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -92,6 +149,14 @@ func initDrand(s key.Store, c *Config) (*Drand, error) {
 		opts:   c,
 		log:    logger,
 		exitCh: make(chan bool, 1),
+
+		statusSince: c.clock.Now().Unix(),
+
+		proposals:   new(groupProposals),
+		evidence:    newEvidenceLog(c.DBFolder()),
+		maintenance: new(maintenanceWindows),
+		keyUsage:    newKeyUsage(c.DBFolder(), logger),
+		dkgProgress: newDKGProgress(),
 	}
 	if err := setupDrand(d, c); err != nil {
 		return nil, err
@@ -99,32 +164,53 @@ func initDrand(s key.Store, c *Config) (*Drand, error) {
 	return d, nil
 }
 
-func setupDrand(d *Drand, c *Config) error {
+func setupDrand(d *Drand, c *Config) (err error) {
 	// Set the private API address to the command-line flag, if given.
 	// Otherwise, set it to the address associated with stored private key.
 	privAddr := c.PrivateListenAddress(d.priv.Public.Address())
 	pubAddr := c.PublicListenAddress("")
+	d.ctx, d.cancel = context.WithCancel(context.Background())
 	// ctx is used to create the gateway below.
 	// Gateway constructors (specifically, the generated gateway stubs that require it)
 	// do not actually use it, so we are passing a background context to be safe.
 	ctx := context.Background()
-	var err error
 	d.log.Info("network", "init", "insecure", c.insecure)
+	// listen on the control port first so a second daemon started with the
+	// same configuration fails before setting up anything else
+	d.control, err = net.NewTCPGrpcControlListener(d, c.ControlPort())
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			d.control.Stop()
+		}
+	}()
 	if pubAddr != "" {
-		handler, err := http.New(ctx, &drandProxy{d}, c.Version(), d.log.With("server", "http"))
+		handler, err := http.New(ctx, &drandProxy{d}, c.Version(), d.log.With("server", "http"), http.WithCORS(c.corsOrigins, c.corsHeaders))
 		if err != nil {
 			return err
 		}
-		if d.pubGateway, err = net.NewRESTPublicGateway(ctx, pubAddr, c.certPath, c.keyPath, c.certmanager, handler, c.insecure); err != nil {
-			return err
+		if d.pubGateway, err = net.NewRESTPublicGateway(ctx, pubAddr, c.certPath, c.keyPath, c.certmanager, handler, c.insecure, c.httpAuth, c.publicFilter); err != nil {
+			return fmt.Errorf("public listener: %w", err)
 		}
 	}
-	d.privGateway, err = net.NewGRPCPrivateGateway(ctx, privAddr, c.certPath, c.keyPath, c.certmanager, d, c.insecure, d.opts.grpcOpts...)
+	if c.certsFolder != "" && c.certmanager != nil {
+		c.certmanager.SetOverlap(c.certsOverlap)
+		d.stopCertsWatch = c.certmanager.WatchFolder(c.certsFolder, certsSyncPeriod)
+	}
+	if c.alertThreshold > 0 {
+		d.stopHaltWatch = d.watchHalts()
+	}
+	d.stopMaintenanceWatch = d.watchMaintenance()
+	d.stopWatchdog = d.watchBeacon()
+	if c.snapshotBucket != nil {
+		d.stopSnapshots = d.uploadSnapshots()
+	}
+	d.privGateway, err = net.NewGRPCPrivateGateway(ctx, privAddr, c.certPath, c.keyPath, c.certmanager, d, c.insecure, c.dialOptions()...)
 	if err != nil {
-		return err
+		return fmt.Errorf("private listener: %w", err)
 	}
-	p := c.ControlPort()
-	d.control = net.NewTCPGrpcControlListener(d, p)
 	go d.control.Start()
 	d.log.Info("private_listen", privAddr, "control_port", c.ControlPort(), "public_listen", pubAddr, "folder", d.opts.ConfigFolder())
 	d.privGateway.StartAll()
@@ -146,15 +232,61 @@ func LoadDrand(s key.Store, c *Config) (*Drand, error) {
 		return nil, err
 	}
 	checkGroup(d.log, d.group)
+	atomic.StoreInt64(&d.period, int64(lastPeriod(d.group)))
 	d.share, err = s.LoadShare()
 	if err != nil {
 		return nil, err
 	}
 	d.log.Debug("serving", d.priv.Public.Address())
-	d.dkgDone = true
+	d.setStatus(drand.NodeState_STATE_DKG_DONE)
+	return d, nil
+}
+
+// LoadReadOnlyDrand restores a drand instance that only serves the beacons of
+// its existing database. The share and the key pair are not needed.
+func LoadReadOnlyDrand(s key.Store, c *Config) (*Drand, error) {
+	c.readOnly = true
+	d, err := initDrand(s, c)
+	if err != nil {
+		return nil, err
+	}
+	d.group, err = s.LoadGroup()
+	if err != nil {
+		return nil, err
+	}
+	atomic.StoreInt64(&d.period, int64(lastPeriod(d.group)))
+	if _, err := d.newBeacon(); err != nil {
+		return nil, err
+	}
+	d.log.Info("serving", "read-only")
+	d.updateStatus(drand.NodeState_STATE_READ_ONLY)
 	return d, nil
 }
 
+// readOnlyKeyPair returns a key pair generated for a read-only node whose key
+// material was removed. It is never saved nor used to sign.
+func readOnlyKeyPair(c *Config) (*key.Pair, error) {
+	addr := c.PrivateListenAddress("")
+	if addr == "" {
+		return nil, errors.New("drand: a read-only node without key pair needs its private listen address")
+	}
+	if c.insecure {
+		return key.NewKeyPair(addr), nil
+	}
+	return key.NewTLSKeyPair(addr), nil
+}
+
+// errReadOnly is returned by the operations a read-only node refuses.
+var errReadOnly = errors.New("drand: read-only node")
+
+// checkWritable returns an error if the node is read-only.
+func (d *Drand) checkWritable() error {
+	if d.opts.readOnly {
+		return errReadOnly
+	}
+	return nil
+}
+
 // WaitDKG waits on the running dkg protocol. In case of an error, it returns
 // it. In case of a finished DKG protocol, it saves the dist. public  key and
 // private share. These should be loadable by the store.
@@ -168,7 +300,12 @@ func (d *Drand) WaitDKG() (*key.Group, error) {
 	d.state.Unlock()
 
 	d.log.Debug("waiting_dkg_end", time.Now())
-	res := <-waitCh
+	var res dkg.OptionResult
+	select {
+	case res = <-waitCh:
+	case <-d.ctx.Done():
+		return nil, errors.New("drand: stopped during dkg")
+	}
 	if res.Error != nil {
 		return nil, fmt.Errorf("drand: error from dkg: %v", res.Error)
 	}
@@ -186,33 +323,73 @@ func (d *Drand) WaitDKG() (*key.Group, error) {
 	}
 
 	s := key.Share(*res.Result.Key)
-	d.share = &s
-	if err := d.store.SaveShare(d.share); err != nil {
-		return nil, err
-	}
 	targetGroup := d.dkgInfo.target
 	// only keep the qualified ones
 	targetGroup.Nodes = qualNodes
 	// setup the dist. public key
-	targetGroup.PublicKey = d.share.Public()
-	d.group = targetGroup
+	targetGroup.PublicKey = s.Public()
 	var output []string
 	for _, node := range qualNodes {
 		output = append(output, fmt.Sprintf("{addr: %s, idx: %d, pub: %s}", node.Address(), node.Index, node.Key))
 	}
-	d.log.Debug("dkg_end", time.Now(), "certified", d.group.Len(), "list", "["+strings.Join(output, ",")+"]")
-	if err := d.store.SaveGroup(d.group); err != nil {
+	d.log.Debug("dkg_end", time.Now(), "certified", targetGroup.Len(), "list", "["+strings.Join(output, ",")+"]")
+	if d.dkgInfo.dryRun {
+		// the dry run only checks the nodes can run the protocol together
+		d.log.Info("dkg_end", "dry_run", "certified", targetGroup.Len(), "threshold", targetGroup.Threshold)
+		d.dkgInfo.board.stop()
+		d.setStatus(d.dkgInfo.prevStatus)
+		d.dkgInfo = nil
+		return targetGroup, nil
+	}
+	if err := d.commitDKG(&s, targetGroup); err != nil {
 		return nil, err
 	}
+	atomic.StoreInt64(&d.period, int64(lastPeriod(d.group)))
+	transcript := d.dkgInfo.board.transcript.finish(res.Result.QUAL, d.group)
+	if err := SaveTranscript(d.opts.ConfigFolder(), transcript); err != nil {
+		d.log.Error("dkg_end", "can't save transcript", "err", err)
+	}
 	d.opts.applyDkgCallback(d.share)
 	d.dkgInfo.board.stop()
 	d.dkgInfo = nil
 	return d.group, nil
 }
 
+// commitDKG saves the share and group resulting from a DKG and starts using
+// them. If any of them can not be saved, the previous share and group are
+// restored so a failed resharing leaves the node on the current chain. It must
+// be called with the state lock held.
+func (d *Drand) commitDKG(share *key.Share, group *key.Group) error {
+	err := d.store.SaveShare(share)
+	if err == nil {
+		err = d.store.SaveGroup(group)
+	}
+	if err == nil {
+		d.share = share
+		d.group = group
+		return nil
+	}
+	d.log.Error("dkg_end", "can't save dkg output", "err", err)
+	if d.share != nil {
+		if rerr := d.store.SaveShare(d.share); rerr != nil {
+			d.log.Error("dkg_end", "rollback", "share", "err", rerr)
+		}
+	}
+	if d.group != nil {
+		if rerr := d.store.SaveGroup(d.group); rerr != nil {
+			d.log.Error("dkg_end", "rollback", "group", "err", rerr)
+		}
+	}
+	d.log.Info("dkg_end", "rollback", "done")
+	return fmt.Errorf("drand: saving dkg output: %w", err)
+}
+
 // StartBeacon initializes the beacon if needed and launch a go
 // routine that runs the generation loop.
 func (d *Drand) StartBeacon(catchup bool) {
+	if d.opts.bootstrapBucket != nil {
+		d.bootstrap()
+	}
 	b, err := d.newBeacon()
 	if err != nil {
 		d.log.Error("init_beacon", err)
@@ -221,7 +398,11 @@ func (d *Drand) StartBeacon(catchup bool) {
 
 	d.log.Info("beacon_start", time.Now(), "catchup", catchup)
 	if catchup {
-		go b.Catchup()
+		d.updateStatus(drand.NodeState_STATE_SYNCING)
+		go func() {
+			b.Catchup()
+			d.syncDone(b)
+		}()
 	} else if err := b.Start(); err != nil {
 		d.log.Error("beacon_start", err)
 	}
@@ -243,10 +424,12 @@ func (d *Drand) transition(oldGroup *key.Group, oldPresent, newPresent bool) {
 	timeToStop := d.group.TransitionTime - 1
 	if !newPresent {
 		// an old node is leaving the network
+		d.running()
 		if err := d.beacon.StopAt(timeToStop); err != nil {
 			d.log.Error("leaving_group", err)
 		} else {
 			d.log.Info("leaving_group", "done", "time", d.opts.clock.Now())
+			d.updateStatus(drand.NodeState_STATE_STOPPED)
 		}
 		return
 	}
@@ -259,18 +442,31 @@ func (d *Drand) transition(oldGroup *key.Group, oldPresent, newPresent bool) {
 	// tell the current beacon to stop just before the new network starts
 	if oldPresent {
 		d.beacon.TransitionNewGroup(newShare, newGroup)
+		d.running()
 	} else {
 		b, err := d.newBeacon()
 		if err != nil {
 			d.log.Fatal("transition", "new_node", "err", err)
 		}
+		d.updateStatus(drand.NodeState_STATE_SYNCING)
 		if err := b.Transition(oldGroup); err != nil {
 			d.log.Error("sync_before", err)
 		}
+		d.syncDone(b)
 		d.log.Info("transition_new", "done")
 	}
 }
 
+// syncDone moves the node from syncing to running the beacon once the beacon
+// b caught up with the chain, unless it was stopped or replaced meanwhile.
+func (d *Drand) syncDone(b *beacon.Handler) {
+	d.state.Lock()
+	defer d.state.Unlock()
+	if d.beacon == b && d.status == drand.NodeState_STATE_SYNCING {
+		d.setStatus(d.runningStatus())
+	}
+}
+
 // StopBeacon stops the beacon generation process and resets it.
 func (d *Drand) StopBeacon() {
 	d.state.Lock()
@@ -280,10 +476,12 @@ func (d *Drand) StopBeacon() {
 	}
 	d.beacon.Stop()
 	d.beacon = nil
+	d.setStatus(drand.NodeState_STATE_STOPPED)
 }
 
 // Stop simply stops all drand operations.
 func (d *Drand) Stop(ctx context.Context) {
+	d.cancel()
 	d.StopBeacon()
 	d.state.Lock()
 	if d.pubGateway != nil {
@@ -291,6 +489,18 @@ func (d *Drand) Stop(ctx context.Context) {
 	}
 	d.privGateway.StopAll(ctx)
 	d.control.Stop()
+	if d.stopCertsWatch != nil {
+		d.stopCertsWatch()
+	}
+	if d.stopHaltWatch != nil {
+		d.stopHaltWatch()
+	}
+	if d.stopSnapshots != nil {
+		d.stopSnapshots()
+	}
+	d.stopMaintenanceWatch()
+	d.stopWatchdog()
+	d.setStatus(drand.NodeState_STATE_STOPPED)
 	d.state.Unlock()
 	d.exitCh <- true
 }
@@ -300,20 +510,23 @@ func (d *Drand) WaitExit() chan bool {
 	return d.exitCh
 }
 
-func (d *Drand) createBoltStore() (chain.Store, error) {
+func (d *Drand) createStore() (chain.Store, error) {
 	fs.CreateSecureFolder(d.opts.DBFolder())
-	return boltdb.NewBoltStore(d.opts.dbFolder, d.opts.boltOpts)
+	return store.New(d.opts.StoreBackend(), d.opts.dbFolder, d.opts.StoreOptions())
 }
 
 func (d *Drand) newBeacon() (*beacon.Handler, error) {
 	d.state.Lock()
 	defer d.state.Unlock()
-	store, err := d.createBoltStore()
+	store, err := d.createStore()
 	if err != nil {
 		return nil, err
 	}
 	pub := d.priv.Public
 	node := d.group.Find(pub)
+	if node == nil && d.opts.readOnly {
+		node = &key.Node{Identity: pub}
+	}
 	if node == nil {
 		return nil, fmt.Errorf("public key %s not found in group", pub)
 	}
@@ -322,12 +535,29 @@ func (d *Drand) newBeacon() (*beacon.Handler, error) {
 		Group:  d.group,
 		Share:  d.share,
 		Clock:  d.opts.clock,
+
+		MaxStoreSize:        d.opts.maxStoreSize,
+		RotateInitiator:     d.opts.rotateInitiator,
+		AggregationGrace:    d.opts.aggregationGrace,
+		MaxClockSkew:        d.opts.maxClockSkew,
+		MaxClockJump:        d.opts.maxClockJump,
+		SyncLimits:          d.opts.syncLimits,
+		PartialWindow:       d.opts.partialWindow,
+		PreviousEpochRounds: d.opts.previousEpoch,
+		CacheSize:           d.opts.cacheSize,
+		ReadOnly:            d.opts.readOnly,
+		OnEquivocation:      d.equivocated,
+		OnPartialSigned:     d.partialSigned,
+	}
+	if d.opts.verifyPeers && !d.opts.insecure && d.opts.certmanager != nil {
+		conf.VerifyPeer = d.opts.certmanager.VerifyPeer
 	}
 	b, err := beacon.NewHandler(d.privGateway.ProtocolClient, store, conf, d.log)
 	if err != nil {
 		return nil, err
 	}
 	d.beacon = b
+	d.beacon.SetPaused(d.paused)
 	d.beacon.AddCallback("opts", d.opts.callbacks)
 	// cancel any sync operations
 	if d.syncerCancel != nil {
@@ -337,6 +567,17 @@ func (d *Drand) newBeacon() (*beacon.Handler, error) {
 	return d.beacon, nil
 }
 
+// lastPeriod returns the period of the chain once all the changes of period
+// of the group took effect.
+func lastPeriod(group *key.Group) time.Duration {
+	if n := len(group.PeriodChanges); n > 0 {
+		return group.PeriodChanges[n-1].Period
+	}
+	return group.Period
+}
+
+// checkGroup logs the identities of the group that are not signed. Their
+// fallback addresses, which the signature covers, are not used.
 func checkGroup(l log.Logger, group *key.Group) {
 	unsigned := group.UnsignedIdentities()
 	if unsigned == nil {
@@ -345,6 +586,10 @@ func checkGroup(l log.Logger, group *key.Group) {
 	var info []string
 	for _, n := range unsigned {
 		info = append(info, fmt.Sprintf("{%s - %s}", n.Address(), key.PointToString(n.Key)[0:10]))
+		if len(n.Fallbacks) > 0 {
+			l.Warn("UNSIGNED_FALLBACKS", n.Address(), "ignored", strings.Join(n.Fallbacks, ","))
+			n.Fallbacks = nil
+		}
 	}
 	l.Info("UNSIGNED_GROUP", "["+strings.Join(info, ",")+"]", "FIX", "upgrade")
 }
@@ -358,4 +603,9 @@ type dkgInfo struct {
 	conf    *dkg.Config
 	proto   *dkg.Protocol
 	started bool
+	// dryRun is true when the outcome of the protocol is discarded
+	dryRun bool
+	// prevStatus is the state of the node before the protocol, restored if
+	// it fails
+	prevStatus drand.NodeState
 }
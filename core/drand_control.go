@@ -22,6 +22,8 @@ import (
 	"github.com/drand/kyber/share/dkg"
 	vss "github.com/drand/kyber/share/vss/pedersen"
 	clock "github.com/jonboulle/clockwork"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // errPreempted is returned on reshares when a subsequent reshare is started concurrently
@@ -31,9 +33,12 @@ var errPreempted = errors.New("time out: pre-empted")
 // the DKG protocol to finish. If the request specifies this node is a leader,
 // it starts the DKG protocol.
 func (d *Drand) InitDKG(c context.Context, in *drand.InitDKGPacket) (*drand.GroupPacket, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	isLeader := in.GetInfo().GetLeader()
 	d.state.Lock()
-	if d.dkgDone {
+	if d.hasShare() {
 		d.state.Unlock()
 		return nil, errors.New("dkg phase already done - call reshare")
 	}
@@ -59,16 +64,89 @@ func (d *Drand) InitDKG(c context.Context, in *drand.InitDKGPacket) (*drand.Grou
 
 	// send it to everyone in the group nodes
 	nodes := group.Nodes
-	if err := d.pushDKGInfo([]*key.Node{}, nodes, 0, group, in.GetInfo().GetSecret(), in.GetInfo().GetTimeout()); err != nil {
+	if err := d.pushDKGInfo([]*key.Node{}, nodes, 0, group, in.GetInfo().GetSecret(), in.GetInfo().GetTimeout(), false); err != nil {
 		return nil, err
 	}
-	finalGroup, err := d.runDKG(true, group, in.GetInfo().GetTimeout(), in.GetEntropy())
+	finalGroup, err := d.runDKG(true, nil, group, in.GetInfo().GetTimeout(), in.GetEntropy())
 	if err != nil {
 		return nil, err
 	}
 	return finalGroup.ToProto(), nil
 }
 
+// InitDKGStream runs InitDKG and streams the progress of the DKG to the
+// client. The last message holds the resulting group.
+func (d *Drand) InitDKGStream(in *drand.InitDKGPacket, stream drand.Control_InitDKGStreamServer) error {
+	return d.streamDKG(stream, func() (*drand.GroupPacket, error) {
+		return d.InitDKG(stream.Context(), in)
+	})
+}
+
+// InitReshareStream runs InitReshare and streams the progress of the
+// resharing to the client. The last message holds the resulting group.
+func (d *Drand) InitReshareStream(in *drand.InitResharePacket, stream drand.Control_InitReshareStreamServer) error {
+	return d.streamDKG(stream, func() (*drand.GroupPacket, error) {
+		return d.InitReshare(stream.Context(), in)
+	})
+}
+
+// dkgStream is the stream of the progress of a DKG or a resharing.
+type dkgStream interface {
+	Send(*drand.DKGProgress) error
+}
+
+// streamDKG runs the protocol and sends its progress to the stream, then the
+// resulting group.
+func (d *Drand) streamDKG(stream dkgStream, run func() (*drand.GroupPacket, error)) error {
+	if err := d.checkWritable(); err != nil {
+		return err
+	}
+	id, watcher := d.dkgProgress.watch()
+	defer d.dkgProgress.unwatch(id)
+	type result struct {
+		group *drand.GroupPacket
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		group, err := run()
+		done <- result{group, err}
+	}()
+	if err := stream.Send(&drand.DKGProgress{Event: drand.DKGEvent_DKG_SETUP}); err != nil {
+		return err
+	}
+	send := func() error {
+		for _, ev := range watcher.pop() {
+			if err := stream.Send(ev); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for {
+		select {
+		case <-watcher.wake:
+			if err := send(); err != nil {
+				return err
+			}
+		case res := <-done:
+			if res.err != nil {
+				return res.err
+			}
+			// send the events that came before the end
+			if err := send(); err != nil {
+				return err
+			}
+			last := d.dkgProgress.current(drand.DKGEvent_DKG_DONE)
+			for _, n := range res.group.GetNodes() {
+				last.Qualified = append(last.Qualified, n.GetIndex())
+			}
+			last.Group = res.group
+			return stream.Send(last)
+		}
+	}
+}
+
 func (d *Drand) leaderRunSetup(newSetup func(d *Drand) (*setupManager, error)) (group *key.Group, err error) {
 	// setup the manager
 	d.state.Lock()
@@ -119,8 +197,13 @@ func (d *Drand) leaderRunSetup(newSetup func(d *Drand) (*setupManager, error)) (
 }
 
 // runDKG setups the proper structures and protocol to run the DKG and waits
-// until it finishes. If leader is true, this node sends the first packet.
-func (d *Drand) runDKG(leader bool, group *key.Group, timeout uint32, randomness *drand.EntropyInfo) (*key.Group, error) {
+// until it finishes. If leader is true, this node sends the first packet once
+// the other nodes are ready. Otherwise, it signals lpeer, the leader, it is
+// ready.
+func (d *Drand) runDKG(leader bool, lpeer net.Peer, group *key.Group, timeout uint32, randomness *drand.EntropyInfo) (*key.Group, error) {
+	if err := d.validateGroup(group); err != nil {
+		return nil, err
+	}
 	reader, user := extractEntropy(randomness)
 	config := &dkg.Config{
 		Suite:          key.KeyGroup.(dkg.Suite),
@@ -134,13 +217,16 @@ func (d *Drand) runDKG(leader bool, group *key.Group, timeout uint32, randomness
 		Auth:           key.DKGAuthScheme,
 	}
 	phaser := d.getPhaser(timeout)
-	board := newBroadcast(d.log, d.privGateway.ProtocolClient, d.priv.Public.Address(), group.Nodes, func(p dkg.Packet) error {
+	board := newBroadcast(d.ctx, d.log, d.privGateway.ProtocolClient, d.priv.Public.Address(), group.Nodes, func(p dkg.Packet) error {
 		return dkg.VerifyPacketSignature(config, p)
 	})
+	board.progress = d.dkgProgress
+	board.chunks.ttl = phaseDuration(timeout)
 	dkgProto, err := dkg.NewProtocol(config, board, phaser, true)
 	if err != nil {
 		return nil, err
 	}
+	d.dkgProgress.start(len(group.Nodes))
 
 	d.state.Lock()
 	dkgInfo := &dkgInfo{
@@ -154,13 +240,31 @@ func (d *Drand) runDKG(leader bool, group *key.Group, timeout uint32, randomness
 	if leader {
 		d.dkgInfo.started = true
 	}
+	dkgInfo.prevStatus = d.status
+	d.setStatus(drand.NodeState_STATE_DKG_IN_PROGRESS)
 	d.state.Unlock()
 
 	if leader {
+		if err := d.waitReady(timeout); err != nil {
+			d.log.Error("init_dkg", "nodes_not_ready", "err", err)
+			d.state.Lock()
+			if d.dkgInfo == dkgInfo {
+				d.cleanupDKG()
+			}
+			d.state.Unlock()
+			return nil, fmt.Errorf("drand: %v", err)
+		}
 		// phaser will kick off the first phase for every other nodes so
 		// nodes will send their deals
 		d.log.Info("init_dkg", "START_DKG")
 		go phaser.Start()
+	} else if err := d.signalReady(lpeer, group, timeout); err != nil {
+		d.state.Lock()
+		if d.dkgInfo == dkgInfo {
+			d.cleanupDKG()
+		}
+		d.state.Unlock()
+		return nil, fmt.Errorf("drand: %v", err)
 	}
 	d.log.Info("init_dkg", "wait_dkg_end")
 	finalGroup, err := d.WaitDKG()
@@ -175,7 +279,7 @@ func (d *Drand) runDKG(leader bool, group *key.Group, timeout uint32, randomness
 	}
 	d.state.Lock()
 	d.cleanupDKG()
-	d.dkgDone = true
+	d.setStatus(drand.NodeState_STATE_DKG_DONE)
 	d.state.Unlock()
 	d.log.Info("init_dkg", "dkg_done", "starting_beacon_time", finalGroup.GenesisTime, "now", d.opts.clock.Now().Unix())
 	// beacon will start at the genesis time specified
@@ -186,20 +290,27 @@ func (d *Drand) runDKG(leader bool, group *key.Group, timeout uint32, randomness
 func (d *Drand) cleanupDKG() {
 	if d.dkgInfo != nil {
 		d.dkgInfo.board.stop()
+		d.setStatus(d.dkgInfo.prevStatus)
 	}
 	d.dkgInfo = nil
 }
 
 // runResharing setups all necessary structures to run the resharing protocol
 // and waits until it finishes (or timeouts). If leader is true, it sends the
-// first packet so other nodes will start as soon as they receive it.
-func (d *Drand) runResharing(leader bool, oldGroup, newGroup *key.Group, timeout uint32) (*key.Group, error) {
+// first packet so other nodes will start as soon as they receive it, once they
+// are ready. Otherwise, it signals lpeer, the leader, it is ready.
+// If dryRun is true, the protocol runs with a different nonce and its outcome
+// is discarded: the node keeps its current share and group.
+func (d *Drand) runResharing(leader bool, lpeer net.Peer, oldGroup, newGroup *key.Group, timeout uint32, dryRun bool) (*key.Group, error) {
 	oldNode := oldGroup.Find(d.priv.Public)
 	oldPresent := oldNode != nil
 	if leader && !oldPresent {
 		d.log.Error("run_reshare", "invalid", "leader", leader, "old_present", oldPresent)
 		return nil, errors.New("can not be a leader if not present in the old group")
 	}
+	if err := d.validateGroup(newGroup); err != nil {
+		return nil, err
+	}
 	newNode := newGroup.Find(d.priv.Public)
 	newPresent := newNode != nil
 	config := &dkg.Config{
@@ -213,6 +324,10 @@ func (d *Drand) runResharing(leader bool, oldGroup, newGroup *key.Group, timeout
 		Nonce:        getNonce(newGroup),
 		Auth:         key.DKGAuthScheme,
 	}
+	if dryRun {
+		// packets of the dry run can't be replayed during the actual resharing
+		config.Nonce = getDryRunNonce(config.Nonce)
+	}
 	err := func() error {
 		d.state.Lock()
 		defer d.state.Unlock()
@@ -238,35 +353,57 @@ func (d *Drand) runResharing(leader bool, oldGroup, newGroup *key.Group, timeout
 	}
 
 	allNodes := nodeUnion(oldGroup.Nodes, newGroup.Nodes)
-	board := newBroadcast(d.log, d.privGateway.ProtocolClient, d.priv.Public.Address(), allNodes, func(p dkg.Packet) error {
+	board := newBroadcast(d.ctx, d.log, d.privGateway.ProtocolClient, d.priv.Public.Address(), allNodes, func(p dkg.Packet) error {
 		return dkg.VerifyPacketSignature(config, p)
 	})
+	board.progress = d.dkgProgress
+	board.chunks.ttl = phaseDuration(timeout)
 	phaser := d.getPhaser(timeout)
 
 	dkgProto, err := dkg.NewProtocol(config, board, phaser, true)
 	if err != nil {
 		return nil, err
 	}
+	d.dkgProgress.start(len(allNodes))
 	info := &dkgInfo{
 		target: newGroup,
 		board:  board,
 		phaser: phaser,
 		conf:   config,
 		proto:  dkgProto,
+		dryRun: dryRun,
 	}
 	d.state.Lock()
 	d.dkgInfo = info
+	info.prevStatus = d.status
+	d.setStatus(drand.NodeState_STATE_RESHARING)
 	if leader {
-		d.log.Info("dkg_reshare", "leader_start", "target_group", hex.EncodeToString(newGroup.Hash()), "index", newNode.Index)
+		d.log.Info("dkg_reshare", "leader_start", "target_group", hex.EncodeToString(newGroup.Hash()), "index", newNode.Index, "dry_run", dryRun)
 		d.dkgInfo.started = true
 	}
 	d.state.Unlock()
 
 	if leader {
+		if err := d.waitReady(timeout); err != nil {
+			d.log.Error("dkg_reshare", "nodes_not_ready", "err", err)
+			d.state.Lock()
+			if d.dkgInfo == info {
+				d.cleanupDKG()
+			}
+			d.state.Unlock()
+			return nil, fmt.Errorf("drand: %v", err)
+		}
 		// start the protocol so everyone else follows
 		// it sends to all previous and new nodes. old nodes will start their
 		// phaser so they will send the deals as soon as they receive this.
 		go phaser.Start()
+	} else if err := d.signalReady(lpeer, newGroup, timeout); err != nil {
+		d.state.Lock()
+		if d.dkgInfo == info {
+			d.cleanupDKG()
+		}
+		d.state.Unlock()
+		return nil, fmt.Errorf("drand: %v", err)
 	}
 
 	d.log.Info("dkg_reshare", "wait_dkg_end")
@@ -279,7 +416,10 @@ func (d *Drand) runResharing(leader bool, oldGroup, newGroup *key.Group, timeout
 		d.state.Unlock()
 		return nil, fmt.Errorf("drand: err during DKG: %v", err)
 	}
-	d.log.Info("dkg_reshare", "finished", "leader", leader)
+	d.log.Info("dkg_reshare", "finished", "leader", leader, "dry_run", dryRun)
+	if dryRun {
+		return finalGroup, nil
+	}
 	// runs the transition of the beacon
 	go d.transition(oldGroup, oldPresent, newPresent)
 	return finalGroup, nil
@@ -324,7 +464,7 @@ func (d *Drand) setupAutomaticDKG(_ context.Context, in *drand.InitDKGPacket) (*
 	}
 
 	d.log.Debug("init_dkg", "send_key", "leader", lpeer.Address())
-	nc, cancel := context.WithTimeout(context.Background(), MaxWaitPrepareDKG)
+	nc, cancel := context.WithTimeout(d.ctx, MaxWaitPrepareDKG)
 	defer cancel()
 
 	err = d.privGateway.ProtocolClient.SignalDKGParticipant(nc, lpeer, prep)
@@ -334,10 +474,14 @@ func (d *Drand) setupAutomaticDKG(_ context.Context, in *drand.InitDKGPacket) (*
 
 	d.log.Debug("init_dkg", "wait_group")
 
-	group, dkgTimeout, err := d.receiver.WaitDKGInfo(nc)
+	info, err := d.receiver.WaitDKGInfo(nc)
 	if err != nil {
 		return nil, err
 	}
+	if info.dryRun {
+		return nil, errors.New("control: dry run is only supported for resharing")
+	}
+	group, dkgTimeout := info.group, info.timeout
 	if group == nil {
 		d.log.Debug("init_dkg", "wait_group", "canceled", "nil_group")
 		return nil, errors.New("canceled operation")
@@ -348,6 +492,12 @@ func (d *Drand) setupAutomaticDKG(_ context.Context, in *drand.InitDKGPacket) (*
 		d.log.Error("genesis", "invalid", "given", group.GenesisTime)
 		return nil, errors.New("control: group with genesis time in the past")
 	}
+	// the genesis seed is the hash of the group every node received: a seed
+	// that differs would make the first round chain from another beacon
+	if !bytes.Equal(group.GetGenesisSeed(), group.SeedHash()) {
+		d.log.Error("genesis", "invalid_seed", "given", hex.EncodeToString(group.GetGenesisSeed()))
+		return nil, errors.New("control: the genesis seed of the group is not the hash of the group")
+	}
 
 	node := group.Find(d.priv.Public)
 	if node == nil {
@@ -359,7 +509,7 @@ func (d *Drand) setupAutomaticDKG(_ context.Context, in *drand.InitDKGPacket) (*
 	d.state.Unlock()
 
 	// run the dkg
-	finalGroup, err := d.runDKG(false, group, dkgTimeout, in.GetEntropy())
+	finalGroup, err := d.runDKG(false, lpeer, group, dkgTimeout, in.GetEntropy())
 	if err != nil {
 		return nil, err
 	}
@@ -413,7 +563,7 @@ func (d *Drand) setupAutomaticResharing(_ context.Context, oldGroup *key.Group,
 	}
 
 	// we wait only a certain amount of time for the prepare phase
-	nc, cancel := context.WithTimeout(context.Background(), MaxWaitPrepareDKG)
+	nc, cancel := context.WithTimeout(d.ctx, MaxWaitPrepareDKG)
 	defer cancel()
 
 	d.log.Info("setup_reshare", "signaling_key_to_leader")
@@ -423,16 +573,32 @@ func (d *Drand) setupAutomaticResharing(_ context.Context, oldGroup *key.Group,
 		return nil, fmt.Errorf("drand: err when signaling key to leader: %s", err)
 	}
 
-	newGroup, dkgTimeout, err := d.receiver.WaitDKGInfo(nc)
+	info, err := d.receiver.WaitDKGInfo(nc)
 	if err != nil {
 		d.log.Error("setup_reshare", "failed to receive dkg info", "err", err)
 		return nil, err
 	}
+	newGroup, dkgTimeout := info.group, info.timeout
+	if info.dryRun != in.GetDryRun() {
+		d.log.Error("setup_reshare", "dry run mismatch", "leader", info.dryRun, "local", in.GetDryRun())
+		return nil, errors.New("control: leader and node disagree on running a dry run")
+	}
 
 	// some assertions that should be true but never too safe
 	if err := d.validateGroupTransition(oldGroup, newGroup); err != nil {
 		return nil, err
 	}
+	if err := d.checkGroupApproval(newGroup); err != nil {
+		return nil, err
+	}
+	if err := validateTransitionRound(newGroup, in.GetTransitionRound()); err != nil {
+		d.log.Error("setup_reshare", "invalid transition round", "err", err)
+		return nil, err
+	}
+	if err := validatePeriodChange(oldGroup, newGroup, in.GetBeaconPeriod()); err != nil {
+		d.log.Error("setup_reshare", "invalid period", "err", err)
+		return nil, err
+	}
 
 	node := newGroup.Find(d.priv.Public)
 	if node == nil {
@@ -441,21 +607,36 @@ func (d *Drand) setupAutomaticResharing(_ context.Context, oldGroup *key.Group,
 		// still participating in the resharing.
 		d.log.Info("setup_reshare", "not_found_in_new_group")
 	} else {
-		d.state.Lock()
-		d.index = int(node.Index)
-		d.state.Unlock()
 		d.log.Info("setup_reshare", "participate_newgroup", "index", node.Index)
 	}
 
 	// run the dkg !
-	finalGroup, err := d.runResharing(false, oldGroup, newGroup, dkgTimeout)
+	finalGroup, err := d.runResharing(false, lpeer, oldGroup, newGroup, dkgTimeout, info.dryRun)
 	if err != nil {
 		d.log.Error("setup_reshare", "failed to run resharing", "err", err)
 		return nil, err
 	}
+	// the index only changes once the new share is saved
+	if node != nil && !info.dryRun {
+		d.state.Lock()
+		d.index = int(node.Index)
+		d.state.Unlock()
+	}
 	return finalGroup.ToProto(), nil
 }
 
+// validateGroup checks the group a DKG is about to run on, with the minimum
+// threshold set by the operator.
+func (d *Drand) validateGroup(group *key.Group) error {
+	if err := group.Validate(); err != nil {
+		return fmt.Errorf("control: invalid group: %v", err)
+	}
+	if err := key.ValidateThreshold(group.Len(), group.Threshold, d.opts.minThreshold); err != nil {
+		return fmt.Errorf("control: invalid group: %v", err)
+	}
+	return nil
+}
+
 func (d *Drand) validateGroupTransition(oldGroup, newGroup *key.Group) error {
 	if oldGroup.GenesisTime != newGroup.GenesisTime {
 		d.log.Error("setup_reshare", "invalid genesis time in received group")
@@ -464,13 +645,28 @@ func (d *Drand) validateGroupTransition(oldGroup, newGroup *key.Group) error {
 
 	if oldGroup.Period != newGroup.Period {
 		d.log.Error("setup_reshare", "invalid period time in received group")
-		return errors.New("control: old and new group have different period - unsupported feature at the moment")
+		return errors.New("control: old and new group have different genesis period")
 	}
 
 	if !bytes.Equal(oldGroup.GetGenesisSeed(), newGroup.GetGenesisSeed()) {
 		d.log.Error("setup_reshare", "invalid genesis seed in received group")
 		return errors.New("control: old and new group have different genesis seed")
 	}
+	if oldGroup.Digest != newGroup.Digest {
+		d.log.Error("setup_reshare", "invalid digest in received group", "digest", newGroup.Digest)
+		return errors.New("control: the digest of the chain can not be changed")
+	}
+	if oldGroup.MessageV1Round != 0 && oldGroup.MessageV1Round != newGroup.MessageV1Round {
+		d.log.Error("setup_reshare", "invalid message format round in received group")
+		return errors.New("control: the message format of the chain can not be changed again")
+	}
+	if oldGroup.MessageV1Round == 0 && newGroup.MessageV1Round != 0 {
+		tRound := chain.NewSchedule(newGroup).CurrentRound(newGroup.TransitionTime)
+		if newGroup.MessageV1Round < tRound {
+			d.log.Error("setup_reshare", "invalid message format round in received group", "round", newGroup.MessageV1Round, "transition_round", tRound)
+			return errors.New("control: the message format can only change from the transition round")
+		}
+	}
 	now := d.opts.clock.Now().Unix()
 	if newGroup.TransitionTime < now {
 		d.log.Error("setup_reshare", "invalid_transition", "given", newGroup.TransitionTime, "now", now)
@@ -479,6 +675,48 @@ func (d *Drand) validateGroupTransition(oldGroup, newGroup *key.Group) error {
 	return nil
 }
 
+// validateTransitionRound checks the new group transitions at the round the
+// operator expects, if any.
+func validateTransitionRound(newGroup *key.Group, expected uint64) error {
+	if expected == 0 {
+		return nil
+	}
+	got := chain.NewSchedule(newGroup).CurrentRound(newGroup.TransitionTime)
+	if got != expected {
+		return fmt.Errorf("control: transition scheduled at round %d instead of %d", got, expected)
+	}
+	return nil
+}
+
+// validatePeriodChange checks the new group keeps the changes of period of the
+// chain and only changes the period from its transition round, to the period
+// the operator expects. A zero period means the current period is expected.
+func validatePeriodChange(oldGroup, newGroup *key.Group, expected uint32) error {
+	old, changes := oldGroup.PeriodChanges, newGroup.PeriodChanges
+	if len(changes) < len(old) || len(changes) > len(old)+1 {
+		return errors.New("control: the new group does not keep the period changes of the chain")
+	}
+	for i := range old {
+		if old[i] != changes[i] {
+			return errors.New("control: the new group does not keep the period changes of the chain")
+		}
+	}
+	sched := chain.NewSchedule(newGroup)
+	tRound := sched.CurrentRound(newGroup.TransitionTime)
+	if len(changes) > len(old) && changes[len(old)].Round != tRound {
+		return fmt.Errorf("control: period changed at round %d instead of the transition round %d",
+			changes[len(old)].Round, tRound)
+	}
+	want := time.Duration(expected) * time.Second
+	if expected == 0 {
+		want = chain.NewSchedule(oldGroup).PeriodAt(tRound)
+	}
+	if got := sched.PeriodAt(tRound); got != want {
+		return fmt.Errorf("control: the new group has a period of %s instead of %s", got, want)
+	}
+	return nil
+}
+
 func (d *Drand) extractGroup(old *drand.GroupInfo) (oldGroup *key.Group, err error) {
 	d.state.Lock()
 	if oldGroup, err = extractGroup(old); err != nil {
@@ -498,6 +736,9 @@ func (d *Drand) extractGroup(old *drand.GroupInfo) (oldGroup *key.Group, err err
 // InitReshare receives information about the old and new group from which to
 // operate the resharing protocol.
 func (d *Drand) InitReshare(c context.Context, in *drand.InitResharePacket) (*drand.GroupPacket, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
 	oldGroup, err := d.extractGroup(in.Old)
 	if err != nil {
 		return nil, err
@@ -527,26 +768,40 @@ func (d *Drand) InitReshare(c context.Context, in *drand.InitResharePacket) (*dr
 		return nil, errors.New("control: genesis time is in the future")
 	}
 	if oldGroup.Period != newGroup.Period {
-		return nil, errors.New("control: old and new group have different period - unsupported feature at the moment")
+		return nil, errors.New("control: old and new group have different genesis period")
+	}
+	if err := validatePeriodChange(oldGroup, newGroup, in.GetBeaconPeriod()); err != nil {
+		return nil, err
 	}
 	if newGroup.TransitionTime < d.opts.clock.Now().Unix() {
 		return nil, errors.New("control: group with transition time in the past")
 	}
+	if in.GetTransitionRound() != 0 {
+		// the keys may have taken too long to arrive
+		atLeast := d.opts.clock.Now().Add(time.Duration(in.GetInfo().GetTimeout()) * time.Second * 3).Unix()
+		if newGroup.TransitionTime < atLeast {
+			return nil, fmt.Errorf("control: transition round %d is too close to run the resharing", in.GetTransitionRound())
+		}
+	}
 	if !bytes.Equal(newGroup.GetGenesisSeed(), oldGroup.GetGenesisSeed()) {
 		return nil, errors.New("control: old and new group have different genesis seed")
 	}
+	if err := d.checkGroupApproval(newGroup); err != nil {
+		return nil, err
+	}
 
 	// send it to everyone in the group nodes
 	if err := d.pushDKGInfo(oldGroup.Nodes, newGroup.Nodes,
 		oldGroup.Threshold,
 		newGroup,
 		in.GetInfo().GetSecret(),
-		in.GetInfo().GetTimeout()); err != nil {
+		in.GetInfo().GetTimeout(),
+		in.GetDryRun()); err != nil {
 		d.log.Error("push_group", err)
 		return nil, errors.New("fail to push new group")
 	}
 
-	finalGroup, err := d.runResharing(true, oldGroup, newGroup, in.GetInfo().GetTimeout())
+	finalGroup, err := d.runResharing(true, nil, oldGroup, newGroup, in.GetInfo().GetTimeout(), in.GetDryRun())
 	if err != nil {
 		return nil, err
 	}
@@ -622,6 +877,54 @@ func (d *Drand) Shutdown(ctx context.Context, in *drand.ShutdownRequest) (*drand
 	return nil, nil
 }
 
+// RoundReports returns the participation of the nodes in the last rounds
+// aggregated by this node.
+func (d *Drand) RoundReports(ctx context.Context, in *drand.RoundReportsRequest) (*drand.RoundReportsResponse, error) {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return nil, errors.New("drand: beacon is not running")
+	}
+	reports := b.RoundReports(int(in.GetLast()))
+	resp := &drand.RoundReportsResponse{Reports: make([]*drand.RoundReport, len(reports))}
+	for i, r := range reports {
+		resp.Reports[i] = roundReportToProto(r)
+	}
+	return resp, nil
+}
+
+// PeerStatus returns the clock skew of the other nodes of the group, as
+// measured from the timestamps of their partials, whether they are degraded
+// or in maintenance and the number of rounds for which they signed two
+// different messages.
+func (d *Drand) PeerStatus(ctx context.Context, in *drand.PeerStatusRequest) (*drand.PeerStatusResponse, error) {
+	d.state.Lock()
+	b := d.beacon
+	group := d.group
+	d.state.Unlock()
+	if b == nil {
+		return nil, errors.New("drand: beacon is not running")
+	}
+	resp := &drand.PeerStatusResponse{ThresholdReachable: b.ThresholdReachable()}
+	inMaintenance := d.maintenance.inMaintenance(d.opts.clock.Now().Unix())
+	for _, s := range b.PeerSkews() {
+		peer := &drand.PeerStatus{
+			Index:    uint32(s.Index),
+			SkewMs:   s.Skew.Milliseconds(),
+			Degraded: s.Degraded,
+
+			Equivocations: uint32(d.evidence.count(s.Index)),
+		}
+		if node := group.Node(uint32(s.Index)); node != nil {
+			peer.Address = node.Address()
+			peer.InMaintenance = inMaintenance[peer.Address]
+		}
+		resp.Peers = append(resp.Peers, peer)
+	}
+	return resp, nil
+}
+
 func extractGroup(i *drand.GroupInfo) (*key.Group, error) {
 	var g = new(key.Group)
 	switch x := i.Location.(type) {
@@ -649,16 +952,75 @@ func extractEntropy(i *drand.EntropyInfo) (io.Reader, bool) {
 }
 
 func (d *Drand) getPhaser(timeout uint32) *dkg.TimePhaser {
-	tDuration := time.Duration(timeout) * time.Second
-	if timeout == 0 {
-		tDuration = DefaultDKGTimeout
-	}
+	tDuration := phaseDuration(timeout)
 	return dkg.NewTimePhaserFunc(func(phase dkg.Phase) {
 		d.opts.clock.Sleep(tDuration)
 		d.log.Debug("phaser_finished", phase)
 	})
 }
 
+// phaseDuration returns the duration of a phase of the DKG from the timeout in
+// seconds given by the leader.
+func phaseDuration(timeout uint32) time.Duration {
+	if timeout == 0 {
+		return DefaultDKGTimeout
+	}
+	return time.Duration(timeout) * time.Second
+}
+
+// waitReady waits, at most one phase of the DKG, for the nodes the leader
+// pushed the group to to be ready.
+func (d *Drand) waitReady(timeout uint32) error {
+	d.state.Lock()
+	r := d.readiness
+	d.state.Unlock()
+	defer func() {
+		d.state.Lock()
+		if d.readiness == r {
+			d.readiness = nil
+		}
+		d.state.Unlock()
+	}()
+	if r == nil {
+		return nil
+	}
+	d.log.Info("init_dkg", "wait_nodes_ready")
+	return r.wait(d.ctx, d.opts.clock, phaseDuration(timeout))
+}
+
+// signalReady tells the leader this node is ready to run the DKG over the
+// group, along with its versions. It only returns an error if the leader
+// refuses to run the DKG with this node: other failures are logged since the
+// leader may start without this node.
+func (d *Drand) signalReady(lpeer net.Peer, group *key.Group, timeout uint32) error {
+	hash := group.Hash()
+	signature, err := key.DKGAuthScheme.Sign(d.priv.Key, hash)
+	if err != nil {
+		d.log.Error("init_dkg", "signal_ready", "err", err)
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(d.ctx, phaseDuration(timeout))
+	defer cancel()
+	err = d.privGateway.ProtocolClient.SignalDKGReady(ctx, lpeer, &drand.DKGReadyPacket{
+		Address:         d.priv.Public.Address(),
+		GroupHash:       hash,
+		Signature:       signature,
+		Version:         d.opts.version,
+		ProtocolVersion: ProtocolVersion,
+		Schemes:         []string{key.SchemeName},
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		d.log.Error("init_dkg", "signal_ready", "leader", lpeer.Address(), "refused", status.Convert(err).Message())
+		return fmt.Errorf("leader refused to run the dkg: %s", status.Convert(err).Message())
+	}
+	if err != nil {
+		d.log.Error("init_dkg", "signal_ready", "leader", lpeer.Address(), "err", err)
+		return nil
+	}
+	d.log.Debug("init_dkg", "signal_ready", "leader", lpeer.Address())
+	return nil
+}
+
 func nodesContainAddr(nodes []*key.Node, addr string) bool {
 	for _, n := range nodes {
 		if n.Address() == addr {
@@ -704,7 +1066,7 @@ func (d *Drand) pushDKGInfoPacket(ctx context.Context, nodes []*key.Node, packet
 
 // pushDKGInfo sends the information to run the DKG to all specified nodes. The
 // call is blocking until all nodes have replied or after one minute timeouts.
-func (d *Drand) pushDKGInfo(outgoing, incoming []*key.Node, previousThreshold int, group *key.Group, secret []byte, timeout uint32) error {
+func (d *Drand) pushDKGInfo(outgoing, incoming []*key.Node, previousThreshold int, group *key.Group, secret []byte, timeout uint32, dryRun bool) error {
 	// sign the group to prove you are the leader
 	signature, err := key.DKGAuthScheme.Sign(d.priv.Key, group.Hash())
 	if err != nil {
@@ -716,10 +1078,17 @@ func (d *Drand) pushDKGInfo(outgoing, incoming []*key.Node, previousThreshold in
 		SecretProof: secret,
 		DkgTimeout:  timeout,
 		Signature:   signature,
+		DryRun:      dryRun,
 	}
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(d.ctx)
 	defer cancel()
 
+	// the nodes signal they are ready once they received the group
+	readiness := newDKGReadiness(d.priv.Public.Address(), outgoing, incoming, previousThreshold, group)
+	d.state.Lock()
+	d.readiness = readiness
+	d.state.Unlock()
+
 	newThreshold := group.Threshold
 	if nodesContainAddr(outgoing, d.priv.Public.Address()) {
 		previousThreshold--
@@ -738,6 +1107,7 @@ func (d *Drand) pushDKGInfo(outgoing, incoming []*key.Node, previousThreshold in
 			total--
 			if ok.err != nil {
 				d.log.Error("push_dkg", "failed to push", "to", ok.address, "err", ok.err)
+				readiness.missing(ok.address)
 				continue
 			}
 			d.log.Debug("push_dkg", "sending_group", "success_to", ok.address, "left", total)
@@ -774,11 +1144,25 @@ func getNonce(g *key.Group) []byte {
 	return h.Sum(nil)
 }
 
+func getDryRunNonce(nonce []byte) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte("dry-run"))
+	_, _ = h.Write(nonce)
+	return h.Sum(nil)
+}
+
 // StartFollowChain syncs up with a chain from other nodes
 func (d *Drand) StartFollowChain(req *drand.StartFollowRequest, stream drand.Control_StartFollowChainServer) error {
+	if err := d.checkWritable(); err != nil {
+		return err
+	}
 	// TODO replace via a more independent chain manager that manages the
 	// transition from following -> participating
 	d.state.Lock()
+	if d.beacon != nil {
+		d.state.Unlock()
+		return errors.New("can't follow a chain while running the beacon")
+	}
 	if d.syncerCancel != nil {
 		d.state.Unlock()
 		return errors.New("syncing is already in progress")
@@ -790,34 +1174,37 @@ func (d *Drand) StartFollowChain(req *drand.StartFollowRequest, stream drand.Con
 	// ctx, cancel := context.WithCancel(context.Background())
 	ctx, cancel := context.WithCancel(stream.Context())
 	d.syncerCancel = cancel
+	prevStatus := d.status
+	d.setStatus(drand.NodeState_STATE_SYNCING)
 	d.state.Unlock()
 	defer func() {
 		d.state.Lock()
 		d.syncerCancel()
 		d.syncerCancel = nil
+		// the beacon started meanwhile sets its own state
+		if d.beacon == nil && d.status == drand.NodeState_STATE_SYNCING {
+			d.setStatus(prevStatus)
+		}
 		d.state.Unlock()
 	}()
 
 	addr := net.RemoteAddress(stream.Context())
-	// TODO put that hash verification back
-	// hash := req.GetInfoHash()
+	hash, err := hex.DecodeString(req.GetInfoHash())
+	if err != nil {
+		return fmt.Errorf("invalid chain hash: %s", err)
+	}
 	peers := make([]net.Peer, 0, len(req.GetNodes()))
 	for _, addr := range req.GetNodes() {
 		// XXX add TLS disable later
 		peers = append(peers, net.CreatePeer(addr, req.GetIsTls()))
 	}
-	info, err := chainInfoFromPeers(stream.Context(), d.privGateway, peers, d.log)
+	info, err := chainInfoFromPeers(stream.Context(), d.privGateway, peers, hash, d.log)
 	if err != nil {
 		return err
 	}
 	d.log.Debug("start_follow_chain", "fetched chain info", "hash", fmt.Sprintf("%x", info.Hash()))
 
-	// TODO UNCOMMENT WHEN HASH INCONSISTENCY FIXED
-	// if !bytes.Equal(info.Hash(),hash) {
-	//  return errors.New("invalid chain info hash!")
-	// }
-
-	store, err := d.createBoltStore()
+	store, err := d.createStore()
 	if err != nil {
 		d.log.Error("start_follow_chain", "unable to create store", "err", err)
 		return fmt.Errorf("unable to create store: %s", err)
@@ -829,16 +1216,40 @@ func (d *Drand) StartFollowChain(req *drand.StartFollowRequest, stream drand.Con
 		store.Close()
 		return fmt.Errorf("unable to insert genesis block: %s", err)
 	}
+	if d.opts.bootstrapBucket != nil {
+		d.importSnapshot(store, info)
+	}
 	// register callback to notify client of progress
 	cbStore := beacon.NewCallbackStore(store)
 	defer cbStore.Close()
-	syncer := beacon.NewSyncer(d.log, cbStore, info, d.privGateway)
+	syncer := beacon.NewSyncer(d.log, cbStore, info, d.privGateway, d.opts.clock, d.opts.syncLimits)
 	cb, done := sendProgressCallback(stream, req.GetUpTo(), info, d.opts.clock, d.log)
 	cbStore.AddCallback(addr, cb)
 	defer cbStore.RemoveCallback(addr)
-	if err := syncer.Follow(ctx, req.GetUpTo(), peers); err != nil {
-		d.log.Error("start_follow_chain", "syncer_stopped", "err", err, "leaving_sync")
-		return err
+	for {
+		err := syncer.Follow(ctx, req.GetUpTo(), peers)
+		if err == nil {
+			break
+		}
+		// a resharing may have changed the message format or the period of
+		// the chain since its info was fetched
+		if latest := refreshChainInfo(ctx, d.privGateway, peers, info, d.log); latest != nil {
+			d.log.Info("start_follow_chain", "chain info updated", "retry", "now")
+			info = latest
+			syncer = beacon.NewSyncer(d.log, cbStore, info, d.privGateway, d.opts.clock, d.opts.syncLimits)
+			continue
+		}
+		if req.GetUpTo() > 0 || ctx.Err() != nil {
+			d.log.Error("start_follow_chain", "syncer_stopped", "err", err, "leaving_sync")
+			return err
+		}
+		// following without end: the nodes may come back later
+		d.log.Info("start_follow_chain", "syncer_stopped", "err", err, "retry_in", info.Period)
+		select {
+		case <-d.opts.clock.After(info.Period):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 	// wait for all the callbacks to be called and progress sent before returning
 	if req.GetUpTo() > 0 {
@@ -852,25 +1263,43 @@ func (d *Drand) StartFollowChain(req *drand.StartFollowRequest, stream drand.Con
 	return ctx.Err()
 }
 
-// chainInfoFromPeers attempts to fetch chain info from one of the passed peers.
-func chainInfoFromPeers(ctx context.Context, privGateway *net.PrivateGateway, peers []net.Peer, l log.Logger) (*chain.Info, error) {
-	var info *chain.Info
+// chainInfoFromPeers attempts to fetch the chain info with the given hash from
+// one of the passed peers.
+func chainInfoFromPeers(ctx context.Context, privGateway *net.PrivateGateway, peers []net.Peer, hash []byte,
+	l log.Logger) (*chain.Info, error) {
 	for _, peer := range peers {
 		ci, err := privGateway.ChainInfo(ctx, peer, new(drand.ChainInfoRequest))
 		if err != nil {
 			l.Debug("start_follow_chain", "error getting chain info", "from", peer.Address(), "err", err)
 			continue
 		}
-		info, err = chain.InfoFromProto(ci)
+		info, err := chain.InfoFromProto(ci)
 		if err != nil {
 			l.Debug("start_follow_chain", "invalid chain info", "from", peer.Address(), "err", err)
 			continue
 		}
+		if !bytes.Equal(info.Hash(), hash) {
+			l.Debug("start_follow_chain", "unexpected chain info", "from", peer.Address(), "hash", fmt.Sprintf("%x", info.Hash()))
+			continue
+		}
+		return info, nil
 	}
-	if info == nil {
-		return nil, errors.New("unable to get a chain info successfully")
+	return nil, errors.New("unable to get a chain info successfully")
+}
+
+// refreshChainInfo returns the chain info given by the peers if it is a valid
+// update of info that differs from it, nil otherwise.
+func refreshChainInfo(ctx context.Context, privGateway *net.PrivateGateway, peers []net.Peer, info *chain.Info,
+	l log.Logger) *chain.Info {
+	latest, err := chainInfoFromPeers(ctx, privGateway, peers, info.Hash(), l)
+	if err != nil || latest.Equal(info) {
+		return nil
+	}
+	if err := latest.CheckUpdate(info); err != nil {
+		l.Error("start_follow_chain", "invalid chain info update", "err", err)
+		return nil
 	}
-	return info, nil
+	return latest
 }
 
 // sendProgressCallback returns a function that sends FollowProgress on the
@@ -887,7 +1316,7 @@ func sendProgressCallback(
 	cb = func(b *chain.Beacon) {
 		err := stream.Send(&drand.FollowProgress{
 			Current: b.Round,
-			Target:  chain.CurrentRound(clk.Now().Unix(), info.Period, info.GenesisTime),
+			Target:  info.Schedule().CurrentRound(clk.Now().Unix()),
 		})
 		if err != nil {
 			l.Error("send_progress_callback", "sending_progress", "err", err)
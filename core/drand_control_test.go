@@ -38,7 +38,7 @@ func TestValidateGroupTransitionPeriod(t *testing.T) {
 	if err == nil {
 		t.Fatal("expected error validating group period")
 	}
-	if err.Error() != "control: old and new group have different period - unsupported feature at the moment" {
+	if err.Error() != "control: old and new group have different genesis period" {
 		t.Fatal("unexpected validation error", err)
 	}
 }
@@ -86,3 +86,82 @@ func TestValidateGroupTransitionTime(t *testing.T) {
 		t.Fatal("unexpected validation error", err)
 	}
 }
+
+func TestValidateGroupTransitionMessageRound(t *testing.T) {
+	d := Drand{
+		log:  log.DefaultLogger(),
+		opts: &Config{clock: clock.NewRealClock()},
+	}
+	genesis := time.Now().Unix() - 100
+	transition := genesis + 200
+	oldgrp := key.Group{Period: 10 * time.Second, GenesisTime: genesis, MessageV1Round: 3}
+	newgrp := key.Group{Period: 10 * time.Second, GenesisTime: genesis, TransitionTime: transition, MessageV1Round: 30,
+		GenesisSeed: oldgrp.GetGenesisSeed()}
+
+	err := d.validateGroupTransition(&oldgrp, &newgrp)
+	if err == nil || err.Error() != "control: the message format of the chain can not be changed again" {
+		t.Fatal("unexpected validation error", err)
+	}
+
+	// migrating before the new group takes over is refused
+	oldgrp.MessageV1Round = 0
+	newgrp.MessageV1Round = 5
+	err = d.validateGroupTransition(&oldgrp, &newgrp)
+	if err == nil || err.Error() != "control: the message format can only change from the transition round" {
+		t.Fatal("unexpected validation error", err)
+	}
+
+	newgrp.MessageV1Round = 21
+	if err := d.validateGroupTransition(&oldgrp, &newgrp); err != nil {
+		t.Fatal("unexpected validation error", err)
+	}
+}
+
+func TestValidateTransitionRound(t *testing.T) {
+	genesis := time.Now().Unix()
+	grp := &key.Group{Period: 10 * time.Second, GenesisTime: genesis, TransitionTime: genesis + 100}
+
+	if err := validateTransitionRound(grp, 0); err != nil {
+		t.Fatal("unexpected validation error", err)
+	}
+	if err := validateTransitionRound(grp, 11); err != nil {
+		t.Fatal("unexpected validation error", err)
+	}
+	err := validateTransitionRound(grp, 12)
+	if err == nil || err.Error() != "control: transition scheduled at round 11 instead of 12" {
+		t.Fatal("unexpected validation error", err)
+	}
+}
+
+func TestValidatePeriodChange(t *testing.T) {
+	period := 10 * time.Second
+	oldgrp := &key.Group{Period: period, GenesisTime: 1000}
+	// the transition happens at round 11
+	newgrp := &key.Group{Period: period, GenesisTime: 1000, TransitionTime: 1100}
+	if err := validatePeriodChange(oldgrp, newgrp, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := validatePeriodChange(oldgrp, newgrp, 20); err == nil {
+		t.Fatal("expected error for a period not changed as expected")
+	}
+
+	newgrp.PeriodChanges = []key.PeriodChange{{Round: 11, Period: 20 * time.Second}}
+	if err := validatePeriodChange(oldgrp, newgrp, 20); err != nil {
+		t.Fatal(err)
+	}
+	// the other nodes must expect the change
+	if err := validatePeriodChange(oldgrp, newgrp, 0); err == nil {
+		t.Fatal("expected error for an unexpected change of period")
+	}
+	newgrp.PeriodChanges[0].Round = 12
+	if err := validatePeriodChange(oldgrp, newgrp, 20); err == nil {
+		t.Fatal("expected error for a change of period outside of the transition round")
+	}
+
+	// previous changes are kept
+	oldgrp.PeriodChanges = []key.PeriodChange{{Round: 5, Period: 5 * time.Second}}
+	newgrp.PeriodChanges = nil
+	if err := validatePeriodChange(oldgrp, newgrp, 0); err == nil {
+		t.Fatal("expected error for a dropped change of period")
+	}
+}
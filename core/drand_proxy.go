@@ -2,6 +2,7 @@ package core
 
 import (
 	"context"
+	"errors"
 	"net"
 	"time"
 
@@ -9,8 +10,10 @@ import (
 	"github.com/drand/drand/client"
 	"github.com/drand/drand/protobuf/drand"
 
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 // drandProxy is used as a proxy between a Public service (e.g. the node as a server)
@@ -40,6 +43,8 @@ func (d *drandProxy) Get(ctx context.Context, round uint64) (client.Result, erro
 		Random:            resp.Randomness,
 		Sig:               resp.Signature,
 		PreviousSignature: resp.PreviousSignature,
+		ChainHash:         resp.ChainHash,
+		Contributors:      resp.Contributors,
 	}, nil
 }
 
@@ -65,6 +70,40 @@ func (d *drandProxy) Info(ctx context.Context) (*chain.Info, error) {
 	return chain.InfoFromProto(info)
 }
 
+// Epochs returns the resharings of the chain, if the server records them.
+func (d *drandProxy) Epochs(ctx context.Context) ([]*chain.Epoch, error) {
+	s, ok := d.r.(interface {
+		Epochs() ([]*chain.Epoch, error)
+	})
+	if !ok {
+		return nil, errors.New("drand: the server does not record the epochs")
+	}
+	return s.Epochs()
+}
+
+// Range returns a page of the beacons of the given range of rounds.
+func (d *drandProxy) Range(ctx context.Context, from, to uint64, limit int) ([]client.Result, uint64, error) {
+	resp, err := d.r.PublicRandRange(ctx, &drand.PublicRandRangeRequest{From: from, To: to, Limit: uint32(limit)})
+	if status.Code(err) == codes.Unimplemented {
+		return nil, 0, client.ErrRangeUnsupported
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	results := make([]client.Result, 0, len(resp.GetBeacons()))
+	for _, b := range resp.GetBeacons() {
+		results = append(results, &client.RandomData{
+			Rnd:               b.Round,
+			Random:            b.Randomness,
+			Sig:               b.Signature,
+			PreviousSignature: b.PreviousSignature,
+			ChainHash:         b.ChainHash,
+			Contributors:      b.Contributors,
+		})
+	}
+	return results, resp.GetNext(), nil
+}
+
 // RoundAt will return the most recent round of randomness that will be available
 // at time for the current client.
 func (d *drandProxy) RoundAt(t time.Time) uint64 {
@@ -74,7 +113,7 @@ func (d *drandProxy) RoundAt(t time.Time) uint64 {
 	if err != nil {
 		return 0
 	}
-	return chain.CurrentRound(t.Unix(), info.Period, info.GenesisTime)
+	return info.Schedule().CurrentRound(t.Unix())
 }
 
 func (d *drandProxy) Close() error {
@@ -104,6 +143,8 @@ func (s *streamProxy) Send(next *drand.PublicRandResponse) error {
 		Random:            next.Randomness,
 		Sig:               next.Signature,
 		PreviousSignature: next.PreviousSignature,
+		ChainHash:         next.ChainHash,
+		Contributors:      next.Contributors,
 	}
 	select {
 	case s.outgoing <- &d:
@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"math"
+	"sync/atomic"
+	"time"
 
 	"github.com/drand/drand/chain"
 	"github.com/drand/drand/chain/beacon"
@@ -13,6 +16,8 @@ import (
 	"github.com/drand/drand/net"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/kyber/encrypt/ecies"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // FreshDKG is the public method to call during a DKG protocol.
@@ -34,6 +39,28 @@ func (d *Drand) BroadcastDKG(c context.Context, in *drand.DKGPacket) (*drand.Emp
 	return new(drand.Empty), nil
 }
 
+// BroadcastDKGChunk receives a part of a DKG packet too large to be sent at
+// once. The packet is processed as with BroadcastDKG once all its chunks are
+// received.
+func (d *Drand) BroadcastDKGChunk(c context.Context, in *drand.DKGChunk) (*drand.Empty, error) {
+	d.state.Lock()
+	if d.dkgInfo == nil {
+		d.state.Unlock()
+		return nil, errors.New("drand: no dkg running")
+	}
+	assembler := d.dkgInfo.board.chunks
+	d.state.Unlock()
+	packet, err := assembler.add(net.RemoteAddress(c), in)
+	if err != nil {
+		return nil, err
+	}
+	if packet == nil {
+		// waiting for the other chunks
+		return new(drand.Empty), nil
+	}
+	return d.BroadcastDKG(c, packet)
+}
+
 // PartialBeacon receives a beacon generation request and answers
 // with the partial signature from this drand node.
 func (d *Drand) PartialBeacon(c context.Context, in *drand.PartialBeaconPacket) (*drand.Empty, error) {
@@ -47,6 +74,31 @@ func (d *Drand) PartialBeacon(c context.Context, in *drand.PartialBeaconPacket)
 	return inst.ProcessPartialBeacon(c, in)
 }
 
+// NewBeacon receives the partial signature of another node and answers with
+// the partial signature of this node for the same round, if it has it.
+func (d *Drand) NewBeacon(c context.Context, in *drand.PartialBeaconPacket) (*drand.BeaconResponse, error) {
+	d.state.Lock()
+	if d.beacon == nil {
+		d.state.Unlock()
+		return nil, errors.New("drand: beacon not setup yet")
+	}
+	inst := d.beacon
+	d.state.Unlock()
+	return inst.ProcessNewBeacon(c, in)
+}
+
+// Epochs returns the resharings of the chain recorded by this node, in
+// increasing round order.
+func (d *Drand) Epochs() ([]*chain.Epoch, error) {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return nil, errors.New("drand: beacon not setup yet")
+	}
+	return b.Epochs()
+}
+
 // PublicRand returns a public random beacon according to the request. If the Round
 // field is 0, then it returns the last one generated.
 func (d *Drand) PublicRand(c context.Context, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error) {
@@ -54,7 +106,7 @@ func (d *Drand) PublicRand(c context.Context, in *drand.PublicRandRequest) (*dra
 	d.state.Lock()
 	defer d.state.Unlock()
 	if d.beacon == nil {
-		return nil, errors.New("drand: beacon generation not started yet")
+		return nil, status.Error(codes.Unavailable, "drand: beacon generation not started yet")
 	}
 	var r *chain.Beacon
 	var err error
@@ -66,10 +118,71 @@ func (d *Drand) PublicRand(c context.Context, in *drand.PublicRandRequest) (*dra
 	}
 	if err != nil || r == nil {
 		d.log.Debug("public_rand", "unstored_beacon", "round", in.GetRound(), "from", addr)
-		return nil, fmt.Errorf("can't retrieve beacon: %w %s", err, r)
+		if last, lerr := d.beacon.Store().Last(); lerr == nil && in.GetRound() > last.Round {
+			delay := d.roundDelay(in.GetRound())
+			return nil, net.RetryError(codes.Unavailable, delay, "drand: round %d not produced yet, retry in %s", in.GetRound(), delay)
+		}
+		return nil, status.Errorf(codes.NotFound, "can't retrieve beacon: %v %s", err, r)
 	}
 	d.log.Info("public_rand", addr, "round", r.Round, "reply", r.String())
-	return beaconToProto(r), nil
+	resp := beaconToProto(r, d.beacon.ChainInfo(), d.beacon.ChainHash())
+	if in.GetRound() == 0 {
+		resp.NextRound, resp.NextRoundTime = chain.NewSchedule(d.group).NextRound(d.opts.clock.Now().Unix())
+	}
+	return resp, nil
+}
+
+// PublicRandRange returns a page of the beacons of the requested range. The
+// page holds at most MaxRangeLimit beacons, DefaultRangeLimit if the request
+// does not set its limit.
+func (d *Drand) PublicRandRange(c context.Context, in *drand.PublicRandRangeRequest) (*drand.PublicRandRangeResponse, error) {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return nil, status.Error(codes.Unavailable, "drand: beacon generation not started yet")
+	}
+	from, to := in.GetFrom(), in.GetTo()
+	if from == 0 {
+		from = 1
+	}
+	if to == 0 {
+		to = math.MaxUint64
+	}
+	if from > to {
+		return nil, status.Errorf(codes.InvalidArgument, "drand: invalid range from %d to %d", from, to)
+	}
+	limit := int(in.GetLimit())
+	if limit == 0 {
+		limit = DefaultRangeLimit
+	}
+	if limit > MaxRangeLimit {
+		limit = MaxRangeLimit
+	}
+	d.log.Debug("public_rand_range", net.RemoteAddress(c), "from", from, "to", to, "limit", limit)
+	info, hash := b.ChainInfo(), b.ChainHash()
+	resp := new(drand.PublicRandRangeResponse)
+	b.Store().Cursor(func(cur chain.Cursor) {
+		for bb := cur.Seek(from); bb != nil && bb.Round <= to; bb = cur.Next() {
+			if len(resp.Beacons) == limit {
+				resp.Next = bb.Round
+				return
+			}
+			resp.Beacons = append(resp.Beacons, beaconToProto(bb, info, hash))
+		}
+	})
+	return resp, nil
+}
+
+// roundDelay returns the time until the given round is expected to be stored,
+// or a period if the round is already late.
+func (d *Drand) roundDelay(round uint64) time.Duration {
+	sched := chain.NewSchedule(d.group)
+	delay := time.Duration(sched.TimeOfRound(round)-d.opts.clock.Now().Unix()) * time.Second
+	if delay <= 0 {
+		return sched.PeriodAt(round)
+	}
+	return delay
 }
 
 // PublicRandStream exports a stream of new beacons as they are generated over gRPC
@@ -78,7 +191,7 @@ func (d *Drand) PublicRandStream(req *drand.PublicRandRequest, stream drand.Publ
 	d.state.Lock()
 	if d.beacon == nil {
 		d.state.Unlock()
-		return errors.New("beacon has not started on this node yet")
+		return status.Error(codes.Unavailable, "beacon has not started on this node yet")
 	}
 	b = d.beacon
 	d.state.Unlock()
@@ -89,12 +202,13 @@ func (d *Drand) PublicRandStream(req *drand.PublicRandRequest, stream drand.Publ
 	addr := net.RemoteAddress(stream.Context())
 	done := make(chan error, 1)
 	d.log.Debug("request", "stream", "from", addr, "round", req.GetRound())
+	info := b.ChainInfo()
 	if req.GetRound() != 0 && req.GetRound() <= lastb.Round {
 		// we need to stream from store first
 		var err error
 		b.Store().Cursor(func(c chain.Cursor) {
 			for bb := c.Seek(req.GetRound()); bb != nil; bb = c.Next() {
-				if err = stream.Send(beaconToProto(bb)); err != nil {
+				if err = stream.Send(beaconToProto(bb, info, b.ChainHash())); err != nil {
 					d.log.Debug("stream", err)
 					return
 				}
@@ -106,13 +220,9 @@ func (d *Drand) PublicRandStream(req *drand.PublicRandRequest, stream drand.Publ
 	}
 	// then we can stream from any new rounds
 	// register a callback for the duration of this stream
+	hash := b.ChainHash()
 	d.beacon.AddCallback(addr, func(b *chain.Beacon) {
-		err := stream.Send(&drand.PublicRandResponse{
-			Round:             b.Round,
-			Signature:         b.Signature,
-			PreviousSignature: b.PreviousSig,
-			Randomness:        b.Randomness(),
-		})
+		err := stream.Send(beaconToProto(b, info, hash))
 		// if connection has a problem, we drop the callback
 		if err != nil {
 			d.beacon.RemoveCallback(addr)
@@ -196,6 +306,28 @@ func (d *Drand) PushDKGInfo(ctx context.Context, in *drand.DKGInfoPacket) (*dran
 	return new(drand.Empty), d.receiver.PushDKGInfo(in)
 }
 
+// SignalDKGReady receives the signal of a node ready to run the DKG the leader
+// is about to start.
+func (d *Drand) SignalDKGReady(ctx context.Context, in *drand.DKGReadyPacket) (*drand.Empty, error) {
+	d.state.Lock()
+	readiness := d.readiness
+	d.state.Unlock()
+	if readiness == nil {
+		return nil, errors.New("drand: no dkg about to start")
+	}
+	node, err := readiness.signal(in)
+	if errors.Is(err, errIncompatible) {
+		d.log.Error("init_dkg", "incompatible_node", "from", node.Address(), "version", in.GetVersion(), "err", err)
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+	d.log.Debug("init_dkg", "node_ready", "from", node.Address())
+	d.dkgProgress.ready(node.Index)
+	return new(drand.Empty), nil
+}
+
 // SyncChain is a inter-node protocol that replies to a syncing request from a
 // given round
 func (d *Drand) SyncChain(req *drand.SyncRequest, stream drand.Protocol_SyncChainServer) error {
@@ -208,7 +340,38 @@ func (d *Drand) SyncChain(req *drand.SyncRequest, stream drand.Protocol_SyncChai
 	return nil
 }
 
+// GetBeacon is a inter-node protocol that replies with the beacon of a single
+// round, e.g. to a node missing the previous beacon of a round
+func (d *Drand) GetBeacon(c context.Context, in *drand.GetBeaconRequest) (*drand.BeaconPacket, error) {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return nil, errors.New("drand: beacon not setup yet")
+	}
+	return b.GetBeacon(c, in)
+}
+
 // GetIdentity returns the identity of this drand node
 func (d *Drand) GetIdentity(ctx context.Context, req *drand.IdentityRequest) (*drand.Identity, error) {
 	return d.priv.Public.ToProto(), nil
 }
+
+// PublicAuth implements the net.PublicAuthenticator interface, restricting
+// the public gRPC API to authenticated clients when configured.
+func (d *Drand) PublicAuth() *net.Auth {
+	return d.opts.grpcAuth
+}
+
+// IPFilter implements the net.IPFilterer interface, restricting the addresses
+// allowed to connect to the private listener when configured.
+func (d *Drand) IPFilter() *net.IPFilter {
+	return d.opts.privateFilter
+}
+
+// RequestTimeout implements the net.RequestTimeouter interface: incoming
+// requests can not take longer than one beacon period to be answered, since
+// any answer arriving later is useless for the current round.
+func (d *Drand) RequestTimeout() time.Duration {
+	return time.Duration(atomic.LoadInt64(&d.period))
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/beacon"
 	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/net"
@@ -20,21 +21,63 @@ import (
 
 	"github.com/kabukky/httpscerts"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // 1 second after end of dkg
 var testBeaconOffset = 1
 var testDkgTimeout = 2 * time.Second
 
+func TestDrandDKGProgress(t *testing.T) {
+	n := 4
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), 1*time.Second)
+	defer dt.Cleanup()
+	var events []*drand.DKGProgress
+	dt.dkgProgress = func(p *drand.DKGProgress) {
+		events = append(events, p)
+	}
+	group := dt.RunDKG()
+
+	require.Equal(t, drand.DKGEvent_DKG_SETUP, events[0].GetEvent())
+	count := make(map[drand.DKGEvent]int)
+	for _, ev := range events {
+		count[ev.GetEvent()]++
+		if ev.GetEvent() == drand.DKGEvent_DKG_STARTED {
+			require.Equal(t, uint32(n), ev.GetNodes())
+		}
+	}
+	require.Equal(t, 1, count[drand.DKGEvent_DKG_STARTED])
+	// all the nodes but the leader signal they are ready
+	require.Equal(t, n-1, count[drand.DKGEvent_DKG_READY])
+	require.Equal(t, n, count[drand.DKGEvent_DKG_DEAL])
+	last := events[len(events)-1]
+	require.Equal(t, drand.DKGEvent_DKG_DONE, last.GetEvent())
+	require.Equal(t, uint32(n), last.GetDeals())
+	require.Len(t, last.GetQualified(), n)
+	finalGroup, err := key.GroupFromProto(last.GetGroup())
+	require.NoError(t, err)
+	require.True(t, group.Equal(finalGroup))
+}
+
 func TestDrandDKGFresh(t *testing.T) {
 	n := 4
 	beaconPeriod := 1 * time.Second
 
 	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), beaconPeriod)
 	defer dt.Cleanup()
+	require.Equal(t, drand.NodeState_STATE_FRESH, nodeState(t, dt.nodes[0]))
 	finalGroup := dt.RunDKG()
 	time.Sleep(getSleepDuration())
 	fmt.Println(" --- DKG FINISHED ---")
+	require.Equal(t, drand.NodeState_STATE_DKG_DONE, nodeState(t, dt.nodes[0]))
+	// every node recorded the packets of the whole group
+	transcript, err := LoadTranscript(dt.nodes[0].drand.opts.ConfigFolder())
+	require.NoError(t, err)
+	require.Len(t, transcript.Deals, n)
+	require.Len(t, transcript.QUAL, n)
+	require.Equal(t, finalGroup.Hash(), transcript.GroupHash)
 	// make the last node fail
 	lastID := dt.nodes[n-1].addr
 	dt.StopDrand(lastID, false)
@@ -49,6 +92,7 @@ func TestDrandDKGFresh(t *testing.T) {
 	// two = genesis + 1st round (happens at genesis)
 	fmt.Println(" --- Test BEACON LENGTH --- ")
 	dt.TestBeaconLength(2, false, dt.Ids(n-1, false)...)
+	require.Equal(t, drand.NodeState_STATE_BEACON_RUNNING, nodeState(t, dt.nodes[0]))
 	fmt.Printf("\n\n --- START LAST DRAND %s ---\n\n", lastID)
 	// start last one
 	dt.StartDrand(lastID, true, false)
@@ -59,6 +103,32 @@ func TestDrandDKGFresh(t *testing.T) {
 	dt.MoveTime(beaconPeriod)
 	dt.TestBeaconLength(3, false, dt.Ids(n, false)...)
 	dt.TestPublicBeacon(lastID, false)
+	// the last node caught up with the others
+	require.Equal(t, drand.NodeState_STATE_BEACON_RUNNING, nodeState(t, dt.nodes[n-1]))
+	// the partials produced by the nodes are counted
+	usage, err := dt.nodes[0].drand.KeyUsage(context.Background(), new(drand.KeyUsageRequest))
+	require.NoError(t, err)
+	require.NotZero(t, usage.GetShare().GetSignatures())
+	require.Equal(t, usage.GetShare().GetSignatures(), usage.GetIdentity().GetSignatures())
+
+	// the chain goes on while a node is paused
+	paused := dt.nodes[0].drand
+	_, err = paused.Pause(context.Background(), new(drand.PauseRequest))
+	require.NoError(t, err)
+	require.Equal(t, drand.NodeState_STATE_PAUSED, nodeState(t, dt.nodes[0]))
+	dt.MoveTime(beaconPeriod)
+	dt.TestBeaconLength(4, false, dt.Ids(n, false)...)
+	_, err = paused.Resume(context.Background(), new(drand.ResumeRequest))
+	require.NoError(t, err)
+	require.Equal(t, drand.NodeState_STATE_BEACON_RUNNING, nodeState(t, dt.nodes[0]))
+	_, err = paused.Resume(context.Background(), new(drand.ResumeRequest))
+	require.Error(t, err)
+}
+
+func nodeState(t *testing.T, n *Node) drand.NodeState {
+	resp, err := n.drand.Status(context.Background(), new(drand.StatusRequest))
+	require.NoError(t, err)
+	return resp.GetState()
 }
 
 func TestDrandDKGBroadcastDeny(t *testing.T) {
@@ -110,6 +180,163 @@ func TestDrandReshareForce(t *testing.T) {
 	fmt.Println(group3)
 }
 
+func TestDrandReshareProgress(t *testing.T) {
+	n := 3
+	thr := 2
+	beaconPeriod := 2 * time.Second
+
+	dt := NewDrandTest2(t, n, thr, beaconPeriod)
+	defer dt.Cleanup()
+	group1 := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group1.GenesisTime)
+	dt.MoveTime(1 * time.Second)
+
+	var events []*drand.DKGProgress
+	dt.dkgProgress = func(p *drand.DKGProgress) {
+		events = append(events, p)
+	}
+	group2, err := dt.RunReshare(n, 0, thr, 1*time.Second, false, false)
+	require.NoError(t, err)
+
+	require.Equal(t, drand.DKGEvent_DKG_SETUP, events[0].GetEvent())
+	count := make(map[drand.DKGEvent]int)
+	for _, ev := range events {
+		count[ev.GetEvent()]++
+	}
+	require.Equal(t, 1, count[drand.DKGEvent_DKG_STARTED])
+	require.Equal(t, n, count[drand.DKGEvent_DKG_DEAL])
+	last := events[len(events)-1]
+	require.Equal(t, drand.DKGEvent_DKG_DONE, last.GetEvent())
+	require.Equal(t, uint32(n), last.GetDeals())
+	finalGroup, err := key.GroupFromProto(last.GetGroup())
+	require.NoError(t, err)
+	require.True(t, group2.Equal(finalGroup))
+}
+
+func TestDrandReshareDryRun(t *testing.T) {
+	oldN := 3
+	oldThr := 2
+	timeout := 1 * time.Second
+	beaconPeriod := 2 * time.Second
+
+	dt := NewDrandTest2(t, oldN, oldThr, beaconPeriod)
+	defer dt.Cleanup()
+	group1 := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group1.GenesisTime)
+	dt.MoveTime(1 * time.Second)
+
+	shares := make([]*key.Share, oldN)
+	for i, node := range dt.nodes {
+		shares[i] = node.drand.share
+	}
+	dt.dryRun = true
+	group2, err := dt.RunReshare(oldN, 0, oldThr, timeout, false, false)
+	require.NoError(t, err)
+	require.Len(t, group2.Nodes, oldN)
+	// the public key is kept by the resharing
+	require.True(t, group1.PublicKey.Key().Equal(group2.PublicKey.Key()))
+
+	// nodes keep their share and group
+	for i, node := range dt.nodes {
+		node.drand.state.Lock()
+		require.Equal(t, shares[i], node.drand.share)
+		require.True(t, node.drand.group.Equal(group1))
+		node.drand.state.Unlock()
+		s, err := node.drand.store.LoadShare()
+		require.NoError(t, err)
+		require.True(t, s.PrivateShare().V.Equal(shares[i].PrivateShare().V))
+		// the other nodes may end the dry run after the leader
+		require.Eventually(t, func() bool {
+			return nodeState(t, node) == drand.NodeState_STATE_BEACON_RUNNING
+		}, 2*time.Second, 10*time.Millisecond)
+	}
+}
+
+func TestDrandResharePeriod(t *testing.T) {
+	n := 3
+	thr := 2
+	timeout := 1 * time.Second
+	beaconPeriod := 2 * time.Second
+	newPeriod := 4 * time.Second
+
+	dt := NewDrandTest2(t, n, thr, beaconPeriod)
+	defer dt.Cleanup()
+	group1 := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group1.GenesisTime)
+	dt.MoveTime(1 * time.Second)
+
+	dt.newPeriod = newPeriod
+	group2, err := dt.RunReshare(n, 0, thr, timeout, false, false)
+	require.NoError(t, err)
+	// the genesis period is kept, the change is recorded from the transition
+	require.Equal(t, beaconPeriod, group2.Period)
+	tRound := chain.CurrentRound(group2.TransitionTime, beaconPeriod, group2.GenesisTime)
+	require.Equal(t, []key.PeriodChange{{Round: tRound, Period: newPeriod}}, group2.PeriodChanges)
+	require.Equal(t, chain.NewChainInfo(group1).Hash(), chain.NewChainInfo(group2).Hash())
+	// the resharing is recorded as a new epoch of the chain
+	epochs, err := dt.GetDrand(dt.Ids(1, true)[0], true).drand.Epochs()
+	require.NoError(t, err)
+	require.Equal(t, []*chain.Epoch{chain.NewEpoch(tRound, group1, group2)}, epochs)
+
+	// rounds happen every period until the transition
+	now := dt.Now().Unix()
+	for now < group2.TransitionTime-1 {
+		dt.MoveTime(beaconPeriod)
+		now = dt.Now().Unix()
+	}
+	dt.MoveToTime(group2.TransitionTime)
+	dt.TestBeaconLength(int(tRound+1), true, dt.Ids(n, true)...)
+	// then every new period
+	dt.MoveTime(beaconPeriod)
+	dt.TestBeaconLength(int(tRound+1), true, dt.Ids(n, true)...)
+	dt.MoveTime(newPeriod - beaconPeriod)
+	dt.TestBeaconLength(int(tRound+2), true, dt.Ids(n, true)...)
+	resp := dt.TestPublicBeacon(dt.Ids(1, true)[0], true)
+	require.Equal(t, tRound+1, resp.GetRound())
+}
+
+func TestDrandReshareDisagreePeriod(t *testing.T) {
+	n := 3
+	thr := 2
+	timeout := 1 * time.Second
+	beaconPeriod := 2 * time.Second
+
+	dt := NewDrandTest2(t, n, thr, beaconPeriod)
+	defer dt.Cleanup()
+	group1 := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group1.GenesisTime)
+	dt.MoveTime(1 * time.Second)
+
+	// the leader changes the period but the other nodes do not expect it
+	leader, err := net.NewControlClient(dt.nodes[0].drand.opts.controlPort)
+	require.NoError(t, err)
+	go func() {
+		_, _ = leader.InitReshareLeader(n, thr, timeout, 0, "thisistheresharing", "", testBeaconOffset, false, 0, false, 3*time.Second, nil)
+	}()
+	time.Sleep(1 * time.Second)
+	errCh := make(chan error, n-1)
+	for _, node := range dt.nodes[1:] {
+		client, err := net.NewControlClient(node.drand.opts.controlPort)
+		require.NoError(t, err)
+		go func() {
+			_, err := client.InitReshare(dt.nodes[0].drand.priv.Public, "thisistheresharing", dt.groupPath, false, 0, false, 0, nil)
+			errCh <- err
+		}()
+	}
+	for i := 0; i < n-1; i++ {
+		select {
+		case err := <-errCh:
+			require.Error(t, err)
+		case <-time.After(10 * time.Second):
+			t.Fatal("resharing did not fail")
+		}
+	}
+}
+
 func TestDrandDKGReshareTimeout(t *testing.T) {
 	oldN := 3
 	newN := 4
@@ -222,7 +449,7 @@ func TestDrandResharePreempt(t *testing.T) {
 	go func() {
 		client, err := net.NewControlClient(dt.nodes[0].drand.opts.controlPort)
 		require.NoError(t, err)
-		_, err = client.InitReshareLeader(newN, Thr, timeout, 0, "unused secret", "", testBeaconOffset)
+		_, err = client.InitReshareLeader(newN, Thr, timeout, 0, "unused secret", "", testBeaconOffset, false, 0, false, 0, nil)
 		// Done resharing
 		if err == nil {
 			panic("initial reshare should fail.")
@@ -310,6 +537,171 @@ func TestDrandPublicChainInfo(t *testing.T) {
 	// require.True(t, group.Equal(received))
 }
 
+func TestDrandPublicRandRange(t *testing.T) {
+	n := 3
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), p)
+	defer dt.Cleanup()
+	group := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group.GenesisTime)
+	for i := 0; i < 3; i++ {
+		dt.MoveTime(group.Period)
+	}
+	dt.TestBeaconLength(5, false, dt.Ids(n, false)...)
+
+	root := dt.nodes[0].drand
+	client := net.NewGrpcClientFromCertManager(root.opts.certmanager)
+	ctx := context.Background()
+	resp, err := client.PublicRandRange(ctx, root.priv.Public, &drand.PublicRandRangeRequest{From: 1, To: 3, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, resp.GetBeacons(), 2)
+	require.Equal(t, uint64(1), resp.GetBeacons()[0].GetRound())
+	require.Equal(t, uint64(3), resp.GetNext())
+
+	resp, err = client.PublicRandRange(ctx, root.priv.Public, &drand.PublicRandRangeRequest{From: resp.GetNext(), To: 3})
+	require.NoError(t, err)
+	require.Len(t, resp.GetBeacons(), 1)
+	require.Equal(t, uint64(3), resp.GetBeacons()[0].GetRound())
+	require.Zero(t, resp.GetNext())
+
+	// an open range ends with the latest beacon
+	resp, err = client.PublicRandRange(ctx, root.priv.Public, new(drand.PublicRandRangeRequest))
+	require.NoError(t, err)
+	require.Len(t, resp.GetBeacons(), 4)
+	require.Zero(t, resp.GetNext())
+
+	_, err = client.PublicRandRange(ctx, root.priv.Public, &drand.PublicRandRangeRequest{From: 3, To: 2})
+	require.Error(t, err)
+}
+
+func TestDrandUnknownCompression(t *testing.T) {
+	store := test.NewKeyStore()
+	require.NoError(t, store.SaveKeyPair(key.NewKeyPair("127.0.0.1:8080")))
+	_, err := NewDrand(store, NewConfig(WithInsecure(), WithCompression("unknown")))
+	require.Error(t, err)
+}
+
+func TestCheckGroupFallbacks(t *testing.T) {
+	pairs, group := test.BatchIdentities(3)
+	signed := group.Find(pairs[0].Public)
+	pairs[0].Public.Fallbacks = []string{"10.0.0.1:4444"}
+	pairs[0].SelfSign()
+	signed.Identity = pairs[0].Public
+	// fallbacks added without the key of the node are not used
+	tampered := group.Find(pairs[1].Public)
+	tampered.Fallbacks = []string{"10.6.6.6:4444"}
+	checkGroup(log.DefaultLogger(), group)
+	require.Equal(t, []string{"10.0.0.1:4444"}, signed.FallbackAddresses())
+	require.Empty(t, tampered.FallbackAddresses())
+}
+
+func TestDrandReadOnly(t *testing.T) {
+	n := 3
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), p)
+	defer dt.Cleanup()
+	group := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+	dt.MoveToTime(group.GenesisTime)
+	dt.MoveTime(group.Period)
+	dt.TestBeaconLength(3, false, dt.Ids(n, false)...)
+
+	// the node restarts as an archive of the chain, its key pair and share
+	// removed: only the group remains in its store
+	node := dt.nodes[0]
+	dt.StopDrand(node.addr, false)
+	store := test.NewKeyStore()
+	require.NoError(t, store.SaveGroup(group))
+	_, err := store.LoadKeyPair()
+	require.Error(t, err)
+	_, err = store.LoadShare()
+	require.Error(t, err)
+	// without key pair, it listens on the address given by the operator
+	_, err = LoadReadOnlyDrand(store, node.drand.opts)
+	require.Error(t, err)
+	WithPrivateListenAddress(node.addr)(node.drand.opts)
+	dr, err := LoadReadOnlyDrand(store, node.drand.opts)
+	require.NoError(t, err)
+	node.drand = dr
+
+	status, err := dr.Status(context.Background(), new(drand.StatusRequest))
+	require.NoError(t, err)
+	require.Equal(t, drand.NodeState_STATE_READ_ONLY, status.GetState())
+
+	client := net.NewGrpcClientFromCertManager(dr.opts.certmanager)
+	resp, err := client.PublicRand(context.Background(), dr.priv.Public, &drand.PublicRandRequest{Round: 2})
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), resp.GetRound())
+
+	// other nodes can still sync from it
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	beacons, err := client.SyncChain(ctx, dr.priv.Public, &drand.SyncRequest{FromRound: 1})
+	require.NoError(t, err)
+	for round := uint64(1); round <= 2; round++ {
+		select {
+		case b, ok := <-beacons:
+			require.True(t, ok)
+			require.Equal(t, round, b.GetRound())
+		case <-time.After(5 * time.Second):
+			t.Fatal("no beacon synced from the read-only node")
+		}
+	}
+	cancel()
+
+	_, err = dr.PartialBeacon(context.Background(), &drand.PartialBeaconPacket{Round: 3})
+	require.Error(t, err)
+	_, err = dr.InitReshare(context.Background(), new(drand.InitResharePacket))
+	require.Equal(t, errReadOnly, err)
+	_, err = dr.Pause(context.Background(), new(drand.PauseRequest))
+	require.Equal(t, errReadOnly, err)
+}
+
+func TestDrandPublicRandDigest(t *testing.T) {
+	n := 3
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), p)
+	defer dt.Cleanup()
+	dt.digest = key.DigestSHA3
+	group := dt.RunDKG()
+	require.Equal(t, key.DigestSHA3, group.Digest)
+	time.Sleep(getSleepDuration())
+	root := dt.nodes[0].drand
+
+	dt.MoveToTime(group.GenesisTime)
+	dt.MoveTime(group.Period)
+	dt.TestBeaconLength(3, false, dt.Ids(n, false)...)
+
+	cm := root.opts.certmanager
+	info, err := NewGrpcClientFromCert(cm).ChainInfo(root.priv.Public)
+	require.NoError(t, err)
+	require.Equal(t, key.DigestSHA3, info.Digest)
+	client := net.NewGrpcClientFromCertManager(cm)
+	resp, err := client.PublicRand(context.Background(), root.priv.Public, new(drand.PublicRandRequest))
+	require.NoError(t, err)
+	expected := sha3.Sum256(resp.GetSignature())
+	require.Equal(t, expected[:], resp.GetRandomness())
+}
+
+func TestDrandPeerVerification(t *testing.T) {
+	n := 3
+	p := 1 * time.Second
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), p)
+	defer dt.Cleanup()
+	for _, node := range dt.nodes {
+		WithPeerVerification()(node.drand.opts)
+	}
+	group := dt.RunDKG()
+	time.Sleep(getSleepDuration())
+
+	// the partials are only accepted from their signer, authenticated by
+	// its certificate
+	dt.MoveToTime(group.GenesisTime)
+	dt.MoveTime(group.Period)
+	dt.TestBeaconLength(3, false, dt.Ids(n, false)...)
+}
+
 // Test if the we can correctly fetch the rounds after a DKG using the
 // PublicRand RPC call
 func TestDrandPublicRand(t *testing.T) {
@@ -336,6 +728,12 @@ func TestDrandPublicRand(t *testing.T) {
 	// get last round first
 	resp, err := client.PublicRand(ctx, rootID, new(drand.PublicRandRequest))
 	require.NoError(t, err)
+	// the latest beacon comes with the round expected next
+	nextRound, nextTime := chain.NextRound(dt.Now().Unix(), group.Period, group.GenesisTime)
+	require.Equal(t, nextRound, resp.GetNextRound())
+	require.Equal(t, nextTime, resp.GetNextRoundTime())
+	require.Equal(t, chain.NewChainInfo(group).Hash(), resp.GetChainHash())
+	require.True(t, len(chain.ContributorIndices(resp.GetContributors())) >= group.Threshold)
 
 	initRound := resp.Round + 1
 	max := initRound + 4
@@ -346,8 +744,39 @@ func TestDrandPublicRand(t *testing.T) {
 		resp, err := client.PublicRand(ctx, rootID, req)
 		require.NoError(t, err)
 		require.Equal(t, i, resp.Round)
+		require.Zero(t, resp.GetNextRound())
 		fmt.Println("REQUEST ROUND ", i, " GOT ROUND ", resp.Round)
 	}
+
+	// a future round comes with the time to wait for it
+	_, err = client.PublicRand(ctx, rootID, &drand.PublicRandRequest{Round: max + 5})
+	require.Equal(t, codes.Unavailable, status.Code(err))
+	delay, ok := net.RetryDelay(err)
+	require.True(t, ok)
+	expected := chain.TimeOfRound(group.Period, group.GenesisTime, max+5) - dt.Now().Unix()
+	require.Equal(t, time.Duration(expected)*time.Second, delay)
+
+	// the node reports a threshold of partials at least for each round
+	ctrl, err := net.NewControlClient(root.opts.controlPort)
+	require.NoError(t, err)
+	reports, err := ctrl.RoundReports(2)
+	require.NoError(t, err)
+	require.Len(t, reports.GetReports(), 2)
+	require.Equal(t, max-1, reports.GetReports()[1].GetRound())
+	for _, r := range reports.GetReports() {
+		require.True(t, len(r.GetPartials()) >= thr)
+		require.Equal(t, n, len(r.GetPartials())+len(r.GetMissing()))
+	}
+
+	// the clocks of the nodes are in sync so none of them is degraded
+	status, err := ctrl.PeerStatus()
+	require.NoError(t, err)
+	require.True(t, status.GetThresholdReachable())
+	require.True(t, len(status.GetPeers()) >= thr-1)
+	for _, p := range status.GetPeers() {
+		require.False(t, p.GetDegraded())
+		require.NotEmpty(t, p.GetAddress())
+	}
 }
 
 // Test if the we can correctly fetch the rounds after a DKG using the
@@ -483,7 +912,7 @@ func TestDrandFollowChain(tt *testing.T) {
 		cancel()
 
 		// check if the beacon is in the database
-		store, err := newNode.drand.createBoltStore()
+		store, err := newNode.drand.createStore()
 		require.NoError(tt, err)
 		defer store.Close()
 		lastB, err := store.Last()
@@ -492,7 +921,35 @@ func TestDrandFollowChain(tt *testing.T) {
 	}
 	fn(resp.GetRound()-2, resp.GetRound()-2)
 	time.Sleep(200 * time.Millisecond)
+	// the remaining rounds are fetched in batches
+	newNode.drand.opts.syncLimits = beacon.SyncLimits{BatchSize: 1}
 	fn(0, resp.GetRound())
+
+	// nodes are not followed if they run another chain
+	time.Sleep(200 * time.Millisecond)
+	wrongHash := fmt.Sprintf("%x", make([]byte, 32))
+	_, errCh, err := newClient.StartFollowChain(context.Background(), wrongHash, addrToFollow, tls, 0)
+	require.NoError(tt, err)
+	select {
+	case e := <-errCh:
+		require.Error(tt, e)
+		require.NotEqual(tt, io.EOF, e)
+	case <-time.After(1 * time.Second):
+		tt.FailNow()
+	}
+
+	// a node running the beacon can't follow another chain
+	rootClient, err := net.NewControlClient(root.drand.opts.controlPort)
+	require.NoError(tt, err)
+	_, errCh, err = rootClient.StartFollowChain(context.Background(), hash, addrToFollow, tls, 0)
+	require.NoError(tt, err)
+	select {
+	case e := <-errCh:
+		require.Error(tt, e)
+		require.NotEqual(tt, io.EOF, e)
+	case <-time.After(1 * time.Second):
+		tt.FailNow()
+	}
 }
 
 // Test if the we can correctly fetch the rounds through the local proxy
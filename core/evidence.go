@@ -0,0 +1,148 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/metrics"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	json "github.com/nikkolasg/hexjson"
+)
+
+// AuditLogFileName is the name of the file, in the db folder, where the
+// evidence of the nodes signing two different messages for the same round is
+// appended, one JSON record per line.
+const AuditLogFileName = "audit.log"
+
+// EquivocationRecord is an entry of the audit log: the evidence that a node
+// signed two different messages for the same round.
+type EquivocationRecord struct {
+	// Time at which the evidence was recorded, in unix seconds
+	Time  int64  `json:"time"`
+	Round uint64 `json:"round"`
+	// Index of the node that signed the two messages
+	Index int `json:"index"`
+	// From is the address of the node the evidence was received from, empty
+	// if this node detected the equivocation
+	From     string                    `json:"from,omitempty"`
+	Evidence *drand.EquivocationPacket `json:"evidence"`
+}
+
+type equivocationID struct {
+	round uint64
+	index int
+}
+
+// evidenceLog records each equivocation once in the audit log and counts
+// them for each node since the daemon started.
+type evidenceLog struct {
+	sync.Mutex
+	folder string
+	seen   map[equivocationID]bool
+	counts map[int]int
+}
+
+func newEvidenceLog(folder string) *evidenceLog {
+	return &evidenceLog{
+		folder: folder,
+		seen:   make(map[equivocationID]bool),
+		counts: make(map[int]int),
+	}
+}
+
+// record appends the evidence to the audit log. It returns false if an
+// evidence was already recorded for the same round and node.
+func (e *evidenceLog) record(r *EquivocationRecord) (bool, error) {
+	e.Lock()
+	defer e.Unlock()
+	id := equivocationID{r.Round, r.Index}
+	if e.seen[id] {
+		return false, nil
+	}
+	e.seen[id] = true
+	e.counts[r.Index]++
+	metrics.Equivocations.WithLabelValues(strconv.Itoa(r.Index)).Inc()
+
+	fd, err := os.OpenFile(path.Join(e.folder, AuditLogFileName), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return true, err
+	}
+	defer fd.Close()
+	return true, json.NewEncoder(fd).Encode(r)
+}
+
+// count returns the number of rounds for which the node of the given index
+// was caught signing two different messages.
+func (e *evidenceLog) count(index int) int {
+	e.Lock()
+	defer e.Unlock()
+	return e.counts[index]
+}
+
+// equivocated records the evidence, detected by the beacon handler, that a
+// node signed two different messages for the same round and sends it to the
+// other nodes of the group.
+func (d *Drand) equivocated(idx int, e *drand.EquivocationPacket) {
+	d.state.Lock()
+	group := d.group
+	d.state.Unlock()
+	if !d.recordEquivocation(e, idx, "") {
+		return
+	}
+	for _, n := range group.Nodes {
+		if n.Address() == d.priv.Public.Address() || int(n.Index) == idx {
+			continue
+		}
+		go func(n net.Peer) {
+			ctx, cancel := context.WithTimeout(d.ctx, evidenceTimeout)
+			defer cancel()
+			if err := d.privGateway.ProtocolClient.EquivocationEvidence(ctx, n, e); err != nil {
+				d.log.Error("equivocation", "failed to send evidence", "to", n.Address(), "err", err)
+			}
+		}(n.Identity)
+	}
+}
+
+// EquivocationEvidence receives the evidence, detected by another node, that
+// a node of the group signed two different messages for the same round. The
+// evidence is verified and recorded in the audit log.
+func (d *Drand) EquivocationEvidence(ctx context.Context, in *drand.EquivocationPacket) (*drand.Empty, error) {
+	d.state.Lock()
+	b := d.beacon
+	d.state.Unlock()
+	if b == nil {
+		return nil, errors.New("drand: beacon is not running")
+	}
+	idx, err := b.VerifyEquivocation(in)
+	if err != nil {
+		return nil, err
+	}
+	d.recordEquivocation(in, idx, net.RemoteAddress(ctx))
+	return new(drand.Empty), nil
+}
+
+// recordEquivocation logs and records the evidence in the audit log. It
+// returns false if it was already recorded.
+func (d *Drand) recordEquivocation(e *drand.EquivocationPacket, idx int, from string) bool {
+	round := e.GetFirst().GetRound()
+	recorded, err := d.evidence.record(&EquivocationRecord{
+		Time:     time.Now().Unix(),
+		Round:    round,
+		Index:    idx,
+		From:     from,
+		Evidence: e,
+	})
+	if err != nil {
+		d.log.Error("equivocation", "failed to write audit log", "err", err)
+	}
+	if recorded {
+		d.log.Error("equivocation", "node signed two messages", "index", idx, "round", round, "from", from)
+	}
+	return recorded
+}
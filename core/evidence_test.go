@@ -0,0 +1,52 @@
+package core
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/drand/drand/protobuf/drand"
+	json "github.com/nikkolasg/hexjson"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvidenceLog(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drandtest*")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	e := newEvidenceLog(tmp)
+
+	evidence := &drand.EquivocationPacket{
+		First:  &drand.PartialBeaconPacket{Round: 5, PreviousSig: []byte("a")},
+		Second: &drand.PartialBeaconPacket{Round: 5, PreviousSig: []byte("b")},
+	}
+	recorded, err := e.record(&EquivocationRecord{Round: 5, Index: 2, Evidence: evidence})
+	require.NoError(t, err)
+	require.True(t, recorded)
+	// the same equivocation sent by another node is recorded once
+	recorded, err = e.record(&EquivocationRecord{Round: 5, Index: 2, From: "other:1234", Evidence: evidence})
+	require.NoError(t, err)
+	require.False(t, recorded)
+	recorded, err = e.record(&EquivocationRecord{Round: 6, Index: 2, From: "other:1234", Evidence: evidence})
+	require.NoError(t, err)
+	require.True(t, recorded)
+	require.Equal(t, 2, e.count(2))
+	require.Equal(t, 0, e.count(1))
+
+	fd, err := os.Open(path.Join(tmp, AuditLogFileName))
+	require.NoError(t, err)
+	defer fd.Close()
+	var records []*EquivocationRecord
+	scanner := bufio.NewScanner(fd)
+	for scanner.Scan() {
+		r := new(EquivocationRecord)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), r))
+		records = append(records, r)
+	}
+	require.Len(t, records, 2)
+	require.Equal(t, uint64(6), records[1].Round)
+	require.Equal(t, "other:1234", records[1].From)
+	require.Equal(t, []byte("b"), records[1].Evidence.GetSecond().GetPreviousSig())
+}
@@ -0,0 +1,236 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+)
+
+// MaxPendingGroups is the maximum number of group proposals a node keeps for
+// each proposing node. The oldest proposal not approved of the node is dropped
+// when it proposes a new one, so a node can't evict the proposals of another.
+const MaxPendingGroups = 10
+
+type groupProposal struct {
+	group    *key.Group
+	hash     []byte
+	from     string
+	approved bool
+}
+
+// groupProposals keeps the groups proposed for a future resharing and whether
+// the operator approved them. They are kept in memory only: the proposals and
+// approvals are lost when the daemon restarts, and must be sent and approved
+// again.
+type groupProposals struct {
+	sync.Mutex
+	proposals []*groupProposal
+}
+
+// add records a proposed group, or approves it again if already proposed. It
+// returns the membership hash of the group.
+func (g *groupProposals) add(group *key.Group, from string, approved bool) []byte {
+	g.Lock()
+	defer g.Unlock()
+	hash := group.MembershipHash()
+	for _, p := range g.proposals {
+		if bytes.Equal(p.hash, hash) {
+			p.approved = p.approved || approved
+			return hash
+		}
+	}
+	count := 0
+	for _, p := range g.proposals {
+		if p.from == from {
+			count++
+		}
+	}
+	if count >= MaxPendingGroups {
+		g.evict(from)
+	}
+	g.proposals = append(g.proposals, &groupProposal{group: group, hash: hash, from: from, approved: approved})
+	return hash
+}
+
+// evict drops the oldest proposal of the node not approved, or its oldest one
+// if all are.
+func (g *groupProposals) evict(from string) {
+	i := -1
+	for j, p := range g.proposals {
+		if p.from != from {
+			continue
+		}
+		if i < 0 {
+			i = j
+		}
+		if !p.approved {
+			i = j
+			break
+		}
+	}
+	g.proposals = append(g.proposals[:i], g.proposals[i+1:]...)
+}
+
+func (g *groupProposals) approve(hash []byte) error {
+	g.Lock()
+	defer g.Unlock()
+	for _, p := range g.proposals {
+		if bytes.Equal(p.hash, hash) {
+			p.approved = true
+			return nil
+		}
+	}
+	return fmt.Errorf("no group proposed with hash %x", hash)
+}
+
+// approved returns true if a group with the same membership was approved.
+func (g *groupProposals) approved(group *key.Group) bool {
+	g.Lock()
+	defer g.Unlock()
+	hash := group.MembershipHash()
+	for _, p := range g.proposals {
+		if p.approved && bytes.Equal(p.hash, hash) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *groupProposals) list() []*drand.PendingGroup {
+	g.Lock()
+	defer g.Unlock()
+	groups := make([]*drand.PendingGroup, len(g.proposals))
+	for i, p := range g.proposals {
+		nodes := make([]string, len(p.group.Nodes))
+		for j, n := range p.group.Nodes {
+			nodes[j] = n.Address()
+		}
+		groups[i] = &drand.PendingGroup{
+			Hash:      p.hash,
+			From:      p.from,
+			Threshold: uint32(p.group.Threshold),
+			Period:    uint32(p.group.Period.Seconds()),
+			Nodes:     nodes,
+			Approved:  p.approved,
+		}
+	}
+	return groups
+}
+
+// proposalMessage returns the message the node at the address signs to
+// propose the group.
+func proposalMessage(addr string, group *key.Group) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(addr))
+	_, _ = h.Write(group.MembershipHash())
+	return h.Sum(nil)
+}
+
+// checkGroupApproval returns an error if the node requires the operator to
+// approve the groups it reshares to and the group was not approved.
+func (d *Drand) checkGroupApproval(group *key.Group) error {
+	if !d.opts.groupApproval || d.proposals.approved(group) {
+		return nil
+	}
+	d.log.Error("setup_reshare", "group not approved", "hash", fmt.Sprintf("%x", group.MembershipHash()))
+	return errors.New("control: the new group has not been approved by the operator")
+}
+
+// ProposeGroup sends the group of a future resharing to all its nodes, and to
+// the nodes of the current group, for their operators to approve it. The group
+// is approved on this node.
+func (d *Drand) ProposeGroup(ctx context.Context, in *drand.ProposeGroupRequest) (*drand.ProposeGroupResponse, error) {
+	group, err := extractGroup(in.GetGroup())
+	if err != nil {
+		return nil, err
+	}
+	addr := d.priv.Public.Address()
+	sig, err := key.AuthScheme.Sign(d.priv.Key, proposalMessage(addr, group))
+	if err != nil {
+		return nil, err
+	}
+	hash := d.proposals.add(group, addr, true)
+	d.state.Lock()
+	nodes := append([]*key.Node{}, group.Nodes...)
+	if d.group != nil {
+		nodes = append(nodes, d.group.Nodes...)
+	}
+	d.state.Unlock()
+
+	packet := &drand.GroupProposal{Group: group.ToProto(), Address: addr, Signature: sig}
+	resp := &drand.ProposeGroupResponse{Hash: hash}
+	sent := map[string]bool{addr: true}
+	for _, n := range nodes {
+		if sent[n.Address()] {
+			continue
+		}
+		sent[n.Address()] = true
+		if err := d.privGateway.ProtocolClient.PushGroupProposal(ctx, n.Identity, packet); err != nil {
+			d.log.Error("propose_group", "failed to send group", "to", n.Address(), "err", err)
+			resp.Failed = append(resp.Failed, n.Address())
+		}
+	}
+	return resp, nil
+}
+
+// ListPendingGroups returns the groups proposed to the node since it started.
+func (d *Drand) ListPendingGroups(ctx context.Context, in *drand.ListPendingGroupsRequest) (*drand.ListPendingGroupsResponse, error) {
+	return &drand.ListPendingGroupsResponse{Groups: d.proposals.list()}, nil
+}
+
+// ApproveGroup approves the proposed group with the given membership hash.
+func (d *Drand) ApproveGroup(ctx context.Context, in *drand.ApproveGroupRequest) (*drand.ApproveGroupResponse, error) {
+	if err := d.proposals.approve(in.GetHash()); err != nil {
+		return nil, err
+	}
+	d.log.Info("approve_group", fmt.Sprintf("%x", in.GetHash()))
+	return new(drand.ApproveGroupResponse), nil
+}
+
+// PushGroupProposal receives a group proposed by another node. It is kept
+// until the operator approves it. The proposal must be signed by a node of the
+// current group or of the proposed group.
+func (d *Drand) PushGroupProposal(ctx context.Context, in *drand.GroupProposal) (*drand.Empty, error) {
+	group, err := key.GroupFromProto(in.GetGroup())
+	if err != nil {
+		return nil, err
+	}
+	d.state.Lock()
+	current := d.group
+	d.state.Unlock()
+	if group.Find(d.priv.Public) == nil && (current == nil || current.Find(d.priv.Public) == nil) {
+		return nil, errors.New("drand: node not included in the proposed group")
+	}
+	from := in.GetAddress()
+	proposer := findAddress(group, from)
+	if proposer == nil && current != nil {
+		proposer = findAddress(current, from)
+	}
+	if proposer == nil {
+		return nil, fmt.Errorf("drand: group proposed by %s, not a node of the current or proposed group", from)
+	}
+	if err := key.AuthScheme.Verify(proposer.Key, proposalMessage(from, group), in.GetSignature()); err != nil {
+		return nil, fmt.Errorf("drand: invalid signature of the group proposal: %w", err)
+	}
+	hash := d.proposals.add(group, from, false)
+	d.log.Info("propose_group", "received", "from", from, "peer", net.RemoteAddress(ctx), "hash", fmt.Sprintf("%x", hash))
+	return new(drand.Empty), nil
+}
+
+// findAddress returns the node of the group listening at the address, nil if
+// none.
+func findAddress(group *key.Group, addr string) *key.Node {
+	for _, n := range group.Nodes {
+		if n.Address() == addr {
+			return n
+		}
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"context"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/drand/drand/test"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupProposals(t *testing.T) {
+	n := 3
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), time.Second)
+	defer dt.Cleanup()
+
+	ids := make([]*key.Identity, n)
+	for i, node := range dt.nodes {
+		ids[i] = node.drand.priv.Public
+	}
+	group := key.NewGroup(ids, key.DefaultThreshold(n), dt.Now().Unix()+100, 3*time.Second, 0)
+	groupPath := path.Join(dt.dir, "proposed.toml")
+	require.NoError(t, key.Save(groupPath, group, false))
+
+	leader, err := net.NewControlClient(dt.nodes[0].drand.opts.controlPort)
+	require.NoError(t, err)
+	resp, err := leader.ProposeGroup(groupPath)
+	require.NoError(t, err)
+	require.Empty(t, resp.GetFailed())
+	require.Equal(t, group.MembershipHash(), resp.GetHash())
+
+	// the group is approved by the leader only
+	follower := dt.nodes[1].drand
+	follower.opts.groupApproval = true
+	ctrl, err := net.NewControlClient(follower.opts.controlPort)
+	require.NoError(t, err)
+	pending, err := ctrl.ListPendingGroups()
+	require.NoError(t, err)
+	require.Len(t, pending.GetGroups(), 1)
+	require.False(t, pending.GetGroups()[0].GetApproved())
+	require.Len(t, pending.GetGroups()[0].GetNodes(), n)
+	require.Error(t, follower.checkGroupApproval(group))
+	require.NoError(t, dt.nodes[0].drand.checkGroupApproval(group))
+
+	require.Error(t, ctrl.ApproveGroup([]byte("unknown")))
+	require.NoError(t, ctrl.ApproveGroup(resp.GetHash()))
+	pending, err = ctrl.ListPendingGroups()
+	require.NoError(t, err)
+	require.True(t, pending.GetGroups()[0].GetApproved())
+
+	// the proposals must be signed by a node of the current or proposed group
+	stranger := test.GenerateIDs(1)[0]
+	proposal := func(signer *key.Pair, addr string) *drand.GroupProposal {
+		sig, err := key.AuthScheme.Sign(signer.Key, proposalMessage(addr, group))
+		require.NoError(t, err)
+		return &drand.GroupProposal{Group: group.ToProto(), Address: addr, Signature: sig}
+	}
+	_, err = follower.PushGroupProposal(context.Background(), proposal(stranger, stranger.Public.Address()))
+	require.Error(t, err)
+	_, err = follower.PushGroupProposal(context.Background(), proposal(stranger, ids[2].Address()))
+	require.Error(t, err)
+	_, err = follower.PushGroupProposal(context.Background(), proposal(dt.nodes[2].drand.priv, ids[2].Address()))
+	require.NoError(t, err)
+
+	// the group created by the leader during the resharing has new indices
+	// and times but the same members
+	reshared := key.NewGroup(ids[1:], key.DefaultThreshold(n), group.GenesisTime, group.Period, 0)
+	reshared.Nodes = append(reshared.Nodes, &key.Node{Identity: ids[0], Index: 2})
+	reshared.TransitionTime = group.GenesisTime + 30
+	require.NoError(t, follower.checkGroupApproval(reshared))
+}
+
+func TestGroupProposalsEviction(t *testing.T) {
+	pairs, _ := test.BatchIdentities(MaxPendingGroups + 2)
+	ids := make([]*key.Identity, len(pairs))
+	for i, p := range pairs {
+		ids[i] = p.Public
+	}
+	g := new(groupProposals)
+	first := key.NewGroup(ids[:1], 1, 0, time.Second, 0)
+	g.add(first, "leader", true)
+	other := key.NewGroup(ids[1:2], 1, 0, time.Second, 0)
+	g.add(other, "other", false)
+	for i := 1; i <= MaxPendingGroups; i++ {
+		g.add(key.NewGroup(ids[:i+1], 1, 0, time.Second, 0), "leader", false)
+	}
+	// the oldest pending group of the node is dropped, not the approved one
+	// nor the one of another node
+	require.Len(t, g.list(), MaxPendingGroups+1)
+	require.True(t, g.approved(first))
+	require.False(t, g.approved(key.NewGroup(ids[:2], 1, 0, time.Second, 0)))
+	require.NoError(t, g.approve(other.MembershipHash()))
+	require.True(t, g.approved(other))
+}
@@ -28,7 +28,7 @@ import (
 // Leader:
 // * Runs drand start <...>
 // * Runs drand share --leader --nodes 10 --threshold 6 --timeout 1m --start-in 10m
-// 		- This commands need to be ran before the clients do it
+//   - This commands need to be ran before the clients do it
 //
 // Then
 // * Leader receives keys one by one, when it has 10 different ones, it creates
@@ -47,14 +47,24 @@ type setupManager struct {
 	catchupPeriod time.Duration
 	beaconPeriod  time.Duration
 	dkgTimeout    time.Duration
-	clock         clock.Clock
-	leaderKey     *key.Identity
-	verifyKeys    func([]*key.Identity) bool
-	l             log.Logger
+	// taggedMessages switches the chain to the domain separated message format
+	taggedMessages bool
+	// digest derives the randomness of a new network from the signatures
+	digest     string
+	clock      clock.Clock
+	leaderKey  *key.Identity
+	verifyKeys func([]*key.Identity) bool
+	l          log.Logger
 
 	isResharing bool
 	oldGroup    *key.Group
 	oldHash     []byte
+	// transitionRound is the round at which the new group takes over, if
+	// scheduled by the operators
+	transitionRound uint64
+	// newPeriod is the period of the chain from the transition round, if
+	// changed by the resharing
+	newPeriod time.Duration
 
 	startDKG     chan *key.Group
 	pushKeyCh    chan pushKey
@@ -73,6 +83,9 @@ func newDKGSetup(
 	if err != nil {
 		return nil, err
 	}
+	if _, err := key.DigestFunc(in.GetDigest()); err != nil {
+		return nil, err
+	}
 	secret := hashSecret(in.GetSecret())
 	verifyKeys := func(keys []*key.Identity) bool {
 		// XXX Later we can add specific name list of DNS, or prexisting
@@ -99,6 +112,14 @@ func newDKGSetup(
 		clock:         c,
 		leaderKey:     leaderKey,
 		hashedSecret:  secret,
+
+		taggedMessages: in.GetTaggedMessages(),
+		digest:         in.GetDigest(),
+	}
+	if sm.digest == key.DigestSHA256 {
+		// the default digest keeps the hashes of the chains created before
+		// the digest could be chosen
+		sm.digest = ""
 	}
 	return sm, nil
 }
@@ -109,7 +130,8 @@ func newReshareSetup(
 	leaderKey *key.Identity,
 	oldGroup *key.Group,
 	in *drand.InitResharePacket) (*setupManager, error) {
-	// period isn't included for resharing since we keep the same period
+	// the period of the group stays the one of the genesis, a change of
+	// period is recorded in the new group from the transition round
 	beaconPeriod := uint32(oldGroup.Period.Seconds())
 	catchupPeriod := in.CatchupPeriod
 	if !in.CatchupPeriodChanged {
@@ -128,6 +150,15 @@ func newReshareSetup(
 		offset = DefaultResharingOffset
 	}
 	sm.beaconOffset = offset
+	if r := in.GetTransitionRound(); r != 0 {
+		// the resharing must have time to run before the transition
+		atLeast := c.Now().Add(sm.dkgTimeout * 3).Unix()
+		if chain.NewSchedule(oldGroup).TimeOfRound(r) < atLeast {
+			return nil, fmt.Errorf("transition round %d is too early to run the resharing", r)
+		}
+		sm.transitionRound = r
+	}
+	sm.newPeriod = time.Duration(in.GetBeaconPeriod()) * time.Second
 	return sm, nil
 }
 
@@ -225,15 +256,35 @@ func (s *setupManager) createAndSend(keys []*key.Identity) {
 		ps := int64(s.beaconPeriod.Seconds())
 		genesis += (ps - genesis%ps)
 		group = key.NewGroup(keys, s.thr, genesis, s.beaconPeriod, s.catchupPeriod)
+		if s.taggedMessages {
+			group.MessageV1Round = 1
+		}
+		group.Digest = s.digest
+		group.GenesisSeed = group.SeedHash()
 	} else {
 		genesis := s.oldGroup.GenesisTime
 		atLeast := s.clock.Now().Add(totalDKG).Unix()
 		// transitioning to the next round time that is at least
 		// "DefaultResharingOffset" time from now.
-		_, transition := chain.NextRound(atLeast, s.beaconPeriod, s.oldGroup.GenesisTime)
+		sched := chain.NewSchedule(s.oldGroup)
+		tRound, transition := sched.NextRound(atLeast)
+		if s.transitionRound != 0 {
+			tRound = s.transitionRound
+			transition = sched.TimeOfRound(tRound)
+		}
 		group = key.NewGroup(keys, s.thr, genesis, s.beaconPeriod, s.catchupPeriod)
 		group.TransitionTime = transition
 		group.GenesisSeed = s.oldGroup.GetGenesisSeed()
+		// the message format can only be migrated once, by the new group
+		group.MessageV1Round = s.oldGroup.MessageV1Round
+		if group.MessageV1Round == 0 && s.taggedMessages {
+			group.MessageV1Round = tRound
+		}
+		group.Digest = s.oldGroup.Digest
+		group.PeriodChanges = append([]key.PeriodChange(nil), s.oldGroup.PeriodChanges...)
+		if s.newPeriod != 0 && s.newPeriod != sched.PeriodAt(tRound) {
+			group.PeriodChanges = append(group.PeriodChanges, key.PeriodChange{Round: tRound, Period: s.newPeriod})
+		}
 	}
 	s.l.Debug("setup", "created_group")
 	fmt.Printf("Generated group:\n%s\n", group.String())
@@ -254,12 +305,12 @@ func (s *setupManager) StopPreemptively() {
 func validInitPacket(in *drand.SetupInfoPacket) (n, thr int, dkg time.Duration, err error) {
 	n = int(in.GetNodes())
 	thr = int(in.GetThreshold())
-	if thr < key.MinimumT(n) {
-		err = fmt.Errorf("invalid thr: %d nodes, need thr %d got %d", n, thr, key.MinimumT(n))
+	if n < key.MinimumGroupSize {
+		err = fmt.Errorf("invalid group size: %d nodes, the minimum is %d", n, key.MinimumGroupSize)
 		return
 	}
-	if thr > n {
-		err = fmt.Errorf("invalid thr: %d nodes, can't have thr %d", n, thr)
+	if err = key.ValidateThreshold(n, thr, 0); err != nil {
+		err = fmt.Errorf("invalid thr: %v", err)
 		return
 	}
 	dkg = time.Duration(in.GetTimeout()) * time.Second
@@ -311,6 +362,7 @@ func (r *setupReceiver) fetchLeaderKey() error {
 type dkgGroup struct {
 	group   *key.Group
 	timeout uint32
+	dryRun  bool
 }
 
 // PushDKGInfo method is being called when a node received a group from the
@@ -334,23 +386,24 @@ func (r *setupReceiver) PushDKGInfo(pg *drand.DKGInfoPacket) error {
 	r.ch <- &dkgGroup{
 		group:   group,
 		timeout: pg.GetDkgTimeout(),
+		dryRun:  pg.GetDryRun(),
 	}
 	return nil
 }
 
-func (r *setupReceiver) WaitDKGInfo(ctx context.Context) (*key.Group, uint32, error) {
+func (r *setupReceiver) WaitDKGInfo(ctx context.Context) (*dkgGroup, error) {
 	select {
 	case dkgGroup := <-r.ch:
 		if dkgGroup == nil {
-			return nil, 0, errors.New("unable to fetch group")
+			return nil, errors.New("unable to fetch group")
 		}
-		r.l.Debug("init_dkg", "received_group")
-		return dkgGroup.group, dkgGroup.timeout, nil
+		r.l.Debug("init_dkg", "received_group", "dry_run", dkgGroup.dryRun)
+		return dkgGroup, nil
 	case <-r.clock.After(MaxWaitPrepareDKG):
 		r.l.Error("init_dkg", "wait_group", "err", "timeout")
-		return nil, 0, errors.New("wait_group timeouts from coordinator")
+		return nil, errors.New("wait_group timeouts from coordinator")
 	case <-ctx.Done():
-		return nil, 0, ctx.Err()
+		return nil, ctx.Err()
 	}
 }
 
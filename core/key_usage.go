@@ -0,0 +1,170 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
+	"github.com/drand/drand/protobuf/drand"
+)
+
+// KeyUsageFileName is the name of the file, in the db folder, where the number
+// of partial signatures produced with the share and the identity key of the
+// node is kept across restarts.
+const KeyUsageFileName = "key_usage.json"
+
+// KeyUsage counts the partial signatures produced with a key, for the
+// operators to enforce their key rotation policies.
+type KeyUsage struct {
+	// Fingerprint identifies the key: the hex encoded hash of its public part
+	Fingerprint string `json:"fingerprint"`
+	// Since is the time of the first partial signature produced with the key,
+	// in unix seconds
+	Since int64 `json:"since"`
+	// Signatures is the number of partial signatures produced with the key
+	Signatures uint64 `json:"signatures"`
+	// LastRound is the last round signed with the key
+	LastRound uint64 `json:"last_round"`
+}
+
+// count records a partial signature of the round with the key of the given
+// fingerprint. The count starts over when the key changes.
+func (u *KeyUsage) count(fingerprint string, round uint64, now int64) {
+	if u.Fingerprint != fingerprint {
+		*u = KeyUsage{Fingerprint: fingerprint, Since: now}
+	}
+	u.Signatures++
+	if round > u.LastRound {
+		u.LastRound = round
+	}
+}
+
+func (u *KeyUsage) toProto() *drand.KeyUsageCount {
+	return &drand.KeyUsageCount{
+		Fingerprint: u.Fingerprint,
+		Since:       u.Since,
+		Signatures:  u.Signatures,
+		LastRound:   u.LastRound,
+	}
+}
+
+// keyUsageRecord is the content of the key usage file.
+type keyUsageRecord struct {
+	Share    KeyUsage `json:"share"`
+	Identity KeyUsage `json:"identity"`
+}
+
+// keyUsage counts the partial signatures produced with the current share and
+// the identity key of the node, and saves the counts in the db folder after
+// each signature.
+type keyUsage struct {
+	sync.Mutex
+	path   string
+	record keyUsageRecord
+	// fingerprint of the last share seen, computed once
+	share            *key.Share
+	shareFingerprint string
+}
+
+// newKeyUsage loads the counts saved in the folder, if any.
+func newKeyUsage(folder string, l log.Logger) *keyUsage {
+	u := &keyUsage{path: path.Join(folder, KeyUsageFileName)}
+	buff, err := ioutil.ReadFile(u.path)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		l.Error("key_usage", "can't read the counts", "err", err)
+	default:
+		if err := json.Unmarshal(buff, &u.record); err != nil {
+			l.Error("key_usage", "can't decode the counts", "err", err)
+		}
+	}
+	u.updateMetrics()
+	return u
+}
+
+// signed counts the partial signature of the round produced with the share by
+// the node holding the identity. A share signing a round older than the last
+// one counted belongs to the epoch before a resharing: it only counts for the
+// identity key.
+func (u *keyUsage) signed(round uint64, ks *key.Share, id *key.Identity, now int64) error {
+	u.Lock()
+	defer u.Unlock()
+	if ks != u.share {
+		u.share = ks
+		u.shareFingerprint = shareFingerprint(ks)
+	}
+	if u.shareFingerprint == u.record.Share.Fingerprint || round > u.record.Share.LastRound {
+		u.record.Share.count(u.shareFingerprint, round, now)
+	}
+	u.record.Identity.count(identityFingerprint(id), round, now)
+	u.updateMetrics()
+	return u.save()
+}
+
+// usage returns the counts of the share and the identity key.
+func (u *keyUsage) usage() keyUsageRecord {
+	u.Lock()
+	defer u.Unlock()
+	return u.record
+}
+
+// save writes the counts to a temporary file renamed over the previous one, so
+// a crash can't leave a truncated file. It must be called with the lock held.
+func (u *keyUsage) save() error {
+	buff, err := json.Marshal(&u.record)
+	if err != nil {
+		return err
+	}
+	tmp := u.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, buff, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, u.path)
+}
+
+// updateMetrics must be called with the lock held.
+func (u *keyUsage) updateMetrics() {
+	metrics.KeySignatures.WithLabelValues("share").Set(float64(u.record.Share.Signatures))
+	metrics.KeySignatures.WithLabelValues("identity").Set(float64(u.record.Identity.Signatures))
+	metrics.KeyUsageSince.WithLabelValues("share").Set(float64(u.record.Share.Since))
+	metrics.KeyUsageSince.WithLabelValues("identity").Set(float64(u.record.Identity.Since))
+}
+
+// shareFingerprint returns the hash of the public share of the node.
+func shareFingerprint(ks *key.Share) string {
+	buff, _ := ks.PubPoly().Eval(ks.Share.I).V.MarshalBinary()
+	h := sha256.Sum256(buff)
+	return hex.EncodeToString(h[:])
+}
+
+// identityFingerprint returns the hash of the public key of the node.
+func identityFingerprint(id *key.Identity) string {
+	return hex.EncodeToString(id.Hash())
+}
+
+// partialSigned counts the partial signature of the round produced with the
+// share.
+func (d *Drand) partialSigned(round uint64, ks *key.Share) {
+	if err := d.keyUsage.signed(round, ks, d.priv.Public, d.opts.clock.Now().Unix()); err != nil {
+		d.log.Error("key_usage", "can't save the counts", "err", err)
+	}
+}
+
+// KeyUsage returns how many partial signatures the node produced with its
+// current share and identity key, and since when.
+func (d *Drand) KeyUsage(ctx context.Context, in *drand.KeyUsageRequest) (*drand.KeyUsageResponse, error) {
+	record := d.keyUsage.usage()
+	return &drand.KeyUsageResponse{
+		Share:    record.Share.toProto(),
+		Identity: record.Identity.toProto(),
+	}, nil
+}
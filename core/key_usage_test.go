@@ -0,0 +1,62 @@
+package core
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/test"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestShare() *key.Share {
+	priPoly := share.NewPriPoly(key.KeyGroup, 2, nil, random.New())
+	_, commits := priPoly.Commit(key.KeyGroup.Point().Base()).Info()
+	return &key.Share{Share: priPoly.Shares(3)[0], Commits: commits}
+}
+
+func TestKeyUsage(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "drand-key-usage")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+
+	pairs, _ := test.BatchIdentities(2)
+	id := pairs[0].Public
+	first, second := newTestShare(), newTestShare()
+
+	u := newKeyUsage(tmp, log.DefaultLogger())
+	require.NoError(t, u.signed(10, first, id, 1000))
+	require.NoError(t, u.signed(11, first, id, 1030))
+	record := u.usage()
+	require.Equal(t, shareFingerprint(first), record.Share.Fingerprint)
+	require.Equal(t, uint64(2), record.Share.Signatures)
+	require.Equal(t, int64(1000), record.Share.Since)
+	require.Equal(t, uint64(11), record.Share.LastRound)
+
+	// the counts are kept across restarts
+	u = newKeyUsage(tmp, log.DefaultLogger())
+	require.Equal(t, record, u.usage())
+
+	// the count of the share starts over after a resharing, while the share
+	// of the previous epoch signing an older round only counts for the
+	// identity key
+	require.NoError(t, u.signed(12, second, id, 1060))
+	require.NoError(t, u.signed(11, first, id, 1061))
+	record = u.usage()
+	require.Equal(t, shareFingerprint(second), record.Share.Fingerprint)
+	require.Equal(t, uint64(1), record.Share.Signatures)
+	require.Equal(t, int64(1060), record.Share.Since)
+	require.Equal(t, uint64(4), record.Identity.Signatures)
+	require.Equal(t, int64(1000), record.Identity.Since)
+
+	// a new identity key starts over
+	require.NoError(t, u.signed(13, second, pairs[1].Public, 1090))
+	record = u.usage()
+	require.Equal(t, identityFingerprint(pairs[1].Public), record.Identity.Fingerprint)
+	require.Equal(t, uint64(1), record.Identity.Signatures)
+	require.Equal(t, uint64(2), record.Share.Signatures)
+}
@@ -0,0 +1,255 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/metrics"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+)
+
+// MaxMaintenanceWindows is the maximum number of windows not over yet a node
+// keeps for each address, so another node can't fill its memory.
+const MaxMaintenanceWindows = 10
+
+// MaxMaintenanceDuration is the longest maintenance window accepted.
+const MaxMaintenanceDuration = 7 * 24 * time.Hour
+
+// maintenanceCheckPeriod is the time between two updates of the maintenance
+// metric.
+const maintenanceCheckPeriod = 10 * time.Second
+
+// maintenanceTimeout is the time given to each node to receive an announced
+// maintenance window.
+const maintenanceTimeout = 10 * time.Second
+
+// maintenanceWindows keeps the maintenance windows not over yet of this node
+// and of the nodes that announced theirs. They are kept in memory only.
+type maintenanceWindows struct {
+	sync.Mutex
+	windows []*drand.MaintenanceWindow
+}
+
+// add records the window, replacing the window of the same node starting at
+// the same time, if any. The windows over at the given time are dropped.
+func (m *maintenanceWindows) add(w *drand.MaintenanceWindow, now int64) error {
+	if err := checkMaintenanceWindow(w, now); err != nil {
+		return err
+	}
+	m.Lock()
+	defer m.Unlock()
+	m.prune(now)
+	count := 0
+	for i, prev := range m.windows {
+		if prev.GetAddress() != w.GetAddress() {
+			continue
+		}
+		if prev.GetStart() == w.GetStart() {
+			m.windows[i] = w
+			return nil
+		}
+		count++
+	}
+	if count >= MaxMaintenanceWindows {
+		return fmt.Errorf("drand: %s already has %d maintenance windows scheduled", w.GetAddress(), count)
+	}
+	m.windows = append(m.windows, w)
+	sort.SliceStable(m.windows, func(i, j int) bool { return m.windows[i].GetStart() < m.windows[j].GetStart() })
+	return nil
+}
+
+// prune drops the windows over. It must be called with the lock held.
+func (m *maintenanceWindows) prune(now int64) {
+	windows := m.windows[:0]
+	for _, w := range m.windows {
+		if w.GetEnd() > now {
+			windows = append(windows, w)
+		}
+	}
+	m.windows = windows
+}
+
+// list returns the windows not over at the given time, ordered by start.
+func (m *maintenanceWindows) list(now int64) []*drand.MaintenanceWindow {
+	m.Lock()
+	defer m.Unlock()
+	m.prune(now)
+	return append([]*drand.MaintenanceWindow{}, m.windows...)
+}
+
+// current returns the window the node at the address is in at the given
+// time, nil if none.
+func (m *maintenanceWindows) current(addr string, now int64) *drand.MaintenanceWindow {
+	m.Lock()
+	defer m.Unlock()
+	for _, w := range m.windows {
+		if w.GetAddress() == addr && w.GetStart() <= now && now < w.GetEnd() {
+			return w
+		}
+	}
+	return nil
+}
+
+// inMaintenance returns the addresses of the nodes in maintenance at the
+// given time.
+func (m *maintenanceWindows) inMaintenance(now int64) map[string]bool {
+	m.Lock()
+	defer m.Unlock()
+	addrs := make(map[string]bool)
+	for _, w := range m.windows {
+		if w.GetStart() <= now && now < w.GetEnd() {
+			addrs[w.GetAddress()] = true
+		}
+	}
+	return addrs
+}
+
+func checkMaintenanceWindow(w *drand.MaintenanceWindow, now int64) error {
+	switch {
+	case w.GetEnd() <= w.GetStart():
+		return errors.New("drand: maintenance window must end after it starts")
+	case w.GetEnd() <= now:
+		return errors.New("drand: maintenance window is already over")
+	case time.Duration(w.GetEnd()-w.GetStart())*time.Second > MaxMaintenanceDuration:
+		return fmt.Errorf("drand: maintenance window longer than %s", MaxMaintenanceDuration)
+	}
+	return nil
+}
+
+// maintenanceMessage returns the message the node signs to announce the
+// window.
+func maintenanceMessage(w *drand.MaintenanceWindow) []byte {
+	h := sha256.New()
+	_, _ = h.Write([]byte(w.GetAddress()))
+	_ = binary.Write(h, binary.BigEndian, w.GetStart())
+	_ = binary.Write(h, binary.BigEndian, w.GetEnd())
+	_, _ = h.Write([]byte(w.GetReason()))
+	return h.Sum(nil)
+}
+
+// watchMaintenance keeps the maintenance metric up to date as the windows
+// start and end. It returns a function stopping the watch.
+func (d *Drand) watchMaintenance() func() {
+	done := make(chan struct{})
+	go func() {
+		for {
+			d.updateMaintenanceMetric()
+			select {
+			case <-d.opts.clock.After(maintenanceCheckPeriod):
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// updateMaintenanceMetric sets the maintenance metric of the nodes of the
+// group, and of the nodes in maintenance not in the group.
+func (d *Drand) updateMaintenanceMetric() {
+	active := d.maintenance.inMaintenance(d.opts.clock.Now().Unix())
+	d.state.Lock()
+	group := d.group
+	d.state.Unlock()
+	metrics.Maintenance.Reset()
+	if group != nil {
+		for _, n := range group.Nodes {
+			metrics.Maintenance.WithLabelValues(n.Address()).Set(0)
+		}
+	}
+	for addr := range active {
+		metrics.Maintenance.WithLabelValues(addr).Set(1)
+	}
+}
+
+// ScheduleMaintenance declares a maintenance window of the node. The window
+// is signed and sent to the other nodes of the group if asked.
+func (d *Drand) ScheduleMaintenance(ctx context.Context, in *drand.ScheduleMaintenanceRequest) (*drand.ScheduleMaintenanceResponse, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
+	w := &drand.MaintenanceWindow{
+		Address: d.priv.Public.Address(),
+		Start:   in.GetStart(),
+		End:     in.GetEnd(),
+		Reason:  in.GetReason(),
+	}
+	sig, err := key.AuthScheme.Sign(d.priv.Key, maintenanceMessage(w))
+	if err != nil {
+		return nil, err
+	}
+	w.Signature = sig
+	if err := d.maintenance.add(w, d.opts.clock.Now().Unix()); err != nil {
+		return nil, err
+	}
+	d.log.Info("maintenance", "scheduled", "start", w.Start, "end", w.End, "reason", w.Reason)
+	d.updateMaintenanceMetric()
+
+	resp := new(drand.ScheduleMaintenanceResponse)
+	if !in.GetAnnounce() {
+		return resp, nil
+	}
+	d.state.Lock()
+	group := d.group
+	d.state.Unlock()
+	if group == nil {
+		return nil, errors.New("drand: no group to announce the maintenance to")
+	}
+	for _, n := range group.Nodes {
+		if n.Address() == d.priv.Public.Address() {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(ctx, maintenanceTimeout)
+		err := d.privGateway.ProtocolClient.AnnounceMaintenance(ctx, n.Identity, w)
+		cancel()
+		if err != nil {
+			d.log.Error("maintenance", "failed to announce", "to", n.Address(), "err", err)
+			resp.Failed = append(resp.Failed, n.Address())
+		}
+	}
+	return resp, nil
+}
+
+// ListMaintenance returns the maintenance windows not over yet.
+func (d *Drand) ListMaintenance(ctx context.Context, in *drand.ListMaintenanceRequest) (*drand.ListMaintenanceResponse, error) {
+	return &drand.ListMaintenanceResponse{Windows: d.maintenance.list(d.opts.clock.Now().Unix())}, nil
+}
+
+// AnnounceMaintenance receives the maintenance window of another node of the
+// group. The window must be signed by the key of the node.
+func (d *Drand) AnnounceMaintenance(ctx context.Context, in *drand.MaintenanceWindow) (*drand.Empty, error) {
+	d.state.Lock()
+	group := d.group
+	d.state.Unlock()
+	if group == nil {
+		return nil, errors.New("drand: no group")
+	}
+	var node *key.Node
+	for _, n := range group.Nodes {
+		if n.Address() == in.GetAddress() {
+			node = n
+			break
+		}
+	}
+	if node == nil {
+		return nil, fmt.Errorf("drand: %s is not a node of the group", in.GetAddress())
+	}
+	if err := key.AuthScheme.Verify(node.Key, maintenanceMessage(in), in.GetSignature()); err != nil {
+		return nil, fmt.Errorf("drand: invalid signature of the maintenance window: %s", err)
+	}
+	if err := d.maintenance.add(in, d.opts.clock.Now().Unix()); err != nil {
+		return nil, err
+	}
+	d.log.Info("maintenance", "announced", "by", in.GetAddress(), "from", net.RemoteAddress(ctx),
+		"start", in.GetStart(), "end", in.GetEnd(), "reason", in.GetReason())
+	d.updateMaintenanceMetric()
+	return new(drand.Empty), nil
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/net"
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMaintenanceWindows(t *testing.T) {
+	m := new(maintenanceWindows)
+	now := int64(1000)
+	window := func(addr string, start, end int64) *drand.MaintenanceWindow {
+		return &drand.MaintenanceWindow{Address: addr, Start: start, End: end}
+	}
+	require.Error(t, m.add(window("a", 1100, 1100), now))
+	require.Error(t, m.add(window("a", 900, 1000), now))
+	require.Error(t, m.add(window("a", now, now+int64(MaxMaintenanceDuration.Seconds())+1), now))
+
+	require.NoError(t, m.add(window("a", 1100, 1200), now))
+	require.NoError(t, m.add(window("b", 900, 1050), now))
+	// the window of a node starting at the same time is replaced
+	require.NoError(t, m.add(window("a", 1100, 1300), now))
+	windows := m.list(now)
+	require.Len(t, windows, 2)
+	require.Equal(t, "b", windows[0].GetAddress())
+	require.Equal(t, int64(1300), windows[1].GetEnd())
+
+	require.NotNil(t, m.current("b", now))
+	require.Nil(t, m.current("a", now))
+	require.Equal(t, map[string]bool{"b": true}, m.inMaintenance(now))
+	require.Equal(t, map[string]bool{"a": true}, m.inMaintenance(1100))
+	// windows over are dropped
+	require.Len(t, m.list(1100), 1)
+
+	for i := 1; i < MaxMaintenanceWindows; i++ {
+		require.NoError(t, m.add(window("a", 1100+int64(i), 1200), now))
+	}
+	require.Error(t, m.add(window("a", 2000, 2100), now))
+	require.NoError(t, m.add(window("b", 2000, 2100), now))
+}
+
+func TestMaintenanceAnnounce(t *testing.T) {
+	n := 3
+	dt := NewDrandTest2(t, n, key.DefaultThreshold(n), time.Second)
+	defer dt.Cleanup()
+
+	ids := make([]*key.Identity, n)
+	for i, node := range dt.nodes {
+		ids[i] = node.drand.priv.Public
+	}
+	group := key.NewGroup(ids, key.DefaultThreshold(n), dt.Now().Unix()+100, time.Second, 0)
+	for _, node := range dt.nodes {
+		node.drand.state.Lock()
+		node.drand.group = group
+		node.drand.state.Unlock()
+	}
+
+	maintained := dt.nodes[0].drand
+	ctrl, err := net.NewControlClient(maintained.opts.controlPort)
+	require.NoError(t, err)
+	start := dt.Now().Unix()
+	resp, err := ctrl.ScheduleMaintenance(start, start+3600, "upgrade", true)
+	require.NoError(t, err)
+	require.Empty(t, resp.GetFailed())
+
+	status, err := ctrl.Status()
+	require.NoError(t, err)
+	require.Equal(t, "upgrade", status.GetMaintenance().GetReason())
+
+	other := dt.nodes[1].drand
+	list, err := other.ListMaintenance(context.Background(), new(drand.ListMaintenanceRequest))
+	require.NoError(t, err)
+	require.Len(t, list.GetWindows(), 1)
+	require.Equal(t, maintained.priv.Public.Address(), list.GetWindows()[0].GetAddress())
+	require.True(t, other.maintenance.inMaintenance(dt.Now().Unix())[maintained.priv.Public.Address()])
+
+	// a window not signed by the node is refused
+	forged := &drand.MaintenanceWindow{
+		Address: dt.nodes[2].drand.priv.Public.Address(),
+		Start:   start,
+		End:     start + 3600,
+	}
+	forged.Signature, err = key.AuthScheme.Sign(maintained.priv.Key, maintenanceMessage(forged))
+	require.NoError(t, err)
+	_, err = other.AnnounceMaintenance(context.Background(), forged)
+	require.Error(t, err)
+}
@@ -0,0 +1,78 @@
+package core
+
+import (
+	"github.com/drand/drand/chain"
+	"github.com/drand/drand/chain/snapshot"
+)
+
+// uploadSnapshots extends the snapshot of the chain in the configured bucket
+// with the new rounds at every snapshot interval, while the node runs a
+// beacon. It returns a function stopping the uploads.
+func (d *Drand) uploadSnapshots() func() {
+	interval := d.opts.snapshotInterval
+	if interval == 0 {
+		interval = DefaultSnapshotInterval
+	}
+	done := make(chan struct{})
+	go func() {
+		var uploader *snapshot.Uploader
+		for {
+			select {
+			case <-d.opts.clock.After(interval):
+			case <-done:
+				return
+			}
+			d.state.Lock()
+			b := d.beacon
+			d.state.Unlock()
+			if b == nil {
+				continue
+			}
+			if uploader == nil {
+				uploader = snapshot.NewUploader(d.opts.snapshotBucket, b.ChainHash(), d.log)
+			}
+			n, err := uploader.Upload(d.ctx, b.Store())
+			if err != nil {
+				d.log.Error("snapshot", "upload failed", "beacons", n, "err", err)
+				continue
+			}
+			if n > 0 {
+				d.log.Info("snapshot", "uploaded", "beacons", n)
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// bootstrap imports the snapshot of the chain of the group from the bootstrap
+// bucket if no beacon is stored yet.
+func (d *Drand) bootstrap() {
+	d.state.Lock()
+	info := chain.NewChainInfo(d.group)
+	store, err := d.createStore()
+	d.state.Unlock()
+	if err != nil {
+		d.log.Error("bootstrap", "unable to create store", "err", err)
+		return
+	}
+	defer store.Close()
+	d.importSnapshot(store, info)
+}
+
+// importSnapshot stores the beacons of the snapshot in the bootstrap bucket if
+// the store holds no beacon but the genesis one. The beacons verified before
+// an error are kept, the rest of the chain is synced from the nodes.
+func (d *Drand) importSnapshot(store chain.Store, info *chain.Info) {
+	if last, err := store.Last(); err == nil && last.Round > 0 {
+		return
+	}
+	d.log.Info("bootstrap", "importing snapshot")
+	n, err := snapshot.Import(d.ctx, d.opts.bootstrapBucket, store, info, func(round uint64) {
+		d.log.Info("bootstrap", "imported", "round", round)
+	})
+	if err != nil {
+		d.log.Error("bootstrap", "import failed", "beacons", n, "err", err)
+		return
+	}
+	d.log.Info("bootstrap", "done", "beacons", n)
+}
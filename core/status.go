@@ -0,0 +1,139 @@
+package core
+
+import (
+	"context"
+	"errors"
+
+	"github.com/drand/drand/key"
+	"github.com/drand/drand/protobuf/drand"
+)
+
+// setStatus records the state the node enters. It must be called with the
+// state lock held.
+func (d *Drand) setStatus(s drand.NodeState) {
+	if d.status == s {
+		return
+	}
+	d.log.Info("node_state", s.String(), "previous", d.status.String())
+	d.status = s
+	d.statusSince = d.opts.clock.Now().Unix()
+}
+
+// updateStatus is setStatus taking the state lock.
+func (d *Drand) updateStatus(s drand.NodeState) {
+	d.state.Lock()
+	defer d.state.Unlock()
+	d.setStatus(s)
+}
+
+// runningStatus returns the state of the node running the beacon, paused or
+// not. It must be called with the state lock held.
+func (d *Drand) runningStatus() drand.NodeState {
+	if d.paused {
+		return drand.NodeState_STATE_PAUSED
+	}
+	return drand.NodeState_STATE_BEACON_RUNNING
+}
+
+// running records that the node runs the beacon, paused or not.
+func (d *Drand) running() {
+	d.state.Lock()
+	defer d.state.Unlock()
+	d.setStatus(d.runningStatus())
+}
+
+// hasShare returns true if the node went through a DKG and holds a share of
+// a group, whatever it does with it now. It must be called with the state
+// lock held.
+func (d *Drand) hasShare() bool {
+	switch d.status {
+	case drand.NodeState_STATE_FRESH, drand.NodeState_STATE_DKG_IN_PROGRESS:
+		return false
+	case drand.NodeState_STATE_SYNCING, drand.NodeState_STATE_RESHARING, drand.NodeState_STATE_STOPPED:
+		// a node syncing or resharing may be joining a group
+		return d.share != nil
+	default:
+		return true
+	}
+}
+
+// Status returns the state of the node and since when it is in that state.
+func (d *Drand) Status(ctx context.Context, in *drand.StatusRequest) (*drand.StatusResponse, error) {
+	d.state.Lock()
+	defer d.state.Unlock()
+	resp := &drand.StatusResponse{
+		State:       d.status,
+		Since:       d.statusSince,
+		Maintenance: d.maintenance.current(d.priv.Public.Address(), d.opts.clock.Now().Unix()),
+		Crypto:      CryptoConfig(d.group),
+	}
+	// the beacon started after a DKG runs from the genesis of the chain
+	if d.status == drand.NodeState_STATE_DKG_DONE && d.beacon != nil &&
+		d.opts.clock.Now().Unix() >= d.group.GenesisTime {
+		resp.State = d.runningStatus()
+		resp.Since = d.group.GenesisTime
+	}
+	return resp, nil
+}
+
+// CryptoConfig returns the cryptographic primitives used by a node of the
+// group. The default digest is reported while the node has no group.
+func CryptoConfig(group *key.Group) *drand.CryptoConfig {
+	var digest string
+	if group != nil {
+		digest = group.Digest
+	}
+	config := &drand.CryptoConfig{Fips: key.FIPSMode()}
+	for _, p := range key.Primitives(digest) {
+		config.Primitives = append(config.Primitives, &drand.CryptoPrimitive{
+			Usage:    p.Usage,
+			Name:     p.Name,
+			Approved: p.Approved,
+		})
+	}
+	return config
+}
+
+// Pause stops the node from sending its partials until Resume is called. The
+// node keeps aggregating the partials of the other nodes and syncing the
+// chain.
+func (d *Drand) Pause(ctx context.Context, in *drand.PauseRequest) (*drand.PauseResponse, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
+	d.state.Lock()
+	defer d.state.Unlock()
+	if d.beacon == nil {
+		return nil, errors.New("drand: beacon is not running")
+	}
+	d.paused = true
+	d.beacon.SetPaused(true)
+	if d.status != drand.NodeState_STATE_SYNCING {
+		d.setStatus(drand.NodeState_STATE_PAUSED)
+	}
+	return new(drand.PauseResponse), nil
+}
+
+// Resume makes a paused node send its partials again from the next round.
+func (d *Drand) Resume(ctx context.Context, in *drand.ResumeRequest) (*drand.ResumeResponse, error) {
+	if err := d.checkWritable(); err != nil {
+		return nil, err
+	}
+	d.state.Lock()
+	defer d.state.Unlock()
+	if !d.paused {
+		return nil, errors.New("drand: node is not paused")
+	}
+	d.paused = false
+	if d.beacon != nil {
+		d.beacon.SetPaused(false)
+	}
+	if d.status == drand.NodeState_STATE_PAUSED {
+		if d.opts.clock.Now().Unix() < d.group.GenesisTime {
+			d.setStatus(drand.NodeState_STATE_DKG_DONE)
+		} else {
+			d.setStatus(drand.NodeState_STATE_BEACON_RUNNING)
+		}
+	}
+	return new(drand.ResumeResponse), nil
+}
@@ -0,0 +1,95 @@
+package core
+
+import (
+	"os"
+	"path"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/fs"
+	"github.com/drand/drand/key"
+	pdkg "github.com/drand/drand/protobuf/crypto/dkg"
+	"github.com/drand/kyber/share/dkg"
+	json "github.com/nikkolasg/hexjson"
+)
+
+// TranscriptFileName is the name of the file, in the group folder, where the
+// transcript of the latest DKG is saved.
+const TranscriptFileName = "dkg_transcript.json"
+
+// DKGTranscript is the record of all the packets a node processed during a
+// DKG, with its outcome. All the packets are signed by their issuers and deals
+// are encrypted, so the transcript can be handed to a third party auditing
+// how the distributed key was generated.
+type DKGTranscript struct {
+	// Time is the time at which the DKG finished
+	Time int64 `json:"time"`
+	// GroupHash is the hash of the resulting group
+	GroupHash []byte `json:"group_hash"`
+	// QUAL are the indexes of the qualified nodes
+	QUAL           []uint32                    `json:"qual"`
+	Deals          []*pdkg.DealBundle          `json:"deals"`
+	Responses      []*pdkg.ResponseBundle      `json:"responses"`
+	Justifications []*pdkg.JustificationBundle `json:"justifications"`
+}
+
+// transcript accumulates the packets passed to the DKG protocol
+type transcript struct {
+	sync.Mutex
+	t DKGTranscript
+}
+
+func (t *transcript) record(p packet) {
+	proto, err := dkgPacketToProto(p)
+	if err != nil {
+		return
+	}
+	t.Lock()
+	defer t.Unlock()
+	switch b := proto.GetBundle().(type) {
+	case *pdkg.Packet_Deal:
+		t.t.Deals = append(t.t.Deals, b.Deal)
+	case *pdkg.Packet_Response:
+		t.t.Responses = append(t.t.Responses, b.Response)
+	case *pdkg.Packet_Justification:
+		t.t.Justifications = append(t.t.Justifications, b.Justification)
+	}
+}
+
+// finish returns the transcript completed with the outcome of the DKG
+func (t *transcript) finish(qual []dkg.Node, group *key.Group) *DKGTranscript {
+	t.Lock()
+	defer t.Unlock()
+	res := t.t
+	res.Time = time.Now().Unix()
+	res.GroupHash = group.Hash()
+	for _, n := range qual {
+		res.QUAL = append(res.QUAL, n.Index)
+	}
+	return &res
+}
+
+// SaveTranscript writes the transcript in the group folder of the config
+// folder.
+func SaveTranscript(configFolder string, t *DKGTranscript) error {
+	folder := fs.CreateSecureFolder(path.Join(configFolder, key.GroupFolderName))
+	fd, err := os.Create(path.Join(folder, TranscriptFileName))
+	if err != nil {
+		return err
+	}
+	defer fd.Close()
+	enc := json.NewEncoder(fd)
+	enc.SetIndent("", "  ")
+	return enc.Encode(t)
+}
+
+// LoadTranscript reads the transcript of the latest DKG from the config folder.
+func LoadTranscript(configFolder string) (*DKGTranscript, error) {
+	fd, err := os.Open(path.Join(configFolder, key.GroupFolderName, TranscriptFileName))
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+	t := new(DKGTranscript)
+	return t, json.NewDecoder(fd).Decode(t)
+}
@@ -59,6 +59,15 @@ type DrandTest2 struct {
 	// nodes that actually ran the resharing phase - it's a combination of nodes
 	// and new nodes. These are the one that should appear in the newGroup
 	resharedNodes []*Node
+	// dryRun makes RunReshare run a dry run resharing
+	dryRun bool
+	// newPeriod is the period RunReshare changes the chain to, if not zero
+	newPeriod time.Duration
+	// dkgProgress is passed the progress of the DKG of the leader in RunDKG and
+	// RunReshare
+	dkgProgress net.DKGProgressFunc
+	// digest derives the randomness of the chain created by RunDKG
+	digest string
 }
 
 // NewDrandTest creates a drand test scenario with initial n nodes and ready to
@@ -111,7 +120,7 @@ func (d *DrandTest2) RunDKG() *key.Group {
 	wg.Add(d.n)
 	// first run the leader and then run the other nodes
 	go func() {
-		_, err := controlClient.InitDKGLeader(d.n, d.thr, d.period, d.catchupPeriod, testDkgTimeout, nil, secret, testBeaconOffset)
+		_, err := controlClient.InitDKGLeader(d.n, d.thr, d.period, d.catchupPeriod, testDkgTimeout, nil, secret, testBeaconOffset, false, d.digest, d.dkgProgress)
 		require.NoError(d.t, err)
 		fmt.Printf("\n\nTEST LEADER FINISHED\n\n")
 		wg.Done()
@@ -125,7 +134,7 @@ func (d *DrandTest2) RunDKG() *key.Group {
 		go func(n *Node) {
 			client, err := net.NewControlClient(n.drand.opts.controlPort)
 			require.NoError(d.t, err)
-			_, err = client.InitDKG(root.drand.priv.Public, nil, secret)
+			_, err = client.InitDKG(root.drand.priv.Public, nil, secret, nil)
 			require.NoError(d.t, err)
 			fmt.Printf("\n\nTEST NONLEADER FINISHED\n\n")
 			wg.Done()
@@ -345,7 +354,7 @@ func (d *DrandTest2) RunReshare(oldRun, newRun, newThr int, timeout time.Duratio
 		// instruct to be ready for a reshare
 		client, err := net.NewControlClient(n.drand.opts.controlPort)
 		require.NoError(d.t, err)
-		_, err = client.InitReshare(leader.drand.priv.Public, secret, d.groupPath, force)
+		_, err = client.InitReshare(leader.drand.priv.Public, secret, d.groupPath, force, 0, d.dryRun, d.newPeriod, nil)
 		if err != nil {
 			errCh <- err
 			return
@@ -362,7 +371,7 @@ func (d *DrandTest2) RunReshare(oldRun, newRun, newThr int, timeout time.Duratio
 		// old root: oldNode.Index leater: leader.addr
 		client, err := net.NewControlClient(leader.drand.opts.controlPort)
 		require.NoError(d.t, err)
-		finalGroup, err := client.InitReshareLeader(d.newN, d.newThr, timeout, 0, secret, "", testBeaconOffset)
+		finalGroup, err := client.InitReshareLeader(d.newN, d.newThr, timeout, 0, secret, "", testBeaconOffset, false, 0, d.dryRun, d.newPeriod, d.dkgProgress)
 		// Done resharing
 		if err != nil {
 			errCh <- err
@@ -395,6 +404,9 @@ func (d *DrandTest2) RunReshare(oldRun, newRun, newThr int, timeout time.Duratio
 	// wait for the return of the clients
 	select {
 	case finalGroup := <-groupCh:
+		if d.dryRun {
+			return finalGroup, nil
+		}
 		d.newGroup = finalGroup
 		require.NoError(d.t, key.Save(d.groupPath, d.newGroup, false))
 		return finalGroup, nil
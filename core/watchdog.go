@@ -0,0 +1,67 @@
+package core
+
+import (
+	"bytes"
+	"sync/atomic"
+	"time"
+
+	"github.com/drand/drand/chain/beacon"
+	"github.com/drand/drand/metrics"
+)
+
+// watchBeacon restarts the beacon loop if it stops handling rounds, e.g.
+// because it panicked or is blocked, while the daemon keeps running. A stall
+// must be seen at two consecutive checks before the beacon is restarted. It
+// returns a function stopping the watchdog.
+func (d *Drand) watchBeacon() func() {
+	done := make(chan struct{})
+	go func() {
+		var suspect *beacon.Handler
+		for {
+			period := time.Duration(atomic.LoadInt64(&d.period))
+			if period == 0 {
+				period = haltCheckPeriod
+			}
+			select {
+			case <-d.opts.clock.After(period):
+			case <-done:
+				return
+			}
+			d.state.Lock()
+			b := d.beacon
+			d.state.Unlock()
+			if b == nil || !b.Stalled(watchdogRounds) {
+				suspect = nil
+				continue
+			}
+			if suspect != b {
+				suspect = b
+				continue
+			}
+			suspect = nil
+			d.restartBeacon(b)
+		}
+	}()
+	return func() { close(done) }
+}
+
+// restartBeacon logs a diagnostic dump of the stalled beacon and replaces it
+// with a new one catching up with the network.
+func (d *Drand) restartBeacon(b *beacon.Handler) {
+	var dump bytes.Buffer
+	b.Dump(&dump)
+	d.log.Error("beacon_watchdog", "beacon loop stalled", "dump", dump.String())
+
+	d.state.Lock()
+	pending := d.group.TransitionTime > d.opts.clock.Now().Unix()
+	d.state.Unlock()
+	if pending {
+		// restarting would run the new group before the transition
+		d.log.Error("beacon_watchdog", "transition pending, not restarting")
+		return
+	}
+	metrics.BeaconRestarts.Inc()
+	d.StopBeacon()
+	d.StartBeacon(true)
+	d.log.Info("beacon_watchdog", "beacon restarted")
+}
@@ -153,10 +153,10 @@ func (l *LocalNode) RunDKG(nodes, thr int, timeout string, leader bool, leaderAd
 	var grp *drand.GroupPacket
 	var err error
 	if leader {
-		grp, err = cl.InitDKGLeader(nodes, thr, p, 0, t, nil, secretDKG, beaconOffset)
+		grp, err = cl.InitDKGLeader(nodes, thr, p, 0, t, nil, secretDKG, beaconOffset, false, "", nil)
 	} else {
 		leader := net.CreatePeer(leaderAddr, l.tls)
-		grp, err = cl.InitDKG(leader, nil, secretDKG)
+		grp, err = cl.InitDKG(leader, nil, secretDKG, nil)
 	}
 	if err != nil {
 		l.log.Error("drand", "dkg run failed", "err", err)
@@ -189,10 +189,10 @@ func (l *LocalNode) RunReshare(nodes, thr int, oldGroup string, timeout string,
 	var grp *drand.GroupPacket
 	var err error
 	if leader {
-		grp, err = cl.InitReshareLeader(nodes, thr, t, 0, secretReshare, oldGroup, beaconOffset)
+		grp, err = cl.InitReshareLeader(nodes, thr, t, 0, secretReshare, oldGroup, beaconOffset, false, 0, false, 0, nil)
 	} else {
 		leader := net.CreatePeer(leaderAddr, l.tls)
-		grp, err = cl.InitReshare(leader, secretReshare, oldGroup, false)
+		grp, err = cl.InitReshare(leader, secretReshare, oldGroup, false, 0, false, 0, nil)
 	}
 	if err != nil {
 		l.log.Error("drand", "reshare failed", "err", err)
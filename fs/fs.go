@@ -9,8 +9,9 @@ import (
 	"path"
 )
 
-const defaultDirectoryPermission = 0740
+const defaultDirectoryPermission = 0700
 const rwFilePermission = 0600
+const publicFilePermission = 0644
 
 // HomeFolder returns the home folder of the current user.
 func HomeFolder() string {
@@ -38,7 +39,10 @@ func CreateSecureFolder(folder string) string {
 		}
 		perm := int(info.Mode().Perm())
 		if perm != int(defaultDirectoryPermission) {
-			fmt.Printf("Folder different permission: %#o vs %#o \n", perm, defaultDirectoryPermission)
+			fmt.Printf("Folder different permission: %#o vs %#o, restricting it\n", perm, defaultDirectoryPermission)
+			if err := os.Chmod(folder, defaultDirectoryPermission); err != nil {
+				fmt.Println("Error restricting folder permission: ", err)
+			}
 			return folder
 		}
 	}
@@ -71,6 +75,20 @@ func CreateSecureFile(file string) (*os.File, error) {
 	return os.OpenFile(file, os.O_RDWR, rwFilePermission)
 }
 
+// CreatePublicFile creates a file readable by everyone but writable by the
+// user only, whatever the umask, and returns the file handle.
+func CreatePublicFile(file string) (*os.File, error) {
+	fd, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	if err := fd.Chmod(publicFilePermission); err != nil {
+		fd.Close()
+		return nil, err
+	}
+	return fd, nil
+}
+
 // Files returns the list of file names included in the given path or error if
 // any.
 func Files(folderPath string) ([]string, error) {
@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"errors"
 	"os"
 	"path"
 	"testing"
@@ -28,7 +29,16 @@ func TestSecureDirAlreadyHere(t *testing.T) {
 	f, err := CreateSecureFile(file)
 	require.NotNil(t, f)
 	require.NoError(t, err)
-	file2 := path.Join(tmpPath, "secured")
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(rwFilePermission), info.Mode().Perm())
+	file2 := path.Join(tmpPath, "public")
+	f, err = CreatePublicFile(file2)
+	require.NoError(t, err)
+	f.Close()
+	info, err = os.Stat(file2)
+	require.NoError(t, err)
+	require.Equal(t, os.FileMode(publicFilePermission), info.Mode().Perm())
 
 	files, err := Files(tmpPath)
 	require.NoError(t, err)
@@ -47,3 +57,22 @@ func TestSecureDirAlreadyHere(t *testing.T) {
 		require.True(t, FileExists(tmpPath, f))
 	}
 }
+
+func TestLockFolder(t *testing.T) {
+	tmpPath := path.Join(os.TempDir(), "lockconfig")
+	os.Mkdir(tmpPath, 0700)
+	defer os.RemoveAll(tmpPath)
+
+	lock, err := LockFolder(tmpPath)
+	require.NoError(t, err)
+	_, err = LockFolder(tmpPath)
+	require.Error(t, err)
+	var locked *LockedError
+	require.True(t, errors.As(err, &locked))
+	require.Equal(t, os.Getpid(), locked.PID)
+
+	require.NoError(t, lock.Unlock())
+	lock, err = LockFolder(tmpPath)
+	require.NoError(t, err)
+	require.NoError(t, lock.Unlock())
+}
@@ -0,0 +1,84 @@
+package fs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// LockFileName is the name of the file used to hold the lock on a folder. The
+// file holds the PID of the process holding the lock.
+const LockFileName = ".lock"
+
+// Lock is an exclusive lock held on a folder by the current process.
+type Lock struct {
+	f *os.File
+}
+
+// LockedError is returned by LockFolder when the folder is locked by another
+// process.
+type LockedError struct {
+	Folder string
+	// PID is the PID of the process holding the lock, zero if unknown
+	PID int
+	Err error
+}
+
+func (e *LockedError) Error() string {
+	if e.PID == 0 {
+		return fmt.Sprintf("folder %s is already in use by another process: %v", e.Folder, e.Err)
+	}
+	return fmt.Sprintf("folder %s is already in use by the process %d: %v", e.Folder, e.PID, e.Err)
+}
+
+func (e *LockedError) Unwrap() error {
+	return e.Err
+}
+
+// LockFolder takes an exclusive lock on the given folder so that two processes
+// can not modify its content concurrently. It returns a *LockedError if the
+// lock is already held by someone else.
+func LockFolder(folder string) (*Lock, error) {
+	name := path.Join(folder, LockFileName)
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_RDWR, rwFilePermission)
+	if err != nil {
+		return nil, err
+	}
+	if err := lockFile(f); err != nil {
+		f.Close()
+		return nil, &LockedError{Folder: folder, PID: lockHolder(name), Err: err}
+	}
+	// the PID is informative only, failing to write it doesn't matter
+	if err := f.Truncate(0); err == nil {
+		_, _ = f.WriteAt([]byte(strconv.Itoa(os.Getpid())), 0)
+	}
+	return &Lock{f: f}, nil
+}
+
+// lockHolder returns the PID written in the lock file, or zero.
+func lockHolder(name string) int {
+	buff, err := ioutil.ReadFile(name)
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(buff)))
+	if err != nil {
+		return 0
+	}
+	return pid
+}
+
+// Unlock releases the lock on the folder.
+func (l *Lock) Unlock() error {
+	// clear the PID before releasing the lock, not after, so it can't erase
+	// the PID of the next holder
+	_ = l.f.Truncate(0)
+	if err := unlockFile(l.f); err != nil {
+		l.f.Close()
+		return err
+	}
+	return l.f.Close()
+}
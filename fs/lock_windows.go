@@ -0,0 +1,16 @@
+//go:build windows
+// +build windows
+
+package fs
+
+import "os"
+
+// advisory locking is not implemented on windows: the lock file is created but
+// concurrent processes are not prevented from using the same folder.
+func lockFile(f *os.File) error {
+	return nil
+}
+
+func unlockFile(f *os.File) error {
+	return nil
+}
@@ -0,0 +1,78 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// corsMaxAge is how long browsers may cache the answer to a preflight request
+const corsMaxAge = 3600
+
+// Option is a function that configures the HTTP handler.
+type Option func(*handler)
+
+// WithCORS restricts the origins allowed to call the API from a browser, and
+// adds headers browsers are allowed to send in their requests. Each element may
+// be a comma separated list, as given on the command line. An origin of "*"
+// allows every origin, which is the default.
+func WithCORS(origins, headers []string) Option {
+	return func(h *handler) {
+		if origins = splitCORSList(origins); len(origins) > 0 {
+			h.corsOrigins = origins
+		}
+		h.corsHeaders = splitCORSList(headers)
+	}
+}
+
+// splitCORSList splits the comma separated elements of the list, ignoring the
+// empty ones.
+func splitCORSList(list []string) []string {
+	var out []string
+	for _, l := range list {
+		for _, e := range strings.Split(l, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				out = append(out, e)
+			}
+		}
+	}
+	return out
+}
+
+// allowedOrigin returns the value of the Access-Control-Allow-Origin header
+// for the given request origin, or an empty string if it is not allowed.
+func (h *handler) allowedOrigin(origin string) string {
+	for _, o := range h.corsOrigins {
+		if o == "*" {
+			return "*"
+		}
+		if origin != "" && strings.EqualFold(o, origin) {
+			return origin
+		}
+	}
+	return ""
+}
+
+// setCORSHeaders sets the CORS headers of the response and reports whether
+// the request is a preflight request that has been fully answered.
+func (h *handler) setCORSHeaders(w http.ResponseWriter, r *http.Request) bool {
+	// the response depends on the origin whether it is allowed or not, so
+	// that caches don't serve it to other origins
+	w.Header().Add("Vary", "Origin")
+	allowed := h.allowedOrigin(r.Header.Get("Origin"))
+	if allowed != "" {
+		w.Header().Set("Access-Control-Allow-Origin", allowed)
+	}
+	if r.Method != http.MethodOptions || r.Header.Get("Access-Control-Request-Method") == "" {
+		return false
+	}
+	if allowed != "" {
+		w.Header().Set("Access-Control-Allow-Methods", "GET, HEAD, OPTIONS")
+		if len(h.corsHeaders) > 0 {
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(h.corsHeaders, ", "))
+		}
+		w.Header().Set("Access-Control-Max-Age", strconv.Itoa(corsMaxAge))
+	}
+	w.WriteHeader(http.StatusNoContent)
+	return true
+}
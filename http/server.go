@@ -3,6 +3,9 @@ package http
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"net/http"
@@ -29,10 +32,15 @@ const (
 var (
 	// Timeout for how long to wait for the drand.PublicClient before timing out
 	reqTimeout = 5 * time.Second
+	// chainInfoMaxAge is how long the chain info is cached before being
+	// fetched again. A resharing can change the period or the message format
+	// of the chain from its transition round, and the new info is published
+	// at least a resharing offset before that round.
+	chainInfoMaxAge = 10 * time.Second
 )
 
 // New creates an HTTP handler for the public Drand API
-func New(ctx context.Context, c client.Client, version string, logger log.Logger) (http.Handler, error) {
+func New(ctx context.Context, c client.Client, version string, logger log.Logger, opts ...Option) (http.Handler, error) {
 	if logger == nil {
 		logger = log.DefaultLogger()
 	}
@@ -45,14 +53,19 @@ func New(ctx context.Context, c client.Client, version string, logger log.Logger
 		context:     ctx,
 		latestRound: 0,
 		version:     version,
+		corsOrigins: []string{"*"},
+	}
+	for _, opt := range opts {
+		opt(&handler)
 	}
 
 	mux := http.NewServeMux()
-	//TODO: aggregated bulk round responses.
-	mux.HandleFunc("/public/latest", withCommonHeaders(version, handler.LatestRand))
-	mux.HandleFunc("/public/", withCommonHeaders(version, handler.PublicRand))
-	mux.HandleFunc("/info", withCommonHeaders(version, handler.ChainInfo))
-	mux.HandleFunc("/health", withCommonHeaders(version, handler.Health))
+	mux.HandleFunc("/public/latest", handler.withCommonHeaders(handler.LatestRand))
+	mux.HandleFunc("/public/range", handler.withCommonHeaders(handler.RandRange))
+	mux.HandleFunc("/public/", handler.withCommonHeaders(handler.PublicRand))
+	mux.HandleFunc("/info", handler.withCommonHeaders(handler.ChainInfo))
+	mux.HandleFunc("/info/epochs", handler.withCommonHeaders(handler.Epochs))
+	mux.HandleFunc("/health", handler.withCommonHeaders(handler.Health))
 
 	instrumented := promhttp.InstrumentHandlerCounter(
 		metrics.HTTPCallCounter,
@@ -64,22 +77,32 @@ func New(ctx context.Context, c client.Client, version string, logger log.Logger
 	return instrumented, nil
 }
 
-func withCommonHeaders(version string, h func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+func (h *handler) withCommonHeaders(next func(http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Server", version)
+		w.Header().Set("Server", h.version)
+		if h.setCORSHeaders(w, r) {
+			return
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		h(w, r)
+		next(w, r)
 	}
 }
 
+// etag returns a strong entity tag for the given response body. Beacons being
+// immutable, the same round always gets the same tag on every node and relay.
+func etag(data []byte) string {
+	h := sha256.Sum256(data)
+	return fmt.Sprintf("%q", hex.EncodeToString(h[:16]))
+}
+
 type handler struct {
 	timeout time.Duration
 	client  client.Client
 	// NOTE: should only be accessed via getChainInfo
-	chainInfo   *chain.Info
-	chainInfoLk sync.RWMutex
-	log         log.Logger
+	chainInfo        *chain.Info
+	chainInfoFetched time.Time
+	chainInfoLk      sync.RWMutex
+	log              log.Logger
 
 	// synchronization for blocking writes until randomness available.
 	pendingLk   sync.RWMutex
@@ -88,6 +111,10 @@ type handler struct {
 	context     context.Context
 	latestRound uint64
 	version     string
+
+	// CORS configuration
+	corsOrigins []string
+	corsHeaders []string
 }
 
 func (h *handler) start() {
@@ -147,9 +174,12 @@ RESET:
 	}
 }
 
+// getChainInfo returns the chain info, fetched again once it is older than
+// chainInfoMaxAge. The previous info is kept if the new one can't be fetched or
+// is not a valid update of it.
 func (h *handler) getChainInfo(ctx context.Context) *chain.Info {
 	h.chainInfoLk.RLock()
-	if h.chainInfo != nil {
+	if h.chainInfo != nil && time.Since(h.chainInfoFetched) < chainInfoMaxAge {
 		info := h.chainInfo
 		h.chainInfoLk.RUnlock()
 		return info
@@ -158,7 +188,7 @@ func (h *handler) getChainInfo(ctx context.Context) *chain.Info {
 
 	h.chainInfoLk.Lock()
 	defer h.chainInfoLk.Unlock()
-	if h.chainInfo != nil {
+	if h.chainInfo != nil && time.Since(h.chainInfoFetched) < chainInfoMaxAge {
 		return h.chainInfo
 	}
 
@@ -167,13 +197,20 @@ func (h *handler) getChainInfo(ctx context.Context) *chain.Info {
 	info, err := h.client.Info(ctx)
 	if err != nil {
 		h.log.Warn("msg", "chain info fetch failed", "err", err)
-		return nil
+		return h.chainInfo
 	}
 	if info == nil {
 		h.log.Warn("msg", "chain info fetch didn't return group info")
-		return nil
+		return h.chainInfo
+	}
+	if h.chainInfo != nil {
+		if err := info.CheckUpdate(h.chainInfo); err != nil {
+			h.log.Warn("msg", "invalid chain info update", "err", err)
+			return h.chainInfo
+		}
 	}
 	h.chainInfo = info
+	h.chainInfoFetched = time.Now()
 	return info
 }
 
@@ -214,7 +251,7 @@ func (h *handler) getRand(ctx context.Context, info *chain.Info, round uint64) (
 	}
 
 	// make sure we aren't going to ask for a round that doesn't exist yet.
-	if time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, round), 0).After(time.Now()) {
+	if time.Unix(info.Schedule().TimeOfRound(round), 0).After(time.Now()) {
 		return nil, nil
 	}
 
@@ -247,9 +284,9 @@ func (h *handler) PublicRand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	roundExpectedTime = time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, roundN), 0)
+	roundExpectedTime = time.Unix(info.Schedule().TimeOfRound(roundN), 0)
 
-	if roundExpectedTime.After(time.Now().Add(info.Period)) {
+	if roundExpectedTime.After(time.Now().Add(info.Schedule().PeriodAt(roundN))) {
 		w.WriteHeader(http.StatusNotFound)
 		h.log.Warn("http_server", "request in the future", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path))
 		return
@@ -271,9 +308,33 @@ func (h *handler) PublicRand(w http.ResponseWriter, r *http.Request) {
 	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control
 	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
 	w.Header().Set("Expires", time.Now().Add(7*24*time.Hour).Format(http.TimeFormat))
+	w.Header().Set("ETag", etag(data))
 	http.ServeContent(w, r, "rand.json", roundExpectedTime, bytes.NewReader(data))
 }
 
+// latestRand is the latest randomness along with the round expected next and
+// its UNIX time, so clients can schedule their next request.
+type latestRand struct {
+	*client.RandomData
+	NextRound     uint64 `json:"next_round,omitempty"`
+	NextRoundTime int64  `json:"next_round_time,omitempty"`
+}
+
+func asRandomData(r client.Result) *client.RandomData {
+	if rd, ok := r.(*client.RandomData); ok {
+		return rd
+	}
+	rd := &client.RandomData{
+		Rnd:    r.Round(),
+		Random: r.Randomness(),
+		Sig:    r.Signature(),
+	}
+	if rp, ok := r.(interface{ PreviousSignature() []byte }); ok {
+		rd.PreviousSignature = rp.PreviousSignature()
+	}
+	return rd
+}
+
 func (h *handler) LatestRand(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
 	defer cancel()
@@ -286,36 +347,123 @@ func (h *handler) LatestRand(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	data, err := json.Marshal(resp)
+	info := h.getChainInfo(r.Context())
+	latest := &latestRand{RandomData: asRandomData(resp)}
+	if info != nil {
+		latest.NextRound, latest.NextRoundTime = info.Schedule().NextRound(time.Now().Unix())
+	}
+	data, err := json.Marshal(latest)
 	if err != nil {
 		w.WriteHeader(http.StatusInternalServerError)
 		h.log.Warn("http_server", "failed to marshal randomness", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "err", err)
 		return
 	}
 
-	info := h.getChainInfo(r.Context())
 	roundTime := time.Now()
 	nextTime := time.Now()
 	if info != nil {
-		roundTime = time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, resp.Round()), 0)
-		next := time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, resp.Round()+1), 0)
+		roundTime = time.Unix(info.Schedule().TimeOfRound(resp.Round()), 0)
+		next := time.Unix(info.Schedule().TimeOfRound(resp.Round()+1), 0)
 		if next.After(nextTime) {
 			nextTime = next
 		} else {
-			nextTime = nextTime.Add(info.Period / catchupExpiryFactor)
+			nextTime = nextTime.Add(info.Schedule().PeriodAt(resp.Round()+1) / catchupExpiryFactor)
 		}
 	}
 
+	// the latest beacon is valid until the next round is produced
 	remaining := time.Until(nextTime)
-	if remaining > 0 && remaining < info.Period {
+	if info != nil && remaining > 0 && remaining < info.Schedule().PeriodAt(resp.Round()+1) {
 		seconds := int(math.Ceil(remaining.Seconds()))
-		w.Header().Set("Cache-Control", fmt.Sprintf("max-age:%d, public", seconds))
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", seconds))
 	} else {
+		w.Header().Set("Cache-Control", "no-cache")
 		h.log.Warn("http_server", "latest rand in the past", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.Path), "remaining", remaining)
 	}
 
 	w.Header().Set("Expires", nextTime.Format(http.TimeFormat))
-	w.Header().Set("Last-Modified", roundTime.Format(http.TimeFormat))
+	w.Header().Set("ETag", etag(data))
+	http.ServeContent(w, r, "rand.json", roundTime, bytes.NewReader(data))
+}
+
+// randRange is a page of beacons served under /public/range.
+type randRange struct {
+	Beacons []*client.RandomData `json:"beacons"`
+	Next    uint64               `json:"next,omitempty"`
+}
+
+// parseRangeParam returns the value of the given query parameter, zero if it
+// is not set.
+func parseRangeParam(q url.Values, name string) (uint64, error) {
+	v := q.Get(name)
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(v, 10, 64)
+}
+
+// rangeComplete reports whether the page holds every round of the range up to
+// the next page, or up to the end of the range if it is the last page.
+func rangeComplete(results []client.Result, from, to, next uint64) bool {
+	if from == 0 {
+		from = 1
+	}
+	end := to
+	if next != 0 {
+		end = next - 1
+	}
+	if end == 0 || end < from || uint64(len(results)) != end-from+1 {
+		return false
+	}
+	for i, r := range results {
+		if r.Round() != from+uint64(i) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *handler) RandRange(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, errF := parseRangeParam(q, "from")
+	to, errT := parseRangeParam(q, "to")
+	limit, errL := parseRangeParam(q, "limit")
+	if errF != nil || errT != nil || errL != nil || (to != 0 && from > to) || limit > math.MaxInt32 {
+		w.WriteHeader(http.StatusBadRequest)
+		h.log.Warn("http_server", "failed to parse client range", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.RawQuery))
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	results, next, err := client.Range(ctx, h.client, from, to, int(limit))
+	if errors.Is(err, client.ErrRangeUnsupported) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		h.log.Warn("http_server", "failed to get range", "client", r.RemoteAddr, "req", url.PathEscape(r.URL.RawQuery), "err", err)
+		return
+	}
+	page := &randRange{Beacons: make([]*client.RandomData, 0, len(results)), Next: next}
+	for _, res := range results {
+		page.Beacons = append(page.Beacons, asRandomData(res))
+	}
+	data, err := json.Marshal(page)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to marshal range", "client", r.RemoteAddr, "err", err)
+		return
+	}
+	// a page holding every round up to the next page, or up to the end of the
+	// range, never changes, while the last page of an open range grows with
+	// the chain and a page with missing rounds may still be filled
+	if rangeComplete(results, from, to, next) {
+		w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
+		w.Header().Set("ETag", etag(data))
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
 	_, _ = w.Write(data)
 }
 
@@ -334,11 +482,47 @@ func (h *handler) ChainInfo(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Headers per recommendation for static assets at
-	// https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Cache-Control
-	w.Header().Set("Cache-Control", "public, max-age=604800, immutable")
-	w.Header().Set("Expires", time.Now().Add(7*24*time.Hour).Format(http.TimeFormat))
-	http.ServeContent(w, r, "info.json", time.Unix(info.GenesisTime, 0), bytes.NewReader(chainBuff.Bytes()))
+	// a resharing can update the info, which is revalidated with its tag
+	maxAge := int(chainInfoMaxAge.Seconds())
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	w.Header().Set("Expires", time.Now().Add(chainInfoMaxAge).Format(http.TimeFormat))
+	w.Header().Set("ETag", etag(chainBuff.Bytes()))
+	http.ServeContent(w, r, "info.json", time.Time{}, bytes.NewReader(chainBuff.Bytes()))
+}
+
+// EpochsClient is implemented by the clients able to list the resharings of
+// their chain, which the handler then serves under /info/epochs.
+type EpochsClient interface {
+	Epochs(ctx context.Context) ([]*chain.Epoch, error)
+}
+
+func (h *handler) Epochs(w http.ResponseWriter, r *http.Request) {
+	ec, ok := h.client.(EpochsClient)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), h.timeout)
+	defer cancel()
+	epochs, err := ec.Epochs(ctx)
+	if err != nil {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		h.log.Warn("http_server", "failed to get epochs", "client", r.RemoteAddr, "err", err)
+		return
+	}
+	if epochs == nil {
+		epochs = []*chain.Epoch{}
+	}
+	data, err := json.Marshal(epochs)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		h.log.Warn("http_server", "failed to marshal epochs", "client", r.RemoteAddr, "err", err)
+		return
+	}
+	// a resharing adds an epoch at any time
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-cache")
+	_, _ = w.Write(data)
 }
 
 func (h *handler) Health(w http.ResponseWriter, r *http.Request) {
@@ -360,7 +544,7 @@ func (h *handler) Health(w http.ResponseWriter, r *http.Request) {
 	if info == nil {
 		w.WriteHeader(http.StatusServiceUnavailable)
 	} else {
-		expected := chain.CurrentRound(time.Now().Unix(), info.Period, info.GenesisTime)
+		expected := info.Schedule().CurrentRound(time.Now().Unix())
 		resp["expected"] = expected
 		if lastSeen == expected || lastSeen+1 == expected {
 			w.WriteHeader(http.StatusOK)
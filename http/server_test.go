@@ -5,11 +5,15 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/drand/drand/chain"
 	"github.com/drand/drand/client"
 	"github.com/drand/drand/client/grpc"
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/protobuf/drand"
 	"github.com/drand/drand/test/mock"
 	"github.com/stretchr/testify/require"
@@ -83,6 +87,12 @@ func TestHTTPRelay(t *testing.T) {
 	if _, ok := body["round"]; !ok {
 		t.Fatal("expected signature in latest response.")
 	}
+	if _, ok := body["next_round"]; !ok {
+		t.Fatal("expected next round in latest response.")
+	}
+	if _, ok := body["next_round_time"]; !ok {
+		t.Fatal("expected next round time in latest response.")
+	}
 }
 
 func validateEndpoint(endpoint string, round float64) error {
@@ -234,3 +244,279 @@ func TestHTTPHealth(t *testing.T) {
 		t.Fatalf("after start server expected to be healthy relatively quickly. %v - %v", string(buf[:]), resp.StatusCode)
 	}
 }
+
+// fixedClient always returns the same randomness for a given round.
+type fixedClient struct {
+	client.Client
+}
+
+func (f *fixedClient) Get(ctx context.Context, round uint64) (client.Result, error) {
+	if round == 0 {
+		round = f.RoundAt(time.Now())
+	}
+	return &client.RandomData{Rnd: round, Sig: []byte{byte(round)}}, nil
+}
+
+func TestHTTPCacheHeaders(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := withClient(t)
+
+	handler, err := New(ctx, &fixedClient{c}, "", nil)
+	require.NoError(t, err)
+	listener, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	server := http.Server{Handler: handler}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Shutdown(ctx) }()
+
+	for _, path := range []string{"info", "public/2", "public/latest"} {
+		u := fmt.Sprintf("http://%s/%s", listener.Addr().String(), path)
+		resp, err := http.Get(u)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		tag := resp.Header.Get("ETag")
+		require.NotEmpty(t, tag, path)
+		require.NotEmpty(t, resp.Header.Get("Expires"), path)
+		require.NotEmpty(t, resp.Header.Get("Cache-Control"), path)
+
+		req, err := http.NewRequest("GET", u, nil)
+		require.NoError(t, err)
+		req.Header.Set("If-None-Match", tag)
+		resp, err = http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		require.Equal(t, http.StatusNotModified, resp.StatusCode, path)
+	}
+}
+
+func TestHTTPCORS(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := withClient(t)
+
+	// the lists given on the command line may be comma separated
+	handler, err := New(ctx, c, "", nil, WithCORS([]string{"https://example.com, https://drand.love"}, []string{"X-Custom"}))
+	require.NoError(t, err)
+
+	req := httptest.NewRequest("OPTIONS", "/info", nil)
+	req.Header.Set("Origin", "https://example.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusNoContent, rr.Code)
+	require.Equal(t, "https://example.com", rr.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "X-Custom", rr.Header().Get("Access-Control-Allow-Headers"))
+	require.Contains(t, rr.Header().Get("Access-Control-Allow-Methods"), "GET")
+
+	req = httptest.NewRequest("OPTIONS", "/info", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Empty(t, rr.Header().Get("Access-Control-Allow-Origin"))
+	require.Empty(t, rr.Header().Get("Access-Control-Allow-Methods"))
+	// the refusal must not be cached for the allowed origins
+	require.Equal(t, "Origin", rr.Header().Get("Vary"))
+
+	req = httptest.NewRequest("GET", "/info", nil)
+	req.Header.Set("Origin", "https://drand.love")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, "https://drand.love", rr.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "Origin", rr.Header().Get("Vary"))
+
+	// all origins are allowed by default
+	handler, err = New(ctx, c, "", nil)
+	require.NoError(t, err)
+	req = httptest.NewRequest("GET", "/info", nil)
+	req.Header.Set("Origin", "https://evil.com")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, "*", rr.Header().Get("Access-Control-Allow-Origin"))
+	require.Equal(t, "Origin", rr.Header().Get("Vary"))
+}
+
+// epochsClient lists a fixed set of epochs.
+type epochsClient struct {
+	client.Client
+	epochs []*chain.Epoch
+}
+
+func (e *epochsClient) Epochs(context.Context) ([]*chain.Epoch, error) {
+	return e.epochs, nil
+}
+
+func TestHTTPEpochs(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := withClient(t)
+
+	// a client that can't list the epochs
+	handler, err := New(ctx, c, "", nil)
+	require.NoError(t, err)
+	server := httptest.NewServer(handler)
+	resp, err := http.Get(server.URL + "/info/epochs")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	server.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	epochs := []*chain.Epoch{{Round: 10, PreviousGroupHash: []byte("group1"), GroupHash: []byte("group2"), PublicKey: []byte("key")}}
+	handler, err = New(ctx, &epochsClient{Client: c, epochs: epochs}, "", nil)
+	require.NoError(t, err)
+	server = httptest.NewServer(handler)
+	defer server.Close()
+	resp, err = http.Get(server.URL + "/info/epochs")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	var got []*chain.Epoch
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&got))
+	require.Equal(t, epochs, got)
+}
+
+// rangeClient serves the rounds up to last by pages of at most 2 beacons,
+// without the missing round if it is set.
+type rangeClient struct {
+	client.Client
+	last    uint64
+	missing uint64
+}
+
+func (rc *rangeClient) Range(_ context.Context, from, to uint64, limit int) ([]client.Result, uint64, error) {
+	if to == 0 || to > rc.last {
+		to = rc.last
+	}
+	if limit == 0 || limit > 2 {
+		limit = 2
+	}
+	var results []client.Result
+	for r := from; r <= to; r++ {
+		if len(results) == limit {
+			return results, r, nil
+		}
+		if r == rc.missing {
+			continue
+		}
+		results = append(results, &client.RandomData{Rnd: r, Sig: []byte{byte(r)}})
+	}
+	return results, 0, nil
+}
+
+func TestHTTPRange(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := withClient(t)
+
+	// a client that can't serve ranges
+	handler, err := New(ctx, &fixedClient{c}, "", nil)
+	require.NoError(t, err)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/public/range?from=1", nil))
+	require.Equal(t, http.StatusNotFound, rr.Code)
+
+	handler, err = New(ctx, &rangeClient{Client: c, last: 5}, "", nil)
+	require.NoError(t, err)
+	for _, query := range []string{"from=a", "from=3&to=2", "limit=-1"} {
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/public/range?"+query, nil))
+		require.Equal(t, http.StatusBadRequest, rr.Code, query)
+	}
+
+	var rounds []uint64
+	from := uint64(2)
+	for from != 0 {
+		rr = httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", fmt.Sprintf("/public/range?from=%d&limit=10", from), nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		var page struct {
+			Beacons []*client.RandomData `json:"beacons"`
+			Next    uint64               `json:"next"`
+		}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&page))
+		require.LessOrEqual(t, len(page.Beacons), 2)
+		for _, b := range page.Beacons {
+			rounds = append(rounds, b.Round())
+		}
+		// the full pages never change, the last one grows with the chain
+		if page.Next != 0 {
+			require.Contains(t, rr.Header().Get("Cache-Control"), "immutable")
+		} else {
+			require.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+		}
+		from = page.Next
+	}
+	require.Equal(t, []uint64{2, 3, 4, 5}, rounds)
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/public/range?from=4&to=5", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Contains(t, rr.Header().Get("Cache-Control"), "immutable")
+
+	// a page missing a round of the range may still be filled
+	handler, err = New(ctx, &rangeClient{Client: c, last: 5, missing: 3}, "", nil)
+	require.NoError(t, err)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/public/range?from=2&to=5&limit=10", nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "no-cache", rr.Header().Get("Cache-Control"))
+}
+
+// infoClient serves the chain info it is given.
+type infoClient struct {
+	client.Client
+	sync.Mutex
+	info *chain.Info
+}
+
+func (i *infoClient) Info(context.Context) (*chain.Info, error) {
+	i.Lock()
+	defer i.Unlock()
+	return i.info, nil
+}
+
+func TestHTTPChainInfoRefresh(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	c, _ := withClient(t)
+	info, err := c.Info(ctx)
+	require.NoError(t, err)
+	ic := &infoClient{Client: c, info: info}
+	handler, err := New(ctx, ic, "", nil)
+	require.NoError(t, err)
+	prev := chainInfoMaxAge
+	chainInfoMaxAge = 0
+	defer func() { chainInfoMaxAge = prev }()
+
+	getInfo := func() (*chain.Info, string) {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", "/info", nil))
+		require.Equal(t, http.StatusOK, rr.Code)
+		served, err := chain.InfoFromJSON(rr.Body)
+		require.NoError(t, err)
+		return served, rr.Header().Get("Cache-Control")
+	}
+	served, cacheControl := getInfo()
+	require.True(t, info.Equal(served))
+	require.NotContains(t, cacheControl, "immutable")
+
+	// a resharing migrates the message format and changes the period
+	updated := *info
+	updated.MessageV1Round = 100
+	updated.PeriodChanges = []key.PeriodChange{{Round: 100, Period: 2 * info.Period}}
+	ic.Lock()
+	ic.info = &updated
+	ic.Unlock()
+	served, _ = getInfo()
+	require.True(t, updated.Equal(served))
+
+	// an info dropping the migration is not a valid update
+	ic.Lock()
+	ic.info = info
+	ic.Unlock()
+	served, _ = getInfo()
+	require.True(t, updated.Equal(served))
+}
@@ -2,6 +2,9 @@ package key
 
 import (
 	"crypto/cipher"
+	"crypto/sha256"
+	"fmt"
+	"sort"
 
 	kyber "github.com/drand/kyber"
 	bls "github.com/drand/kyber-bls12381"
@@ -10,6 +13,8 @@ import (
 	"github.com/drand/kyber/sign/schnorr"
 	"github.com/drand/kyber/sign/tbls"
 	"github.com/drand/kyber/util/random"
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/sha3"
 )
 
 // TODO: global variables are evil, make that a config
@@ -30,6 +35,60 @@ var SigGroup = Pairing.G2()
 // and keys respectively are.
 var Scheme = tbls.NewThresholdSchemeOnG2(Pairing)
 
+// SchemeName identifies Scheme used to sign chained beacons. Nodes announce
+// the schemes they support before running a DKG.
+const SchemeName = "pedersen-bls-chained"
+
+// Names of the digests deriving the randomness of a round from its signature.
+// A group without a digest uses DigestSHA256, as the chains created before the
+// digest could be chosen.
+const (
+	DigestSHA256  = "sha256"
+	DigestSHA3    = "sha3-256"
+	DigestBLAKE2b = "blake2b-256"
+)
+
+var digests = map[string]func([]byte) []byte{
+	DigestSHA256: func(b []byte) []byte {
+		h := sha256.Sum256(b)
+		return h[:]
+	},
+	DigestSHA3: func(b []byte) []byte {
+		h := sha3.Sum256(b)
+		return h[:]
+	},
+	DigestBLAKE2b: func(b []byte) []byte {
+		h := blake2b.Sum256(b)
+		return h[:]
+	},
+}
+
+// DigestNames returns the sorted names of the supported digests.
+func DigestNames() []string {
+	names := make([]string, 0, len(digests))
+	for name := range digests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DigestFunc returns the digest of the given name, SHA-256 for an empty name.
+// In FIPS mode, only the approved digests are returned.
+func DigestFunc(name string) (func([]byte) []byte, error) {
+	if name == "" {
+		name = DigestSHA256
+	}
+	digest, ok := digests[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown digest %q, supported digests are %v", name, DigestNames())
+	}
+	if FIPSMode() && !fipsDigests[name] {
+		return nil, fmt.Errorf("digest %q is not approved in FIPS mode", name)
+	}
+	return digest, nil
+}
+
 // AuthScheme is the signature scheme used to identify public identities
 var AuthScheme = sign.NewSchemeOnG2(Pairing)
 
@@ -0,0 +1,71 @@
+package key
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+// Encodings supported to export and import keys.
+const (
+	HexEncoding    = "hex"
+	Base64Encoding = "base64"
+	PEMEncoding    = "pem"
+)
+
+// PEM block types of the keys exported by drand.
+const (
+	PrivatePEMType    = "DRAND PRIVATE KEY"
+	PublicPEMType     = "DRAND PUBLIC KEY"
+	DistPublicPEMType = "DRAND DISTRIBUTED PUBLIC KEY"
+)
+
+// EncodeKey encodes the binary form of a key. The PEM block type is only used
+// with the PEM encoding.
+func EncodeKey(encoding, pemType string, buff []byte) ([]byte, error) {
+	switch encoding {
+	case HexEncoding:
+		return []byte(hex.EncodeToString(buff) + "\n"), nil
+	case Base64Encoding:
+		return []byte(base64.StdEncoding.EncodeToString(buff) + "\n"), nil
+	case PEMEncoding:
+		return pem.EncodeToMemory(&pem.Block{Type: pemType, Bytes: buff}), nil
+	default:
+		return nil, fmt.Errorf("unknown key encoding %q", encoding)
+	}
+}
+
+// DecodeKey returns the binary form of a key encoded with EncodeKey. With the
+// PEM encoding, the block must be of the given type.
+func DecodeKey(encoding, pemType string, data []byte) ([]byte, error) {
+	switch encoding {
+	case HexEncoding:
+		return hex.DecodeString(string(bytes.TrimSpace(data)))
+	case Base64Encoding:
+		return base64.StdEncoding.DecodeString(string(bytes.TrimSpace(data)))
+	case PEMEncoding:
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, errors.New("no PEM block found")
+		}
+		if block.Type != pemType {
+			return nil, fmt.Errorf("PEM block of type %q instead of %q", block.Type, pemType)
+		}
+		return block.Bytes, nil
+	default:
+		return nil, fmt.Errorf("unknown key encoding %q", encoding)
+	}
+}
+
+// NewKeyPairFromKey returns the key pair of the given private key, signed for
+// the given address.
+func NewKeyPairFromKey(buff []byte, address string, tls bool) (*Pair, error) {
+	k := KeyGroup.Scalar()
+	if err := k.UnmarshalBinary(buff); err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return newKeyPair(k, address, tls), nil
+}
@@ -0,0 +1,32 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeKey(t *testing.T) {
+	kp := NewKeyPair(testAddr)
+	buff, err := kp.Key.MarshalBinary()
+	require.NoError(t, err)
+	for _, encoding := range []string{HexEncoding, Base64Encoding, PEMEncoding} {
+		encoded, err := EncodeKey(encoding, PrivatePEMType, buff)
+		require.NoError(t, err)
+		decoded, err := DecodeKey(encoding, PrivatePEMType, encoded)
+		require.NoError(t, err)
+		require.Equal(t, buff, decoded)
+	}
+
+	encoded, err := EncodeKey(PEMEncoding, PrivatePEMType, buff)
+	require.NoError(t, err)
+	_, err = DecodeKey(PEMEncoding, PublicPEMType, encoded)
+	require.Error(t, err)
+	_, err = EncodeKey("der", PrivatePEMType, buff)
+	require.Error(t, err)
+
+	imported, err := NewKeyPairFromKey(buff, testAddr, true)
+	require.NoError(t, err)
+	require.True(t, imported.Public.Key.Equal(kp.Public.Key))
+	require.NoError(t, imported.Public.ValidSignature())
+}
@@ -0,0 +1,68 @@
+package key
+
+import "sync/atomic"
+
+// fipsMode is 1 when the node restricts its primitives to the FIPS approved
+// ones.
+var fipsMode = func() uint32 {
+	if fipsBuild {
+		return 1
+	}
+	return 0
+}()
+
+// SetFIPSMode restricts, or not, the hash primitives that can be chosen to the
+// ones approved by FIPS 140. The FIPS mode is on by default in the binaries
+// built with the fips tag. The primitives fixed by the protocol, e.g. the
+// BLS signatures or the hash of the identities, can't be changed without
+// breaking the compatibility with the existing chains: they are only reported
+// as not approved.
+func SetFIPSMode(on bool) {
+	var v uint32
+	if on {
+		v = 1
+	}
+	atomic.StoreUint32(&fipsMode, v)
+}
+
+// FIPSMode returns true if the primitives are restricted to the ones approved
+// by FIPS 140.
+func FIPSMode() bool {
+	return atomic.LoadUint32(&fipsMode) == 1
+}
+
+// fipsDigests are the digests approved by FIPS 180-4 and FIPS 202.
+var fipsDigests = map[string]bool{
+	DigestSHA256: true,
+	DigestSHA3:   true,
+}
+
+// Primitive describes a cryptographic primitive used by drand.
+type Primitive struct {
+	// Usage is what the primitive is used for
+	Usage string
+	Name  string
+	// Approved is true if the primitive is approved by FIPS 140
+	Approved bool
+}
+
+// Primitives returns the cryptographic primitives used by a node of a chain
+// deriving its randomness with the given digest.
+func Primitives(digest string) []Primitive {
+	if digest == "" {
+		digest = DigestSHA256
+	}
+	tlsSuites := "ECDHE with AES-GCM or ChaCha20-Poly1305"
+	if FIPSMode() {
+		tlsSuites = "TLS 1.2 ECDHE P-256/P-384 with AES-GCM"
+	}
+	return []Primitive{
+		{Usage: "beacon signature", Name: "BLS12-381 " + SchemeName},
+		{Usage: "randomness digest", Name: digest, Approved: fipsDigests[digest]},
+		{Usage: "chain hash", Name: DigestSHA256, Approved: true},
+		{Usage: "identity and group hash", Name: DigestBLAKE2b},
+		{Usage: "DKG authentication", Name: "Schnorr over BLS12-381"},
+		{Usage: "mnemonic key derivation", Name: "PBKDF2-HMAC-SHA512", Approved: true},
+		{Usage: "TLS cipher suites", Name: tlsSuites, Approved: FIPSMode()},
+	}
+}
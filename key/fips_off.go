@@ -0,0 +1,8 @@
+//go:build !fips
+// +build !fips
+
+package key
+
+// fipsBuild turns the FIPS mode on by default in the binaries built with the
+// fips tag.
+const fipsBuild = false
@@ -0,0 +1,37 @@
+package key
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFIPSMode(t *testing.T) {
+	defer SetFIPSMode(fipsBuild)
+
+	SetFIPSMode(false)
+	_, err := DigestFunc(DigestBLAKE2b)
+	require.NoError(t, err)
+
+	SetFIPSMode(true)
+	require.True(t, FIPSMode())
+	_, err = DigestFunc(DigestBLAKE2b)
+	require.Error(t, err)
+	for _, name := range []string{"", DigestSHA256, DigestSHA3} {
+		_, err = DigestFunc(name)
+		require.NoError(t, err)
+	}
+
+	approved := make(map[string]bool)
+	for _, p := range Primitives(DigestSHA3) {
+		approved[p.Usage] = p.Approved
+	}
+	require.True(t, approved["randomness digest"])
+	require.True(t, approved["TLS cipher suites"])
+	require.False(t, approved["beacon signature"])
+	for _, p := range Primitives(DigestBLAKE2b) {
+		if p.Usage == "randomness digest" {
+			require.False(t, p.Approved)
+		}
+	}
+}
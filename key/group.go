@@ -45,11 +45,29 @@ type Group struct {
 	// In case of a resharing, this is the time at which the network will
 	// transition from the old network to the new network.
 	TransitionTime int64
+	// MessageV1Round is the first round whose beacon signs the domain
+	// separated message format. Zero means the original format is used for all
+	// rounds.
+	MessageV1Round uint64
+	// PeriodChanges are the changes of the period decided by the resharings,
+	// in increasing round order. Period stays the period of the genesis.
+	PeriodChanges []PeriodChange
+	// Digest names the digest deriving the randomness of a round from its
+	// signature, chosen when creating the network. Empty means SHA-256.
+	Digest string
 	// The distributed public key of this group. It is nil if the group has not
 	// ran a DKG protocol yet.
 	PublicKey *DistPublic
 }
 
+// PeriodChange records that the period of the chain changes from a round on.
+// The round happens at the time given by the previous period and the next
+// ones every Period.
+type PeriodChange struct {
+	Round  uint64
+	Period time.Duration
+}
+
 // Find returns the Node that is equal to the given identity (without the
 // index). If the node is not found, Find returns nil.
 func (g *Group) Find(pub *Identity) *Node {
@@ -100,12 +118,44 @@ func (g *Group) Hash() []byte {
 	if g.TransitionTime != 0 {
 		_ = binary.Write(h, binary.LittleEndian, g.TransitionTime)
 	}
+	if g.MessageV1Round != 0 {
+		_ = binary.Write(h, binary.LittleEndian, g.MessageV1Round)
+	}
+	for _, c := range g.PeriodChanges {
+		_ = binary.Write(h, binary.LittleEndian, c.Round)
+		_ = binary.Write(h, binary.LittleEndian, uint32(c.Period.Seconds()))
+	}
+	if g.Digest != "" {
+		_, _ = h.Write([]byte(g.Digest))
+	}
 	if g.PublicKey != nil {
 		_, _ = h.Write(g.PublicKey.Hash())
 	}
 	return h.Sum(nil)
 }
 
+// MembershipHash returns a hash of the public keys and addresses of the nodes,
+// the threshold and the period of the group. Unlike Hash, it does not depend
+// on the indices of the nodes nor on the times and distributed key set by the
+// DKG, so it identifies a group before it is created.
+func (g *Group) MembershipHash() []byte {
+	keys := make([][]byte, 0, len(g.Nodes))
+	for _, n := range g.Nodes {
+		buff, _ := n.Key.MarshalBinary()
+		keys = append(keys, append(buff, []byte(n.Address())...))
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	h := hashFunc()
+	for _, k := range keys {
+		_, _ = h.Write(k)
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(g.Threshold))
+	_ = binary.Write(h, binary.LittleEndian, uint64(g.Period))
+	return h.Sum(nil)
+}
+
 // Points returns itself under the form of a list of kyber.Point
 func (g *Group) Points() []kyber.Point {
 	pts := make([]kyber.Point, g.Len())
@@ -143,6 +193,20 @@ func (g *Group) Equal(g2 *Group) bool {
 	if g.TransitionTime != g2.TransitionTime {
 		return false
 	}
+	if g.MessageV1Round != g2.MessageV1Round {
+		return false
+	}
+	if g.Digest != g2.Digest {
+		return false
+	}
+	if len(g.PeriodChanges) != len(g2.PeriodChanges) {
+		return false
+	}
+	for i := range g.PeriodChanges {
+		if g.PeriodChanges[i] != g2.PeriodChanges[i] {
+			return false
+		}
+	}
 	for i := 0; i < g.Len(); i++ {
 		if !g.Nodes[i].Equal(g2.Nodes[i]) {
 			return false
@@ -169,9 +233,18 @@ type GroupTOML struct {
 	CatchupPeriod  string
 	Nodes          []*NodeTOML
 	GenesisTime    int64
-	TransitionTime int64           `toml:",omitempty"`
-	GenesisSeed    string          `toml:",omitempty"`
-	PublicKey      *DistPublicTOML `toml:",omitempty"`
+	TransitionTime int64               `toml:",omitempty"`
+	MessageV1Round uint64              `toml:",omitempty"`
+	PeriodChanges  []*PeriodChangeTOML `toml:",omitempty"`
+	Digest         string              `toml:",omitempty"`
+	GenesisSeed    string              `toml:",omitempty"`
+	PublicKey      *DistPublicTOML     `toml:",omitempty"`
+}
+
+// PeriodChangeTOML is the TOML representation of a change of period
+type PeriodChangeTOML struct {
+	Round  uint64
+	Period string
 }
 
 // FromTOML decodes the group from the toml struct
@@ -218,6 +291,19 @@ func (g *Group) FromTOML(i interface{}) (err error) {
 	if gt.TransitionTime != 0 {
 		g.TransitionTime = gt.TransitionTime
 	}
+	g.MessageV1Round = gt.MessageV1Round
+	if _, err := DigestFunc(gt.Digest); err != nil {
+		return fmt.Errorf("group: %v", err)
+	}
+	g.Digest = gt.Digest
+	g.PeriodChanges = nil
+	for _, c := range gt.PeriodChanges {
+		period, err := time.ParseDuration(c.Period)
+		if err != nil {
+			return fmt.Errorf("group: period change at round %d: %v", c.Round, err)
+		}
+		g.PeriodChanges = append(g.PeriodChanges, PeriodChange{Round: c.Round, Period: period})
+	}
 	if gt.GenesisSeed != "" {
 		if g.GenesisSeed, err = hex.DecodeString(gt.GenesisSeed); err != nil {
 			return fmt.Errorf("group: decoding genesis seed %v", err)
@@ -245,10 +331,26 @@ func (g *Group) TOML() interface{} {
 	if g.TransitionTime != 0 {
 		gtoml.TransitionTime = g.TransitionTime
 	}
+	gtoml.MessageV1Round = g.MessageV1Round
+	gtoml.Digest = g.Digest
+	for _, c := range g.PeriodChanges {
+		gtoml.PeriodChanges = append(gtoml.PeriodChanges, &PeriodChangeTOML{Round: c.Round, Period: c.Period.String()})
+	}
 	gtoml.GenesisSeed = hex.EncodeToString(g.GetGenesisSeed())
 	return gtoml
 }
 
+// SeedHash returns the genesis seed of a new chain created by the group: the
+// hash of the group before the DKG, without its distributed key, so that all
+// nodes know and agree on it before the first round. The first beacon of the
+// chain uses it as previous signature.
+func (g *Group) SeedHash() []byte {
+	c := *g
+	c.PublicKey = nil
+	c.GenesisSeed = nil
+	return c.Hash()
+}
+
 // GetGenesisSeed exposes the hash of the genesis seed for the group
 func (g *Group) GetGenesisSeed() []byte {
 	if g.GenesisSeed != nil {
@@ -313,6 +415,54 @@ func MinimumT(n int) int {
 	return (n >> 1) + 1
 }
 
+// MinimumGroupSize is the minimum number of nodes of a group: smaller groups
+// either can't lose a single node or let one node produce the beacons alone.
+const MinimumGroupSize = 3
+
+// ValidateThreshold checks that the threshold of a group of n nodes is at
+// most n and at least the floor, or MinimumT(n) when the floor is lower.
+func ValidateThreshold(n, threshold, floor int) error {
+	if min := MinimumT(n); floor < min {
+		floor = min
+	}
+	switch {
+	case threshold <= 1:
+		return fmt.Errorf("insecure threshold %d: a single node could produce the beacons", threshold)
+	case threshold < floor:
+		return fmt.Errorf("threshold %d too low for %d nodes: minimum is %d", threshold, n, floor)
+	case threshold > n:
+		return fmt.Errorf("threshold %d greater than the number of nodes %d", threshold, n)
+	}
+	return nil
+}
+
+// Validate checks that the group has at least MinimumGroupSize nodes, a valid
+// threshold, and that no two nodes share an address or a key. It is only
+// enforced when a DKG or a resharing creates a group, so that existing groups
+// keep loading.
+func (g *Group) Validate() error {
+	if g.Len() < MinimumGroupSize {
+		return fmt.Errorf("%d nodes in the group, the minimum is %d", g.Len(), MinimumGroupSize)
+	}
+	if err := ValidateThreshold(g.Len(), g.Threshold, 0); err != nil {
+		return err
+	}
+	addrs := make(map[string]bool, g.Len())
+	keys := make(map[string]bool, g.Len())
+	for _, n := range g.Nodes {
+		if addrs[n.Address()] {
+			return fmt.Errorf("duplicate address %s", n.Address())
+		}
+		addrs[n.Address()] = true
+		k := n.Key.String()
+		if keys[k] {
+			return fmt.Errorf("duplicate key for %s", n.Address())
+		}
+		keys[k] = true
+	}
+	return nil
+}
+
 // GroupFromProto convertes a protobuf group into a local Group object
 func GroupFromProto(g *proto.GroupPacket) (*Group, error) {
 	var nodes = make([]*Node, 0, len(g.GetNodes()))
@@ -352,6 +502,18 @@ func GroupFromProto(g *proto.GroupPacket) (*Group, error) {
 		Nodes:          nodes,
 		GenesisTime:    genesisTime,
 		TransitionTime: int64(g.GetTransitionTime()),
+		MessageV1Round: g.GetMessageV1Round(),
+		Digest:         g.GetDigest(),
+	}
+	if _, err := DigestFunc(group.Digest); err != nil {
+		return nil, err
+	}
+	for _, c := range g.GetPeriodChanges() {
+		period := time.Duration(c.GetPeriod()) * time.Second
+		if period == time.Duration(0) {
+			return nil, fmt.Errorf("period changed to zero at round %d", c.GetRound())
+		}
+		group.PeriodChanges = append(group.PeriodChanges, PeriodChange{Round: c.GetRound(), Period: period})
 	}
 	if g.GetGenesisSeed() != nil {
 		group.GenesisSeed = g.GetGenesisSeed()
@@ -370,15 +532,9 @@ func (g *Group) ToProto() *proto.GroupPacket {
 	var out = new(proto.GroupPacket)
 	var ids = make([]*proto.Node, len(g.Nodes))
 	for i, id := range g.Nodes {
-		key, _ := id.Key.MarshalBinary()
 		ids[i] = &proto.Node{
-			Public: &proto.Identity{
-				Address:   id.Address(),
-				Tls:       id.IsTLS(),
-				Key:       key,
-				Signature: id.Signature,
-			},
-			Index: id.Index,
+			Public: id.Identity.ToProto(),
+			Index:  id.Index,
 		}
 	}
 	out.Nodes = ids
@@ -387,6 +543,11 @@ func (g *Group) ToProto() *proto.GroupPacket {
 	out.Threshold = uint32(g.Threshold)
 	out.GenesisTime = uint64(g.GenesisTime)
 	out.TransitionTime = uint64(g.TransitionTime)
+	out.MessageV1Round = g.MessageV1Round
+	out.Digest = g.Digest
+	for _, c := range g.PeriodChanges {
+		out.PeriodChanges = append(out.PeriodChanges, &proto.PeriodChange{Round: c.Round, Period: uint32(c.Period.Seconds())})
+	}
 	out.GenesisSeed = g.GetGenesisSeed()
 	if g.PublicKey != nil {
 		var coeffs = make([][]byte, len(g.PublicKey.Coefficients))
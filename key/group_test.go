@@ -1,6 +1,7 @@
 package key
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -17,7 +18,7 @@ func newIds(n int) []*Node {
 	for i := 0; i < n; i++ {
 		ids[i] = &Node{
 			Index:    uint32(i),
-			Identity: NewKeyPair("127.0.0.1:3000").Public,
+			Identity: NewKeyPair(fmt.Sprintf("127.0.0.1:%d", 3000+i)).Public,
 		}
 	}
 	return ids
@@ -117,6 +118,7 @@ func TestGroupSaveLoad(t *testing.T) {
 	group.Period = time.Second * 4
 	group.GenesisTime = time.Now().Add(10 * time.Second).Unix()
 	group.TransitionTime = time.Now().Add(10 * time.Second).Unix()
+	group.PeriodChanges = []PeriodChange{{Round: 20, Period: 6 * time.Second}}
 
 	genesis := group.GenesisTime
 	transition := group.TransitionTime
@@ -145,6 +147,7 @@ func TestGroupSaveLoad(t *testing.T) {
 	require.Equal(t, seed, loaded.GetGenesisSeed())
 	require.Equal(t, genesis, loaded.GenesisTime)
 	require.Equal(t, transition, loaded.TransitionTime)
+	require.Equal(t, group.PeriodChanges, loaded.PeriodChanges)
 
 	require.Equal(t, group.Hash(), loaded.Hash())
 }
@@ -153,10 +156,12 @@ func TestGroupSaveLoad(t *testing.T) {
 func makeGroup(t *testing.T) *Group {
 	t.Helper()
 
-	fakeKey := KeyGroup.Point().Pick(random.New())
-	group := LoadGroup([]*Node{}, 1, &DistPublic{Coefficients: []kyber.Point{fakeKey}}, 30*time.Second, 0)
-	group.Threshold = MinimumT(0)
-	return group
+	n := MinimumGroupSize
+	coeffs := make([]kyber.Point, MinimumT(n))
+	for i := range coeffs {
+		coeffs[i] = KeyGroup.Point().Pick(random.New())
+	}
+	return LoadGroup(newIds(n), 1, &DistPublic{Coefficients: coeffs}, 30*time.Second, 0)
 }
 
 func TestConvertGroup(t *testing.T) {
@@ -164,9 +169,122 @@ func TestConvertGroup(t *testing.T) {
 	group.Period = 5 * time.Second
 	group.TransitionTime = time.Now().Unix()
 	group.GenesisTime = time.Now().Unix()
+	group.MessageV1Round = 42
+	group.PeriodChanges = []PeriodChange{{Round: 50, Period: 10 * time.Second}}
+	group.Digest = DigestSHA3
 
 	proto := group.ToProto()
 	received, err := GroupFromProto(proto)
 	require.NoError(t, err)
 	require.True(t, received.Equal(group))
+
+	proto.Digest = "md5"
+	_, err = GroupFromProto(proto)
+	require.Error(t, err)
+}
+
+func TestDigest(t *testing.T) {
+	sig := []byte("signature")
+	sha, err := DigestFunc("")
+	require.NoError(t, err)
+	seen := map[string]bool{string(sha(sig)): true}
+	for _, name := range DigestNames() {
+		digest, err := DigestFunc(name)
+		require.NoError(t, err)
+		require.Len(t, digest(sig), 32)
+		seen[string(digest(sig))] = true
+	}
+	// the empty name is SHA-256, the others differ
+	require.Len(t, seen, len(DigestNames()))
+	_, err = DigestFunc("md5")
+	require.Error(t, err)
+
+	// the default digest keeps the hash of the group
+	group := makeGroup(t)
+	hash := group.Hash()
+	group.Digest = DigestBLAKE2b
+	require.NotEqual(t, hash, group.Hash())
+}
+
+func TestGroupMembershipHash(t *testing.T) {
+	n := 5
+	ids := make([]*Identity, n)
+	for i := range ids {
+		ids[i] = NewKeyPair(fmt.Sprintf("127.0.0.1:%d", 3000+i)).Public
+	}
+	group := NewGroup(ids, 3, 1000, 10*time.Second, 0)
+	// the order of the nodes and the times do not change the membership
+	reversed := make([]*Identity, n)
+	for i := range ids {
+		reversed[i] = ids[n-1-i]
+	}
+	other := NewGroup(reversed, 3, 2000, 10*time.Second, 0)
+	other.TransitionTime = 3000
+	require.Equal(t, group.MembershipHash(), other.MembershipHash())
+
+	require.NotEqual(t, group.MembershipHash(), NewGroup(ids, 4, 1000, 10*time.Second, 0).MembershipHash())
+	require.NotEqual(t, group.MembershipHash(), NewGroup(ids, 3, 1000, 20*time.Second, 0).MembershipHash())
+	require.NotEqual(t, group.MembershipHash(), NewGroup(ids[1:], 3, 1000, 10*time.Second, 0).MembershipHash())
+}
+
+func TestGroupSeedHash(t *testing.T) {
+	group := makeGroup(t)
+	seed := group.SeedHash()
+	// the seed of a group created by a DKG is known before the DKG
+	fresh := *group
+	fresh.PublicKey = nil
+	fresh.GenesisSeed = nil
+	require.Equal(t, seed, fresh.GetGenesisSeed())
+	require.Equal(t, seed, group.SeedHash())
+
+	// the seed depends on the group, not on the seed set
+	group.GenesisSeed = []byte("another seed")
+	require.Equal(t, seed, group.SeedHash())
+	group.GenesisTime++
+	require.NotEqual(t, seed, group.SeedHash())
+}
+
+func TestGroupValidate(t *testing.T) {
+	group := &Group{Nodes: newIds(4), Threshold: 3}
+	require.NoError(t, group.Validate())
+
+	group.Threshold = 2
+	require.Error(t, group.Validate())
+	group.Threshold = 5
+	require.Error(t, group.Validate())
+	group.Threshold = 3
+
+	duplicate := *group.Nodes[1].Identity
+	duplicate.Addr = "127.0.0.1:4000"
+	group.Nodes = append(group.Nodes, &Node{Identity: &duplicate, Index: 4})
+	require.Error(t, group.Validate())
+	group.Nodes[4] = &Node{Identity: group.Nodes[1].Identity, Index: 4}
+	require.Error(t, group.Validate())
+
+	small := &Group{Nodes: newIds(2), Threshold: 2}
+	require.Error(t, small.Validate())
+
+	require.Error(t, ValidateThreshold(1, 1, 0))
+	require.NoError(t, ValidateThreshold(5, 3, 0))
+	// the floor raises the minimum threshold
+	require.Error(t, ValidateThreshold(5, 3, 4))
+	require.NoError(t, ValidateThreshold(5, 4, 4))
+}
+
+func TestGroupLoadUnvalidated(t *testing.T) {
+	// a group created before the validation was enforced still loads
+	group := LoadGroup(newIds(2), 1, &DistPublic{Coefficients: []kyber.Point{
+		KeyGroup.Point().Pick(random.New()),
+		KeyGroup.Point().Pick(random.New()),
+	}}, 30*time.Second, 0)
+	group.Threshold = 2
+	require.Error(t, group.Validate())
+
+	received, err := GroupFromProto(group.ToProto())
+	require.NoError(t, err)
+	require.True(t, received.Equal(group))
+
+	decoded := new(Group)
+	require.NoError(t, decoded.FromTOML(group.TOML()))
+	require.True(t, decoded.Equal(group))
 }
@@ -2,6 +2,7 @@ package key
 
 import (
 	"bytes"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -30,6 +31,9 @@ type Identity struct {
 	Addr      string
 	TLS       bool
 	Signature []byte
+	// Fallbacks are additional addresses where the node can be reached. They
+	// are tried in order when Addr is not reachable.
+	Fallbacks []string
 }
 
 // Address implements the net.Peer interface
@@ -37,6 +41,11 @@ func (i *Identity) Address() string {
 	return i.Addr
 }
 
+// FallbackAddresses implements the net.FallbackPeer interface
+func (i *Identity) FallbackAddresses() []string {
+	return i.Fallbacks
+}
+
 // IsTLS returns true if this address is reachable over TLS.
 func (i *Identity) IsTLS() bool {
 	return i.TLS
@@ -48,10 +57,16 @@ func (i *Identity) String() string {
 
 // Hash returns the hash of the public key without signing the signature. The hash
 // is the input to the signature scheme. It does _not_ hash the address & tls
-// field as those may need to change while the node keeps the same key.
+// field as those may need to change while the node keeps the same key. The
+// fallback addresses are hashed, when there are any, so that they can't be
+// changed without the key of the node.
 func (i *Identity) Hash() []byte {
 	h := hashFunc()
 	_, _ = i.Key.MarshalTo(h)
+	for _, addr := range i.Fallbacks {
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(addr)))
+		_, _ = h.Write([]byte(addr))
+	}
 	return h.Sum(nil)
 }
 
@@ -73,6 +88,14 @@ func (i *Identity) Equal(i2 *Identity) bool {
 	if !i.Key.Equal(i2.Key) {
 		return false
 	}
+	if len(i.Fallbacks) != len(i2.Fallbacks) {
+		return false
+	}
+	for j := range i.Fallbacks {
+		if i.Fallbacks[j] != i2.Fallbacks[j] {
+			return false
+		}
+	}
 	return true
 }
 
@@ -109,6 +132,21 @@ func NewTLSKeyPair(address string) *Pair {
 	return kp
 }
 
+// newKeyPair returns the key pair of the given private key, signed for the
+// given address.
+func newKeyPair(k kyber.Scalar, address string, tls bool) *Pair {
+	p := &Pair{
+		Key: k,
+		Public: &Identity{
+			Key:  KeyGroup.Point().Mul(k, nil),
+			Addr: address,
+			TLS:  tls,
+		},
+	}
+	p.SelfSign()
+	return p
+}
+
 // PairTOML is the TOML-able version of a private key
 type PairTOML struct {
 	Key string
@@ -120,6 +158,7 @@ type PublicTOML struct {
 	Key       string
 	TLS       bool
 	Signature string
+	Fallbacks []string `toml:",omitempty"`
 }
 
 // TOML returns a struct that can be marshaled using a TOML-encoding library
@@ -159,6 +198,7 @@ func (i *Identity) FromTOML(t interface{}) error {
 	}
 	i.Addr = ptoml.Address
 	i.TLS = ptoml.TLS
+	i.Fallbacks = ptoml.Fallbacks
 	if ptoml.Signature != "" {
 		i.Signature, err = hex.DecodeString(ptoml.Signature)
 	}
@@ -173,6 +213,7 @@ func (i *Identity) TOML() interface{} {
 		Key:       hexKey,
 		TLS:       i.TLS,
 		Signature: hex.EncodeToString(i.Signature),
+		Fallbacks: i.Fallbacks,
 	}
 }
 
@@ -205,6 +246,11 @@ func IdentityFromProto(n *proto.Identity) (*Identity, error) {
 	if err != nil {
 		return nil, err
 	}
+	for _, addr := range n.GetFallbacks() {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			return nil, err
+		}
+	}
 	public := KeyGroup.Point()
 	if err := public.UnmarshalBinary(n.GetKey()); err != nil {
 		return nil, err
@@ -215,6 +261,7 @@ func IdentityFromProto(n *proto.Identity) (*Identity, error) {
 		TLS:       n.Tls,
 		Key:       public,
 		Signature: n.GetSignature(),
+		Fallbacks: n.GetFallbacks(),
 	}
 	return id, nil
 }
@@ -227,6 +274,7 @@ func (i *Identity) ToProto() *proto.Identity {
 		Key:       buff,
 		Tls:       i.TLS,
 		Signature: i.Signature,
+		Fallbacks: i.Fallbacks,
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	kyber "github.com/drand/kyber"
@@ -37,6 +38,44 @@ func TestKeyPublic(t *testing.T) {
 	require.Equal(t, kp.Public.Key.String(), p2.Key.String())
 }
 
+func TestKeyFallbacks(t *testing.T) {
+	kp := NewTLSKeyPair(testAddr)
+	unsigned := kp.Public.Hash()
+	kp.Public.Fallbacks = []string{"10.0.0.1:4444", "192.168.0.1:4444"}
+	// the fallbacks are part of the signed identity
+	require.Error(t, kp.Public.ValidSignature())
+	require.NotEqual(t, unsigned, kp.Public.Hash())
+	kp.SelfSign()
+
+	var writer bytes.Buffer
+	require.NoError(t, toml.NewEncoder(&writer).Encode(kp.Public.TOML()))
+	ptoml := new(PublicTOML)
+	_, err := toml.DecodeReader(&writer, ptoml)
+	require.NoError(t, err)
+	fromTOML := new(Identity)
+	require.NoError(t, fromTOML.FromTOML(ptoml))
+	require.True(t, kp.Public.Equal(fromTOML))
+
+	fromProto, err := IdentityFromProto(kp.Public.ToProto())
+	require.NoError(t, err)
+	require.True(t, kp.Public.Equal(fromProto))
+
+	require.NoError(t, fromProto.ValidSignature())
+	// they can't be changed without the key of the node
+	fromProto.Fallbacks = []string{"10.6.6.6:4444", "192.168.0.1:4444"}
+	require.Error(t, fromProto.ValidSignature())
+
+	// fallbacks are kept when the group is sent over the network
+	_, group := BatchIdentities(MinimumGroupSize)
+	group.Period = time.Second
+	group.GenesisTime = time.Now().Unix()
+	group.PublicKey = nil
+	group.Nodes[0] = &Node{Identity: kp.Public, Index: 0}
+	fromGroup, err := GroupFromProto(group.ToProto())
+	require.NoError(t, err)
+	require.Equal(t, kp.Public.Fallbacks, fromGroup.Nodes[0].FallbackAddresses())
+}
+
 func TestKeySignature(t *testing.T) {
 	kp := NewTLSKeyPair(testAddr)
 	validSig := kp.Public.Signature
@@ -0,0 +1,48 @@
+package key
+
+import (
+	"crypto/sha512"
+	"errors"
+	"strings"
+
+	bip39 "github.com/tyler-smith/go-bip39"
+)
+
+// mnemonicEntropyBits is the entropy of the generated mnemonics, encoded in
+// 24 words.
+const mnemonicEntropyBits = 256
+
+// mnemonicKeyDomain separates the derivation of the identity key from any
+// other use of the same mnemonic.
+const mnemonicKeyDomain = "drand:identity:v1"
+
+// NewMnemonic returns a fresh BIP39 mnemonic from which an identity key can be
+// derived with NewKeyPairFromMnemonic.
+func NewMnemonic() (string, error) {
+	entropy, err := bip39.NewEntropy(mnemonicEntropyBits)
+	if err != nil {
+		return "", err
+	}
+	return bip39.NewMnemonic(entropy)
+}
+
+// NewKeyPairFromMnemonic deterministically derives the identity key pair from
+// a BIP39 mnemonic, so it can be restored from a paper backup. Only the
+// identity is derived: the share must be obtained again through a DKG or a
+// resharing.
+func NewKeyPairFromMnemonic(mnemonic, address string, tls bool) (*Pair, error) {
+	mnemonic = strings.Join(strings.Fields(mnemonic), " ")
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, "")
+	if err != nil {
+		return nil, err
+	}
+	h := sha512.New()
+	_, _ = h.Write([]byte(mnemonicKeyDomain))
+	_, _ = h.Write(seed)
+	// the 512 bits digest is reduced modulo the group order without bias
+	k := KeyGroup.Scalar().SetBytes(h.Sum(nil))
+	if k.Equal(KeyGroup.Scalar().Zero()) {
+		return nil, errors.New("invalid key derived from mnemonic")
+	}
+	return newKeyPair(k, address, tls), nil
+}
@@ -0,0 +1,36 @@
+package key
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPairFromMnemonic(t *testing.T) {
+	mnemonic, err := NewMnemonic()
+	require.NoError(t, err)
+	require.Len(t, strings.Fields(mnemonic), 24)
+
+	p1, err := NewKeyPairFromMnemonic(mnemonic, testAddr, true)
+	require.NoError(t, err)
+	require.NoError(t, p1.Public.ValidSignature())
+	// the mnemonic may be written down with different spacing
+	p2, err := NewKeyPairFromMnemonic(" "+strings.ReplaceAll(mnemonic, " ", "\n")+"\n", "127.0.0.1:81", false)
+	require.NoError(t, err)
+	require.True(t, p1.Key.Equal(p2.Key))
+	require.True(t, p1.Public.Key.Equal(p2.Public.Key))
+
+	other, err := NewMnemonic()
+	require.NoError(t, err)
+	p3, err := NewKeyPairFromMnemonic(other, testAddr, true)
+	require.NoError(t, err)
+	require.False(t, p1.Key.Equal(p3.Key))
+
+	words := strings.Fields(mnemonic)
+	words[0], words[1] = words[1], words[0]
+	if words[0] != words[1] {
+		_, err = NewKeyPairFromMnemonic(strings.Join(words, " "), testAddr, true)
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,158 @@
+package key
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/BurntSushi/toml"
+	"github.com/drand/drand/fs"
+	kyber "github.com/drand/kyber"
+	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
+)
+
+// sharePartFileName is the name of the file holding one part of a split share
+// in each of the locations.
+const sharePartFileName = "dist_key.private.part"
+
+// shareSplit describes how the private share is split with Shamir's secret
+// sharing: any threshold of the parts, each stored at its own location, is
+// enough to recover it while fewer parts reveal nothing about it.
+type shareSplit struct {
+	threshold int
+	locations []string
+}
+
+// sharePartTOML is the content of one part file. The public parts of the
+// share are kept in clear in each of them.
+type sharePartTOML struct {
+	Index     int
+	Commits   []string
+	Part      int
+	Threshold int
+	Parts     int
+	Piece     string
+}
+
+// NewSplitShareFileStore returns a file store that splits the private share
+// across the given locations instead of saving it in the config folder. The
+// share can be recovered as long as threshold locations are available. The
+// threshold must be at least 2, otherwise each part would be a copy of the
+// share.
+func NewSplitShareFileStore(baseFolder string, threshold int, locations []string) (Store, error) {
+	if threshold < 2 || threshold > len(locations) {
+		return nil, fmt.Errorf("invalid share split: threshold %d for %d locations", threshold, len(locations))
+	}
+	for _, l := range locations {
+		if fs.CreateSecureFolder(l) == "" {
+			return nil, fmt.Errorf("can't create share location %s", l)
+		}
+	}
+	store := NewFileStore(baseFolder).(*fileStore)
+	store.split = &shareSplit{threshold: threshold, locations: locations}
+	return store, nil
+}
+
+// SharePartFiles returns the files holding the parts of the private share if
+// the store splits it, nil otherwise.
+func SharePartFiles(s Store) []string {
+	f, ok := s.(*fileStore)
+	if !ok || f.split == nil {
+		return nil
+	}
+	files := make([]string, len(f.split.locations))
+	for i := range files {
+		files[i] = f.split.partFile(i)
+	}
+	return files
+}
+
+func (s *shareSplit) partFile(i int) string {
+	return path.Join(s.locations[i], sharePartFileName)
+}
+
+func (s *shareSplit) save(sh *Share) error {
+	n := len(s.locations)
+	poly := share.NewPriPoly(KeyGroup, s.threshold, sh.Share.V, random.New())
+	pieces := poly.Shares(n)
+	commits := sh.TOML().(*ShareTOML).Commits
+	for i, piece := range pieces {
+		part := &sharePartTOML{
+			Index:     sh.Share.I,
+			Commits:   commits,
+			Part:      piece.I,
+			Threshold: s.threshold,
+			Parts:     n,
+			Piece:     ScalarToString(piece.V),
+		}
+		fd, err := fs.CreateSecureFile(s.partFile(i))
+		if err != nil {
+			return fmt.Errorf("can't save share part to %s: %s", s.partFile(i), err)
+		}
+		err = toml.NewEncoder(fd).Encode(part)
+		fd.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// load recovers the share from the parts found, as long as there are enough
+// of them.
+func (s *shareSplit) load() (*Share, error) {
+	var pieces []*share.PriShare
+	var first *sharePartTOML
+	for i := range s.locations {
+		part := new(sharePartTOML)
+		if _, err := toml.DecodeFile(s.partFile(i), part); err != nil {
+			continue
+		}
+		if first == nil {
+			first = part
+		} else if part.Index != first.Index || part.Threshold != first.Threshold || part.Parts != first.Parts {
+			return nil, fmt.Errorf("share part %s does not belong to the same share", s.partFile(i))
+		}
+		v, err := StringToScalar(KeyGroup, part.Piece)
+		if err != nil {
+			return nil, fmt.Errorf("invalid share part %s: %s", s.partFile(i), err)
+		}
+		pieces = append(pieces, &share.PriShare{I: part.Part, V: v})
+	}
+	if first == nil {
+		return nil, errors.New("no share part found")
+	}
+	if len(pieces) < first.Threshold {
+		return nil, fmt.Errorf("only %d share parts found, %d needed", len(pieces), first.Threshold)
+	}
+	secret, err := share.RecoverSecret(KeyGroup, pieces, first.Threshold, first.Parts)
+	if err != nil {
+		return nil, err
+	}
+	commits := make([]kyber.Point, len(first.Commits))
+	for i, c := range first.Commits {
+		if commits[i], err = StringToPoint(KeyGroup, c); err != nil {
+			return nil, err
+		}
+	}
+	sh := &Share{
+		Commits: commits,
+		Share:   &share.PriShare{I: first.Index, V: secret},
+	}
+	// corrupted or mixed up parts recover a wrong share
+	if !sh.PubPoly().Eval(first.Index).V.Equal(KeyGroup.Point().Mul(secret, nil)) {
+		return nil, errors.New("share recovered from the parts does not match its public commitment")
+	}
+	return sh, nil
+}
+
+func (s *shareSplit) reset() error {
+	for i := range s.locations {
+		if err := os.Remove(s.partFile(i)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
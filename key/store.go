@@ -56,6 +56,8 @@ type fileStore struct {
 	shareFile      string
 	distKeyFile    string
 	groupFile      string
+	// split is set when the share is split across multiple locations
+	split *shareSplit
 }
 
 // NewFileStore is used to create the config folder and all the subfolders.
@@ -106,13 +108,33 @@ func (f *fileStore) SaveGroup(g *Group) error {
 }
 
 func (f *fileStore) SaveShare(share *Share) error {
+	if f.split != nil {
+		fmt.Printf("crypto store: saving private share split in %d parts\n", len(f.split.locations))
+		if err := f.split.save(share); err != nil {
+			return err
+		}
+		// the share must not remain in clear on the disk
+		return Delete(f.shareFile)
+	}
 	fmt.Printf("crypto store: saving private share in %s\n", f.shareFile)
 	return Save(f.shareFile, share, true)
 }
 
 func (f *fileStore) LoadShare() (*Share, error) {
+	if f.split != nil {
+		if _, err := os.Stat(f.shareFile); err != nil {
+			return f.split.load()
+		}
+	}
 	s := new(Share)
-	return s, Load(f.shareFile, s)
+	if err := Load(f.shareFile, s); err != nil {
+		return s, err
+	}
+	if f.split != nil {
+		// the share has been saved before being split
+		return s, f.SaveShare(s)
+	}
+	return s, nil
 }
 
 func (f *fileStore) Reset(...ResetOption) error {
@@ -122,6 +144,11 @@ func (f *fileStore) Reset(...ResetOption) error {
 	if err := Delete(f.shareFile); err != nil {
 		return fmt.Errorf("drand: errd eleting share file: %v", err)
 	}
+	if f.split != nil {
+		if err := f.split.reset(); err != nil {
+			return fmt.Errorf("drand: err deleting share parts: %v", err)
+		}
+	}
 
 	if err := Delete(f.groupFile); err != nil {
 		return fmt.Errorf("drand: err deleting group file: %v", err)
@@ -130,7 +157,7 @@ func (f *fileStore) Reset(...ResetOption) error {
 }
 
 // Save the given Tomler interface to the given path. If secure is true, the
-// file will have a 0700 security.
+// file will have a 0600 security, otherwise it is readable by everyone.
 // TODO: move that to fs/
 func Save(filePath string, t Tomler, secure bool) error {
 	var fd *os.File
@@ -138,7 +165,7 @@ func Save(filePath string, t Tomler, secure bool) error {
 	if secure {
 		fd, err = fs.CreateSecureFile(filePath)
 	} else {
-		fd, err = os.Create(filePath)
+		fd, err = fs.CreatePublicFile(filePath)
 	}
 	if err != nil {
 		return fmt.Errorf("config: can't save %s to %s: %s", reflect.TypeOf(t).String(), filePath, err)
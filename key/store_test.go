@@ -7,6 +7,7 @@ import (
 
 	kyber "github.com/drand/kyber"
 	"github.com/drand/kyber/share"
+	"github.com/drand/kyber/util/random"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,3 +66,68 @@ func TestKeysSaveLoad(t *testing.T) {
 	require.Equal(t, testShare.Share.V, loadedShare.Share.V)
 	require.Equal(t, testShare.Share.I, loadedShare.Share.I)
 }
+
+func TestSplitShareStore(t *testing.T) {
+	tmp := path.Join(os.TempDir(), "drand-split")
+	os.RemoveAll(tmp)
+	defer os.RemoveAll(tmp)
+	locations := []string{path.Join(tmp, "a"), path.Join(tmp, "b"), path.Join(tmp, "c")}
+
+	_, err := NewSplitShareFileStore(path.Join(tmp, "config"), 4, locations)
+	require.Error(t, err)
+	// each part would be a copy of the share
+	_, err = NewSplitShareFileStore(path.Join(tmp, "config"), 1, locations)
+	require.Error(t, err)
+
+	priPoly := share.NewPriPoly(KeyGroup, 2, nil, random.New())
+	_, commits := priPoly.Commit(KeyGroup.Point().Base()).Info()
+	testShare := &Share{
+		Commits: commits,
+		Share:   priPoly.Shares(5)[3],
+	}
+	// a share saved in clear is split when loaded
+	plain := NewFileStore(path.Join(tmp, "config"))
+	require.NoError(t, plain.SaveShare(testShare))
+	store, err := NewSplitShareFileStore(path.Join(tmp, "config"), 2, locations)
+	require.NoError(t, err)
+	loaded, err := store.LoadShare()
+	require.NoError(t, err)
+	require.True(t, testShare.Share.V.Equal(loaded.Share.V))
+	_, err = os.Stat(store.(*fileStore).shareFile)
+	require.True(t, os.IsNotExist(err))
+
+	// any two parts are enough
+	require.NoError(t, os.Remove(path.Join(locations[1], sharePartFileName)))
+	loaded, err = store.LoadShare()
+	require.NoError(t, err)
+	require.True(t, testShare.Share.V.Equal(loaded.Share.V))
+	require.Equal(t, testShare.Share.I, loaded.Share.I)
+	require.True(t, testShare.Commits[1].Equal(loaded.Commits[1]))
+
+	// a single part reveals nothing
+	require.NoError(t, os.Remove(path.Join(locations[2], sharePartFileName)))
+	_, err = store.LoadShare()
+	require.Error(t, err)
+
+	// the recovered share is checked against its commitments
+	require.NoError(t, store.SaveShare(testShare))
+	require.Equal(t, []string{
+		path.Join(locations[0], sharePartFileName),
+		path.Join(locations[1], sharePartFileName),
+		path.Join(locations[2], sharePartFileName),
+	}, SharePartFiles(store))
+	other := &Share{
+		Commits: commits,
+		Share:   priPoly.Shares(5)[4],
+	}
+	other.Share.I = testShare.Share.I
+	require.NoError(t, store.SaveShare(other))
+	_, err = store.LoadShare()
+	require.Error(t, err)
+	require.Nil(t, SharePartFiles(plain))
+
+	require.NoError(t, store.SaveShare(testShare))
+	require.NoError(t, store.Reset())
+	_, err = store.LoadShare()
+	require.Error(t, err)
+}
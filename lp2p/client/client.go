@@ -167,6 +167,8 @@ func (c *Client) Watch(ctx context.Context) <-chan client.Result {
 					Random:            resp.Randomness,
 					Sig:               resp.Signature,
 					PreviousSignature: resp.PreviousSignature,
+					ChainHash:         resp.ChainHash,
+					Contributors:      resp.Contributors,
 				}:
 				default:
 					c.log.Warn("gossip client", "randomness notification dropped due to a full channel")
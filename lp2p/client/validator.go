@@ -26,6 +26,10 @@ func randomnessValidator(info *chain.Info, cache client.Cache, c *Client) pubsub
 			return pubsub.ValidationAccept
 		}
 
+		if hash := rand.GetChainHash(); len(hash) > 0 && !bytes.Equal(hash, info.Hash()) {
+			return pubsub.ValidationReject
+		}
+
 		b := chain.Beacon{
 			Round:       rand.GetRound(),
 			Signature:   rand.GetSignature(),
@@ -33,7 +37,7 @@ func randomnessValidator(info *chain.Info, cache client.Cache, c *Client) pubsub
 		}
 
 		// Unwilling to relay beacons in the future.
-		if time.Unix(chain.TimeOfRound(info.Period, info.GenesisTime, b.Round), 0).After(time.Now()) {
+		if time.Unix(info.Schedule().TimeOfRound(b.Round), 0).After(time.Now()) {
 			return pubsub.ValidationReject
 		}
 
@@ -61,7 +65,7 @@ func randomnessValidator(info *chain.Info, cache client.Cache, c *Client) pubsub
 			}
 		}
 
-		if err := chain.VerifyBeacon(info.PublicKey, &b); err != nil {
+		if err := info.VerifyBeacon(&b); err != nil {
 			return pubsub.ValidationReject
 		}
 		return pubsub.ValidationAccept
@@ -157,6 +157,8 @@ func (g *GossipRelayNode) background(w client.Watcher) {
 					Signature:         res.Signature(),
 					PreviousSignature: rd.PreviousSignature,
 					Randomness:        res.Randomness(),
+					ChainHash:         rd.ChainHash,
+					Contributors:      rd.Contributors,
 				})
 				if err != nil {
 					g.l.Error("relay_node", "err marshaling", "err", err)
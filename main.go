@@ -10,7 +10,7 @@ import (
 func main() {
 	app := drand.CLI()
 	if err := app.Run(os.Args); err != nil {
-		fmt.Printf("%+v\n", err)
+		fmt.Fprintf(os.Stderr, "%+v\n", err)
 		os.Exit(1)
 	}
 }
@@ -38,12 +38,136 @@ var (
 		Name: "group_connections",
 		Help: "Number of peers with current GrpcClient connections",
 	})
+	// PeerLastSuccess (Group) unix time of the last successful unary RPC to
+	// each peer
+	PeerLastSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "peer_last_success_time",
+		Help: "Time of the last successful RPC to each peer address",
+	}, []string{"peer_address"})
+	// PeerFailureStreak (Group) number of consecutive unary RPCs that could not
+	// reach each peer
+	PeerFailureStreak = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "peer_failure_streak",
+		Help: "Number of consecutive RPCs to each peer address that failed as unavailable or timed out",
+	}, []string{"peer_address"})
+	// PeerRTT (Group) round-trip time of the last successful unary RPC to each
+	// peer
+	PeerRTT = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "peer_rtt_seconds",
+		Help: "Round-trip time of the last successful RPC to each peer address",
+	}, []string{"peer_address"})
 	// BeaconDiscrepancyLatency (Group) millisecond duration between time beacon created and
 	// calculated time of round.
 	BeaconDiscrepancyLatency = prometheus.NewGauge(prometheus.GaugeOpts{
 		Name: "beacon_discrepancy_latency",
 		Help: "Discrepancy between beacon creation time and calculated round time",
 	})
+	// PartialsReceived (Group) number of partial signatures received from each
+	// node of the group, including this one
+	PartialsReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_received",
+		Help: "Number of partial signatures received from each node index",
+	}, []string{"index"})
+	// PartialReplays (Group) number of copies of already verified partial
+	// signatures dropped before their verification, for each node of the group
+	PartialReplays = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partial_replays_suppressed",
+		Help: "Number of replayed partial signatures dropped before verification for each node index",
+	}, []string{"index"})
+	// PartialsExpired (Group) number of partial signatures dropped before
+	// their verification because their round is too old, for each node of the
+	// group
+	PartialsExpired = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partials_expired",
+		Help: "Number of partial signatures for expired rounds dropped before verification for each node index",
+	}, []string{"index"})
+	// PartialDelay (Group) millisecond duration between the start of the round
+	// and the reception of the last partial of each node
+	PartialDelay = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "partial_delay",
+		Help: "Delay between the round start and the reception of the last partial signature of each node index",
+	}, []string{"index"})
+	// PartialSendLatency (Group) duration of the successful sends of the
+	// partial signatures of this node to each node of the group
+	PartialSendLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "partial_send_duration_seconds",
+		Help:    "Duration of the successful sends of partial signatures to each node address",
+		Buckets: prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"address"})
+	// PartialSendFailures (Group) number of partial signatures of this node
+	// that each node of the group did not receive
+	PartialSendFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "partial_send_failures",
+		Help: "Number of partial signatures not delivered to each node address",
+	}, []string{"address"})
+	// BeaconAggregationLatency (Group) millisecond duration between the start
+	// of the round and the aggregation of its beacon by this node
+	BeaconAggregationLatency = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "beacon_aggregation_latency",
+		Help: "Delay between the round start and the aggregation of its beacon",
+	})
+	// MissedRounds (Group) number of consecutive rounds missing from the chain
+	MissedRounds = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "missed_rounds",
+		Help: "Number of consecutive rounds that should have been produced after the last beacon stored",
+	})
+	// HaltAlerts (Group) number of alerts raised because the chain halted
+	HaltAlerts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "halt_alerts",
+		Help: "Number of alerts raised because consecutive rounds were missed",
+	})
+	// EquivocationAlerts (Group) number of partial signatures this node
+	// refused to emit because it already signed another message for the round
+	EquivocationAlerts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "equivocation_alerts",
+		Help: "Number of partial signatures refused because another message was already signed for the round",
+	})
+	// Equivocations (Group) number of rounds for which each node of the group
+	// was caught signing two different messages
+	Equivocations = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "equivocations",
+		Help: "Number of rounds for which each node index signed two different messages",
+	}, []string{"index"})
+	// KeySignatures (Group) number of partial signatures produced with the
+	// current share and with the identity key of the node
+	KeySignatures = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "key_signatures",
+		Help: "Number of partial signatures produced with the current share or identity key",
+	}, []string{"key"})
+	// KeyUsageSince (Group) unix time of the first partial signature produced
+	// with the current share and with the identity key of the node
+	KeyUsageSince = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "key_usage_since",
+		Help: "Time of the first partial signature produced with the current share or identity key",
+	}, []string{"key"})
+	// Maintenance (Group) 1 while the node at the address is in a maintenance
+	// window declared by its operator, 0 otherwise
+	Maintenance = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "maintenance",
+		Help: "Whether the node at the address is in a declared maintenance window",
+	}, []string{"address"})
+	// BeaconRestarts (Group) number of times the watchdog restarted a stalled beacon loop
+	BeaconRestarts = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_restarts",
+		Help: "Number of times the watchdog restarted a stalled beacon loop",
+	})
+	// BeaconCacheHits (Group) number of beacons read from the cache of the
+	// latest beacons instead of the database
+	BeaconCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_cache_hits",
+		Help: "Number of beacons read from the in-memory cache",
+	})
+	// BeaconCacheMisses (Group) number of beacons read from the database
+	// because they were not in the cache
+	BeaconCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "beacon_cache_misses",
+		Help: "Number of beacons read from the database because they were not in the in-memory cache",
+	})
+	// StoreSize (Group) size in bytes of the beacon database
+	StoreSize = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "store_size_bytes",
+		Help: "Size of the beacon database on disk",
+	})
 
 	// HTTPCallCounter (HTTP) how many http requests
 	HTTPCallCounter = prometheus.NewCounterVec(prometheus.CounterOpts{
@@ -158,7 +282,28 @@ func bindMetrics() error {
 		APICallCounter,
 		GroupDialFailures,
 		GroupConnections,
+		PeerLastSuccess,
+		PeerFailureStreak,
+		PeerRTT,
 		BeaconDiscrepancyLatency,
+		StoreSize,
+		BeaconCacheHits,
+		BeaconCacheMisses,
+		PartialsReceived,
+		PartialReplays,
+		PartialsExpired,
+		PartialDelay,
+		PartialSendLatency,
+		PartialSendFailures,
+		BeaconAggregationLatency,
+		MissedRounds,
+		HaltAlerts,
+		EquivocationAlerts,
+		Equivocations,
+		Maintenance,
+		KeySignatures,
+		KeyUsageSince,
+		BeaconRestarts,
 	}
 	for _, c := range group {
 		if err := GroupMetrics.Register(c); err != nil {
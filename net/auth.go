@@ -0,0 +1,166 @@
+package net
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// publicMethodPrefix is the prefix of all methods of the Public service.
+const publicMethodPrefix = "/drand.Public/"
+
+// authorizationHeader is the HTTP header, or gRPC metadata key, carrying the
+// bearer token of a request.
+const authorizationHeader = "authorization"
+
+// Auth restricts the access to the public API to the clients presenting one of
+// the bearer tokens, or a TLS certificate signed by one of the client CAs.
+type Auth struct {
+	tokens    [][]byte
+	clientCAs *x509.CertPool
+}
+
+// NewAuth returns an Auth accepting the given bearer tokens and the client
+// certificates signed by the CAs found in the given PEM files.
+func NewAuth(tokens []string, clientCAPaths ...string) (*Auth, error) {
+	a := new(Auth)
+	for _, t := range tokens {
+		if t == "" {
+			return nil, errors.New("auth: empty bearer token")
+		}
+		a.tokens = append(a.tokens, []byte(t))
+	}
+	if len(clientCAPaths) > 0 {
+		a.clientCAs = x509.NewCertPool()
+		for _, p := range clientCAPaths {
+			pem, err := ioutil.ReadFile(p)
+			if err != nil {
+				return nil, err
+			}
+			if !a.clientCAs.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("auth: no certificate found in %s", p)
+			}
+		}
+	}
+	if len(a.tokens) == 0 && a.clientCAs == nil {
+		return nil, errors.New("auth: no token nor client CA given")
+	}
+	return a, nil
+}
+
+// PublicAuthenticator is an optional interface a Service can implement to
+// restrict the access to its Public gRPC service. A nil Auth means the
+// service is open to everyone. The Protocol service is never restricted since
+// the other members of the group must be able to reach it.
+type PublicAuthenticator interface {
+	PublicAuth() *Auth
+}
+
+// validToken returns true if the "Bearer <token>" value carries one of the
+// accepted tokens.
+func (a *Auth) validToken(value string) bool {
+	const prefix = "bearer "
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return false
+	}
+	token := []byte(value[len(prefix):])
+	valid := false
+	for _, t := range a.tokens {
+		if subtle.ConstantTimeCompare(t, token) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// validState returns true if the client presented a certificate signed by one
+// of the client CAs.
+func (a *Auth) validState(state *tls.ConnectionState) bool {
+	if a.clientCAs == nil || state == nil || len(state.PeerCertificates) == 0 {
+		return false
+	}
+	intermediates := x509.NewCertPool()
+	for _, c := range state.PeerCertificates[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err := state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		Roots:         a.clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return err == nil
+}
+
+// applyTLS makes the server ask for client certificates. They remain optional
+// and unverified at the TLS level since clients can authenticate with a token
+// instead, and the other nodes present certificates signed by other CAs: they
+// are verified by validState.
+func (a *Auth) applyTLS(c *tls.Config) {
+	if a == nil || a.clientCAs == nil {
+		return
+	}
+	c.ClientAuth = tls.RequestClientCert
+}
+
+// Handler returns an HTTP handler answering 401 to unauthenticated requests
+// and passing the others to h.
+func (a *Auth) Handler(h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !a.validToken(r.Header.Get(authorizationHeader)) && !a.validState(r.TLS) {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+func (a *Auth) authorize(ctx context.Context, method string) error {
+	if !strings.HasPrefix(method, publicMethodPrefix) {
+		return nil
+	}
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, v := range md.Get(authorizationHeader) {
+			if a.validToken(v) {
+				return nil
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		if info, ok := p.AuthInfo.(credentials.TLSInfo); ok && a.validState(&info.State) {
+			return nil
+		}
+	}
+	return status.Errorf(codes.Unauthenticated, "missing or invalid credentials for %s", method)
+}
+
+func (a *Auth) unaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := a.authorize(ctx, info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+func (a *Auth) streamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := a.authorize(ss.Context(), info.FullMethod); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
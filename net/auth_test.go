@@ -0,0 +1,75 @@
+package net
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type authRandomnessServer struct {
+	testRandomnessServer
+	auth *Auth
+}
+
+func (a *authRandomnessServer) PublicAuth() *Auth {
+	return a.auth
+}
+
+func TestAuthGRPC(t *testing.T) {
+	ctx := context.Background()
+	auth, err := NewAuth([]string{"secret"})
+	require.NoError(t, err)
+	randServer := &authRandomnessServer{testRandomnessServer{round: 42}, auth}
+
+	lis, err := NewGRPCListenerForPrivate(ctx, "localhost:", "", "", randServer, true)
+	require.NoError(t, err)
+	go lis.Start()
+	defer lis.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	client := NewGrpcClient()
+	p := &testPeer{lis.Addr(), false}
+	_, err = client.PublicRand(ctx, p, &drand.PublicRandRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	bad := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer wrong")
+	_, err = client.PublicRand(bad, p, &drand.PublicRandRequest{})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	good := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer secret")
+	resp, err := client.PublicRand(good, p, &drand.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), resp.GetRound())
+
+	// the protocol service stays open to the other members
+	require.NoError(t, auth.authorize(ctx, protocolMethodPrefix+"PartialBeacon"))
+}
+
+func TestAuthHTTP(t *testing.T) {
+	_, err := NewAuth(nil)
+	require.Error(t, err)
+	auth, err := NewAuth([]string{"secret"})
+	require.NoError(t, err)
+	handler := auth.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	req := httptest.NewRequest("GET", "/public/latest", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusUnauthorized, rr.Code)
+
+	req.Header.Set("Authorization", "Bearer secret")
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	require.Equal(t, http.StatusOK, rr.Code)
+	require.Equal(t, "ok", rr.Body.String())
+}
@@ -1,18 +1,39 @@
 package net
 
 import (
+	"context"
+	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"time"
 
+	"github.com/drand/drand/fs"
 	"github.com/drand/drand/log"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
 )
 
 // CertManager is used to managed certificates. It is most commonly used for
 // testing with self signed certificate. By default, it returns the bundled set
 // of certificates coming with the OS (Go's implementation).
+//
+// The trusted certificates can be synchronized with the content of a folder,
+// so peers can rotate their certificates: a certificate removed from the
+// folder is still trusted during the overlap window, letting the peer switch
+// to its new certificate at any time during that window.
 type CertManager struct {
+	sync.Mutex
 	pool *x509.CertPool
+	// trusted maps the PEM content of each added certificate to the time it
+	// has been removed from the synchronized folder, zero if it is present.
+	trusted map[string]time.Time
+	overlap time.Duration
+	clock   func() time.Time
 }
 
 // NewCertManager returns a cert manager filled with the trusted certificates of
@@ -22,11 +43,17 @@ func NewCertManager() *CertManager {
 	if err != nil {
 		panic(err)
 	}
-	return &CertManager{pool}
+	return &CertManager{
+		pool:    pool,
+		trusted: make(map[string]time.Time),
+		clock:   time.Now,
+	}
 }
 
 // Pool returns the pool of trusted certificates
 func (p *CertManager) Pool() *x509.CertPool {
+	p.Lock()
+	defer p.Unlock()
 	return p.pool
 }
 
@@ -37,9 +64,249 @@ func (p *CertManager) Add(certPath string) error {
 	if err != nil {
 		return err
 	}
+	p.Lock()
+	defer p.Unlock()
 	if !p.pool.AppendCertsFromPEM(b) {
 		return fmt.Errorf("peer cert: failed to append certificate %s", certPath)
 	}
+	p.trusted[string(b)] = time.Time{}
 	log.DefaultLogger().Debug("cert_manager", "add", "server cert path", certPath)
 	return nil
 }
+
+// SetOverlap sets for how long a certificate removed from the synchronized
+// folder is still trusted.
+func (p *CertManager) SetOverlap(overlap time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+	p.overlap = overlap
+}
+
+// Sync makes the certificates at the given paths trusted, and the other ones
+// untrusted once the overlap window has passed since they were removed.
+func (p *CertManager) Sync(certPaths []string) error {
+	present := make(map[string]bool, len(certPaths))
+	for _, path := range certPaths {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(b) {
+			return fmt.Errorf("peer cert: no certificate in %s", path)
+		}
+		present[string(b)] = true
+	}
+
+	p.Lock()
+	defer p.Unlock()
+	now := p.clock()
+	changed := false
+	for pem := range present {
+		if removed, ok := p.trusted[pem]; !ok || !removed.IsZero() {
+			p.trusted[pem] = time.Time{}
+			changed = true
+		}
+	}
+	for pem, removed := range p.trusted {
+		switch {
+		case present[pem]:
+		case removed.IsZero():
+			p.trusted[pem] = now
+		case now.Sub(removed) >= p.overlap:
+			delete(p.trusted, pem)
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		return err
+	}
+	for pem := range p.trusted {
+		pool.AppendCertsFromPEM([]byte(pem))
+	}
+	p.pool = pool
+	log.DefaultLogger().Info("cert_manager", "trusted certificates updated", "count", len(p.trusted))
+	return nil
+}
+
+// WatchFolder synchronizes the trusted certificates with the files of the
+// folder at each period, until the returned function is called.
+func (p *CertManager) WatchFolder(folder string, period time.Duration) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				paths, err := fs.Files(folder)
+				if err == nil {
+					err = p.Sync(paths)
+				}
+				if err != nil {
+					log.DefaultLogger().Error("cert_manager", "sync failed", "folder", folder, "err", err)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return cancel
+}
+
+// VerifyPeer checks that the client of the inbound request presented a TLS
+// certificate trusted by the manager and valid for the host of the given
+// address, i.e. that the request comes from the node listening at that
+// address.
+func (p *CertManager) VerifyPeer(ctx context.Context, addr string) error {
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return errors.New("peer cert: unknown peer")
+	}
+	info, ok := pr.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(info.State.PeerCertificates) == 0 {
+		return errors.New("peer cert: the peer presented no certificate")
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		host = addr
+	}
+	certs := info.State.PeerCertificates
+	intermediates := x509.NewCertPool()
+	for _, c := range certs[1:] {
+		intermediates.AddCert(c)
+	}
+	_, err = certs[0].Verify(x509.VerifyOptions{
+		Roots:         p.Pool(),
+		Intermediates: intermediates,
+		DNSName:       host,
+		// the nodes present the certificate they serve, which is usually
+		// only meant for server authentication
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("peer cert: the certificate is not valid for %s: %w", addr, err)
+	}
+	return nil
+}
+
+// clientCredentials returns gRPC credentials verifying the servers against
+// the certificates trusted at the time of each handshake, so reconnections
+// pick up the rotated certificates. If cert is not nil, its certificate is
+// presented to the servers asking for one.
+func (p *CertManager) clientCredentials(cert *certReloader) credentials.TransportCredentials {
+	return &managedCredentials{
+		TransportCredentials: credentials.NewClientTLSFromCert(p.Pool(), ""),
+		manager:              p,
+		cert:                 cert,
+	}
+}
+
+type managedCredentials struct {
+	credentials.TransportCredentials
+	manager    *CertManager
+	cert       *certReloader
+	serverName string
+}
+
+func (m *managedCredentials) ClientHandshake(ctx context.Context, authority string, conn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	creds := credentials.NewTLS(clientTLSConfig(m.manager.Pool(), m.serverName, m.cert))
+	return creds.ClientHandshake(ctx, authority, conn)
+}
+
+func (m *managedCredentials) Clone() credentials.TransportCredentials {
+	return &managedCredentials{
+		TransportCredentials: m.TransportCredentials.Clone(),
+		manager:              m.manager,
+		cert:                 m.cert,
+		serverName:           m.serverName,
+	}
+}
+
+// clientTLSConfig returns the TLS configuration of a client trusting the given
+// pool, or the system certificates if it is nil, and presenting the
+// certificate of cert if it is not nil.
+func clientTLSConfig(pool *x509.CertPool, serverName string, cert *certReloader) *tls.Config {
+	c := &tls.Config{RootCAs: pool, ServerName: serverName}
+	if cert != nil {
+		c.GetClientCertificate = cert.GetClientCertificate
+	}
+	applyFIPS(c)
+	return c
+}
+
+func (m *managedCredentials) OverrideServerName(name string) error {
+	m.serverName = name
+	return m.TransportCredentials.OverrideServerName(name)
+}
+
+// certReloader serves the certificate of a node, reloading it from the disk
+// when the files change, so a node can renew its certificate without restart.
+type certReloader struct {
+	sync.Mutex
+	certPath, keyPath string
+	cert              *tls.Certificate
+	modTime           time.Time
+}
+
+func newCertReloader(certPath, keyPath string) (*certReloader, error) {
+	c := &certReloader{certPath: certPath, keyPath: keyPath}
+	if err := c.reload(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// lastModTime returns the latest modification time of the two files.
+func (c *certReloader) lastModTime() (time.Time, error) {
+	var last time.Time
+	for _, path := range []string{c.certPath, c.keyPath} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return last, err
+		}
+		if info.ModTime().After(last) {
+			last = info.ModTime()
+		}
+	}
+	return last, nil
+}
+
+func (c *certReloader) reload() error {
+	modTime, err := c.lastModTime()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(c.certPath, c.keyPath)
+	if err != nil {
+		return err
+	}
+	c.cert = &cert
+	c.modTime = modTime
+	return nil
+}
+
+// GetCertificate implements the tls.Config callback. The previous certificate
+// keeps being served if the new files can not be loaded, e.g. while they are
+// being written.
+func (c *certReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	c.Lock()
+	defer c.Unlock()
+	if modTime, err := c.lastModTime(); err == nil && !modTime.Equal(c.modTime) {
+		if err := c.reload(); err != nil {
+			log.DefaultLogger().Warn("cert_reloader", "failed to reload certificate", "err", err)
+		} else {
+			log.DefaultLogger().Info("cert_reloader", "certificate reloaded", "path", c.certPath)
+		}
+	}
+	return c.cert, nil
+}
+
+// GetClientCertificate implements the tls.Config callback of the clients, so
+// a node authenticates to its peers with the certificate it serves.
+func (c *certReloader) GetClientCertificate(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return c.GetCertificate(nil)
+}
@@ -0,0 +1,142 @@
+package net
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+	"path"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/protobuf/drand"
+	testnet "github.com/drand/drand/test/net"
+	"github.com/kabukky/httpscerts"
+	"github.com/stretchr/testify/require"
+)
+
+func genCert(t *testing.T, dir, name string) (certPath, keyPath string, cert *x509.Certificate) {
+	certPath = path.Join(dir, name+".crt")
+	keyPath = path.Join(dir, name+".key")
+	require.NoError(t, httpscerts.Generate(certPath, keyPath, "127.0.0.1"))
+	pair, err := tls.LoadX509KeyPair(certPath, keyPath)
+	require.NoError(t, err)
+	cert, err = x509.ParseCertificate(pair.Certificate[0])
+	require.NoError(t, err)
+	return certPath, keyPath, cert
+}
+
+func trusts(m *CertManager, cert *x509.Certificate) bool {
+	_, err := cert.Verify(x509.VerifyOptions{Roots: m.Pool(), DNSName: "127.0.0.1"})
+	return err == nil
+}
+
+func TestCertManagerSync(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drand-certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	oldPath, _, oldCert := genCert(t, dir, "old")
+	newPath, _, newCert := genCert(t, dir, "new")
+
+	now := time.Now()
+	m := NewCertManager()
+	m.clock = func() time.Time { return now }
+	m.SetOverlap(time.Hour)
+
+	require.NoError(t, m.Add(oldPath))
+	require.True(t, trusts(m, oldCert))
+	require.False(t, trusts(m, newCert))
+
+	// the peer rotated its certificate: both are trusted during the overlap
+	require.NoError(t, m.Sync([]string{newPath}))
+	require.True(t, trusts(m, oldCert))
+	require.True(t, trusts(m, newCert))
+
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, m.Sync([]string{newPath}))
+	require.True(t, trusts(m, oldCert))
+
+	now = now.Add(31 * time.Minute)
+	require.NoError(t, m.Sync([]string{newPath}))
+	require.False(t, trusts(m, oldCert))
+	require.True(t, trusts(m, newCert))
+}
+
+func TestCertReloader(t *testing.T) {
+	dir, err := ioutil.TempDir("", "drand-certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	certPath, keyPath, first := genCert(t, dir, "node")
+
+	r, err := newCertReloader(certPath, keyPath)
+	require.NoError(t, err)
+	served, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, first.Raw, served.Certificate[0])
+
+	_, _, second := genCert(t, dir, "node")
+	future := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certPath, future, future))
+	served, err = r.GetCertificate(nil)
+	require.NoError(t, err)
+	require.Equal(t, second.Raw, served.Certificate[0])
+}
+
+// peerServer records the result of the verification of the sender of each
+// partial against addr.
+type peerServer struct {
+	*testnet.EmptyServer
+	manager *CertManager
+	addr    string
+	err     error
+}
+
+func (p *peerServer) PartialBeacon(c context.Context, in *drand.PartialBeaconPacket) (*drand.Empty, error) {
+	p.err = p.manager.VerifyPeer(c, p.addr)
+	return new(drand.Empty), nil
+}
+
+func TestVerifyPeer(t *testing.T) {
+	if runtime.GOOS == runtimeGOOSWindows {
+		t.Skip("crypto/x509: system root pool is not available on Windows")
+	}
+	ctx := context.Background()
+	dir, err := ioutil.TempDir("", "drand-certs")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	serverCert, serverKey, _ := genCert(t, dir, "server")
+	clientCert, clientKey, _ := genCert(t, dir, "client")
+
+	m := NewCertManager()
+	require.NoError(t, m.Add(serverCert))
+	require.NoError(t, m.Add(clientCert))
+	server := &peerServer{manager: m}
+	lis, err := NewGRPCListenerForPrivate(ctx, "127.0.0.1:", serverCert, serverKey, server, false)
+	require.NoError(t, err)
+	go lis.Start()
+	defer lis.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+	p := &testPeer{lis.Addr(), true}
+
+	// a client without certificate is not authenticated
+	anonymous := NewGrpcClientFromCertManager(m)
+	server.addr = "127.0.0.1:4444"
+	err = anonymous.PartialBeacon(ctx, p, &drand.PartialBeaconPacket{})
+	require.NoError(t, err)
+	require.Error(t, server.err)
+
+	client := NewGrpcClientFromCertManager(m).(*grpcClient)
+	client.cert, err = newCertReloader(clientCert, clientKey)
+	require.NoError(t, err)
+	err = client.PartialBeacon(ctx, p, &drand.PartialBeaconPacket{})
+	require.NoError(t, err)
+	require.NoError(t, server.err)
+
+	// the certificate is not valid for another node
+	server.addr = "10.0.0.1:4444"
+	err = client.PartialBeacon(ctx, p, &drand.PartialBeaconPacket{})
+	require.NoError(t, err)
+	require.Error(t, server.err)
+}
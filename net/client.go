@@ -24,10 +24,17 @@ type CallOption = grpc.CallOption
 type ProtocolClient interface {
 	GetIdentity(ctx context.Context, p Peer, in *drand.IdentityRequest, opts ...CallOption) (*drand.Identity, error)
 	SyncChain(ctx context.Context, p Peer, in *drand.SyncRequest, opts ...CallOption) (chan *drand.BeaconPacket, error)
+	GetBeacon(ctx context.Context, p Peer, in *drand.GetBeaconRequest, opts ...CallOption) (*drand.BeaconPacket, error)
 	PartialBeacon(ctx context.Context, p Peer, in *drand.PartialBeaconPacket, opts ...CallOption) error
+	NewBeacon(ctx context.Context, p Peer, in *drand.PartialBeaconPacket, opts ...CallOption) (*drand.BeaconResponse, error)
 	BroadcastDKG(c context.Context, p Peer, in *drand.DKGPacket, opts ...CallOption) error
+	BroadcastDKGChunk(c context.Context, p Peer, in *drand.DKGChunk, opts ...CallOption) error
 	SignalDKGParticipant(ctx context.Context, p Peer, in *drand.SignalDKGPacket, opts ...CallOption) error
 	PushDKGInfo(ctx context.Context, p Peer, in *drand.DKGInfoPacket, opts ...grpc.CallOption) error
+	SignalDKGReady(ctx context.Context, p Peer, in *drand.DKGReadyPacket, opts ...CallOption) error
+	PushGroupProposal(ctx context.Context, p Peer, in *drand.GroupProposal, opts ...CallOption) error
+	EquivocationEvidence(ctx context.Context, p Peer, in *drand.EquivocationPacket, opts ...CallOption) error
+	AnnounceMaintenance(ctx context.Context, p Peer, in *drand.MaintenanceWindow, opts ...CallOption) error
 }
 
 // PublicClient holds all the methods of the public API . See
@@ -35,6 +42,7 @@ type ProtocolClient interface {
 type PublicClient interface {
 	PublicRandStream(ctx context.Context, p Peer, in *drand.PublicRandRequest, opts ...CallOption) (chan *drand.PublicRandResponse, error)
 	PublicRand(ctx context.Context, p Peer, in *drand.PublicRandRequest) (*drand.PublicRandResponse, error)
+	PublicRandRange(ctx context.Context, p Peer, in *drand.PublicRandRangeRequest) (*drand.PublicRandRangeResponse, error)
 	PrivateRand(ctx context.Context, p Peer, in *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error)
 	ChainInfo(ctx context.Context, p Peer, in *drand.ChainInfoRequest) (*drand.ChainInfoPacket, error)
 	Home(ctx context.Context, p Peer, in *drand.HomeRequest) (*drand.HomeResponse, error)
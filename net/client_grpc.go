@@ -2,7 +2,6 @@ package net
 
 import (
 	"context"
-	"crypto/tls"
 	"fmt"
 	"io"
 	"net"
@@ -18,6 +17,8 @@ import (
 	"golang.org/x/net/proxy"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
 )
 
 var _ Client = (*grpcClient)(nil)
@@ -30,6 +31,8 @@ type grpcClient struct {
 	opts    []grpc.DialOption
 	timeout time.Duration
 	manager *CertManager
+	// cert is the certificate presented to the peers, if any
+	cert *certReloader
 }
 
 var defaultTimeout = 1 * time.Minute
@@ -100,6 +103,17 @@ func (g *grpcClient) PublicRand(ctx context.Context, p Peer, in *drand.PublicRan
 	return client.PublicRand(ctx, in)
 }
 
+func (g *grpcClient) PublicRandRange(ctx context.Context, p Peer, in *drand.PublicRandRangeRequest) (*drand.PublicRandRangeResponse, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+	client := drand.NewPublicClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	return client.PublicRandRange(ctx, in)
+}
+
 const grpcClientRandStreamBacklog = 10
 
 // XXX move that to core/ client
@@ -190,6 +204,46 @@ func (g *grpcClient) SignalDKGParticipant(ctx context.Context, p Peer, in *drand
 	return err
 }
 
+func (g *grpcClient) SignalDKGReady(ctx context.Context, p Peer, in *drand.DKGReadyPacket, opts ...CallOption) error {
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	_, err = client.SignalDKGReady(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) PushGroupProposal(ctx context.Context, p Peer, in *drand.GroupProposal, opts ...CallOption) error {
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	_, err = client.PushGroupProposal(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) EquivocationEvidence(ctx context.Context, p Peer, in *drand.EquivocationPacket, opts ...CallOption) error {
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	_, err = client.EquivocationEvidence(ctx, in, opts...)
+	return err
+}
+
+func (g *grpcClient) AnnounceMaintenance(ctx context.Context, p Peer, in *drand.MaintenanceWindow, opts ...CallOption) error {
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	_, err = client.AnnounceMaintenance(ctx, in, opts...)
+	return err
+}
+
 func (g *grpcClient) BroadcastDKG(ctx context.Context, p Peer, in *drand.DKGPacket, opts ...CallOption) error {
 	c, err := g.conn(p)
 	if err != nil {
@@ -202,6 +256,18 @@ func (g *grpcClient) BroadcastDKG(ctx context.Context, p Peer, in *drand.DKGPack
 	return err
 }
 
+func (g *grpcClient) BroadcastDKGChunk(ctx context.Context, p Peer, in *drand.DKGChunk, opts ...CallOption) error {
+	c, err := g.conn(p)
+	if err != nil {
+		return err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	_, err = client.BroadcastDKGChunk(ctx, in, opts...)
+	return err
+}
+
 func (g *grpcClient) PartialBeacon(ctx context.Context, p Peer, in *drand.PartialBeaconPacket, opts ...CallOption) error {
 	c, err := g.conn(p)
 	if err != nil {
@@ -213,6 +279,28 @@ func (g *grpcClient) PartialBeacon(ctx context.Context, p Peer, in *drand.Partia
 	return err
 }
 
+func (g *grpcClient) NewBeacon(ctx context.Context, p Peer, in *drand.PartialBeaconPacket, opts ...CallOption) (*drand.BeaconResponse, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	return client.NewBeacon(ctx, in, opts...)
+}
+
+func (g *grpcClient) GetBeacon(ctx context.Context, p Peer, in *drand.GetBeaconRequest, opts ...CallOption) (*drand.BeaconPacket, error) {
+	c, err := g.conn(p)
+	if err != nil {
+		return nil, err
+	}
+	client := drand.NewProtocolClient(c)
+	ctx, cancel := g.getTimeoutContext(ctx)
+	defer cancel()
+	return client.GetBeacon(ctx, in, opts...)
+}
+
 // MaxSyncBuffer is the maximum number of queued rounds when syncing
 const MaxSyncBuffer = 100
 
@@ -246,8 +334,7 @@ func (g *grpcClient) SyncChain(ctx context.Context, p Peer, in *drand.SyncReques
 				log.DefaultLogger().Info("grpc client", "chain sync", "error", "context done", "to", p.Address())
 				fmt.Println(" --- STREAM CONTEXT DONE")
 				return
-			default:
-				resp <- reply
+			case resp <- reply:
 			}
 		}
 	}()
@@ -275,23 +362,24 @@ func (g *grpcClient) conn(p Peer) (*grpc.ClientConn, error) {
 	c, ok := g.conns[p.Address()]
 	if !ok {
 		log.DefaultLogger().Debug("grpc client", "initiating", "to", p.Address(), "tls", p.IsTLS())
+		target, peerOpts := dialTarget(p)
+		peerOpts = append(peerOpts, grpc.WithChainUnaryInterceptor(peerMetricsUnaryInterceptor(p.Address())))
 		if !p.IsTLS() {
-			c, err = grpc.Dial(p.Address(), append(g.opts, grpc.WithInsecure())...)
+			c, err = grpc.Dial(target, append(append(g.opts, peerOpts...), grpc.WithInsecure())...)
 			if err != nil {
 				metrics.GroupDialFailures.WithLabelValues(p.Address()).Inc()
 			}
 		} else {
 			var opts []grpc.DialOption
 			opts = append(opts, g.opts...)
+			opts = append(opts, peerOpts...)
 			if g.manager != nil {
-				pool := g.manager.Pool()
-				creds := credentials.NewClientTLSFromCert(pool, "")
-				opts = append(opts, grpc.WithTransportCredentials(creds))
+				opts = append(opts, grpc.WithTransportCredentials(g.manager.clientCredentials(g.cert)))
 			} else {
-				config := &tls.Config{}
+				config := clientTLSConfig(nil, "", g.cert)
 				opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(config)))
 			}
-			c, err = grpc.Dial(p.Address(), opts...)
+			c, err = grpc.Dial(target, opts...)
 			if err != nil {
 				metrics.GroupDialFailures.WithLabelValues(p.Address()).Inc()
 			}
@@ -302,6 +390,33 @@ func (g *grpcClient) conn(p Peer) (*grpc.ClientConn, error) {
 	return c, err
 }
 
+// fallbackScheme is the resolver scheme used to dial peers having fallback
+// addresses.
+const fallbackScheme = "drand-fallback"
+
+// dialTarget returns the target to dial to reach the peer. If the peer has
+// fallback addresses, they are given to gRPC through a dedicated resolver: the
+// default "pick first" balancing tries the addresses in order and keeps using
+// the first one that works.
+func dialTarget(p Peer) (string, []grpc.DialOption) {
+	addrs := peerAddresses(p)
+	if len(addrs) == 1 {
+		return p.Address(), nil
+	}
+	var state resolver.State
+	for _, addr := range addrs {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		// each address is verified against its own host name with TLS
+		state.Addresses = append(state.Addresses, resolver.Address{Addr: addr, ServerName: host})
+	}
+	r := manual.NewBuilderWithScheme(fallbackScheme)
+	r.InitialState(state)
+	return fallbackScheme + ":///" + p.Address(), []grpc.DialOption{grpc.WithResolvers(r)}
+}
+
 type httpHandler struct {
 	httpgrpc.HTTPClient
 }
@@ -2,7 +2,9 @@ package net
 
 import (
 	ctx "context"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"strings"
 	"time"
@@ -11,6 +13,7 @@ import (
 
 	"github.com/drand/drand/log"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
 )
 
 // ControlListener is used to keep state of the connections of our drand instance
@@ -19,16 +22,41 @@ type ControlListener struct {
 	lis   net.Listener
 }
 
-// NewTCPGrpcControlListener registers the pairing between a ControlServer and a grpx server
-func NewTCPGrpcControlListener(s control.ControlServer, controlAddr string) ControlListener {
+// NewTCPGrpcControlListener registers the pairing between a ControlServer and a grpx server.
+// It returns an error identifying the daemon already listening on the address, if any.
+func NewTCPGrpcControlListener(s control.ControlServer, controlAddr string) (ControlListener, error) {
 	lis, err := net.Listen(controlListenAddr(controlAddr))
 	if err != nil {
-		log.DefaultLogger().Error("grpc listener", "failure", "err", err)
-		return ControlListener{}
+		return ControlListener{}, controlInUse(controlAddr, err)
 	}
-	grpcServer := grpc.NewServer()
+	// control commands such as a DKG can legitimately run for a long time so
+	// no request timeout is enforced here, only panic recovery.
+	grpcServer := grpc.NewServer(serverInterceptors(nil, log.DefaultLogger(), nil, nil)...)
 	control.RegisterControlServer(grpcServer, s)
-	return ControlListener{conns: grpcServer, lis: lis}
+	reflection.Register(grpcServer)
+	return ControlListener{conns: grpcServer, lis: lis}, nil
+}
+
+// controlInUseTimeout is how long the daemon listening on a control address
+// already in use is given to tell its public key.
+const controlInUseTimeout = 2 * time.Second
+
+// controlInUse returns the error of a control listener that could not listen
+// on the address, naming the public key of the drand daemon listening on it if
+// there is one.
+func controlInUse(controlAddr string, err error) error {
+	client, cerr := NewControlClient(controlAddr)
+	if cerr != nil {
+		return fmt.Errorf("control port %s: %w", controlAddr, err)
+	}
+	defer client.conn.Close()
+	c, cancel := ctx.WithTimeout(ctx.Background(), controlInUseTimeout)
+	defer cancel()
+	resp, cerr := client.client.PublicKey(c, &control.PublicKeyRequest{})
+	if cerr != nil {
+		return fmt.Errorf("control port %s: %w", controlAddr, err)
+	}
+	return fmt.Errorf("control port %s is in use by the drand daemon with public key %x: %w", controlAddr, resp.GetPubKey(), err)
 }
 
 // Start the listener for the control commands
@@ -41,6 +69,8 @@ func (g *ControlListener) Start() {
 // Stop the listener and connections
 func (g *ControlListener) Stop() {
 	g.conns.Stop()
+	// the listener is only closed by the server if it is serving
+	g.lis.Close()
 }
 
 // ControlClient is a struct that implement control.ControlClient and is used to
@@ -75,34 +105,52 @@ func (c *ControlClient) Ping() error {
 	return err
 }
 
-// InitReshareLeader sets up the node to be ready for a resharing protocol.
+// InitReshareLeader sets up the node to be ready for a resharing protocol. A
+// non zero beacon period changes the period of the chain from the transition
+// round.
 // NOTE: only group referral via filesystem path is supported at the moment.
 // XXX Might be best to move to core/
+// The progress of the resharing is passed to progress if not nil.
 func (c *ControlClient) InitReshareLeader(
 	nodes, threshold int,
 	timeout, catchupPeriod time.Duration,
 	secret, oldPath string,
-	offset int) (*control.GroupPacket, error) {
+	offset int,
+	taggedMessages bool,
+	transitionRound uint64,
+	dryRun bool,
+	beaconPeriod time.Duration,
+	progress DKGProgressFunc) (*control.GroupPacket, error) {
 	request := &control.InitResharePacket{
 		Old: &control.GroupInfo{
 			Location: &control.GroupInfo_Path{Path: oldPath},
 		},
 		Info: &control.SetupInfoPacket{
-			Nodes:        uint32(nodes),
-			Threshold:    uint32(threshold),
-			Leader:       true,
-			Timeout:      uint32(timeout.Seconds()),
-			Secret:       []byte(secret),
-			BeaconOffset: uint32(offset),
+			Nodes:          uint32(nodes),
+			Threshold:      uint32(threshold),
+			Leader:         true,
+			Timeout:        uint32(timeout.Seconds()),
+			Secret:         []byte(secret),
+			BeaconOffset:   uint32(offset),
+			TaggedMessages: taggedMessages,
 		},
 		CatchupPeriodChanged: catchupPeriod >= 0,
 		CatchupPeriod:        uint32(catchupPeriod.Seconds()),
+		TransitionRound:      transitionRound,
+		DryRun:               dryRun,
+		BeaconPeriod:         uint32(beaconPeriod.Seconds()),
 	}
-	return c.client.InitReshare(ctx.Background(), request)
+	return c.initReshare(request, progress)
 }
 
 // InitReshare sets up the node to be ready for a resharing protocol.
-func (c *ControlClient) InitReshare(leader Peer, secret, oldPath string, force bool) (*control.GroupPacket, error) {
+// A non zero transition round makes the node refuse a new group taking over
+// at another round. A dry run resharing leaves the share and group of the
+// node untouched. The node refuses a new group whose period differs from the
+// given beacon period, or from the current period if zero. The progress of the
+// resharing is passed to progress if not nil.
+func (c *ControlClient) InitReshare(leader Peer, secret, oldPath string, force bool, transitionRound uint64, dryRun bool,
+	beaconPeriod time.Duration, progress DKGProgressFunc) (*control.GroupPacket, error) {
 	request := &control.InitResharePacket{
 		Old: &control.GroupInfo{
 			Location: &control.GroupInfo_Path{Path: oldPath},
@@ -114,37 +162,51 @@ func (c *ControlClient) InitReshare(leader Peer, secret, oldPath string, force b
 			Secret:        []byte(secret),
 			Force:         force,
 		},
+		TransitionRound: transitionRound,
+		DryRun:          dryRun,
+		BeaconPeriod:    uint32(beaconPeriod.Seconds()),
 	}
-	return c.client.InitReshare(ctx.Background(), request)
+	return c.initReshare(request, progress)
 }
 
+// DKGProgressFunc is called with the progress events of a DKG.
+type DKGProgressFunc func(*control.DKGProgress)
+
 // InitDKGLeader sets up the node to be ready for a first DKG protocol.
 // groupPart
 // NOTE: only group referral via filesystem path is supported at the moment.
 // XXX Might be best to move to core/
+// The progress of the DKG is passed to progress if not nil. The digest derives
+// the randomness of the new network from the signatures, SHA-256 if empty.
 func (c *ControlClient) InitDKGLeader(nodes, threshold int,
 	beaconPeriod, catchupPeriod, timeout time.Duration,
 	entropy *control.EntropyInfo,
 	secret string,
-	offset int) (*control.GroupPacket, error) {
+	offset int,
+	taggedMessages bool,
+	digest string,
+	progress DKGProgressFunc) (*control.GroupPacket, error) {
 	request := &control.InitDKGPacket{
 		Info: &control.SetupInfoPacket{
-			Nodes:        uint32(nodes),
-			Threshold:    uint32(threshold),
-			Leader:       true,
-			Timeout:      uint32(timeout.Seconds()),
-			Secret:       []byte(secret),
-			BeaconOffset: uint32(offset),
+			Nodes:          uint32(nodes),
+			Threshold:      uint32(threshold),
+			Leader:         true,
+			Timeout:        uint32(timeout.Seconds()),
+			Secret:         []byte(secret),
+			BeaconOffset:   uint32(offset),
+			TaggedMessages: taggedMessages,
+			Digest:         digest,
 		},
 		Entropy:       entropy,
 		BeaconPeriod:  uint32(beaconPeriod.Seconds()),
 		CatchupPeriod: uint32(catchupPeriod.Seconds()),
 	}
-	return c.client.InitDKG(ctx.Background(), request)
+	return c.initDKG(request, progress)
 }
 
-// InitDKG sets up the node to be ready for a first DKG protocol.
-func (c *ControlClient) InitDKG(leader Peer, entropy *control.EntropyInfo, secret string) (*control.GroupPacket, error) {
+// InitDKG sets up the node to be ready for a first DKG protocol. The progress
+// of the DKG is passed to progress if not nil.
+func (c *ControlClient) InitDKG(leader Peer, entropy *control.EntropyInfo, secret string, progress DKGProgressFunc) (*control.GroupPacket, error) {
 	request := &control.InitDKGPacket{
 		Info: &control.SetupInfoPacket{
 			Leader:        false,
@@ -154,7 +216,49 @@ func (c *ControlClient) InitDKG(leader Peer, entropy *control.EntropyInfo, secre
 		},
 		Entropy: entropy,
 	}
-	return c.client.InitDKG(ctx.Background(), request)
+	return c.initDKG(request, progress)
+}
+
+// initDKG runs the DKG and returns the resulting group, passing the progress
+// events to the given function.
+func (c *ControlClient) initDKG(request *control.InitDKGPacket, progress DKGProgressFunc) (*control.GroupPacket, error) {
+	stream, err := c.client.InitDKGStream(ctx.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return recvDKG(stream, progress)
+}
+
+// initReshare runs the resharing and returns the resulting group, passing the
+// progress events to the given function.
+func (c *ControlClient) initReshare(request *control.InitResharePacket, progress DKGProgressFunc) (*control.GroupPacket, error) {
+	stream, err := c.client.InitReshareStream(ctx.Background(), request)
+	if err != nil {
+		return nil, err
+	}
+	return recvDKG(stream, progress)
+}
+
+// recvDKG passes the progress events of the stream to the given function until
+// it receives the resulting group.
+func recvDKG(stream interface {
+	Recv() (*control.DKGProgress, error)
+}, progress DKGProgressFunc) (*control.GroupPacket, error) {
+	for {
+		ev, err := stream.Recv()
+		if err == io.EOF {
+			return nil, errors.New("dkg ended without group")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if progress != nil {
+			progress(ev)
+		}
+		if group := ev.GetGroup(); group != nil {
+			return group, nil
+		}
+	}
 }
 
 // Share returns the share of the remote node
@@ -188,6 +292,75 @@ func (c *ControlClient) Shutdown() (*control.ShutdownResponse, error) {
 	return c.client.Shutdown(ctx.Background(), &control.ShutdownRequest{})
 }
 
+// RoundReports returns the participation of the nodes in the last rounds
+// aggregated by the daemon, all the rounds it keeps if last is zero.
+func (c *ControlClient) RoundReports(last int) (*control.RoundReportsResponse, error) {
+	return c.client.RoundReports(ctx.Background(), &control.RoundReportsRequest{Last: uint32(last)})
+}
+
+// PeerStatus returns the clock skew of the other nodes of the group
+func (c *ControlClient) PeerStatus() (*control.PeerStatusResponse, error) {
+	return c.client.PeerStatus(ctx.Background(), &control.PeerStatusRequest{})
+}
+
+// ProposeGroup sends the group at the given path to all its nodes for their
+// operators to approve it
+func (c *ControlClient) ProposeGroup(groupPath string) (*control.ProposeGroupResponse, error) {
+	return c.client.ProposeGroup(ctx.Background(), &control.ProposeGroupRequest{
+		Group: &control.GroupInfo{Location: &control.GroupInfo_Path{Path: groupPath}},
+	})
+}
+
+// ListPendingGroups returns the groups proposed to the daemon
+func (c *ControlClient) ListPendingGroups() (*control.ListPendingGroupsResponse, error) {
+	return c.client.ListPendingGroups(ctx.Background(), &control.ListPendingGroupsRequest{})
+}
+
+// ApproveGroup approves the proposed group with the given membership hash
+func (c *ControlClient) ApproveGroup(hash []byte) error {
+	_, err := c.client.ApproveGroup(ctx.Background(), &control.ApproveGroupRequest{Hash: hash})
+	return err
+}
+
+// Status returns the state of the daemon
+func (c *ControlClient) Status() (*control.StatusResponse, error) {
+	return c.client.Status(ctx.Background(), &control.StatusRequest{})
+}
+
+// Pause stops the daemon from sending its partial signatures
+func (c *ControlClient) Pause() error {
+	_, err := c.client.Pause(ctx.Background(), &control.PauseRequest{})
+	return err
+}
+
+// Resume makes a paused daemon send its partial signatures again
+func (c *ControlClient) Resume() error {
+	_, err := c.client.Resume(ctx.Background(), &control.ResumeRequest{})
+	return err
+}
+
+// ScheduleMaintenance declares a maintenance window of the daemon between the
+// given unix times, announced to the other nodes of the group if asked
+func (c *ControlClient) ScheduleMaintenance(start, end int64, reason string, announce bool) (*control.ScheduleMaintenanceResponse, error) {
+	return c.client.ScheduleMaintenance(ctx.Background(), &control.ScheduleMaintenanceRequest{
+		Start:    start,
+		End:      end,
+		Reason:   reason,
+		Announce: announce,
+	})
+}
+
+// ListMaintenance returns the maintenance windows known to the daemon
+func (c *ControlClient) ListMaintenance() (*control.ListMaintenanceResponse, error) {
+	return c.client.ListMaintenance(ctx.Background(), &control.ListMaintenanceRequest{})
+}
+
+// KeyUsage returns how many partial signatures the daemon produced with its
+// share and identity key
+func (c *ControlClient) KeyUsage() (*control.KeyUsageResponse, error) {
+	return c.client.KeyUsage(ctx.Background(), &control.KeyUsageRequest{})
+}
+
 const progressFollowQueue = 100
 
 // StartFollowChain initates the client catching up on an existing chain it is not part of
@@ -1,12 +1,17 @@
 package net
 
 import (
+	"context"
 	"io/ioutil"
 	"os"
 	"runtime"
+	"strings"
 	"testing"
 
+	control "github.com/drand/drand/protobuf/drand"
 	testnet "github.com/drand/drand/test/net"
+	"google.golang.org/grpc"
+	rpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
 )
 
 const runtimeGOOSWindows = "windows"
@@ -35,7 +40,10 @@ func TestControlUnix(t *testing.T) {
 	}
 	defer os.RemoveAll(name)
 	s := testnet.EmptyServer{}
-	service := NewTCPGrpcControlListener(&s, "unix://"+name+"/sock")
+	service, err := NewTCPGrpcControlListener(&s, "unix://"+name+"/sock")
+	if err != nil {
+		t.Fatal(err)
+	}
 	client, err := NewControlClient("unix://" + name + "/sock")
 
 	if err != nil {
@@ -45,3 +53,69 @@ func TestControlUnix(t *testing.T) {
 	client.conn.Close()
 	service.lis.Close()
 }
+
+func TestControlReflection(t *testing.T) {
+	s := testnet.EmptyServer{}
+	service, err := NewTCPGrpcControlListener(&s, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go service.Start()
+	defer service.Stop()
+	addr := service.lis.Addr().String()
+
+	conn, err := grpc.Dial(addr, grpc.WithInsecure())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	stream, err := rpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = stream.Send(&rpb.ServerReflectionRequest{
+		MessageRequest: &rpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var found bool
+	for _, svc := range resp.GetListServicesResponse().GetService() {
+		found = found || svc.GetName() == "drand.Control"
+	}
+	if !found {
+		t.Fatalf("control service not listed: %v", resp.GetListServicesResponse().GetService())
+	}
+}
+
+type keyServer struct {
+	testnet.EmptyServer
+	key []byte
+}
+
+func (s *keyServer) PublicKey(context.Context, *control.PublicKeyRequest) (*control.PublicKeyResponse, error) {
+	return &control.PublicKeyResponse{PubKey: s.key}, nil
+}
+
+func TestControlPortInUse(t *testing.T) {
+	s := &keyServer{key: []byte{0xca, 0xfe}}
+	service, err := NewTCPGrpcControlListener(s, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go service.Start()
+	defer service.Stop()
+	addr := service.lis.Addr().String()
+
+	_, err = NewTCPGrpcControlListener(&testnet.EmptyServer{}, addr)
+	if err == nil {
+		t.Fatal("listening on a control port in use should fail")
+	}
+	if !strings.Contains(err.Error(), "public key cafe") {
+		t.Fatalf("the error does not name the daemon using the port: %v", err)
+	}
+}
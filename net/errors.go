@@ -0,0 +1,47 @@
+package net
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryError returns a gRPC error with the given code and message telling the
+// client to retry the request after the given delay, for example when asking
+// for a round not produced yet.
+func RetryError(code codes.Code, delay time.Duration, format string, a ...interface{}) error {
+	if delay < 0 {
+		delay = 0
+	}
+	st := status.New(code, fmt.Sprintf(format, a...))
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(delay)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// RetryDelay returns the delay after which the server asked to retry the
+// request that failed with the given error, and false if it did not.
+func RetryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, d := range st.Details() {
+		info, ok := d.(*errdetails.RetryInfo)
+		if !ok {
+			continue
+		}
+		delay, err := ptypes.Duration(info.GetRetryDelay())
+		if err != nil {
+			return 0, false
+		}
+		return delay, true
+	}
+	return 0, false
+}
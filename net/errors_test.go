@@ -0,0 +1,30 @@
+package net
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryError(t *testing.T) {
+	err := RetryError(codes.Unavailable, 3*time.Second, "round %d not produced yet", 10)
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("unexpected code %s", status.Code(err))
+	}
+	if msg := status.Convert(err).Message(); msg != "round 10 not produced yet" {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	delay, ok := RetryDelay(err)
+	if !ok || delay != 3*time.Second {
+		t.Fatalf("unexpected retry delay %s %v", delay, ok)
+	}
+
+	for _, err := range []error{nil, errors.New("no details"), status.Error(codes.NotFound, "not found")} {
+		if _, ok := RetryDelay(err); ok {
+			t.Fatalf("retry delay found in %v", err)
+		}
+	}
+}
@@ -61,7 +61,13 @@ func NewGRPCPrivateGateway(ctx context.Context,
 		Listener: l,
 	}
 	if !insecure {
-		pg.ProtocolClient = NewGrpcClientFromCertManager(certs, opts...)
+		client := NewGrpcClientFromCertManager(certs, opts...).(*grpcClient)
+		// present our certificate to the other nodes so they can check who
+		// is sending them requests
+		if client.cert, err = newCertReloader(certPath, keyPath); err != nil {
+			return nil, err
+		}
+		pg.ProtocolClient = client
 	} else {
 		pg.ProtocolClient = NewGrpcClient(opts...)
 	}
@@ -89,14 +95,17 @@ func (g *PublicGateway) StopAll(ctx context.Context) {
 
 // NewRESTPublicGateway returns a grpc gateway listening on "listen" for the
 // public methods, listening on "port" for the control methods, using the given
-// Service s with the given options.
+// Service s with the given options. If auth is not nil, the requests must be
+// authenticated. If filter is not nil, it restricts the allowed clients.
 func NewRESTPublicGateway(
 	ctx context.Context,
 	listen, certPath, keyPath string,
 	certs *CertManager,
 	handler http.Handler,
-	insecure bool) (*PublicGateway, error) {
-	l, err := NewRESTListenerForPublic(ctx, listen, certPath, keyPath, handler, insecure)
+	insecure bool,
+	auth *Auth,
+	filter *IPFilter) (*PublicGateway, error) {
+	l, err := NewRESTListenerForPublic(ctx, listen, certPath, keyPath, handler, insecure, auth, filter)
 	if err != nil {
 		return nil, err
 	}
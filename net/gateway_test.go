@@ -14,6 +14,7 @@ import (
 	testnet "github.com/drand/drand/test/net"
 	"github.com/kabukky/httpscerts"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
 )
 
 type testPeer struct {
@@ -60,7 +61,7 @@ func testListener(t *testing.T) {
 	require.NoError(t, err)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(resp http.ResponseWriter, r *http.Request) { resp.Write([]byte("ok")) })
-	lisREST, err := NewRESTListenerForPublic(ctx, "localhost:", "", "", mux, true)
+	lisREST, err := NewRESTListenerForPublic(ctx, "localhost:", "", "", mux, true, nil, nil)
 	require.NoError(t, err)
 
 	peerGRPC := &testPeer{lisGRPC.Addr(), false}
@@ -103,7 +104,7 @@ func testListenerTLS(t *testing.T) {
 	require.NoError(t, err)
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", func(resp http.ResponseWriter, r *http.Request) { resp.Write([]byte("ok")) })
-	lisREST, err := NewRESTListenerForPublic(ctx, hostAddr+":", certPath, keyPath, mux, false)
+	lisREST, err := NewRESTListenerForPublic(ctx, hostAddr+":", certPath, keyPath, mux, false, nil, nil)
 	require.NoError(t, err)
 
 	peerGRPC := &testPeer{lisGRPC.Addr(), true}
@@ -125,3 +126,47 @@ func testListenerTLS(t *testing.T) {
 	expected := &drand.PublicRandResponse{Round: randServer.round}
 	require.Equal(t, expected.GetRound(), resp.GetRound())
 }
+
+type testFallbackPeer struct {
+	testPeer
+	fallbacks []string
+}
+
+func (t *testFallbackPeer) FallbackAddresses() []string {
+	return t.fallbacks
+}
+
+func TestFallbackAddresses(t *testing.T) {
+	ctx := context.Background()
+	randServer := &testRandomnessServer{round: 42}
+	lisGRPC, err := NewGRPCListenerForPrivate(ctx, "localhost:", "", "", randServer, true)
+	require.NoError(t, err)
+	go lisGRPC.Start()
+	defer lisGRPC.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	// the main address is not reachable
+	peer := &testFallbackPeer{
+		testPeer:  testPeer{"127.0.0.1:0", false},
+		fallbacks: []string{lisGRPC.Addr()},
+	}
+	client := NewGrpcClientWithTimeout(5 * time.Second)
+	resp, err := client.PublicRand(ctx, peer, &drand.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, randServer.round, resp.GetRound())
+}
+
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+	randServer := &testRandomnessServer{round: 42}
+	lisGRPC, err := NewGRPCListenerForPrivate(ctx, "localhost:", "", "", randServer, true)
+	require.NoError(t, err)
+	go lisGRPC.Start()
+	defer lisGRPC.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	client := NewGrpcClient(grpc.WithDefaultCallOptions(grpc.UseCompressor("gzip")))
+	resp, err := client.PublicRand(ctx, &testPeer{lisGRPC.Addr(), false}, &drand.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, randServer.round, resp.GetRound())
+}
@@ -0,0 +1,209 @@
+package net
+
+import (
+	"context"
+	"net"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// RequestTimeouter is an optional interface a Service can implement to bound
+// the time spent on each unary request it receives. A zero or negative
+// duration means no additional deadline is enforced.
+type RequestTimeouter interface {
+	RequestTimeout() time.Duration
+}
+
+// recoveryUnaryInterceptor returns an interceptor that recovers from any panic
+// occurring inside a unary handler. The stack trace is logged and the caller
+// receives an Internal error instead of the whole daemon crashing.
+func recoveryUnaryInterceptor(l log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = panicToError(l, info.FullMethod, r)
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// recoveryStreamInterceptor is the streaming counterpart of
+// recoveryUnaryInterceptor.
+func recoveryStreamInterceptor(l log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = panicToError(l, info.FullMethod, r)
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+func panicToError(l log.Logger, method string, r interface{}) error {
+	l.Error("grpc_handler", "panic", "method", method, "err", r, "stack", string(debug.Stack()))
+	return status.Errorf(codes.Internal, "internal error while processing %s", method)
+}
+
+// timeoutUnaryInterceptor returns an interceptor that attaches a deadline to
+// the context of each unary request, as given by the timeout function. Streams
+// are long lived by nature so they are not bounded.
+func timeoutUnaryInterceptor(timeout func() time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		t := timeout()
+		if t <= 0 {
+			return handler(ctx, req)
+		}
+		ctx, cancel := context.WithTimeout(ctx, t)
+		defer cancel()
+		return handler(ctx, req)
+	}
+}
+
+// peerMetricsUnaryInterceptor returns an interceptor recording, for the peer
+// at the given address, the time and round-trip time of the last successful
+// call and the number of consecutive calls that could not reach it. The calls
+// the peer answers with an error, or canceled by this node, are not counted.
+func peerMetricsUnaryInterceptor(addr string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		switch status.Code(err) {
+		case codes.OK:
+			now := time.Now()
+			metrics.PeerLastSuccess.WithLabelValues(addr).Set(float64(now.Unix()))
+			metrics.PeerRTT.WithLabelValues(addr).Set(now.Sub(start).Seconds())
+			metrics.PeerFailureStreak.WithLabelValues(addr).Set(0)
+		case codes.Unavailable, codes.DeadlineExceeded:
+			metrics.PeerFailureStreak.WithLabelValues(addr).Inc()
+		}
+		return err
+	}
+}
+
+// serverInterceptors returns the chain of interceptors every drand gRPC server
+// uses: panic recovery first, then metrics and, if the service supports it,
+// per request deadlines.
+func serverInterceptors(s interface{}, l log.Logger, unary []grpc.UnaryServerInterceptor, stream []grpc.StreamServerInterceptor) []grpc.ServerOption {
+	unaries := []grpc.UnaryServerInterceptor{recoveryUnaryInterceptor(l)}
+	unaries = append(unaries, unary...)
+	if t, ok := s.(RequestTimeouter); ok {
+		unaries = append(unaries, timeoutUnaryInterceptor(t.RequestTimeout))
+	}
+	streams := []grpc.StreamServerInterceptor{recoveryStreamInterceptor(l)}
+	streams = append(streams, stream...)
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(unaries...),
+		grpc.ChainStreamInterceptor(streams...),
+	}
+}
+
+// MaxInFlightPerPeer is the maximum number of protocol requests a single peer
+// can have being processed concurrently by a node. Honest members only send a
+// handful of packets per round so this limit is never reached in practice.
+const MaxInFlightPerPeer = 16
+
+// MaxStreamsPerPeer is the maximum number of protocol streams, i.e. to sync the
+// chain, a single peer can have open at the same time on a node. Streams are
+// long lived so they have their own limit, apart from the requests.
+const MaxStreamsPerPeer = 8
+
+// protocolMethodPrefix is the prefix of all methods of the Protocol service,
+// which are the ones exposed to the other members of the group.
+const protocolMethodPrefix = "/drand.Protocol/"
+
+// inFlightLimiter keeps track of the number of requests being processed for
+// each peer, so a single misbehaving peer can not exhaust the goroutines and
+// memory of a node by flooding it with requests.
+type inFlightLimiter struct {
+	sync.Mutex
+	max   int
+	peers map[string]int
+}
+
+func newInFlightLimiter(max int) *inFlightLimiter {
+	return &inFlightLimiter{
+		max:   max,
+		peers: make(map[string]int),
+	}
+}
+
+// acquire returns false if the peer already reached its limit. Otherwise the
+// caller must call release once the request is processed.
+func (i *inFlightLimiter) acquire(peer string) bool {
+	i.Lock()
+	defer i.Unlock()
+	if i.peers[peer] >= i.max {
+		return false
+	}
+	i.peers[peer]++
+	return true
+}
+
+func (i *inFlightLimiter) release(peer string) {
+	i.Lock()
+	defer i.Unlock()
+	i.peers[peer]--
+	if i.peers[peer] <= 0 {
+		delete(i.peers, peer)
+	}
+}
+
+// unaryInterceptor rejects the protocol requests of a peer with a
+// ResourceExhausted error when it has too many requests in flight.
+func (i *inFlightLimiter) unaryInterceptor(l log.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, protocolMethodPrefix) {
+			return handler(ctx, req)
+		}
+		p := peerHost(ctx)
+		if !i.acquire(p) {
+			l.Debug("grpc_handler", "too many requests in flight", "peer", p, "method", info.FullMethod)
+			return nil, status.Errorf(codes.ResourceExhausted, "too many requests in flight from %s", p)
+		}
+		defer i.release(p)
+		return handler(ctx, req)
+	}
+}
+
+// streamInterceptor rejects the protocol streams of a peer with a
+// ResourceExhausted error when it has too many streams open.
+func (i *inFlightLimiter) streamInterceptor(l log.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !strings.HasPrefix(info.FullMethod, protocolMethodPrefix) {
+			return handler(srv, ss)
+		}
+		p := peerHost(ss.Context())
+		if !i.acquire(p) {
+			l.Debug("grpc_handler", "too many streams open", "peer", p, "method", info.FullMethod)
+			return status.Errorf(codes.ResourceExhausted, "too many streams open from %s", p)
+		}
+		defer i.release(p)
+		return handler(srv, ss)
+	}
+}
+
+// peerHost returns the host of the remote peer of the request without the
+// port, since a peer can open multiple connections.
+func peerHost(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
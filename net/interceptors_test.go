@@ -0,0 +1,157 @@
+package net
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/log"
+	"github.com/drand/drand/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestRecoveryInterceptor(t *testing.T) {
+	l := log.NewLogger(nil, log.LogNone)
+	info := &grpc.UnaryServerInfo{FullMethod: "/drand.Protocol/PartialBeacon"}
+	panicking := func(ctx context.Context, req interface{}) (interface{}, error) {
+		panic("boom")
+	}
+	resp, err := recoveryUnaryInterceptor(l)(context.Background(), nil, info, panicking)
+	require.Nil(t, resp)
+	require.Error(t, err)
+	require.Equal(t, codes.Internal, status.Code(err))
+
+	streamInfo := &grpc.StreamServerInfo{FullMethod: "/drand.Protocol/SyncChain"}
+	err = recoveryStreamInterceptor(l)(nil, nil, streamInfo, func(srv interface{}, ss grpc.ServerStream) error {
+		panic("boom")
+	})
+	require.Equal(t, codes.Internal, status.Code(err))
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	info := &grpc.UnaryServerInfo{FullMethod: "/drand.Public/PublicRand"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		_, ok := ctx.Deadline()
+		return ok, nil
+	}
+	resp, err := timeoutUnaryInterceptor(func() time.Duration { return time.Second })(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	require.True(t, resp.(bool))
+
+	resp, err = timeoutUnaryInterceptor(func() time.Duration { return 0 })(context.Background(), nil, info, handler)
+	require.NoError(t, err)
+	require.False(t, resp.(bool))
+}
+
+func TestInFlightLimiter(t *testing.T) {
+	l := log.NewLogger(nil, log.LogNone)
+	limiter := newInFlightLimiter(1)
+	interceptor := limiter.unaryInterceptor(l)
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4444}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: addr})
+	info := &grpc.UnaryServerInfo{FullMethod: "/drand.Protocol/PartialBeacon"}
+
+	var inner error
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		// a second request from the same host on another connection is refused
+		other := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5555}
+		_, inner = interceptor(peer.NewContext(context.Background(), &peer.Peer{Addr: other}), nil, info, func(context.Context, interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return nil, nil
+	}
+	_, err := interceptor(ctx, nil, info, handler)
+	require.NoError(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(inner))
+
+	// the slot is released once the request is processed
+	_, err = interceptor(ctx, nil, info, func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	require.NoError(t, err)
+
+	// public methods are not limited
+	publicInfo := &grpc.UnaryServerInfo{FullMethod: "/drand.Public/PublicRand"}
+	_, err = interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return interceptor(ctx, nil, publicInfo, func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	})
+	require.NoError(t, err)
+}
+
+// peerStream is a server stream of the given context.
+type peerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (p *peerStream) Context() context.Context { return p.ctx }
+
+func TestStreamLimiter(t *testing.T) {
+	l := log.NewLogger(nil, log.LogNone)
+	interceptor := newInFlightLimiter(1).streamInterceptor(l)
+	addr := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 4444}
+	ss := &peerStream{ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: addr})}
+	info := &grpc.StreamServerInfo{FullMethod: "/drand.Protocol/SyncChain", IsServerStream: true}
+	noop := func(interface{}, grpc.ServerStream) error { return nil }
+
+	var inner error
+	err := interceptor(nil, ss, info, func(interface{}, grpc.ServerStream) error {
+		// a second stream from the same host on another connection is refused
+		other := &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 5555}
+		inner = interceptor(nil, &peerStream{ctx: peer.NewContext(context.Background(), &peer.Peer{Addr: other})}, info, noop)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, codes.ResourceExhausted, status.Code(inner))
+
+	// the slot is released once the stream ends
+	require.NoError(t, interceptor(nil, ss, info, noop))
+
+	// the public streams, e.g. watchers behind a NAT or a relay, are not limited
+	interceptor = newInFlightLimiter(MaxStreamsPerPeer).streamInterceptor(l)
+	public := &grpc.StreamServerInfo{FullMethod: "/drand.Public/PublicRandStream", IsServerStream: true}
+	var open func(n int) error
+	open = func(n int) error {
+		if n == 0 {
+			return nil
+		}
+		return interceptor(nil, ss, public, func(interface{}, grpc.ServerStream) error {
+			return open(n - 1)
+		})
+	}
+	require.NoError(t, open(MaxStreamsPerPeer+1))
+}
+
+func TestPeerMetricsInterceptor(t *testing.T) {
+	addr := "peer-metrics.test:4444"
+	interceptor := peerMetricsUnaryInterceptor(addr)
+	call := func(err error) error {
+		return interceptor(context.Background(), "/drand.Protocol/PartialBeacon", nil, nil, nil,
+			func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+				time.Sleep(10 * time.Millisecond)
+				return err
+			})
+	}
+	streak := func() float64 { return testutil.ToFloat64(metrics.PeerFailureStreak.WithLabelValues(addr)) }
+
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	require.Equal(t, unavailable, call(unavailable))
+	require.Error(t, call(status.Error(codes.DeadlineExceeded, "timeout")))
+	require.Equal(t, float64(2), streak())
+	// the errors returned by the peer and the calls canceled by this node
+	// don't tell whether the peer is reachable
+	require.Error(t, call(status.Error(codes.NotFound, "not found")))
+	require.Error(t, call(status.Error(codes.Canceled, "canceled")))
+	require.Equal(t, float64(2), streak())
+	require.Zero(t, testutil.ToFloat64(metrics.PeerLastSuccess.WithLabelValues(addr)))
+
+	before := time.Now().Unix()
+	require.NoError(t, call(nil))
+	require.Zero(t, streak())
+	require.GreaterOrEqual(t, testutil.ToFloat64(metrics.PeerLastSuccess.WithLabelValues(addr)), float64(before))
+	require.GreaterOrEqual(t, testutil.ToFloat64(metrics.PeerRTT.WithLabelValues(addr)), 0.01)
+}
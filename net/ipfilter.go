@@ -0,0 +1,110 @@
+package net
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/drand/drand/log"
+)
+
+// IPFilter decides which remote addresses can connect to a listener, based on
+// lists of allowed and denied networks. It is applied when accepting the
+// connections, before any TLS handshake or request handling.
+type IPFilter struct {
+	allow []*net.IPNet
+	deny  []*net.IPNet
+}
+
+// NewIPFilter returns a filter from lists of networks in CIDR notation, e.g.
+// 10.0.0.0/8, or of single IP addresses. A denied address is always rejected.
+// If the allow list is not empty, only the addresses it contains are accepted.
+func NewIPFilter(allow, deny []string) (*IPFilter, error) {
+	var err error
+	f := new(IPFilter)
+	if f.allow, err = parseNetworks(allow); err != nil {
+		return nil, err
+	}
+	if f.deny, err = parseNetworks(deny); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func parseNetworks(list []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(list))
+	for _, s := range list {
+		if ip := net.ParseIP(s); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, n, err := net.ParseCIDR(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: %w", s, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+func contains(nets []*net.IPNet, ip net.IP) bool {
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed returns true if the given IP address can connect.
+func (f *IPFilter) Allowed(ip net.IP) bool {
+	if f == nil {
+		return true
+	}
+	if ip == nil || contains(f.deny, ip) {
+		return false
+	}
+	return len(f.allow) == 0 || contains(f.allow, ip)
+}
+
+// Listener returns a listener closing right away the connections coming from
+// addresses the filter does not allow.
+func (f *IPFilter) Listener(l net.Listener) net.Listener {
+	if f == nil {
+		return l
+	}
+	return &filteredListener{Listener: l, filter: f}
+}
+
+type filteredListener struct {
+	net.Listener
+	filter *IPFilter
+}
+
+func (l *filteredListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		var ip net.IP
+		if addr, ok := c.RemoteAddr().(*net.TCPAddr); ok {
+			ip = addr.IP
+		}
+		if l.filter.Allowed(ip) {
+			return c, nil
+		}
+		log.DefaultLogger().Debug("listener", l.Addr().String(), "rejected", c.RemoteAddr().String())
+		c.Close()
+	}
+}
+
+// IPFilterer is an optional interface a Service can implement to filter the
+// connections to the listener of its Protocol and Public gRPC services. A nil
+// filter accepts all connections.
+type IPFilterer interface {
+	IPFilter() *IPFilter
+}
@@ -0,0 +1,68 @@
+package net
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/drand/drand/protobuf/drand"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPFilter(t *testing.T) {
+	_, err := NewIPFilter([]string{"10.0.0.0/33"}, nil)
+	require.Error(t, err)
+
+	f, err := NewIPFilter([]string{"10.0.0.0/8", "192.168.1.1"}, []string{"10.1.0.0/16"})
+	require.NoError(t, err)
+	require.True(t, f.Allowed(net.ParseIP("10.2.3.4")))
+	require.True(t, f.Allowed(net.ParseIP("192.168.1.1")))
+	require.False(t, f.Allowed(net.ParseIP("192.168.1.2")))
+	require.False(t, f.Allowed(net.ParseIP("10.1.2.3")))
+	require.False(t, f.Allowed(nil))
+
+	deny, err := NewIPFilter(nil, []string{"127.0.0.1"})
+	require.NoError(t, err)
+	require.True(t, deny.Allowed(net.ParseIP("8.8.8.8")))
+	var open *IPFilter
+	require.True(t, open.Allowed(net.ParseIP("127.0.0.1")))
+}
+
+type filteredRandomnessServer struct {
+	testRandomnessServer
+	filter *IPFilter
+}
+
+func (f *filteredRandomnessServer) IPFilter() *IPFilter {
+	return f.filter
+}
+
+func TestIPFilterListener(t *testing.T) {
+	ctx := context.Background()
+	deny, err := NewIPFilter(nil, []string{"127.0.0.0/8"})
+	require.NoError(t, err)
+	randServer := &filteredRandomnessServer{testRandomnessServer{round: 42}, deny}
+
+	lis, err := NewGRPCListenerForPrivate(ctx, "127.0.0.1:", "", "", randServer, true)
+	require.NoError(t, err)
+	go lis.Start()
+	defer lis.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+
+	client := NewGrpcClient()
+	tctx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	_, err = client.PublicRand(tctx, &testPeer{lis.Addr(), false}, &drand.PublicRandRequest{})
+	require.Error(t, err)
+
+	randServer.filter = nil
+	lis2, err := NewGRPCListenerForPrivate(ctx, "127.0.0.1:", "", "", randServer, true)
+	require.NoError(t, err)
+	go lis2.Start()
+	defer lis2.Stop(ctx)
+	time.Sleep(100 * time.Millisecond)
+	resp, err := client.PublicRand(ctx, &testPeer{lis2.Addr(), false}, &drand.PublicRandRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), resp.GetRound())
+}
@@ -1,13 +1,15 @@
 package net
 
 import (
-	"encoding/json"
 	"io"
 
 	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	json "github.com/nikkolasg/hexjson"
 )
 
-// HexJSON transforms json into hex string instead of b64
+// HexJSON transforms json into hex string instead of b64. The fields of the
+// protobuf messages are named after their name in the protobuf definition and
+// the integers, rounds included, are encoded as numbers.
 type HexJSON struct{}
 
 // ContentType always Returns "application/json".
@@ -0,0 +1,80 @@
+package net
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/drand/drand/protobuf/drand"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files")
+
+func fixedBytes(n int, b byte) []byte {
+	return bytes.Repeat([]byte{b}, n)
+}
+
+// TestHexJSONGolden checks the JSON encoding of the public messages does not
+// change, since third-party clients parse it.
+func TestHexJSONGolden(t *testing.T) {
+	messages := map[string]interface{}{
+		"public_rand_request.json": &drand.PublicRandRequest{Round: 1234},
+		"public_rand_response.json": &drand.PublicRandResponse{
+			Round:             18446744073709551615,
+			Signature:         fixedBytes(48, 0x01),
+			PreviousSignature: fixedBytes(48, 0x02),
+			Randomness:        fixedBytes(32, 0x03),
+			NextRound:         5,
+			NextRoundTime:     1595431050,
+			ChainHash:         fixedBytes(32, 0x04),
+			Contributors:      []byte{0x0f},
+		},
+		"private_rand_request.json":  &drand.PrivateRandRequest{Request: fixedBytes(8, 0x05)},
+		"private_rand_response.json": &drand.PrivateRandResponse{Response: fixedBytes(8, 0x06)},
+		"home_response.json":         &drand.HomeResponse{Status: "drand up and running"},
+		"chain_info.json": &drand.ChainInfoPacket{
+			PublicKey:      fixedBytes(48, 0x07),
+			Period:         30,
+			GenesisTime:    1595431050,
+			Hash:           fixedBytes(32, 0x08),
+			GroupHash:      fixedBytes(32, 0x09),
+			MessageV1Round: 100,
+			PeriodChanges:  []*drand.PeriodChange{{Round: 200, Period: 3}},
+		},
+	}
+	m := new(HexJSON)
+	for name, msg := range messages {
+		buff, err := m.Marshal(msg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		golden := path.Join("testdata", name)
+		if *updateGolden {
+			if err := ioutil.WriteFile(golden, append(buff, '\n'), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		expected, err := ioutil.ReadFile(golden)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(bytes.TrimSpace(expected), buff) {
+			t.Fatalf("%s: got\n%s\nexpected\n%s", name, buff, expected)
+		}
+
+		decoded := reflect.New(reflect.TypeOf(msg).Elem()).Interface()
+		if err := m.Unmarshal(expected, decoded); err != nil {
+			t.Fatal(err)
+		}
+		again, err := m.Marshal(decoded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(again, buff) {
+			t.Fatalf("%s: round trip gives\n%s", name, again)
+		}
+	}
+}
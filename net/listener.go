@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 
+	"github.com/drand/drand/key"
 	"github.com/drand/drand/log"
 	"github.com/drand/drand/metrics"
 	"github.com/drand/drand/protobuf/drand"
@@ -15,8 +16,16 @@ import (
 	http_grpc_server "github.com/weaveworks/common/httpgrpc/server"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/reflection"
+	// registers the gzip compressor so nodes can receive compressed messages
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
+// MaxMessageSize is the maximum size in bytes of a message a drand node
+// accepts from the network. The largest messages are the DKG deal bundles,
+// which stay well below this limit even for large groups.
+const MaxMessageSize = 1 << 20
+
 func registerGRPCMetrics() {
 	if err := metrics.PrivateMetrics.Register(grpc_prometheus.DefaultServerMetrics); err != nil {
 		log.DefaultLogger().Warn("grpc Listener", "failed metrics registration", "err", err)
@@ -34,6 +43,9 @@ func NewGRPCListenerForPrivate(
 	if err != nil {
 		return nil, err
 	}
+	if f, ok := s.(IPFilterer); ok {
+		lis = f.IPFilter().Listener(lis)
+	}
 
 	if !insecure {
 		grpcCreds, err := credentials.NewServerTLSFromFile(certPath, keyPath)
@@ -42,12 +54,29 @@ func NewGRPCListenerForPrivate(
 		}
 		opts = append(opts, grpc.Creds(grpcCreds))
 	}
-	opts = append(opts,
-		grpc.StreamInterceptor(grpc_prometheus.StreamServerInterceptor),
-		grpc.UnaryInterceptor(grpc_prometheus.UnaryServerInterceptor))
+	opts = append(opts, grpc.MaxRecvMsgSize(MaxMessageSize))
+	unary := []grpc.UnaryServerInterceptor{
+		grpc_prometheus.UnaryServerInterceptor,
+		newInFlightLimiter(MaxInFlightPerPeer).unaryInterceptor(log.DefaultLogger()),
+	}
+	stream := []grpc.StreamServerInterceptor{
+		grpc_prometheus.StreamServerInterceptor,
+		newInFlightLimiter(MaxStreamsPerPeer).streamInterceptor(log.DefaultLogger()),
+	}
+	var auth *Auth
+	if a, ok := s.(PublicAuthenticator); ok {
+		auth = a.PublicAuth()
+	}
+	if auth != nil {
+		unary = append(unary, auth.unaryInterceptor())
+		stream = append(stream, auth.streamInterceptor())
+	}
+	opts = append(opts, serverInterceptors(s, log.DefaultLogger(), unary, stream)...)
 	grpcServer := grpc.NewServer(opts...)
 	drand.RegisterPublicServer(grpcServer, s)
 	drand.RegisterProtocolServer(grpcServer, s)
+	// let generic tools such as grpcurl discover the services
+	reflection.Register(grpcServer)
 
 	var g Listener
 	if insecure {
@@ -57,14 +86,18 @@ func NewGRPCListenerForPrivate(
 			lis:        lis,
 		}
 	} else {
-		x509KeyPair, err := tls.LoadX509KeyPair(certPath, keyPath)
+		certs, err := newCertReloader(certPath, keyPath)
 		if err != nil {
 			return nil, err
 		}
 
 		gr := &restListener{
-			restServer: buildTLSServer(grpcServer, &x509KeyPair),
+			restServer: buildTLSServer(grpcServer, certs),
 		}
+		// the nodes authenticate with their certificate, checked by the
+		// Service against the group with CertManager.VerifyPeer
+		gr.restServer.TLSConfig.ClientAuth = tls.RequestClientCert
+		auth.applyTLS(gr.restServer.TLSConfig)
 		gr.lis = tls.NewListener(lis, gr.restServer.TLSConfig)
 		g = gr
 	}
@@ -75,15 +108,23 @@ func NewGRPCListenerForPrivate(
 }
 
 // NewRESTListenerForPublic creates a new listener for the Public API over REST with TLS.
+// If auth is not nil, only the authenticated requests reach the handler. If
+// filter is not nil, only the connections it allows are accepted.
 func NewRESTListenerForPublic(
 	ctx context.Context,
 	bindingAddr, certPath, keyPath string,
 	handler http.Handler,
-	insecure bool) (Listener, error) {
+	insecure bool,
+	auth *Auth,
+	filter *IPFilter) (Listener, error) {
 	lis, err := net.Listen("tcp", bindingAddr)
 	if err != nil {
 		return nil, err
 	}
+	lis = filter.Listener(lis)
+	if auth != nil {
+		handler = auth.Handler(handler)
+	}
 
 	g := &restListener{
 		lis: lis,
@@ -94,19 +135,20 @@ func NewRESTListenerForPublic(
 			Handler: handler,
 		}
 	} else {
-		x509KeyPair, err := tls.LoadX509KeyPair(certPath, keyPath)
+		certs, err := newCertReloader(certPath, keyPath)
 		if err != nil {
 			return nil, err
 		}
 
-		g.restServer = buildTLSServer(handler, &x509KeyPair)
+		g.restServer = buildTLSServer(handler, certs)
+		auth.applyTLS(g.restServer.TLSConfig)
 		g.lis = tls.NewListener(lis, g.restServer.TLSConfig)
 	}
 	return g, nil
 }
 
-func buildTLSServer(httpHandler http.Handler, x509KeyPair *tls.Certificate) *http.Server {
-	return &http.Server{
+func buildTLSServer(httpHandler http.Handler, certs *certReloader) *http.Server {
+	server := &http.Server{
 		Handler: httpHandler,
 		TLSConfig: &tls.Config{
 			// From https://blog.cloudflare.com/exposing-go-on-the-internet/
@@ -134,10 +176,33 @@ func buildTLSServer(httpHandler http.Handler, x509KeyPair *tls.Certificate) *htt
 			},
 			// End Cloudflare recommendations.
 
-			Certificates: []tls.Certificate{*x509KeyPair},
-			NextProtos:   []string{"h2"},
+			GetCertificate: certs.GetCertificate,
+			NextProtos:     []string{"h2"},
 		},
 	}
+	applyFIPS(server.TLSConfig)
+	return server
+}
+
+// fipsCipherSuites are the cipher suites approved by FIPS 140.
+var fipsCipherSuites = []uint16{
+	tls.TLS_ECDHE_ECDSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_RSA_WITH_AES_256_GCM_SHA384,
+	tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256,
+	tls.TLS_ECDHE_RSA_WITH_AES_128_GCM_SHA256,
+}
+
+// applyFIPS restricts the TLS configuration to the curves and cipher suites
+// approved by FIPS 140 when the FIPS mode is on. TLS 1.3 is disabled since its
+// cipher suites can't be chosen.
+func applyFIPS(c *tls.Config) {
+	if !key.FIPSMode() {
+		return
+	}
+	c.MinVersion = tls.VersionTLS12
+	c.MaxVersion = tls.VersionTLS12
+	c.CurvePreferences = []tls.CurveID{tls.CurveP256, tls.CurveP384}
+	c.CipherSuites = fipsCipherSuites
 }
 
 type restListener struct {
@@ -16,6 +16,22 @@ type Peer interface {
 	IsTLS() bool
 }
 
+// FallbackPeer is a Peer that can be reached at several addresses. Clients
+// try the fallback addresses in order when the main address is unreachable.
+type FallbackPeer interface {
+	Peer
+	FallbackAddresses() []string
+}
+
+// peerAddresses returns all the addresses of the peer, the main one first.
+func peerAddresses(p Peer) []string {
+	addrs := []string{p.Address()}
+	if fp, ok := p.(FallbackPeer); ok {
+		addrs = append(addrs, fp.FallbackAddresses()...)
+	}
+	return addrs
+}
+
 type sPeer struct {
 	addr string
 	tls  bool
@@ -92,10 +92,22 @@ type PublicRandResponse struct {
 
 	Round             uint64 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
 	Signature         []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
-	PreviousSignature []byte `protobuf:"bytes,3,opt,name=previous_signature,json=previousSignature,proto3" json:"previous_signature,omitempty"`
+	PreviousSignature []byte `protobuf:"bytes,3,opt,name=previous_signature,proto3" json:"previous_signature,omitempty"`
 	// randomness is simply there to demonstrate - it is the hash of the
 	// signature. It should be computed locally.
 	Randomness []byte `protobuf:"bytes,4,opt,name=randomness,proto3" json:"randomness,omitempty"`
+	// next_round is the round expected to be produced next and
+	// next_round_time its expected UNIX time. They are only set when the
+	// latest beacon is requested.
+	NextRound     uint64 `protobuf:"varint,5,opt,name=next_round,proto3" json:"next_round,omitempty"`
+	NextRoundTime int64  `protobuf:"varint,6,opt,name=next_round_time,proto3" json:"next_round_time,omitempty"`
+	// chain_hash is the hash of the chain this beacon belongs to, so clients
+	// do not mix beacons from different chains.
+	ChainHash []byte `protobuf:"bytes,7,opt,name=chain_hash,proto3" json:"chain_hash,omitempty"`
+	// contributors is the bitmap of the indices of the nodes whose partial
+	// signature the node held when it aggregated the beacon: the bit i%8 of
+	// the byte i/8 is set for the index i. It is not covered by the signature.
+	Contributors []byte `protobuf:"bytes,8,opt,name=contributors,proto3" json:"contributors,omitempty"`
 }
 
 func (x *PublicRandResponse) Reset() {
@@ -158,6 +170,161 @@ func (x *PublicRandResponse) GetRandomness() []byte {
 	return nil
 }
 
+func (x *PublicRandResponse) GetNextRound() uint64 {
+	if x != nil {
+		return x.NextRound
+	}
+	return 0
+}
+
+func (x *PublicRandResponse) GetNextRoundTime() int64 {
+	if x != nil {
+		return x.NextRoundTime
+	}
+	return 0
+}
+
+func (x *PublicRandResponse) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *PublicRandResponse) GetContributors() []byte {
+	if x != nil {
+		return x.Contributors
+	}
+	return nil
+}
+
+// PublicRandRangeRequest requests the beacons from the round from to the round
+// to included.
+type PublicRandRangeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// from is the first round of the page, the round 1 if unspecified.
+	From uint64 `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	// to is the last round requested, the latest one if unspecified.
+	To uint64 `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	// limit is the maximum number of beacons of the page. The node uses its
+	// default page size if it is unspecified, and caps it to its maximum.
+	Limit uint32 `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *PublicRandRangeRequest) Reset() {
+	*x = PublicRandRangeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicRandRangeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicRandRangeRequest) ProtoMessage() {}
+
+func (x *PublicRandRangeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicRandRangeRequest.ProtoReflect.Descriptor instead.
+func (*PublicRandRangeRequest) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PublicRandRangeRequest) GetFrom() uint64 {
+	if x != nil {
+		return x.From
+	}
+	return 0
+}
+
+func (x *PublicRandRangeRequest) GetTo() uint64 {
+	if x != nil {
+		return x.To
+	}
+	return 0
+}
+
+func (x *PublicRandRangeRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// PublicRandRangeResponse holds a page of beacons in increasing round order.
+type PublicRandRangeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Beacons []*PublicRandResponse `protobuf:"bytes,1,rep,name=beacons,proto3" json:"beacons,omitempty"`
+	// next is the round from which to request the next page, zero if the page
+	// is the last one of the range.
+	Next uint64 `protobuf:"varint,2,opt,name=next,proto3" json:"next,omitempty"`
+}
+
+func (x *PublicRandRangeResponse) Reset() {
+	*x = PublicRandRangeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_api_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PublicRandRangeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublicRandRangeResponse) ProtoMessage() {}
+
+func (x *PublicRandRangeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_api_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublicRandRangeResponse.ProtoReflect.Descriptor instead.
+func (*PublicRandRangeResponse) Descriptor() ([]byte, []int) {
+	return file_drand_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PublicRandRangeResponse) GetBeacons() []*PublicRandResponse {
+	if x != nil {
+		return x.Beacons
+	}
+	return nil
+}
+
+func (x *PublicRandRangeResponse) GetNext() uint64 {
+	if x != nil {
+		return x.Next
+	}
+	return 0
+}
+
 // PrivateRandRequest is the message to send when requesting a private random
 // value.
 type PrivateRandRequest struct {
@@ -174,7 +341,7 @@ type PrivateRandRequest struct {
 func (x *PrivateRandRequest) Reset() {
 	*x = PrivateRandRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[2]
+		mi := &file_drand_api_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -187,7 +354,7 @@ func (x *PrivateRandRequest) String() string {
 func (*PrivateRandRequest) ProtoMessage() {}
 
 func (x *PrivateRandRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[2]
+	mi := &file_drand_api_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -200,7 +367,7 @@ func (x *PrivateRandRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrivateRandRequest.ProtoReflect.Descriptor instead.
 func (*PrivateRandRequest) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{2}
+	return file_drand_api_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PrivateRandRequest) GetRequest() []byte {
@@ -224,7 +391,7 @@ type PrivateRandResponse struct {
 func (x *PrivateRandResponse) Reset() {
 	*x = PrivateRandResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[3]
+		mi := &file_drand_api_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -237,7 +404,7 @@ func (x *PrivateRandResponse) String() string {
 func (*PrivateRandResponse) ProtoMessage() {}
 
 func (x *PrivateRandResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[3]
+	mi := &file_drand_api_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +417,7 @@ func (x *PrivateRandResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PrivateRandResponse.ProtoReflect.Descriptor instead.
 func (*PrivateRandResponse) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{3}
+	return file_drand_api_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *PrivateRandResponse) GetResponse() []byte {
@@ -269,7 +436,7 @@ type HomeRequest struct {
 func (x *HomeRequest) Reset() {
 	*x = HomeRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[4]
+		mi := &file_drand_api_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -282,7 +449,7 @@ func (x *HomeRequest) String() string {
 func (*HomeRequest) ProtoMessage() {}
 
 func (x *HomeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[4]
+	mi := &file_drand_api_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -295,7 +462,7 @@ func (x *HomeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HomeRequest.ProtoReflect.Descriptor instead.
 func (*HomeRequest) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{4}
+	return file_drand_api_proto_rawDescGZIP(), []int{6}
 }
 
 type HomeResponse struct {
@@ -309,7 +476,7 @@ type HomeResponse struct {
 func (x *HomeResponse) Reset() {
 	*x = HomeResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_api_proto_msgTypes[5]
+		mi := &file_drand_api_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -322,7 +489,7 @@ func (x *HomeResponse) String() string {
 func (*HomeResponse) ProtoMessage() {}
 
 func (x *HomeResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_api_proto_msgTypes[5]
+	mi := &file_drand_api_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -335,7 +502,7 @@ func (x *HomeResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HomeResponse.ProtoReflect.Descriptor instead.
 func (*HomeResponse) Descriptor() ([]byte, []int) {
-	return file_drand_api_proto_rawDescGZIP(), []int{5}
+	return file_drand_api_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *HomeResponse) GetStatus() string {
@@ -353,50 +520,76 @@ var file_drand_api_proto_rawDesc = []byte{
 	0x63, 0x6f, 0x6d, 0x6d, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x29, 0x0a, 0x11,
 	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x97, 0x01, 0x0a, 0x12, 0x50, 0x75, 0x62, 0x6c,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0xa6, 0x02, 0x0a, 0x12, 0x50, 0x75, 0x62, 0x6c,
 	0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14,
 	0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72,
 	0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
 	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
-	0x72, 0x65, 0x12, 0x2d, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73,
-	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x11,
-	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
-	0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73,
-	0x73, 0x22, 0x2e, 0x0a, 0x12, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x22, 0x31, 0x0a, 0x13, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0d, 0x0a, 0x0b, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x26, 0x0a, 0x0c, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
-	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x32, 0xcb, 0x02, 0x0a, 0x06,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x41, 0x0a, 0x0a, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63,
-	0x52, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62,
+	0x72, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12,
+	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65, 0x73, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x72, 0x61, 0x6e, 0x64, 0x6f, 0x6d, 0x6e, 0x65,
+	0x73, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x12, 0x28, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6e, 0x65, 0x78,
+	0x74, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a, 0x0c,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x08, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73,
+	0x22, 0x52, 0x0a, 0x16, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x0e,
+	0x0a, 0x02, 0x74, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x02, 0x74, 0x6f, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x22, 0x62, 0x0a, 0x17, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61,
+	0x6e, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x33, 0x0a, 0x07, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52,
+	0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x52, 0x07, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x04, 0x6e, 0x65, 0x78, 0x74, 0x22, 0x2e, 0x0a, 0x12, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x31, 0x0a, 0x13, 0x50, 0x72, 0x69, 0x76,
+	0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x08, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x0d, 0x0a, 0x0b, 0x48,
+	0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x26, 0x0a, 0x0c, 0x48, 0x6f,
+	0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x32, 0x9d, 0x03, 0x0a, 0x06, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x12, 0x41, 0x0a,
+	0x0a, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x12, 0x18, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75,
+	0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x49, 0x0a, 0x10, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x53, 0x74,
+	0x72, 0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62,
 	0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
 	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e,
-	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x10, 0x50, 0x75, 0x62,
-	0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x12, 0x18, 0x2e,
-	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
-	0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52,
-	0x61, 0x6e, 0x64, 0x12, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x76,
-	0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a,
-	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61,
-	0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3c, 0x0a, 0x09, 0x43, 0x68,
-	0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
-	0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
-	0x1a, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65,
-	0x12, 0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71,
-	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x48, 0x6f, 0x6d,
-	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72,
-	0x61, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x0b, 0x50,
+	0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x12, 0x19, 0x2e, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x72,
+	0x69, 0x76, 0x61, 0x74, 0x65, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x3c, 0x0a, 0x09, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x17,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x43, 0x68, 0x61, 0x69, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12,
+	0x2f, 0x0a, 0x04, 0x48, 0x6f, 0x6d, 0x65, 0x12, 0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e,
+	0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x48, 0x6f, 0x6d, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x50, 0x0a, 0x0f, 0x50, 0x75, 0x62, 0x6c, 0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x61,
+	0x6e, 0x67, 0x65, 0x12, 0x1d, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c,
+	0x69, 0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x52, 0x61, 0x6e, 0x64, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -411,33 +604,38 @@ func file_drand_api_proto_rawDescGZIP() []byte {
 	return file_drand_api_proto_rawDescData
 }
 
-var file_drand_api_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_drand_api_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_drand_api_proto_goTypes = []interface{}{
-	(*PublicRandRequest)(nil),   // 0: drand.PublicRandRequest
-	(*PublicRandResponse)(nil),  // 1: drand.PublicRandResponse
-	(*PrivateRandRequest)(nil),  // 2: drand.PrivateRandRequest
-	(*PrivateRandResponse)(nil), // 3: drand.PrivateRandResponse
-	(*HomeRequest)(nil),         // 4: drand.HomeRequest
-	(*HomeResponse)(nil),        // 5: drand.HomeResponse
-	(*ChainInfoRequest)(nil),    // 6: drand.ChainInfoRequest
-	(*ChainInfoPacket)(nil),     // 7: drand.ChainInfoPacket
+	(*PublicRandRequest)(nil),       // 0: drand.PublicRandRequest
+	(*PublicRandResponse)(nil),      // 1: drand.PublicRandResponse
+	(*PublicRandRangeRequest)(nil),  // 2: drand.PublicRandRangeRequest
+	(*PublicRandRangeResponse)(nil), // 3: drand.PublicRandRangeResponse
+	(*PrivateRandRequest)(nil),      // 4: drand.PrivateRandRequest
+	(*PrivateRandResponse)(nil),     // 5: drand.PrivateRandResponse
+	(*HomeRequest)(nil),             // 6: drand.HomeRequest
+	(*HomeResponse)(nil),            // 7: drand.HomeResponse
+	(*ChainInfoRequest)(nil),        // 8: drand.ChainInfoRequest
+	(*ChainInfoPacket)(nil),         // 9: drand.ChainInfoPacket
 }
 var file_drand_api_proto_depIdxs = []int32{
-	0, // 0: drand.Public.PublicRand:input_type -> drand.PublicRandRequest
-	0, // 1: drand.Public.PublicRandStream:input_type -> drand.PublicRandRequest
-	2, // 2: drand.Public.PrivateRand:input_type -> drand.PrivateRandRequest
-	6, // 3: drand.Public.ChainInfo:input_type -> drand.ChainInfoRequest
-	4, // 4: drand.Public.Home:input_type -> drand.HomeRequest
-	1, // 5: drand.Public.PublicRand:output_type -> drand.PublicRandResponse
-	1, // 6: drand.Public.PublicRandStream:output_type -> drand.PublicRandResponse
-	3, // 7: drand.Public.PrivateRand:output_type -> drand.PrivateRandResponse
-	7, // 8: drand.Public.ChainInfo:output_type -> drand.ChainInfoPacket
-	5, // 9: drand.Public.Home:output_type -> drand.HomeResponse
-	5, // [5:10] is the sub-list for method output_type
-	0, // [0:5] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	1, // 0: drand.PublicRandRangeResponse.beacons:type_name -> drand.PublicRandResponse
+	0, // 1: drand.Public.PublicRand:input_type -> drand.PublicRandRequest
+	0, // 2: drand.Public.PublicRandStream:input_type -> drand.PublicRandRequest
+	4, // 3: drand.Public.PrivateRand:input_type -> drand.PrivateRandRequest
+	8, // 4: drand.Public.ChainInfo:input_type -> drand.ChainInfoRequest
+	6, // 5: drand.Public.Home:input_type -> drand.HomeRequest
+	2, // 6: drand.Public.PublicRandRange:input_type -> drand.PublicRandRangeRequest
+	1, // 7: drand.Public.PublicRand:output_type -> drand.PublicRandResponse
+	1, // 8: drand.Public.PublicRandStream:output_type -> drand.PublicRandResponse
+	5, // 9: drand.Public.PrivateRand:output_type -> drand.PrivateRandResponse
+	9, // 10: drand.Public.ChainInfo:output_type -> drand.ChainInfoPacket
+	7, // 11: drand.Public.Home:output_type -> drand.HomeResponse
+	3, // 12: drand.Public.PublicRandRange:output_type -> drand.PublicRandRangeResponse
+	7, // [7:13] is the sub-list for method output_type
+	1, // [1:7] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_drand_api_proto_init() }
@@ -472,7 +670,7 @@ func file_drand_api_proto_init() {
 			}
 		}
 		file_drand_api_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrivateRandRequest); i {
+			switch v := v.(*PublicRandRangeRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -484,7 +682,7 @@ func file_drand_api_proto_init() {
 			}
 		}
 		file_drand_api_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PrivateRandResponse); i {
+			switch v := v.(*PublicRandRangeResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -496,7 +694,7 @@ func file_drand_api_proto_init() {
 			}
 		}
 		file_drand_api_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*HomeRequest); i {
+			switch v := v.(*PrivateRandRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -508,6 +706,30 @@ func file_drand_api_proto_init() {
 			}
 		}
 		file_drand_api_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PrivateRandResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*HomeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_api_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HomeResponse); i {
 			case 0:
 				return &v.state
@@ -526,7 +748,7 @@ func file_drand_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_drand_api_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
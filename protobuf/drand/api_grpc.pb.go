@@ -29,6 +29,9 @@ type PublicClient interface {
 	ChainInfo(ctx context.Context, in *ChainInfoRequest, opts ...grpc.CallOption) (*ChainInfoPacket, error)
 	// Home is a simple endpoint
 	Home(ctx context.Context, in *HomeRequest, opts ...grpc.CallOption) (*HomeResponse, error)
+	// PublicRandRange returns a page of the beacons of a range of rounds. The
+	// number of beacons of a page is capped by the node.
+	PublicRandRange(ctx context.Context, in *PublicRandRangeRequest, opts ...grpc.CallOption) (*PublicRandRangeResponse, error)
 }
 
 type publicClient struct {
@@ -107,6 +110,15 @@ func (c *publicClient) Home(ctx context.Context, in *HomeRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *publicClient) PublicRandRange(ctx context.Context, in *PublicRandRangeRequest, opts ...grpc.CallOption) (*PublicRandRangeResponse, error) {
+	out := new(PublicRandRangeResponse)
+	err := c.cc.Invoke(ctx, "/drand.Public/PublicRandRange", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PublicServer is the server API for Public service.
 // All implementations should embed UnimplementedPublicServer
 // for forward compatibility
@@ -123,6 +135,9 @@ type PublicServer interface {
 	ChainInfo(context.Context, *ChainInfoRequest) (*ChainInfoPacket, error)
 	// Home is a simple endpoint
 	Home(context.Context, *HomeRequest) (*HomeResponse, error)
+	// PublicRandRange returns a page of the beacons of a range of rounds. The
+	// number of beacons of a page is capped by the node.
+	PublicRandRange(context.Context, *PublicRandRangeRequest) (*PublicRandRangeResponse, error)
 }
 
 // UnimplementedPublicServer should be embedded to have forward compatible implementations.
@@ -145,6 +160,10 @@ func (*UnimplementedPublicServer) Home(context.Context, *HomeRequest) (*HomeResp
 	return nil, status.Errorf(codes.Unimplemented, "method Home not implemented")
 }
 
+func (*UnimplementedPublicServer) PublicRandRange(context.Context, *PublicRandRangeRequest) (*PublicRandRangeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PublicRandRange not implemented")
+}
+
 func RegisterPublicServer(s *grpc.Server, srv PublicServer) {
 	s.RegisterService(&_Public_serviceDesc, srv)
 }
@@ -242,6 +261,24 @@ func _Public_Home_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Public_PublicRandRange_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PublicRandRangeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PublicServer).PublicRandRange(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Public/PublicRandRange",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PublicServer).PublicRandRange(ctx, req.(*PublicRandRangeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Public_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "drand.Public",
 	HandlerType: (*PublicServer)(nil),
@@ -262,6 +299,10 @@ var _Public_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Home",
 			Handler:    _Public_Home_Handler,
 		},
+		{
+			MethodName: "PublicRandRange",
+			Handler:    _Public_PublicRandRange_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -38,6 +38,42 @@ type ControlClient interface {
 	GroupFile(ctx context.Context, in *GroupRequest, opts ...grpc.CallOption) (*GroupPacket, error)
 	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
 	StartFollowChain(ctx context.Context, in *StartFollowRequest, opts ...grpc.CallOption) (Control_StartFollowChainClient, error)
+	// RoundReports returns which nodes sent their partial signature and how
+	// long the aggregation took for the last rounds aggregated by the node.
+	RoundReports(ctx context.Context, in *RoundReportsRequest, opts ...grpc.CallOption) (*RoundReportsResponse, error)
+	// PeerStatus returns the clock skew of the other nodes of the group and
+	// whether they are degraded.
+	PeerStatus(ctx context.Context, in *PeerStatusRequest, opts ...grpc.CallOption) (*PeerStatusResponse, error)
+	// ProposeGroup sends the group of a future resharing to all its nodes, for
+	// their operators to approve it.
+	ProposeGroup(ctx context.Context, in *ProposeGroupRequest, opts ...grpc.CallOption) (*ProposeGroupResponse, error)
+	// ListPendingGroups returns the groups proposed to the node.
+	ListPendingGroups(ctx context.Context, in *ListPendingGroupsRequest, opts ...grpc.CallOption) (*ListPendingGroupsResponse, error)
+	// ApproveGroup approves a proposed group, so that the node accepts to
+	// reshare towards it when group approval is required.
+	ApproveGroup(ctx context.Context, in *ApproveGroupRequest, opts ...grpc.CallOption) (*ApproveGroupResponse, error)
+	// Status returns the state of the node: whether it runs a DKG, a
+	// resharing, the beacon or syncs the chain.
+	Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	// Pause stops the node from sending its partial signatures, e.g. during a
+	// maintenance, while it keeps following the chain.
+	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
+	// Resume makes a paused node send its partial signatures again.
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	// InitDKGStream starts a fresh DKG protocol like InitDKG and streams its progress. The last message holds the resulting group.
+	InitDKGStream(ctx context.Context, in *InitDKGPacket, opts ...grpc.CallOption) (Control_InitDKGStreamClient, error)
+	// ScheduleMaintenance declares a maintenance window of the node, and
+	// optionally announces it to the other nodes of the group.
+	ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceResponse, error)
+	// ListMaintenance returns the maintenance windows not over yet of the node
+	// and of the nodes that announced theirs.
+	ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error)
+	// KeyUsage returns how many partial signatures the node produced with its
+	// current share and identity key, and since when.
+	KeyUsage(ctx context.Context, in *KeyUsageRequest, opts ...grpc.CallOption) (*KeyUsageResponse, error)
+	// InitReshareStream starts a resharing protocol like InitReshare and
+	// streams its progress. The last message holds the resulting group.
+	InitReshareStream(ctx context.Context, in *InitResharePacket, opts ...grpc.CallOption) (Control_InitReshareStreamClient, error)
 }
 
 type controlClient struct {
@@ -161,6 +197,169 @@ func (x *controlStartFollowChainClient) Recv() (*FollowProgress, error) {
 	return m, nil
 }
 
+func (c *controlClient) RoundReports(ctx context.Context, in *RoundReportsRequest, opts ...grpc.CallOption) (*RoundReportsResponse, error) {
+	out := new(RoundReportsResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/RoundReports", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) PeerStatus(ctx context.Context, in *PeerStatusRequest, opts ...grpc.CallOption) (*PeerStatusResponse, error) {
+	out := new(PeerStatusResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/PeerStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ProposeGroup(ctx context.Context, in *ProposeGroupRequest, opts ...grpc.CallOption) (*ProposeGroupResponse, error) {
+	out := new(ProposeGroupResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/ProposeGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ListPendingGroups(ctx context.Context, in *ListPendingGroupsRequest, opts ...grpc.CallOption) (*ListPendingGroupsResponse, error) {
+	out := new(ListPendingGroupsResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/ListPendingGroups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ApproveGroup(ctx context.Context, in *ApproveGroupRequest, opts ...grpc.CallOption) (*ApproveGroupResponse, error) {
+	out := new(ApproveGroupResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/ApproveGroup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Status(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error) {
+	out := new(StatusResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/Status", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
+	out := new(PauseResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/Pause", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/Resume", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) InitDKGStream(ctx context.Context, in *InitDKGPacket, opts ...grpc.CallOption) (Control_InitDKGStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[1], "/drand.Control/InitDKGStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlInitDKGStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_InitDKGStreamClient interface {
+	Recv() (*DKGProgress, error)
+	grpc.ClientStream
+}
+
+type controlInitDKGStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlInitDKGStreamClient) Recv() (*DKGProgress, error) {
+	m := new(DKGProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *controlClient) ScheduleMaintenance(ctx context.Context, in *ScheduleMaintenanceRequest, opts ...grpc.CallOption) (*ScheduleMaintenanceResponse, error) {
+	out := new(ScheduleMaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/ScheduleMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) ListMaintenance(ctx context.Context, in *ListMaintenanceRequest, opts ...grpc.CallOption) (*ListMaintenanceResponse, error) {
+	out := new(ListMaintenanceResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/ListMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) KeyUsage(ctx context.Context, in *KeyUsageRequest, opts ...grpc.CallOption) (*KeyUsageResponse, error) {
+	out := new(KeyUsageResponse)
+	err := c.cc.Invoke(ctx, "/drand.Control/KeyUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *controlClient) InitReshareStream(ctx context.Context, in *InitResharePacket, opts ...grpc.CallOption) (Control_InitReshareStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_Control_serviceDesc.Streams[2], "/drand.Control/InitReshareStream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &controlInitReshareStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Control_InitReshareStreamClient interface {
+	Recv() (*DKGProgress, error)
+	grpc.ClientStream
+}
+
+type controlInitReshareStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *controlInitReshareStreamClient) Recv() (*DKGProgress, error) {
+	m := new(DKGProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ControlServer is the server API for Control service.
 // All implementations should embed UnimplementedControlServer
 // for forward compatibility
@@ -186,6 +385,42 @@ type ControlServer interface {
 	GroupFile(context.Context, *GroupRequest) (*GroupPacket, error)
 	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
 	StartFollowChain(*StartFollowRequest, Control_StartFollowChainServer) error
+	// RoundReports returns which nodes sent their partial signature and how
+	// long the aggregation took for the last rounds aggregated by the node.
+	RoundReports(context.Context, *RoundReportsRequest) (*RoundReportsResponse, error)
+	// PeerStatus returns the clock skew of the other nodes of the group and
+	// whether they are degraded.
+	PeerStatus(context.Context, *PeerStatusRequest) (*PeerStatusResponse, error)
+	// ProposeGroup sends the group of a future resharing to all its nodes, for
+	// their operators to approve it.
+	ProposeGroup(context.Context, *ProposeGroupRequest) (*ProposeGroupResponse, error)
+	// ListPendingGroups returns the groups proposed to the node.
+	ListPendingGroups(context.Context, *ListPendingGroupsRequest) (*ListPendingGroupsResponse, error)
+	// ApproveGroup approves a proposed group, so that the node accepts to
+	// reshare towards it when group approval is required.
+	ApproveGroup(context.Context, *ApproveGroupRequest) (*ApproveGroupResponse, error)
+	// Status returns the state of the node: whether it runs a DKG, a
+	// resharing, the beacon or syncs the chain.
+	Status(context.Context, *StatusRequest) (*StatusResponse, error)
+	// Pause stops the node from sending its partial signatures, e.g. during a
+	// maintenance, while it keeps following the chain.
+	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
+	// Resume makes a paused node send its partial signatures again.
+	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	// InitDKGStream starts a fresh DKG protocol like InitDKG and streams its progress. The last message holds the resulting group.
+	InitDKGStream(*InitDKGPacket, Control_InitDKGStreamServer) error
+	// ScheduleMaintenance declares a maintenance window of the node, and
+	// optionally announces it to the other nodes of the group.
+	ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceResponse, error)
+	// ListMaintenance returns the maintenance windows not over yet of the node
+	// and of the nodes that announced theirs.
+	ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error)
+	// KeyUsage returns how many partial signatures the node produced with its
+	// current share and identity key, and since when.
+	KeyUsage(context.Context, *KeyUsageRequest) (*KeyUsageResponse, error)
+	// InitReshareStream starts a resharing protocol like InitReshare and
+	// streams its progress. The last message holds the resulting group.
+	InitReshareStream(*InitResharePacket, Control_InitReshareStreamServer) error
 }
 
 // UnimplementedControlServer should be embedded to have forward compatible implementations.
@@ -222,6 +457,50 @@ func (*UnimplementedControlServer) Shutdown(context.Context, *ShutdownRequest) (
 func (*UnimplementedControlServer) StartFollowChain(*StartFollowRequest, Control_StartFollowChainServer) error {
 	return status.Errorf(codes.Unimplemented, "method StartFollowChain not implemented")
 }
+func (*UnimplementedControlServer) RoundReports(context.Context, *RoundReportsRequest) (*RoundReportsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RoundReports not implemented")
+}
+func (*UnimplementedControlServer) PeerStatus(context.Context, *PeerStatusRequest) (*PeerStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PeerStatus not implemented")
+}
+func (*UnimplementedControlServer) ProposeGroup(context.Context, *ProposeGroupRequest) (*ProposeGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ProposeGroup not implemented")
+}
+func (*UnimplementedControlServer) ListPendingGroups(context.Context, *ListPendingGroupsRequest) (*ListPendingGroupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingGroups not implemented")
+}
+func (*UnimplementedControlServer) ApproveGroup(context.Context, *ApproveGroupRequest) (*ApproveGroupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveGroup not implemented")
+}
+func (*UnimplementedControlServer) Status(context.Context, *StatusRequest) (*StatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Status not implemented")
+}
+func (*UnimplementedControlServer) Pause(context.Context, *PauseRequest) (*PauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (*UnimplementedControlServer) Resume(context.Context, *ResumeRequest) (*ResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+
+func (*UnimplementedControlServer) InitDKGStream(*InitDKGPacket, Control_InitDKGStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method InitDKGStream not implemented")
+}
+
+func (*UnimplementedControlServer) ScheduleMaintenance(context.Context, *ScheduleMaintenanceRequest) (*ScheduleMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScheduleMaintenance not implemented")
+}
+
+func (*UnimplementedControlServer) ListMaintenance(context.Context, *ListMaintenanceRequest) (*ListMaintenanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMaintenance not implemented")
+}
+
+func (*UnimplementedControlServer) KeyUsage(context.Context, *KeyUsageRequest) (*KeyUsageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeyUsage not implemented")
+}
+
+func (*UnimplementedControlServer) InitReshareStream(*InitResharePacket, Control_InitReshareStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method InitReshareStream not implemented")
+}
 
 func RegisterControlServer(s *grpc.Server, srv ControlServer) {
 	s.RegisterService(&_Control_serviceDesc, srv)
@@ -410,6 +689,246 @@ func (x *controlStartFollowChainServer) Send(m *FollowProgress) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Control_RoundReports_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoundReportsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).RoundReports(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/RoundReports",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).RoundReports(ctx, req.(*RoundReportsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_PeerStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PeerStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).PeerStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/PeerStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).PeerStatus(ctx, req.(*PeerStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ProposeGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ProposeGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ProposeGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/ProposeGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ProposeGroup(ctx, req.(*ProposeGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ListPendingGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingGroupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListPendingGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/ListPendingGroups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListPendingGroups(ctx, req.(*ListPendingGroupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ApproveGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveGroupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ApproveGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/ApproveGroup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ApproveGroup(ctx, req.(*ApproveGroupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Status_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Status(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/Status",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Status(ctx, req.(*StatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/Pause",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Pause(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/Resume",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_InitDKGStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InitDKGPacket)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).InitDKGStream(m, &controlInitDKGStreamServer{stream})
+}
+
+type Control_InitDKGStreamServer interface {
+	Send(*DKGProgress) error
+	grpc.ServerStream
+}
+
+type controlInitDKGStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlInitDKGStreamServer) Send(m *DKGProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Control_ScheduleMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScheduleMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ScheduleMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/ScheduleMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ScheduleMaintenance(ctx, req.(*ScheduleMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_ListMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMaintenanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).ListMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/ListMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).ListMaintenance(ctx, req.(*ListMaintenanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_KeyUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeyUsageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ControlServer).KeyUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Control/KeyUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ControlServer).KeyUsage(ctx, req.(*KeyUsageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Control_InitReshareStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(InitResharePacket)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ControlServer).InitReshareStream(m, &controlInitReshareStreamServer{stream})
+}
+
+type Control_InitReshareStreamServer interface {
+	Send(*DKGProgress) error
+	grpc.ServerStream
+}
+
+type controlInitReshareStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *controlInitReshareStreamServer) Send(m *DKGProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _Control_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "drand.Control",
 	HandlerType: (*ControlServer)(nil),
@@ -450,6 +969,50 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Shutdown",
 			Handler:    _Control_Shutdown_Handler,
 		},
+		{
+			MethodName: "RoundReports",
+			Handler:    _Control_RoundReports_Handler,
+		},
+		{
+			MethodName: "PeerStatus",
+			Handler:    _Control_PeerStatus_Handler,
+		},
+		{
+			MethodName: "ProposeGroup",
+			Handler:    _Control_ProposeGroup_Handler,
+		},
+		{
+			MethodName: "ListPendingGroups",
+			Handler:    _Control_ListPendingGroups_Handler,
+		},
+		{
+			MethodName: "ApproveGroup",
+			Handler:    _Control_ApproveGroup_Handler,
+		},
+		{
+			MethodName: "Status",
+			Handler:    _Control_Status_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _Control_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _Control_Resume_Handler,
+		},
+		{
+			MethodName: "ScheduleMaintenance",
+			Handler:    _Control_ScheduleMaintenance_Handler,
+		},
+		{
+			MethodName: "ListMaintenance",
+			Handler:    _Control_ListMaintenance_Handler,
+		},
+		{
+			MethodName: "KeyUsage",
+			Handler:    _Control_KeyUsage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -457,6 +1020,16 @@ var _Control_serviceDesc = grpc.ServiceDesc{
 			Handler:       _Control_StartFollowChain_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "InitDKGStream",
+			Handler:       _Control_InitDKGStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "InitReshareStream",
+			Handler:       _Control_InitReshareStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "drand/control.proto",
 }
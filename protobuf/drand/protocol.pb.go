@@ -153,6 +153,8 @@ type DKGInfoPacket struct {
 	// signature from the coordinator to prove he is the one sending that group
 	// file.
 	Signature []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	// dry_run is true when the nodes must not use the outcome of the DKG
+	DryRun bool `protobuf:"varint,5,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
 }
 
 func (x *DKGInfoPacket) Reset() {
@@ -215,6 +217,107 @@ func (x *DKGInfoPacket) GetSignature() []byte {
 	return nil
 }
 
+func (x *DKGInfoPacket) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+// DKGReadyPacket is the packet a node sends to the coordinator once it is
+// ready to receive the packets of the DKG over the given group.
+type DKGReadyPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// address of the node in the group
+	Address   string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	GroupHash []byte `protobuf:"bytes,2,opt,name=group_hash,json=groupHash,proto3" json:"group_hash,omitempty"`
+	// signature of the group hash with the key of the node
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// version of the binary run by the node
+	Version string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	// version of the protocol between the nodes
+	ProtocolVersion uint32 `protobuf:"varint,5,opt,name=protocol_version,json=protocolVersion,proto3" json:"protocol_version,omitempty"`
+	// signature schemes supported by the node
+	Schemes []string `protobuf:"bytes,6,rep,name=schemes,proto3" json:"schemes,omitempty"`
+}
+
+func (x *DKGReadyPacket) Reset() {
+	*x = DKGReadyPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DKGReadyPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DKGReadyPacket) ProtoMessage() {}
+
+func (x *DKGReadyPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DKGReadyPacket.ProtoReflect.Descriptor instead.
+func (*DKGReadyPacket) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *DKGReadyPacket) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *DKGReadyPacket) GetGroupHash() []byte {
+	if x != nil {
+		return x.GroupHash
+	}
+	return nil
+}
+
+func (x *DKGReadyPacket) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *DKGReadyPacket) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DKGReadyPacket) GetProtocolVersion() uint32 {
+	if x != nil {
+		return x.ProtocolVersion
+	}
+	return 0
+}
+
+func (x *DKGReadyPacket) GetSchemes() []string {
+	if x != nil {
+		return x.Schemes
+	}
+	return nil
+}
+
 type PartialBeaconPacket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -229,12 +332,19 @@ type PartialBeaconPacket struct {
 	// partial signature - a threshold of them needs to be aggregated to produce
 	// the final beacon at the given round.
 	PartialSig []byte `protobuf:"bytes,3,opt,name=partial_sig,json=partialSig,proto3" json:"partial_sig,omitempty"`
+	// chain_hash is the hash of the chain the partial is produced for. Packets
+	// from another chain are rejected; an empty hash is accepted from nodes
+	// that do not set it.
+	ChainHash []byte `protobuf:"bytes,4,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+	// timestamp is the UNIX time in milliseconds at which the partial was
+	// sent, so nodes can detect the peers whose clock is skewed
+	Timestamp int64 `protobuf:"varint,5,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 }
 
 func (x *PartialBeaconPacket) Reset() {
 	*x = PartialBeaconPacket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_protocol_proto_msgTypes[3]
+		mi := &file_drand_protocol_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -247,7 +357,7 @@ func (x *PartialBeaconPacket) String() string {
 func (*PartialBeaconPacket) ProtoMessage() {}
 
 func (x *PartialBeaconPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_protocol_proto_msgTypes[3]
+	mi := &file_drand_protocol_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -260,7 +370,7 @@ func (x *PartialBeaconPacket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PartialBeaconPacket.ProtoReflect.Descriptor instead.
 func (*PartialBeaconPacket) Descriptor() ([]byte, []int) {
-	return file_drand_protocol_proto_rawDescGZIP(), []int{3}
+	return file_drand_protocol_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *PartialBeaconPacket) GetRound() uint64 {
@@ -284,6 +394,205 @@ func (x *PartialBeaconPacket) GetPartialSig() []byte {
 	return nil
 }
 
+func (x *PartialBeaconPacket) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *PartialBeaconPacket) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+// BeaconResponse is the answer to a NewBeacon request.
+type BeaconResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// partial is the partial signature of the node for the round and the
+	// previous signature of the request, nil if it did not sign it yet.
+	Partial *PartialBeaconPacket `protobuf:"bytes,1,opt,name=partial,proto3" json:"partial,omitempty"`
+}
+
+func (x *BeaconResponse) Reset() {
+	*x = BeaconResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconResponse) ProtoMessage() {}
+
+func (x *BeaconResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconResponse.ProtoReflect.Descriptor instead.
+func (*BeaconResponse) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BeaconResponse) GetPartial() *PartialBeaconPacket {
+	if x != nil {
+		return x.Partial
+	}
+	return nil
+}
+
+// GroupProposal is a group proposed for a future resharing, signed by the
+// node proposing it.
+type GroupProposal struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group *GroupPacket `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	// address of the node proposing the group, a node of the current or of
+	// the proposed group
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`
+	// signature of the proposal by the longterm key of the node
+	Signature []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *GroupProposal) Reset() {
+	*x = GroupProposal{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GroupProposal) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GroupProposal) ProtoMessage() {}
+
+func (x *GroupProposal) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GroupProposal.ProtoReflect.Descriptor instead.
+func (*GroupProposal) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GroupProposal) GetGroup() *GroupPacket {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *GroupProposal) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *GroupProposal) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// EquivocationPacket is the evidence that a node signed two different
+// messages for the same round: both partials verify under its share, and
+// anyone holding the distributed public key can check them.
+type EquivocationPacket struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	First  *PartialBeaconPacket `protobuf:"bytes,1,opt,name=first,proto3" json:"first,omitempty"`
+	Second *PartialBeaconPacket `protobuf:"bytes,2,opt,name=second,proto3" json:"second,omitempty"`
+	// chain_hash is the hash of the chain the partials are produced for
+	ChainHash []byte `protobuf:"bytes,3,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+}
+
+func (x *EquivocationPacket) Reset() {
+	*x = EquivocationPacket{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EquivocationPacket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EquivocationPacket) ProtoMessage() {}
+
+func (x *EquivocationPacket) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EquivocationPacket.ProtoReflect.Descriptor instead.
+func (*EquivocationPacket) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *EquivocationPacket) GetFirst() *PartialBeaconPacket {
+	if x != nil {
+		return x.First
+	}
+	return nil
+}
+
+func (x *EquivocationPacket) GetSecond() *PartialBeaconPacket {
+	if x != nil {
+		return x.Second
+	}
+	return nil
+}
+
+func (x *EquivocationPacket) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
 // DKGPacket is the packet that nodes send to others nodes as part of the
 // broadcasting protocol.
 type DKGPacket struct {
@@ -297,7 +606,7 @@ type DKGPacket struct {
 func (x *DKGPacket) Reset() {
 	*x = DKGPacket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_protocol_proto_msgTypes[4]
+		mi := &file_drand_protocol_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -310,7 +619,7 @@ func (x *DKGPacket) String() string {
 func (*DKGPacket) ProtoMessage() {}
 
 func (x *DKGPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_protocol_proto_msgTypes[4]
+	mi := &file_drand_protocol_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -323,7 +632,7 @@ func (x *DKGPacket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DKGPacket.ProtoReflect.Descriptor instead.
 func (*DKGPacket) Descriptor() ([]byte, []int) {
-	return file_drand_protocol_proto_rawDescGZIP(), []int{4}
+	return file_drand_protocol_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DKGPacket) GetDkg() *dkg.Packet {
@@ -333,6 +642,81 @@ func (x *DKGPacket) GetDkg() *dkg.Packet {
 	return nil
 }
 
+// DKGChunk is a part of a serialized DKGPacket.
+type DKGChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// id is the hash of the serialized packet
+	Id []byte `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// index of this chunk, starting at 0
+	Index uint32 `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	// total number of chunks of the packet
+	Total uint32 `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Data  []byte `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *DKGChunk) Reset() {
+	*x = DKGChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DKGChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DKGChunk) ProtoMessage() {}
+
+func (x *DKGChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DKGChunk.ProtoReflect.Descriptor instead.
+func (*DKGChunk) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DKGChunk) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *DKGChunk) GetIndex() uint32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *DKGChunk) GetTotal() uint32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *DKGChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
 // SyncRequest is from a node that needs to sync up with the current head of the
 // chain
 type SyncRequest struct {
@@ -341,12 +725,18 @@ type SyncRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	FromRound uint64 `protobuf:"varint,1,opt,name=from_round,json=fromRound,proto3" json:"from_round,omitempty"`
+	// chain_hash is the hash of the chain to sync, the request is rejected by
+	// nodes following another chain.
+	ChainHash []byte `protobuf:"bytes,2,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+	// up_to is the last round to send, after which the stream ends. Zero
+	// streams the new beacons without end.
+	UpTo uint64 `protobuf:"varint,3,opt,name=up_to,json=upTo,proto3" json:"up_to,omitempty"`
 }
 
 func (x *SyncRequest) Reset() {
 	*x = SyncRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_protocol_proto_msgTypes[5]
+		mi := &file_drand_protocol_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -359,7 +749,7 @@ func (x *SyncRequest) String() string {
 func (*SyncRequest) ProtoMessage() {}
 
 func (x *SyncRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_protocol_proto_msgTypes[5]
+	mi := &file_drand_protocol_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,7 +762,7 @@ func (x *SyncRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
 func (*SyncRequest) Descriptor() ([]byte, []int) {
-	return file_drand_protocol_proto_rawDescGZIP(), []int{5}
+	return file_drand_protocol_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *SyncRequest) GetFromRound() uint64 {
@@ -382,6 +772,78 @@ func (x *SyncRequest) GetFromRound() uint64 {
 	return 0
 }
 
+func (x *SyncRequest) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *SyncRequest) GetUpTo() uint64 {
+	if x != nil {
+		return x.UpTo
+	}
+	return 0
+}
+
+// GetBeaconRequest is from a node that needs the beacon of a single round
+type GetBeaconRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round uint64 `protobuf:"varint,1,opt,name=round,proto3" json:"round,omitempty"`
+	// chain_hash is the hash of the chain of the beacon, the request is
+	// rejected by nodes following another chain.
+	ChainHash []byte `protobuf:"bytes,2,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+}
+
+func (x *GetBeaconRequest) Reset() {
+	*x = GetBeaconRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_drand_protocol_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetBeaconRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBeaconRequest) ProtoMessage() {}
+
+func (x *GetBeaconRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_drand_protocol_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBeaconRequest.ProtoReflect.Descriptor instead.
+func (*GetBeaconRequest) Descriptor() ([]byte, []int) {
+	return file_drand_protocol_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetBeaconRequest) GetRound() uint64 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *GetBeaconRequest) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
 type BeaconPacket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -390,12 +852,17 @@ type BeaconPacket struct {
 	PreviousSig []byte `protobuf:"bytes,1,opt,name=previous_sig,json=previousSig,proto3" json:"previous_sig,omitempty"`
 	Round       uint64 `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	Signature   []byte `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	// chain_hash is the hash of the chain the beacon belongs to
+	ChainHash []byte `protobuf:"bytes,4,opt,name=chain_hash,json=chainHash,proto3" json:"chain_hash,omitempty"`
+	// contributors is the bitmap of the indices of the nodes whose partial
+	// signature the node held when it aggregated the beacon
+	Contributors []byte `protobuf:"bytes,5,opt,name=contributors,proto3" json:"contributors,omitempty"`
 }
 
 func (x *BeaconPacket) Reset() {
 	*x = BeaconPacket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_drand_protocol_proto_msgTypes[6]
+		mi := &file_drand_protocol_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -408,7 +875,7 @@ func (x *BeaconPacket) String() string {
 func (*BeaconPacket) ProtoMessage() {}
 
 func (x *BeaconPacket) ProtoReflect() protoreflect.Message {
-	mi := &file_drand_protocol_proto_msgTypes[6]
+	mi := &file_drand_protocol_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -421,7 +888,7 @@ func (x *BeaconPacket) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BeaconPacket.ProtoReflect.Descriptor instead.
 func (*BeaconPacket) Descriptor() ([]byte, []int) {
-	return file_drand_protocol_proto_rawDescGZIP(), []int{6}
+	return file_drand_protocol_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *BeaconPacket) GetPreviousSig() []byte {
@@ -445,6 +912,20 @@ func (x *BeaconPacket) GetSignature() []byte {
 	return nil
 }
 
+func (x *BeaconPacket) GetChainHash() []byte {
+	if x != nil {
+		return x.ChainHash
+	}
+	return nil
+}
+
+func (x *BeaconPacket) GetContributors() []byte {
+	if x != nil {
+		return x.Contributors
+	}
+	return nil
+}
+
 var File_drand_protocol_proto protoreflect.FileDescriptor
 
 var file_drand_protocol_proto_rawDesc = []byte{
@@ -462,7 +943,7 @@ var file_drand_protocol_proto_rawDesc = []byte{
 	0x74, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
 	0x75, 0x73, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20,
 	0x01, 0x28, 0x0c, 0x52, 0x11, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x47, 0x72, 0x6f,
-	0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x22, 0xa2, 0x01, 0x0a, 0x0d, 0x44, 0x4b, 0x47, 0x49, 0x6e,
+	0x75, 0x70, 0x48, 0x61, 0x73, 0x68, 0x22, 0xbb, 0x01, 0x0a, 0x0d, 0x44, 0x4b, 0x47, 0x49, 0x6e,
 	0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f,
 	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x72,
 	0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52,
@@ -472,51 +953,134 @@ var file_drand_protocol_proto_rawDesc = []byte{
 	0x64, 0x6b, 0x67, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
 	0x0d, 0x52, 0x0a, 0x64, 0x6b, 0x67, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x12, 0x1c, 0x0a,
 	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x6f, 0x0a, 0x13, 0x50,
-	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76,
-	0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b,
-	0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x12, 0x1f, 0x0a, 0x0b, 0x70,
-	0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c,
-	0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x22, 0x2a, 0x0a, 0x09,
-	0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x1d, 0x0a, 0x03, 0x64, 0x6b, 0x67,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x63,
-	0x6b, 0x65, 0x74, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x22, 0x2c, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63,
-	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72, 0x6f, 0x6d, 0x5f,
-	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x66, 0x72, 0x6f,
-	0x6d, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x65, 0x0a, 0x0c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
-	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f,
-	0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70, 0x72,
-	0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75,
-	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x32, 0xd6, 0x02,
-	0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x36, 0x0a, 0x0b, 0x47, 0x65,
-	0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x2e, 0x64, 0x72, 0x61, 0x6e,
-	0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
-	0x74, 0x1a, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69,
-	0x74, 0x79, 0x12, 0x3c, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x44, 0x4b, 0x47, 0x50,
-	0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x12, 0x16, 0x2e, 0x64, 0x72, 0x61,
-	0x6e, 0x64, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b,
-	0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
-	0x12, 0x31, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x44, 0x4b, 0x47, 0x49, 0x6e, 0x66, 0x6f, 0x12,
-	0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x4b, 0x47, 0x49, 0x6e, 0x66, 0x6f, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x12, 0x2e, 0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74,
-	0x44, 0x4b, 0x47, 0x12, 0x10, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x4b, 0x47, 0x50,
-	0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d,
-	0x70, 0x74, 0x79, 0x12, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65,
-	0x61, 0x63, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72,
-	0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
-	0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x36,
-	0x0a, 0x09, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x2e, 0x64, 0x72,
-	0x61, 0x6e, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
-	0x13, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61,
-	0x63, 0x6b, 0x65, 0x74, 0x30, 0x01, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x62,
-	0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x64,
+	0x72, 0x79, 0x5f, 0x72, 0x75, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x64, 0x72,
+	0x79, 0x52, 0x75, 0x6e, 0x22, 0xc6, 0x01, 0x0a, 0x0e, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x61, 0x64,
+	0x79, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x48, 0x61, 0x73, 0x68,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x63, 0x6f, 0x6c, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x73, 0x63, 0x68, 0x65, 0x6d, 0x65, 0x73, 0x22, 0xac, 0x01,
+	0x0a, 0x13, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50,
+	0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x53, 0x69, 0x67, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c,
+	0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x22, 0x46, 0x0a, 0x0e,
+	0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x34,
+	0x0a, 0x07, 0x70, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x70, 0x61, 0x72,
+	0x74, 0x69, 0x61, 0x6c, 0x22, 0x71, 0x0a, 0x0d, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x28, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x99, 0x01, 0x0a, 0x12, 0x45, 0x71, 0x75, 0x69,
+	0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x30,
+	0x0a, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x05, 0x66, 0x69, 0x72, 0x73, 0x74,
+	0x12, 0x32, 0x0a, 0x06, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c,
+	0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x68, 0x61,
+	0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x48,
+	0x61, 0x73, 0x68, 0x22, 0x2a, 0x0a, 0x09, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x1d, 0x0a, 0x03, 0x64, 0x6b, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e,
+	0x64, 0x6b, 0x67, 0x2e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x03, 0x64, 0x6b, 0x67, 0x22,
+	0x5a, 0x0a, 0x08, 0x44, 0x4b, 0x47, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x02, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x05, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x60, 0x0a, 0x0b, 0x53,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x66, 0x72,
+	0x6f, 0x6d, 0x5f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x66, 0x72, 0x6f, 0x6d, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x13, 0x0a, 0x05, 0x75, 0x70, 0x5f, 0x74,
+	0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x75, 0x70, 0x54, 0x6f, 0x22, 0x47, 0x0a,
+	0x10, 0x47, 0x65, 0x74, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61,
+	0x69, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x22, 0xa8, 0x01, 0x0a, 0x0c, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x70, 0x72, 0x65, 0x76, 0x69,
+	0x6f, 0x75, 0x73, 0x5f, 0x73, 0x69, 0x67, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x53, 0x69, 0x67, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64,
+	0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x48, 0x61, 0x73, 0x68, 0x12, 0x22, 0x0a,
+	0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x6f, 0x72,
+	0x73, 0x32, 0xf5, 0x05, 0x0a, 0x08, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x63, 0x6f, 0x6c, 0x12, 0x36,
+	0x0a, 0x0b, 0x47, 0x65, 0x74, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x16, 0x2e,
+	0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49, 0x64, 0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x49, 0x64,
+	0x65, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x3c, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c,
+	0x44, 0x4b, 0x47, 0x50, 0x61, 0x72, 0x74, 0x69, 0x63, 0x69, 0x70, 0x61, 0x6e, 0x74, 0x12, 0x16,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x44, 0x4b, 0x47,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45,
+	0x6d, 0x70, 0x74, 0x79, 0x12, 0x31, 0x0a, 0x0b, 0x50, 0x75, 0x73, 0x68, 0x44, 0x4b, 0x47, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x4b, 0x47, 0x49,
+	0x6e, 0x66, 0x6f, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x35, 0x0a, 0x0e, 0x53, 0x69, 0x67, 0x6e, 0x61,
+	0x6c, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x61, 0x64, 0x79, 0x12, 0x15, 0x2e, 0x64, 0x72, 0x61, 0x6e,
+	0x64, 0x2e, 0x44, 0x4b, 0x47, 0x52, 0x65, 0x61, 0x64, 0x79, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x2e,
+	0x0a, 0x0c, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x44, 0x4b, 0x47, 0x12, 0x10,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x4b, 0x47, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x32,
+	0x0a, 0x11, 0x42, 0x72, 0x6f, 0x61, 0x64, 0x63, 0x61, 0x73, 0x74, 0x44, 0x4b, 0x47, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x12, 0x0f, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x44, 0x4b, 0x47, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70,
+	0x74, 0x79, 0x12, 0x39, 0x0a, 0x0d, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74,
+	0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a,
+	0x0c, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3e, 0x0a,
+	0x09, 0x4e, 0x65, 0x77, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x50, 0x61, 0x72, 0x74, 0x69, 0x61, 0x6c, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e,
+	0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x15, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42,
+	0x65, 0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x36, 0x0a,
+	0x09, 0x53, 0x79, 0x6e, 0x63, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x2e, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13,
+	0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63,
+	0x6b, 0x65, 0x74, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x09, 0x47, 0x65, 0x74, 0x42, 0x65, 0x61, 0x63,
+	0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x65, 0x74, 0x42, 0x65,
+	0x61, 0x63, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74,
+	0x12, 0x37, 0x0a, 0x11, 0x50, 0x75, 0x73, 0x68, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x72, 0x6f,
+	0x70, 0x6f, 0x73, 0x61, 0x6c, 0x12, 0x14, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x47, 0x72,
+	0x6f, 0x75, 0x70, 0x50, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x61, 0x6c, 0x1a, 0x0c, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3f, 0x0a, 0x14, 0x45, 0x71, 0x75,
+	0x69, 0x76, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x76, 0x69, 0x64, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x19, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x71, 0x75, 0x69, 0x76, 0x6f,
+	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x50, 0x61, 0x63, 0x6b, 0x65, 0x74, 0x1a, 0x0c, 0x2e, 0x64,
+	0x72, 0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x12, 0x3d, 0x0a, 0x13, 0x41, 0x6e,
+	0x6e, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63,
+	0x65, 0x12, 0x18, 0x2e, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2e, 0x4d, 0x61, 0x69, 0x6e, 0x74, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x1a, 0x0c, 0x2e, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x42, 0x27, 0x5a, 0x25, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x64, 0x72, 0x61, 0x6e, 0x64, 0x2f, 0x64, 0x72,
+	0x61, 0x6e, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x72, 0x61,
+	0x6e, 0x64, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -531,41 +1095,66 @@ func file_drand_protocol_proto_rawDescGZIP() []byte {
 	return file_drand_protocol_proto_rawDescData
 }
 
-var file_drand_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_drand_protocol_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
 var file_drand_protocol_proto_goTypes = []interface{}{
 	(*IdentityRequest)(nil),     // 0: drand.IdentityRequest
 	(*SignalDKGPacket)(nil),     // 1: drand.SignalDKGPacket
 	(*DKGInfoPacket)(nil),       // 2: drand.DKGInfoPacket
-	(*PartialBeaconPacket)(nil), // 3: drand.PartialBeaconPacket
-	(*DKGPacket)(nil),           // 4: drand.DKGPacket
-	(*SyncRequest)(nil),         // 5: drand.SyncRequest
-	(*BeaconPacket)(nil),        // 6: drand.BeaconPacket
-	(*Identity)(nil),            // 7: drand.Identity
-	(*GroupPacket)(nil),         // 8: drand.GroupPacket
-	(*dkg.Packet)(nil),          // 9: dkg.Packet
-	(*Empty)(nil),               // 10: drand.Empty
+	(*DKGReadyPacket)(nil),      // 3: drand.DKGReadyPacket
+	(*PartialBeaconPacket)(nil), // 4: drand.PartialBeaconPacket
+	(*BeaconResponse)(nil),      // 5: drand.BeaconResponse
+	(*GroupProposal)(nil),       // 6: drand.GroupProposal
+	(*EquivocationPacket)(nil),  // 7: drand.EquivocationPacket
+	(*DKGPacket)(nil),           // 8: drand.DKGPacket
+	(*DKGChunk)(nil),            // 9: drand.DKGChunk
+	(*SyncRequest)(nil),         // 10: drand.SyncRequest
+	(*GetBeaconRequest)(nil),    // 11: drand.GetBeaconRequest
+	(*BeaconPacket)(nil),        // 12: drand.BeaconPacket
+	(*Identity)(nil),            // 13: drand.Identity
+	(*GroupPacket)(nil),         // 14: drand.GroupPacket
+	(*dkg.Packet)(nil),          // 15: dkg.Packet
+	(*MaintenanceWindow)(nil),   // 16: drand.MaintenanceWindow
+	(*Empty)(nil),               // 17: drand.Empty
 }
 var file_drand_protocol_proto_depIdxs = []int32{
-	7,  // 0: drand.SignalDKGPacket.node:type_name -> drand.Identity
-	8,  // 1: drand.DKGInfoPacket.new_group:type_name -> drand.GroupPacket
-	9,  // 2: drand.DKGPacket.dkg:type_name -> dkg.Packet
-	0,  // 3: drand.Protocol.GetIdentity:input_type -> drand.IdentityRequest
-	1,  // 4: drand.Protocol.SignalDKGParticipant:input_type -> drand.SignalDKGPacket
-	2,  // 5: drand.Protocol.PushDKGInfo:input_type -> drand.DKGInfoPacket
-	4,  // 6: drand.Protocol.BroadcastDKG:input_type -> drand.DKGPacket
-	3,  // 7: drand.Protocol.PartialBeacon:input_type -> drand.PartialBeaconPacket
-	5,  // 8: drand.Protocol.SyncChain:input_type -> drand.SyncRequest
-	7,  // 9: drand.Protocol.GetIdentity:output_type -> drand.Identity
-	10, // 10: drand.Protocol.SignalDKGParticipant:output_type -> drand.Empty
-	10, // 11: drand.Protocol.PushDKGInfo:output_type -> drand.Empty
-	10, // 12: drand.Protocol.BroadcastDKG:output_type -> drand.Empty
-	10, // 13: drand.Protocol.PartialBeacon:output_type -> drand.Empty
-	6,  // 14: drand.Protocol.SyncChain:output_type -> drand.BeaconPacket
-	9,  // [9:15] is the sub-list for method output_type
-	3,  // [3:9] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	13, // 0: drand.SignalDKGPacket.node:type_name -> drand.Identity
+	14, // 1: drand.DKGInfoPacket.new_group:type_name -> drand.GroupPacket
+	4,  // 2: drand.BeaconResponse.partial:type_name -> drand.PartialBeaconPacket
+	14, // 3: drand.GroupProposal.group:type_name -> drand.GroupPacket
+	4,  // 4: drand.EquivocationPacket.first:type_name -> drand.PartialBeaconPacket
+	4,  // 5: drand.EquivocationPacket.second:type_name -> drand.PartialBeaconPacket
+	15, // 6: drand.DKGPacket.dkg:type_name -> dkg.Packet
+	0,  // 7: drand.Protocol.GetIdentity:input_type -> drand.IdentityRequest
+	1,  // 8: drand.Protocol.SignalDKGParticipant:input_type -> drand.SignalDKGPacket
+	2,  // 9: drand.Protocol.PushDKGInfo:input_type -> drand.DKGInfoPacket
+	3,  // 10: drand.Protocol.SignalDKGReady:input_type -> drand.DKGReadyPacket
+	8,  // 11: drand.Protocol.BroadcastDKG:input_type -> drand.DKGPacket
+	9,  // 12: drand.Protocol.BroadcastDKGChunk:input_type -> drand.DKGChunk
+	4,  // 13: drand.Protocol.PartialBeacon:input_type -> drand.PartialBeaconPacket
+	4,  // 14: drand.Protocol.NewBeacon:input_type -> drand.PartialBeaconPacket
+	10, // 15: drand.Protocol.SyncChain:input_type -> drand.SyncRequest
+	11, // 16: drand.Protocol.GetBeacon:input_type -> drand.GetBeaconRequest
+	6,  // 17: drand.Protocol.PushGroupProposal:input_type -> drand.GroupProposal
+	7,  // 18: drand.Protocol.EquivocationEvidence:input_type -> drand.EquivocationPacket
+	16, // 19: drand.Protocol.AnnounceMaintenance:input_type -> drand.MaintenanceWindow
+	13, // 20: drand.Protocol.GetIdentity:output_type -> drand.Identity
+	17, // 21: drand.Protocol.SignalDKGParticipant:output_type -> drand.Empty
+	17, // 22: drand.Protocol.PushDKGInfo:output_type -> drand.Empty
+	17, // 23: drand.Protocol.SignalDKGReady:output_type -> drand.Empty
+	17, // 24: drand.Protocol.BroadcastDKG:output_type -> drand.Empty
+	17, // 25: drand.Protocol.BroadcastDKGChunk:output_type -> drand.Empty
+	17, // 26: drand.Protocol.PartialBeacon:output_type -> drand.Empty
+	5,  // 27: drand.Protocol.NewBeacon:output_type -> drand.BeaconResponse
+	12, // 28: drand.Protocol.SyncChain:output_type -> drand.BeaconPacket
+	12, // 29: drand.Protocol.GetBeacon:output_type -> drand.BeaconPacket
+	17, // 30: drand.Protocol.PushGroupProposal:output_type -> drand.Empty
+	17, // 31: drand.Protocol.EquivocationEvidence:output_type -> drand.Empty
+	17, // 32: drand.Protocol.AnnounceMaintenance:output_type -> drand.Empty
+	20, // [20:33] is the sub-list for method output_type
+	7,  // [7:20] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_drand_protocol_proto_init() }
@@ -612,7 +1201,7 @@ func file_drand_protocol_proto_init() {
 			}
 		}
 		file_drand_protocol_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*PartialBeaconPacket); i {
+			switch v := v.(*DKGReadyPacket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -624,7 +1213,7 @@ func file_drand_protocol_proto_init() {
 			}
 		}
 		file_drand_protocol_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DKGPacket); i {
+			switch v := v.(*PartialBeaconPacket); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -636,7 +1225,7 @@ func file_drand_protocol_proto_init() {
 			}
 		}
 		file_drand_protocol_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SyncRequest); i {
+			switch v := v.(*BeaconResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -648,6 +1237,78 @@ func file_drand_protocol_proto_init() {
 			}
 		}
 		file_drand_protocol_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GroupProposal); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EquivocationPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DKGPacket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DKGChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetBeaconRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_drand_protocol_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*BeaconPacket); i {
 			case 0:
 				return &v.state
@@ -666,7 +1327,7 @@ func file_drand_protocol_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_drand_protocol_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   7,
+			NumMessages:   13,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -33,6 +33,28 @@ type ProtocolClient interface {
 	PartialBeacon(ctx context.Context, in *PartialBeaconPacket, opts ...grpc.CallOption) (*Empty, error)
 	// SyncRequest forces a daemon to sync up its chain with other nodes
 	SyncChain(ctx context.Context, in *SyncRequest, opts ...grpc.CallOption) (Protocol_SyncChainClient, error)
+	// BroadcastDKGChunk sends a part of a DKG packet too large to be sent
+	// at once. The packet is processed once all its chunks are received.
+	BroadcastDKGChunk(ctx context.Context, in *DKGChunk, opts ...grpc.CallOption) (*Empty, error)
+	// PushGroupProposal sends the group of a future resharing to a node, for
+	// its operator to approve it.
+	PushGroupProposal(ctx context.Context, in *GroupProposal, opts ...grpc.CallOption) (*Empty, error)
+	// SignalDKGReady is called by the nodes to tell the coordinator they are
+	// ready to run the DKG over the group it pushed. The coordinator starts the
+	// DKG once the nodes are ready.
+	SignalDKGReady(ctx context.Context, in *DKGReadyPacket, opts ...grpc.CallOption) (*Empty, error)
+	// EquivocationEvidence sends the evidence that a node of the group signed
+	// two different messages for the same round.
+	EquivocationEvidence(ctx context.Context, in *EquivocationPacket, opts ...grpc.CallOption) (*Empty, error)
+	// AnnounceMaintenance tells the other nodes of the group that the node
+	// will be in maintenance during the window.
+	AnnounceMaintenance(ctx context.Context, in *MaintenanceWindow, opts ...grpc.CallOption) (*Empty, error)
+	// NewBeacon sends its partial beacon to another node, which answers with
+	// its own partial for the same round if it already signed it.
+	NewBeacon(ctx context.Context, in *PartialBeaconPacket, opts ...grpc.CallOption) (*BeaconResponse, error)
+	// GetBeacon returns the beacon of a single round, e.g. to a node missing
+	// the previous beacon of the partials it receives.
+	GetBeacon(ctx context.Context, in *GetBeaconRequest, opts ...grpc.CallOption) (*BeaconPacket, error)
 }
 
 type protocolClient struct {
@@ -120,6 +142,69 @@ func (x *protocolSyncChainClient) Recv() (*BeaconPacket, error) {
 	return m, nil
 }
 
+func (c *protocolClient) BroadcastDKGChunk(ctx context.Context, in *DKGChunk, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/BroadcastDKGChunk", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) PushGroupProposal(ctx context.Context, in *GroupProposal, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/PushGroupProposal", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) SignalDKGReady(ctx context.Context, in *DKGReadyPacket, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/SignalDKGReady", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) EquivocationEvidence(ctx context.Context, in *EquivocationPacket, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/EquivocationEvidence", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) AnnounceMaintenance(ctx context.Context, in *MaintenanceWindow, opts ...grpc.CallOption) (*Empty, error) {
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/AnnounceMaintenance", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) NewBeacon(ctx context.Context, in *PartialBeaconPacket, opts ...grpc.CallOption) (*BeaconResponse, error) {
+	out := new(BeaconResponse)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/NewBeacon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *protocolClient) GetBeacon(ctx context.Context, in *GetBeaconRequest, opts ...grpc.CallOption) (*BeaconPacket, error) {
+	out := new(BeaconPacket)
+	err := c.cc.Invoke(ctx, "/drand.Protocol/GetBeacon", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProtocolServer is the server API for Protocol service.
 // All implementations should embed UnimplementedProtocolServer
 // for forward compatibility
@@ -140,6 +225,28 @@ type ProtocolServer interface {
 	PartialBeacon(context.Context, *PartialBeaconPacket) (*Empty, error)
 	// SyncRequest forces a daemon to sync up its chain with other nodes
 	SyncChain(*SyncRequest, Protocol_SyncChainServer) error
+	// BroadcastDKGChunk sends a part of a DKG packet too large to be sent
+	// at once. The packet is processed once all its chunks are received.
+	BroadcastDKGChunk(context.Context, *DKGChunk) (*Empty, error)
+	// PushGroupProposal sends the group of a future resharing to a node, for
+	// its operator to approve it.
+	PushGroupProposal(context.Context, *GroupProposal) (*Empty, error)
+	// SignalDKGReady is called by the nodes to tell the coordinator they are
+	// ready to run the DKG over the group it pushed. The coordinator starts the
+	// DKG once the nodes are ready.
+	SignalDKGReady(context.Context, *DKGReadyPacket) (*Empty, error)
+	// EquivocationEvidence sends the evidence that a node of the group signed
+	// two different messages for the same round.
+	EquivocationEvidence(context.Context, *EquivocationPacket) (*Empty, error)
+	// AnnounceMaintenance tells the other nodes of the group that the node
+	// will be in maintenance during the window.
+	AnnounceMaintenance(context.Context, *MaintenanceWindow) (*Empty, error)
+	// NewBeacon sends its partial beacon to another node, which answers with
+	// its own partial for the same round if it already signed it.
+	NewBeacon(context.Context, *PartialBeaconPacket) (*BeaconResponse, error)
+	// GetBeacon returns the beacon of a single round, e.g. to a node missing
+	// the previous beacon of the partials it receives.
+	GetBeacon(context.Context, *GetBeaconRequest) (*BeaconPacket, error)
 }
 
 // UnimplementedProtocolServer should be embedded to have forward compatible implementations.
@@ -164,6 +271,29 @@ func (*UnimplementedProtocolServer) PartialBeacon(context.Context, *PartialBeaco
 func (*UnimplementedProtocolServer) SyncChain(*SyncRequest, Protocol_SyncChainServer) error {
 	return status.Errorf(codes.Unimplemented, "method SyncChain not implemented")
 }
+func (*UnimplementedProtocolServer) BroadcastDKGChunk(context.Context, *DKGChunk) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BroadcastDKGChunk not implemented")
+}
+func (*UnimplementedProtocolServer) PushGroupProposal(context.Context, *GroupProposal) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PushGroupProposal not implemented")
+}
+func (*UnimplementedProtocolServer) SignalDKGReady(context.Context, *DKGReadyPacket) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SignalDKGReady not implemented")
+}
+func (*UnimplementedProtocolServer) EquivocationEvidence(context.Context, *EquivocationPacket) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EquivocationEvidence not implemented")
+}
+
+func (*UnimplementedProtocolServer) AnnounceMaintenance(context.Context, *MaintenanceWindow) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AnnounceMaintenance not implemented")
+}
+
+func (*UnimplementedProtocolServer) NewBeacon(context.Context, *PartialBeaconPacket) (*BeaconResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NewBeacon not implemented")
+}
+func (*UnimplementedProtocolServer) GetBeacon(context.Context, *GetBeaconRequest) (*BeaconPacket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBeacon not implemented")
+}
 
 func RegisterProtocolServer(s *grpc.Server, srv ProtocolServer) {
 	s.RegisterService(&_Protocol_serviceDesc, srv)
@@ -280,6 +410,132 @@ func (x *protocolSyncChainServer) Send(m *BeaconPacket) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Protocol_BroadcastDKGChunk_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DKGChunk)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).BroadcastDKGChunk(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/BroadcastDKGChunk",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).BroadcastDKGChunk(ctx, req.(*DKGChunk))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_PushGroupProposal_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GroupProposal)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).PushGroupProposal(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/PushGroupProposal",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).PushGroupProposal(ctx, req.(*GroupProposal))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_SignalDKGReady_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DKGReadyPacket)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).SignalDKGReady(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/SignalDKGReady",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).SignalDKGReady(ctx, req.(*DKGReadyPacket))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_EquivocationEvidence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EquivocationPacket)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).EquivocationEvidence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/EquivocationEvidence",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).EquivocationEvidence(ctx, req.(*EquivocationPacket))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_AnnounceMaintenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MaintenanceWindow)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).AnnounceMaintenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/AnnounceMaintenance",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).AnnounceMaintenance(ctx, req.(*MaintenanceWindow))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_NewBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PartialBeaconPacket)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).NewBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/NewBeacon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).NewBeacon(ctx, req.(*PartialBeaconPacket))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Protocol_GetBeacon_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBeaconRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProtocolServer).GetBeacon(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/drand.Protocol/GetBeacon",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProtocolServer).GetBeacon(ctx, req.(*GetBeaconRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Protocol_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "drand.Protocol",
 	HandlerType: (*ProtocolServer)(nil),
@@ -304,6 +560,34 @@ var _Protocol_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PartialBeacon",
 			Handler:    _Protocol_PartialBeacon_Handler,
 		},
+		{
+			MethodName: "BroadcastDKGChunk",
+			Handler:    _Protocol_BroadcastDKGChunk_Handler,
+		},
+		{
+			MethodName: "PushGroupProposal",
+			Handler:    _Protocol_PushGroupProposal_Handler,
+		},
+		{
+			MethodName: "SignalDKGReady",
+			Handler:    _Protocol_SignalDKGReady_Handler,
+		},
+		{
+			MethodName: "EquivocationEvidence",
+			Handler:    _Protocol_EquivocationEvidence_Handler,
+		},
+		{
+			MethodName: "AnnounceMaintenance",
+			Handler:    _Protocol_AnnounceMaintenance_Handler,
+		},
+		{
+			MethodName: "NewBeacon",
+			Handler:    _Protocol_NewBeacon_Handler,
+		},
+		{
+			MethodName: "GetBeacon",
+			Handler:    _Protocol_GetBeacon_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
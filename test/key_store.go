@@ -1,6 +1,10 @@
 package test
 
-import "github.com/drand/drand/key"
+import (
+	"errors"
+
+	"github.com/drand/drand/key"
+)
 
 type KeyStore struct {
 	priv  *key.Pair
@@ -19,6 +23,9 @@ func (k *KeyStore) SaveKeyPair(p *key.Pair) error {
 }
 
 func (k *KeyStore) LoadKeyPair() (*key.Pair, error) {
+	if k.priv == nil {
+		return nil, errors.New("no key pair saved")
+	}
 	return k.priv, nil
 }
 
@@ -28,6 +35,9 @@ func (k *KeyStore) SaveShare(share *key.Share) error {
 }
 
 func (k *KeyStore) LoadShare() (*key.Share, error) {
+	if k.share == nil {
+		return nil, errors.New("no share saved")
+	}
 	return k.share, nil
 }
 
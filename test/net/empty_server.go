@@ -24,6 +24,11 @@ func (s *EmptyServer) PublicRand(context.Context, *drand.PublicRandRequest) (*dr
 	return nil, nil
 }
 
+// PublicRandRange is an empty implementation
+func (s *EmptyServer) PublicRandRange(context.Context, *drand.PublicRandRangeRequest) (*drand.PublicRandRangeResponse, error) {
+	return nil, nil
+}
+
 // PrivateRand is an empty implementation
 func (s *EmptyServer) PrivateRand(context.Context, *drand.PrivateRandRequest) (*drand.PrivateRandResponse, error) {
 	return nil, nil
@@ -44,6 +49,11 @@ func (s *EmptyServer) SignalDKGParticipant(context.Context, *drand.SignalDKGPack
 	return nil, nil
 }
 
+// SignalDKGReady is an empty implementation
+func (s *EmptyServer) SignalDKGReady(context.Context, *drand.DKGReadyPacket) (*drand.Empty, error) {
+	return nil, nil
+}
+
 // PushDKGInfo is an empty implementation
 func (s *EmptyServer) PushDKGInfo(context.Context, *drand.DKGInfoPacket) (*drand.Empty, error) {
 	return nil, nil
@@ -54,11 +64,27 @@ func (s *EmptyServer) BroadcastDKG(context.Context, *drand.DKGPacket) (*drand.Em
 	return nil, nil
 }
 
+// BroadcastDKGChunk is an empty implementation
+func (s *EmptyServer) BroadcastDKGChunk(context.Context, *drand.DKGChunk) (*drand.Empty, error) {
+	return nil, nil
+}
+
 // SyncChain is an empty implementation
 func (s *EmptyServer) SyncChain(*drand.SyncRequest, drand.Protocol_SyncChainServer) error {
 	return nil
 }
 
+// InitDKGStream is the control method to start a DKG and stream its progress
+func (s *EmptyServer) InitDKGStream(*drand.InitDKGPacket, drand.Control_InitDKGStreamServer) error {
+	return nil
+}
+
+// InitReshareStream is the control method to start a resharing and stream its
+// progress
+func (s *EmptyServer) InitReshareStream(*drand.InitResharePacket, drand.Control_InitReshareStreamServer) error {
+	return nil
+}
+
 // StartFollowChain is the control method to instruct a drand daemon to follow
 // its chain
 func (s *EmptyServer) StartFollowChain(*drand.StartFollowRequest, drand.Control_StartFollowChainServer) error {
@@ -114,3 +140,83 @@ func (s *EmptyServer) GroupFile(context.Context, *drand.GroupRequest) (*drand.Gr
 func (s *EmptyServer) Shutdown(context.Context, *drand.ShutdownRequest) (*drand.ShutdownResponse, error) {
 	return nil, nil
 }
+
+// RoundReports is an empty implementation
+func (s *EmptyServer) RoundReports(context.Context, *drand.RoundReportsRequest) (*drand.RoundReportsResponse, error) {
+	return nil, nil
+}
+
+// PeerStatus is an empty implementation
+func (s *EmptyServer) PeerStatus(context.Context, *drand.PeerStatusRequest) (*drand.PeerStatusResponse, error) {
+	return nil, nil
+}
+
+// ProposeGroup is an empty implementation
+func (s *EmptyServer) ProposeGroup(context.Context, *drand.ProposeGroupRequest) (*drand.ProposeGroupResponse, error) {
+	return nil, nil
+}
+
+// ListPendingGroups is an empty implementation
+func (s *EmptyServer) ListPendingGroups(context.Context, *drand.ListPendingGroupsRequest) (*drand.ListPendingGroupsResponse, error) {
+	return nil, nil
+}
+
+// ApproveGroup is an empty implementation
+func (s *EmptyServer) ApproveGroup(context.Context, *drand.ApproveGroupRequest) (*drand.ApproveGroupResponse, error) {
+	return nil, nil
+}
+
+// Status is an empty implementation
+func (s *EmptyServer) Status(context.Context, *drand.StatusRequest) (*drand.StatusResponse, error) {
+	return nil, nil
+}
+
+// Pause is an empty implementation
+func (s *EmptyServer) Pause(context.Context, *drand.PauseRequest) (*drand.PauseResponse, error) {
+	return nil, nil
+}
+
+// Resume is an empty implementation
+func (s *EmptyServer) Resume(context.Context, *drand.ResumeRequest) (*drand.ResumeResponse, error) {
+	return nil, nil
+}
+
+// ScheduleMaintenance is an empty implementation
+func (s *EmptyServer) ScheduleMaintenance(context.Context, *drand.ScheduleMaintenanceRequest) (*drand.ScheduleMaintenanceResponse, error) {
+	return nil, nil
+}
+
+// ListMaintenance is an empty implementation
+func (s *EmptyServer) ListMaintenance(context.Context, *drand.ListMaintenanceRequest) (*drand.ListMaintenanceResponse, error) {
+	return nil, nil
+}
+
+// KeyUsage is an empty implementation
+func (s *EmptyServer) KeyUsage(context.Context, *drand.KeyUsageRequest) (*drand.KeyUsageResponse, error) {
+	return nil, nil
+}
+
+// PushGroupProposal is an empty implementation
+func (s *EmptyServer) PushGroupProposal(context.Context, *drand.GroupProposal) (*drand.Empty, error) {
+	return nil, nil
+}
+
+// EquivocationEvidence is an empty implementation
+func (s *EmptyServer) EquivocationEvidence(context.Context, *drand.EquivocationPacket) (*drand.Empty, error) {
+	return nil, nil
+}
+
+// NewBeacon is an empty implementation
+func (s *EmptyServer) NewBeacon(context.Context, *drand.PartialBeaconPacket) (*drand.BeaconResponse, error) {
+	return nil, nil
+}
+
+// AnnounceMaintenance is an empty implementation
+func (s *EmptyServer) AnnounceMaintenance(context.Context, *drand.MaintenanceWindow) (*drand.Empty, error) {
+	return nil, nil
+}
+
+// GetBeacon is an empty implementation
+func (s *EmptyServer) GetBeacon(context.Context, *drand.GetBeaconRequest) (*drand.BeaconPacket, error) {
+	return nil, nil
+}